@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"KnowledgeHub/config"
+	"KnowledgeHub/internal/app"
+	"KnowledgeHub/internal/services"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	var (
+		opts   app.ExportOptions
+		format string
+		path   string
+	)
+
+	flag.StringVar(&opts.SpaceKey, "space", "", "key of the space to export (default: the whole knowledge base)")
+	flag.StringVar(&format, "format", string(services.ExportFormatMarkdown), "archive format: markdown, html or json")
+	flag.StringVar(&path, "out", "", "output zip file (required)")
+	flag.Parse()
+
+	opts.Format = services.ExportFormat(format)
+	if path == "" || !opts.Format.IsValid() {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	out, err := os.Create(path) //nolint:gosec // path comes from the command line
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = app.Export(cfg, opts, out)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(path)
+		log.Fatal(err)
+	}
+}
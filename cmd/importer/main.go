@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"KnowledgeHub/config"
+	"KnowledgeHub/internal/app"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	var opts app.ImportOptions
+
+	flag.StringVar(&opts.Dir, "dir", ".", "knowledge base root directory")
+	flag.StringVar(&opts.Username, "user", "", "username of the importing user (required)")
+	flag.BoolVar(&opts.Commit, "commit", false, "create spaces and articles instead of a dry run")
+	flag.Parse()
+
+	if opts.Username == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	if err = app.Import(cfg, opts, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
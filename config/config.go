@@ -2,19 +2,29 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type (
 	Config struct {
-		App     App
-		HTTP    HTTP
-		Log     Log
-		PG      PG
-		Metrics Metrics
-		Swagger Swagger
-		JWT     JWT
+		App         App
+		HTTP        HTTP
+		Log         Log
+		PG          PG
+		Metrics     Metrics
+		Tracing     Tracing
+		Swagger     Swagger
+		JWT         JWT
+		RateLimit   RateLimit
+		Lockout     Lockout
+		Mail        Mail
+		Account     Account
+		Storage     Storage
+		Attachments Attachments
+		Render      Render
+		Import      Import
 	}
 
 	App struct {
@@ -25,10 +35,24 @@ type (
 	HTTP struct {
 		Port           string `env:"HTTP_PORT,required"`
 		UsePreforkMode bool   `env:"HTTP_USE_PREFORK_MODE" envDefault:"false"`
+		// DrainDelay - скільки /readyz повідомляє про зупинку до закриття сервера,
+		// щоб балансувальник встиг прибрати екземпляр
+		DrainDelay time.Duration `env:"HTTP_DRAIN_DELAY" envDefault:"5s"`
+		// ReadTimeout, WriteTimeout - на читання запиту й запис відповіді для звичайних запитів
+		ReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT" envDefault:"5s"`
+		WriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT" envDefault:"5s"`
+		// TransferTimeout замінює їх на маршрутах, що приймають або віддають файли:
+		// вкладення, імпорт та експорт; 0 - без обмеження
+		TransferTimeout time.Duration `env:"HTTP_TRANSFER_TIMEOUT" envDefault:"10m"`
+		// TrustedProxies - IP або CIDR балансувальників, чий X-Forwarded-For приймається;
+		// порожній список - IP клієнта береться з з'єднання
+		TrustedProxies []string `env:"HTTP_TRUSTED_PROXIES" envSeparator:","`
 	}
 
 	Log struct {
 		Level string `env:"LOG_LEVEL,required"`
+		// Format - json або console (текст для локальної розробки)
+		Format string `env:"LOG_FORMAT" envDefault:"json"`
 	}
 
 	PG struct {
@@ -40,15 +64,99 @@ type (
 		Enabled bool `env:"METRICS_ENABLED" envDefault:"true"`
 	}
 
+	Tracing struct {
+		// Exporter - none, stdout або otlp (адресу колектора задає OTEL_EXPORTER_OTLP_ENDPOINT)
+		Exporter    string  `env:"TRACING_EXPORTER" envDefault:"none"`
+		SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+	}
+
 	Swagger struct {
 		Enabled bool `env:"SWAGGER_ENABLED" envDefault:"false"`
 	}
 
 	JWT struct {
-		Secret           string `env:"JWT_SECRET,required"`
+		// Secret потрібен лише для HS* алгоритмів
+		Secret           string `env:"JWT_SECRET"`
 		AccessTokenTTL   int    `env:"JWT_ACCESS_TOKEN_TTL" envDefault:"900"`
 		RefreshTokenTTL  int    `env:"JWT_REFRESH_TOKEN_TTL" envDefault:"604800"`
 		SigningAlgorithm string `env:"JWT_SIGNING_ALGORITHM" envDefault:"HS256"`
+		// PrivateKeyPath - PEM ключ підпису для RS*/PS*/ES*/EdDSA
+		PrivateKeyPath string `env:"JWT_PRIVATE_KEY_PATH"`
+		// PublicKeyPaths - попередні ключі, які ще приймаються під час ротації: "path" або "kid=path"
+		PublicKeyPaths []string `env:"JWT_PUBLIC_KEY_PATHS" envSeparator:","`
+		// KeyID - kid ключа підпису; за замовчуванням JWK thumbprint (RFC 7638)
+		KeyID string `env:"JWT_KEY_ID"`
+	}
+
+	RateLimit struct {
+		Enabled bool `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+		// Auth* - ліміт на IP для login, register та refresh
+		AuthPerMinute int `env:"RATE_LIMIT_AUTH_PER_MINUTE" envDefault:"20"`
+		AuthBurst     int `env:"RATE_LIMIT_AUTH_BURST" envDefault:"10"`
+		// Login* - ліміт спроб входу на один username
+		LoginPerMinute int `env:"RATE_LIMIT_LOGIN_PER_MINUTE" envDefault:"5"`
+		LoginBurst     int `env:"RATE_LIMIT_LOGIN_BURST" envDefault:"5"`
+	}
+
+	Lockout struct {
+		// MaxFailures - невдалих входів поспіль до блокування; 0 вимикає блокування
+		MaxFailures int `env:"LOCKOUT_MAX_FAILURES" envDefault:"5"`
+		// Duration - перше блокування; кожне наступне вдвічі довше, до MaxDuration
+		Duration    time.Duration `env:"LOCKOUT_DURATION" envDefault:"1m"`
+		MaxDuration time.Duration `env:"LOCKOUT_MAX_DURATION" envDefault:"1h"`
+	}
+
+	Mail struct {
+		// Transport - smtp, file (кожен лист файлом .eml у FileDir) або log (лист у журналі).
+		// Значення за замовчуванням немає: log записує робочі посилання з листів у журнал,
+		// тож транспорт обирається явно, а сервер без нього не стартує
+		Transport string `env:"MAIL_TRANSPORT"`
+		From      string `env:"MAIL_FROM" envDefault:"KnowledgeHub <no-reply@localhost>"`
+		SMTPHost  string `env:"MAIL_SMTP_HOST"`
+		SMTPPort  int    `env:"MAIL_SMTP_PORT" envDefault:"587"`
+		// SMTPUsername, SMTPPassword - облікові дані; порожні - без автентифікації
+		SMTPUsername string `env:"MAIL_SMTP_USERNAME"`
+		SMTPPassword string `env:"MAIL_SMTP_PASSWORD"`
+		// SMTPImplicitTLS - TLS з першого байта (порт 465) замість STARTTLS
+		SMTPImplicitTLS bool   `env:"MAIL_SMTP_IMPLICIT_TLS" envDefault:"false"`
+		FileDir         string `env:"MAIL_FILE_DIR" envDefault:"./data/mail"`
+	}
+
+	Account struct {
+		// LinkBaseURL - адреса клієнта, що відкриває /verify-email та /reset-password з листів
+		LinkBaseURL      string        `env:"ACCOUNT_LINK_BASE_URL" envDefault:"http://localhost:8080"`
+		VerificationTTL  time.Duration `env:"ACCOUNT_VERIFICATION_TTL" envDefault:"48h"`
+		PasswordResetTTL time.Duration `env:"ACCOUNT_PASSWORD_RESET_TTL" envDefault:"1h"`
+	}
+
+	Storage struct {
+		// Backend - local або s3
+		Backend   string `env:"STORAGE_BACKEND" envDefault:"local"`
+		LocalPath string `env:"STORAGE_LOCAL_PATH" envDefault:"./data/blobs"`
+		// S3* - параметри S3-сумісного сховища; для MinIO потрібен path-style
+		S3Endpoint  string `env:"STORAGE_S3_ENDPOINT"`
+		S3Region    string `env:"STORAGE_S3_REGION" envDefault:"us-east-1"`
+		S3Bucket    string `env:"STORAGE_S3_BUCKET"`
+		S3AccessKey string `env:"STORAGE_S3_ACCESS_KEY"`
+		S3SecretKey string `env:"STORAGE_S3_SECRET_KEY"`
+		S3PathStyle bool   `env:"STORAGE_S3_PATH_STYLE" envDefault:"true"`
+	}
+
+	Attachments struct {
+		// MaxSize - максимальний розмір одного файлу в байтах
+		MaxSize int64 `env:"ATTACHMENTS_MAX_SIZE" envDefault:"26214400"`
+		// AllowedTypes - MIME типи, визначені за вмістом файлу; порожній список - зображення та PDF
+		AllowedTypes []string `env:"ATTACHMENTS_ALLOWED_TYPES" envSeparator:","`
+	}
+
+	Render struct {
+		// CacheSize - кількість ревізій статей, відрендерених у HTML, що тримаються в пам'яті
+		CacheSize int `env:"RENDER_CACHE_SIZE" envDefault:"1000"`
+	}
+
+	Import struct {
+		// MaxSize - максимальний розмір zip архіву, що приймає /v1/admin/import, в байтах
+		MaxSize int64 `env:"IMPORT_MAX_SIZE" envDefault:"104857600"`
 	}
 )
 
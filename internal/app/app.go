@@ -1,21 +1,49 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"KnowledgeHub/config"
 	"KnowledgeHub/internal/controller/http"
+	"KnowledgeHub/internal/metrics"
+	pgrepo "KnowledgeHub/internal/repo/postgres"
+	"KnowledgeHub/migrations"
+	"KnowledgeHub/pkg/health"
 	"KnowledgeHub/pkg/httpserver"
 	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/mailer"
 	"KnowledgeHub/pkg/postgres"
+	"KnowledgeHub/pkg/storage"
+	"KnowledgeHub/pkg/tracing"
+)
+
+const (
+	_tracingShutdownTimeout = 5 * time.Second
+	_readinessTimeout       = 2 * time.Second
+	// _readinessProbeKey - ключ, наявність якого перевіряє readiness; сам об'єкт не потрібен
+	_readinessProbeKey = "readiness/probe"
 )
 
 // Run creates objects via constructors.
 func Run(cfg *config.Config) {
-	l := logger.New(cfg.Log.Level)
+	l := logger.New(cfg.Log.Level, logger.Format(cfg.Log.Format))
+
+	// Tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:       cfg.Tracing.Exporter,
+		ServiceName:    cfg.App.Name,
+		ServiceVersion: cfg.App.Version,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - tracing.Setup: %w", err))
+	}
 
 	// Repository
 	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
@@ -24,11 +52,57 @@ func Run(cfg *config.Config) {
 	}
 	defer pg.Close()
 
+	err = pg.Migrate(context.Background(), migrations.FS)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - pg.Migrate: %w", err))
+	}
+
+	if cfg.Metrics.Enabled {
+		err = metrics.Register(metrics.NewPoolCollector(pg.Pool))
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - metrics.Register: %w", err))
+		}
+	}
+
+	store := pgrepo.NewRepository(pg)
+
+	// Blob storage
+	blobs, err := newBlobStore(cfg.Storage)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newBlobStore: %w", err))
+	}
+
+	// Mail
+	mail, err := newMailer(cfg.Mail, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newMailer: %w", err))
+	}
+
+	// Readiness
+	readiness := health.NewReadiness(_readinessTimeout)
+	readiness.Register("postgres", pg.Pool.Ping)
+	readiness.Register("storage", func(ctx context.Context) error {
+		// Відповідь про відсутній об'єкт означає, що сховище доступне
+		_, err := blobs.Exists(ctx, _readinessProbeKey)
+		return err
+	})
+
 	// HTTP Server
-	httpServer := httpserver.NewServer(
+	httpServer, err := httpserver.NewServer(
 		httpserver.Port(cfg.HTTP.Port),
+		httpserver.ReadTimeout(cfg.HTTP.ReadTimeout),
+		httpserver.WriteTimeout(cfg.HTTP.WriteTimeout),
+		httpserver.TrustedProxies(cfg.HTTP.TrustedProxies),
 	)
-	http.NewRouter(httpServer.Engine, cfg, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - httpserver.NewServer: %w", err))
+	}
+
+	err = http.NewRouter(httpServer.Engine, cfg, l, store, blobs, mail, readiness)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - http.NewRouter: %w", err))
+	}
+
 	httpServer.Start()
 
 	// Waiting signal
@@ -38,6 +112,11 @@ func Run(cfg *config.Config) {
 	select {
 	case s := <-interrupt:
 		l.Info("app - Run - signal: %s", s.String())
+
+		// Спершу знімаємо готовність і чекаємо, поки балансувальник перестане
+		// надсилати запити; поточні запити дочікується httpServer.Shutdown
+		readiness.Drain()
+		time.Sleep(cfg.HTTP.DrainDelay)
 	case err = <-httpServer.Notify():
 		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
 	}
@@ -47,4 +126,55 @@ func Run(cfg *config.Config) {
 	if err != nil {
 		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
 	}
+
+	// Не чекаємо недоступного колектора довше, ніж сам сервер на завершення запитів
+	ctx, cancel := context.WithTimeout(context.Background(), _tracingShutdownTimeout)
+	defer cancel()
+
+	err = shutdownTracing(ctx)
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - shutdownTracing: %w", err))
+	}
+}
+
+// newBlobStore створює сховище вкладень згідно з cfg.Backend
+func newBlobStore(cfg config.Storage) (storage.BlobStore, error) {
+	switch cfg.Backend {
+	case "local":
+		return storage.NewLocal(cfg.LocalPath)
+	case "s3":
+		return storage.NewS3(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// newMailer створює транспорт листів згідно з cfg.Transport
+func newMailer(cfg config.Mail, l logger.Interface) (mailer.Mailer, error) {
+	switch cfg.Transport {
+	case "smtp":
+		return mailer.NewSMTP(mailer.SMTPConfig{
+			Host:        cfg.SMTPHost,
+			Port:        cfg.SMTPPort,
+			Username:    cfg.SMTPUsername,
+			Password:    cfg.SMTPPassword,
+			ImplicitTLS: cfg.SMTPImplicitTLS,
+			From:        cfg.From,
+		})
+	case "file":
+		return mailer.NewFile(cfg.FileDir, cfg.From)
+	case "log":
+		return mailer.NewLog(l), nil
+	case "":
+		return nil, errors.New("MAIL_TRANSPORT is not set: choose smtp, file or log")
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", cfg.Transport)
+	}
 }
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"KnowledgeHub/config"
+	pgrepo "KnowledgeHub/internal/repo/postgres"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/postgres"
+)
+
+// ExportOptions - параметри експорту з командного рядка
+type ExportOptions struct {
+	// SpaceKey - ключ простору; порожній - вся база знань
+	SpaceKey string
+	Format   services.ExportFormat
+}
+
+// Export записує zip архів простору або всієї бази знань у out
+func Export(cfg *config.Config, opts ExportOptions, out io.Writer) error {
+	ctx := context.Background()
+
+	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
+	if err != nil {
+		return fmt.Errorf("app - Export - postgres.New: %w", err)
+	}
+	defer pg.Close()
+
+	store := pgrepo.NewRepository(pg)
+
+	blobs, err := newBlobStore(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("app - Export - newBlobStore: %w", err)
+	}
+
+	var spaceID uint
+
+	if opts.SpaceKey != "" {
+		space, spaceErr := store.Space().GetSpaceByKey(ctx, opts.SpaceKey)
+		if spaceErr != nil {
+			return fmt.Errorf("app - Export - GetSpaceByKey: %w", spaceErr)
+		}
+
+		if space == nil {
+			return fmt.Errorf("app - Export: space %q not found", opts.SpaceKey)
+		}
+
+		spaceID = space.ID
+	}
+
+	exportService := services.NewExportService(store.Article(), store.Space(), store.PageTree(), store.Tag(),
+		store.Attachment(), store.User(), blobs)
+
+	err = exportService.Export(ctx, out, services.ExportOptions{SpaceID: spaceID, Format: opts.Format})
+	if err != nil {
+		return fmt.Errorf("app - Export - ExportService.Export: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"KnowledgeHub/config"
+	pgrepo "KnowledgeHub/internal/repo/postgres"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/migrations"
+	"KnowledgeHub/pkg/postgres"
+)
+
+// ImportOptions - параметри імпорту з командного рядка
+type ImportOptions struct {
+	// Dir - корінь бази знань: директорії верхнього рівня стають просторами
+	Dir string
+	// Username - користувач, від імені якого йде імпорт
+	Username string
+	Commit   bool
+}
+
+// Import імпортує директорію markdown файлів і друкує звіт у out
+func Import(cfg *config.Config, opts ImportOptions, out io.Writer) error {
+	ctx := context.Background()
+
+	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
+	if err != nil {
+		return fmt.Errorf("app - Import - postgres.New: %w", err)
+	}
+	defer pg.Close()
+
+	err = pg.Migrate(ctx, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("app - Import - pg.Migrate: %w", err)
+	}
+
+	store := pgrepo.NewRepository(pg)
+
+	blobs, err := newBlobStore(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("app - Import - newBlobStore: %w", err)
+	}
+
+	user, err := store.User().GetUserByUsername(ctx, opts.Username)
+	if err != nil {
+		return fmt.Errorf("app - Import - GetUserByUsername: %w", err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("app - Import: user %q not found", opts.Username)
+	}
+
+	accessService := services.NewAccessService(store.SpaceMember(), store.Article())
+	importService := services.NewImportService(
+		services.NewArticleService(store.Article(), store.ArticleRevision(), store.Space(), accessService),
+		services.NewSpaceService(store.Space(), store.Article(), store.PageTree(), store.SpaceMember(), accessService),
+		services.NewTagService(store.Tag(), store.Article()),
+		services.NewAttachmentService(store.Attachment(), store.Article(), blobs,
+			services.WithMaxAttachmentSize(cfg.Attachments.MaxSize),
+			services.WithAllowedAttachmentTypes(cfg.Attachments.AllowedTypes),
+		),
+		store.User(),
+		store.Space(),
+	)
+
+	report, err := importService.Import(ctx, os.DirFS(opts.Dir), services.ImportOptions{
+		UserID: user.ID,
+		Commit: opts.Commit,
+	})
+	if report != nil {
+		printImportReport(out, report)
+	}
+
+	if err != nil {
+		return fmt.Errorf("app - Import - ImportService.Import: %w", err)
+	}
+
+	return nil
+}
+
+func printImportReport(out io.Writer, report *services.ImportReport) {
+	for _, space := range report.Spaces {
+		state := "new"
+		if space.Exists {
+			state = "existing"
+		}
+
+		_, _ = fmt.Fprintf(out, "space %s (%s)\n", space.Key, state)
+	}
+
+	for _, article := range report.Articles {
+		_, _ = fmt.Fprintf(out, "%s -> %s [%s]", article.Path, article.Slug, article.Status)
+
+		if article.ID != 0 {
+			_, _ = fmt.Fprintf(out, " id=%d", article.ID)
+		}
+
+		if article.Parent != "" {
+			_, _ = fmt.Fprintf(out, " parent=%s", article.Parent)
+		}
+
+		_, _ = fmt.Fprintln(out)
+
+		for _, warning := range article.Warnings {
+			_, _ = fmt.Fprintf(out, "  warning: %s\n", warning)
+		}
+	}
+
+	for _, skipped := range report.Skipped {
+		_, _ = fmt.Fprintf(out, "skipped %s: %s\n", skipped.Path, skipped.Reason)
+	}
+
+	summary := report.Summary
+	_, _ = fmt.Fprintf(out, "%d articles, %d new spaces, %d attachments, %d links, %d warnings, %d skipped\n",
+		summary.Articles, summary.Spaces, summary.Attachments, summary.Links, summary.Warnings, summary.Skipped)
+
+	if !report.Committed {
+		_, _ = fmt.Fprintln(out, "dry run: nothing was imported, run with -commit to import")
+	}
+}
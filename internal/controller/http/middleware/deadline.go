@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TransferDeadline продовжує дедлайни читання запиту й запису відповіді до timeout від
+// початку обробки. Загальні HTTP_READ_TIMEOUT і HTTP_WRITE_TIMEOUT розраховані на JSON запити,
+// тож маршрути, що приймають або віддають файли, ставлять цей middleware перед обробником.
+// timeout 0 знімає обмеження.
+func TransferDeadline(timeout time.Duration, logger logger.Interface) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var deadline time.Time
+		if timeout > 0 {
+			deadline = time.Now().Add(timeout)
+		}
+
+		rc := http.NewResponseController(ctx.Writer)
+
+		err := errors.Join(rc.SetReadDeadline(deadline), rc.SetWriteDeadline(deadline))
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			RequestLogger(ctx, logger).Warn("Failed to extend deadlines for %s %s: %v",
+				ctx.Request.Method, ctx.FullPath(), err)
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// slowUpload надсилає тіло запиту двома частинами з паузою pause між ними
+func slowUpload(url string, pause time.Duration) (*http.Response, error) {
+	body, writer := io.Pipe()
+
+	go func() {
+		_, _ = writer.Write([]byte(strings.Repeat("a", 1024)))
+		time.Sleep(pause)
+		_, _ = writer.Write([]byte(strings.Repeat("b", 1024)))
+		_ = writer.Close()
+	}()
+
+	return http.Post(url, "application/octet-stream", body)
+}
+
+func TestTransferDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	upload := func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		c.String(http.StatusOK, "%d", len(data))
+	}
+
+	router := gin.New()
+	router.POST("/plain", upload)
+	router.POST("/transfer", TransferDeadline(time.Minute, logger.New("debug")), upload)
+
+	server := httptest.NewUnstartedServer(router)
+	server.Config.ReadTimeout = 100 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	// Без middleware загальний ReadTimeout обриває повільне завантаження
+	resp, err := slowUpload(server.URL+"/plain", 300*time.Millisecond)
+	if err == nil {
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusOK {
+			t.Fatalf("Expected the server read timeout to cut off /plain")
+		}
+	}
+
+	resp, err = slowUpload(server.URL+"/transfer", 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Upload to /transfer error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	got, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(got) != "2048" {
+		t.Errorf("Expected 200 with 2048 bytes read, got %d: %s", resp.StatusCode, got)
+	}
+}
@@ -1,66 +1,70 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/metrics"
+	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/apperror"
 	"KnowledgeHub/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	// ErrAuthorizationRequired - запит без заголовка Authorization
+	ErrAuthorizationRequired = apperror.Unauthorized("authorization_required", "authorization header is required")
+	// ErrInvalidAuthorizationHeader - заголовок Authorization не у форматі "Bearer <token>"
+	ErrInvalidAuthorizationHeader = apperror.Unauthorized("invalid_authorization_header",
+		"invalid authorization header format")
+	// ErrNotAuthenticated - обробнику потрібен користувач, але запит не автентифіковано
+	ErrNotAuthenticated = apperror.Unauthorized("not_authenticated", "user not authenticated")
+)
+
 func JWTAuthMiddleware(jwtService *services.JWTService, logger logger.Interface) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" {
-			logger.Info("Missing Authorization header from %s", ctx.ClientIP())
-			ctx.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Authorization header is required",
-			})
-			ctx.Abort()
+			RequestLogger(ctx, logger).Info("Missing Authorization header from %s", ctx.ClientIP())
+			response.Abort(ctx, ErrAuthorizationRequired)
 			return
 		}
 
 		token, err := jwtService.ExtractTokenFromHeader(authHeader)
 		if err != nil {
-			logger.Info("Invalid Authorization header format from %s: %v", ctx.ClientIP(), err)
-			ctx.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid authorization header format",
-			})
-			ctx.Abort()
+			RequestLogger(ctx, logger).Info("Invalid Authorization header format from %s: %v", ctx.ClientIP(), err)
+			tokenRejected(ErrInvalidAuthorizationHeader)
+			response.Abort(ctx, ErrInvalidAuthorizationHeader)
 			return
 		}
 
 		claims, err := jwtService.ValidateAccessToken(token)
 		if err != nil {
-			logger.Info("Token validation failed from %s: %v", ctx.ClientIP(), err)
-
-			var errorMessage string
-			switch err {
-			case services.ErrExpiredToken:
-				errorMessage = "Token has expired"
-			case services.ErrInvalidToken:
-				errorMessage = "Invalid token"
-			case services.ErrInvalidClaims:
-				errorMessage = "Invalid token claims"
-			default:
-				errorMessage = "Token validation failed"
+			RequestLogger(ctx, logger).Info("Token validation failed from %s: %v", ctx.ClientIP(), err)
+			tokenRejected(err)
+			response.Abort(ctx, err)
+			return
+		}
+
+		err = jwtService.CheckRevoked(ctx.Request.Context(), claims)
+		if err != nil {
+			if errors.Is(err, services.ErrRevokedToken) {
+				RequestLogger(ctx, logger).Info("Revoked token used by user %d from %s", claims.UserID, ctx.ClientIP())
+				tokenRejected(err)
+			} else {
+				RequestLogger(ctx, logger).Error("Token revocation check failed: %v", err)
 			}
 
-			ctx.JSON(http.StatusUnauthorized, gin.H{
-				"error": errorMessage,
-			})
-			ctx.Abort()
+			response.Abort(ctx, err)
 			return
 		}
 
-		ctx.Set("user_id", claims.UserID)
-		ctx.Set("username", claims.Username)
-		ctx.Set("email", claims.Email)
-		ctx.Set("jwt_claims", claims)
+		setClaims(ctx, logger, claims)
 
-		logger.Info("User %s (ID: %d) authenticated successfully from %s",
+		RequestLogger(ctx, logger).Debug("User %s (ID: %d) authenticated successfully from %s",
 			claims.Username, claims.UserID, ctx.ClientIP())
 
 		ctx.Next()
@@ -79,30 +83,53 @@ func OptionalJWTAuthMiddleware(jwtService *services.JWTService, logger logger.In
 
 		token, err := jwtService.ExtractTokenFromHeader(authHeader)
 		if err != nil {
-			logger.Info("Invalid Authorization header format from %s: %v", ctx.ClientIP(), err)
+			RequestLogger(ctx, logger).Info("Invalid Authorization header format from %s: %v", ctx.ClientIP(), err)
+			tokenRejected(ErrInvalidAuthorizationHeader)
 			ctx.Next()
 			return
 		}
 
 		claims, err := jwtService.ValidateAccessToken(token)
+		if err == nil {
+			err = jwtService.CheckRevoked(ctx.Request.Context(), claims)
+		}
+
 		if err != nil {
-			logger.Info("Token validation failed from %s: %v", ctx.ClientIP(), err)
+			RequestLogger(ctx, logger).Info("Token validation failed from %s: %v", ctx.ClientIP(), err)
+			tokenRejected(err)
 			ctx.Next()
 			return
 		}
 
-		ctx.Set("user_id", claims.UserID)
-		ctx.Set("username", claims.Username)
-		ctx.Set("email", claims.Email)
-		ctx.Set("jwt_claims", claims)
+		setClaims(ctx, logger, claims)
 
-		logger.Info("User %s (ID: %d) optionally authenticated from %s",
+		RequestLogger(ctx, logger).Debug("User %s (ID: %d) optionally authenticated from %s",
 			claims.Username, claims.UserID, ctx.ClientIP())
 
 		ctx.Next()
 	}
 }
 
+// tokenRejected враховує відхилений токен у метриках за кодом помилки. Збої сховища
+// відкликаних токенів (помилки без типу) не є відмовою токену і не враховуються.
+func tokenRejected(err error) {
+	if appErr, ok := apperror.As(err); ok {
+		metrics.ObserveTokenValidationFailure(appErr.Code)
+	}
+}
+
+// setClaims зберігає дані автентифікованого користувача в контексті запиту
+// і додає user_id до записів журналу цього запиту
+func setClaims(ctx *gin.Context, l logger.Interface, claims *services.JWTClaims) {
+	ctx.Set("user_id", claims.UserID)
+	ctx.Set("username", claims.Username)
+	ctx.Set("email", claims.Email)
+	ctx.Set("role", claims.UserRole())
+	ctx.Set("jwt_claims", claims)
+
+	addLogFields(ctx, l, logger.Fields{"user_id": claims.UserID})
+}
+
 func GetUserIDFromContext(ctx *gin.Context) (uint, bool) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
@@ -133,6 +160,16 @@ func GetEmailFromContext(ctx *gin.Context) (string, bool) {
 	return emailStr, ok
 }
 
+func GetRoleFromContext(ctx *gin.Context) (models.Role, bool) {
+	role, exists := ctx.Get("role")
+	if !exists {
+		return "", false
+	}
+
+	userRole, ok := role.(models.Role)
+	return userRole, ok
+}
+
 func GetJWTClaimsFromContext(ctx *gin.Context) (*services.JWTClaims, bool) {
 	claims, exists := ctx.Get("jwt_claims")
 	if !exists {
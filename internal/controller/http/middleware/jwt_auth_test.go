@@ -1,18 +1,20 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"KnowledgeHub/config"
+	"KnowledgeHub/internal/repo/mocks"
 	"KnowledgeHub/internal/services"
 	"KnowledgeHub/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
-func getTestJWTService() *services.JWTService {
+func getTestJWTService(opts ...services.JWTOption) *services.JWTService {
 	cfg := &config.Config{
 		JWT: config.JWT{
 			Secret:           "test_secret_key_for_testing_purposes_only",
@@ -21,7 +23,12 @@ func getTestJWTService() *services.JWTService {
 			SigningAlgorithm: "HS256",
 		},
 	}
-	return services.NewJWTService(cfg)
+	jwtService, err := services.NewJWTService(cfg, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return jwtService
 }
 
 func TestJWTAuthMiddleware_ValidToken(t *testing.T) {
@@ -124,6 +131,44 @@ func TestJWTAuthMiddleware_InvalidTokenFormat(t *testing.T) {
 	}
 }
 
+func TestJWTAuthMiddleware_RevokedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	jwtService := getTestJWTService(services.WithRevocationStore(mockRepo.RevokedToken()))
+	logger := logger.New("debug")
+
+	tokenPair, err := jwtService.GenerateTokenPair(1, "testuser", "test@example.com")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	claims, err := jwtService.ValidateAccessToken(tokenPair.AccessToken)
+	if err != nil {
+		t.Fatalf("Failed to validate token: %v", err)
+	}
+
+	if err = jwtService.RevokeAccessToken(context.Background(), claims); err != nil {
+		t.Fatalf("Failed to revoke token: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(JWTAuthMiddleware(jwtService, logger))
+	router.GET("/protected", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
 func TestOptionalJWTAuthMiddleware_NoToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
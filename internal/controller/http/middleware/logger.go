@@ -1,39 +1,66 @@
 package middleware
 
 import (
-	"strconv"
-	"strings"
+	"time"
 
 	"KnowledgeHub/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func buildRequestMessage(ctx *gin.Context, status int, bodySize int) string {
-	var result strings.Builder
-
-	result.WriteString(ctx.ClientIP())
-	result.WriteString(" - ")
-	result.WriteString(ctx.Request.Method)
-	result.WriteString(" ")
-	result.WriteString(ctx.Request.RequestURI)
-	result.WriteString(" - ")
-	result.WriteString(strconv.Itoa(status))
-	result.WriteString(" ")
-	result.WriteString(strconv.Itoa(bodySize))
+// RequestLogger повертає логер поточного запиту з полями request_id та user_id,
+// або l, якщо запит пройшов повз LoggerMiddleware
+func RequestLogger(ctx *gin.Context, l logger.Interface) logger.Interface {
+	return logger.FromContext(ctx.Request.Context(), l)
+}
 
-	return result.String()
+// addLogFields додає поля до логера запиту; наступні записи запиту, зокрема
+// підсумковий запис LoggerMiddleware, міститимуть їх
+func addLogFields(ctx *gin.Context, l logger.Interface, fields logger.Fields) {
+	requestLogger := RequestLogger(ctx, l).With(fields)
+	ctx.Request = ctx.Request.WithContext(logger.NewContext(ctx.Request.Context(), requestLogger))
 }
 
+// LoggerMiddleware кладе в контекст запиту логер з його request_id і після обробки
+// записує підсумок: маршрут, статус, розмір та тривалість відповіді
 func LoggerMiddleware(l logger.Interface) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		fields := logger.Fields{}
+		if requestID, ok := GetRequestIDFromContext(ctx); ok {
+			fields["request_id"] = requestID
+		}
+
+		// Зв'язок записів журналу з трасою запиту
+		if spanContext := trace.SpanContextFromContext(ctx.Request.Context()); spanContext.HasTraceID() {
+			fields["trace_id"] = spanContext.TraceID().String()
+		}
+
+		addLogFields(ctx, l, fields)
+
 		// Перед виконанням основного хендлера
 		ctx.Next()
 
 		// Після того як обробка завершена
 		status := ctx.Writer.Status()
-		bodySize := ctx.Writer.Size()
+		entry := RequestLogger(ctx, l).With(logger.Fields{
+			"method":     ctx.Request.Method,
+			"route":      ctx.FullPath(),
+			"path":       ctx.Request.URL.Path,
+			"status":     status,
+			"size":       ctx.Writer.Size(),
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip":  ctx.ClientIP(),
+		})
+
+		// Внутрішні помилки, якими обробники відповіли 500, записує response.Error
+		if len(ctx.Errors) > 0 {
+			entry.With(logger.Fields{"errors": ctx.Errors.Errors()}).Error("HTTP request failed")
+			return
+		}
 
-		l.Info(buildRequestMessage(ctx, status, bodySize))
+		entry.Info("HTTP request")
 	}
 }
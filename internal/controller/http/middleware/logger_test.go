@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLoggerMiddleware_RequestFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	l := logger.New("debug", logger.Output(&buf))
+	jwtService := getTestJWTService()
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), LoggerMiddleware(l))
+	router.GET("/items/:id", JWTAuthMiddleware(jwtService, l), func(c *gin.Context) {
+		RequestLogger(c, l).Info("handler line")
+		c.Status(http.StatusNoContent)
+	})
+
+	tokenPair, err := jwtService.GenerateSessionTokenPair("", 7, "testuser", "test@example.com", models.RoleEditor)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+	req.Header.Set("X-Request-ID", "req-42")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var handlerEntry, accessEntry map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Log line is not JSON: %q", line)
+		}
+
+		if entry["request_id"] != "req-42" {
+			t.Errorf("Expected request_id on every line, got %v", entry)
+		}
+
+		switch entry["message"] {
+		case "handler line":
+			handlerEntry = entry
+		case "HTTP request":
+			accessEntry = entry
+		}
+	}
+
+	if handlerEntry == nil || handlerEntry["user_id"] != float64(7) {
+		t.Errorf("Expected handler line with user_id, got %v", handlerEntry)
+	}
+
+	if accessEntry == nil {
+		t.Fatalf("Expected access line, got %s", buf.String())
+	}
+
+	want := map[string]interface{}{
+		"route":   "/items/:id",
+		"path":    "/items/1",
+		"method":  http.MethodGet,
+		"status":  float64(http.StatusNoContent),
+		"user_id": float64(7),
+	}
+	for key, value := range want {
+		if accessEntry[key] != value {
+			t.Errorf("Access line %s = %v, want %v", key, accessEntry[key], value)
+		}
+	}
+
+	if _, ok := accessEntry["latency_ms"]; !ok {
+		t.Errorf("Expected latency_ms on access line, got %v", accessEntry)
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"time"
+
+	"KnowledgeHub/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware враховує кожен запит у метриках HTTP за шаблоном маршруту та статусом
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		metrics.ObserveHTTPRequest(ctx.Request.Method, ctx.FullPath(), ctx.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"strconv"
+
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrRateLimited - ліміт запитів вичерпано
+var ErrRateLimited = apperror.New(apperror.KindTooManyRequests, "rate_limited", "too many requests")
+
+// KeyFunc повертає ключ відра для запиту; порожній ключ означає, що ліміт не застосовується
+type KeyFunc func(ctx *gin.Context) string
+
+// ClientIPKey - ключ за IP клієнта
+func ClientIPKey(ctx *gin.Context) string {
+	return ctx.ClientIP()
+}
+
+// RateLimitMiddleware обмежує частоту запитів за ключем key. name відокремлює відра
+// різних лімітів у спільному сховищі. Відповідь містить заголовки RateLimit-Limit,
+// RateLimit-Remaining та RateLimit-Reset, а відмова 429 - ще й Retry-After.
+// Якщо сховище недоступне, запит пропускається: обмеження не має зупиняти вхід.
+func RateLimitMiddleware(
+	store ratelimit.Store,
+	name string,
+	limit ratelimit.Limit,
+	key KeyFunc,
+	l logger.Interface,
+) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		k := key(ctx)
+		if k == "" {
+			ctx.Next()
+			return
+		}
+
+		result, err := store.Take(ctx.Request.Context(), name+":"+k, limit)
+		if err != nil {
+			RequestLogger(ctx, l).Error("Rate limit %s check failed: %v", name, err)
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		ctx.Header("RateLimit-Reset", response.RetryAfter(result.Reset))
+
+		if !result.Allowed {
+			RequestLogger(ctx, l).Warn("Rate limit %s exceeded by %s", name, k)
+			response.Abort(ctx, ErrRateLimited.WithRetryAfter(result.RetryAfter))
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/pkg/httpserver"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RateLimitMiddleware(ratelimit.NewMemory(), "test", ratelimit.PerMinute(6, 2), ClientIPKey,
+		logger.New("debug")))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		w := serve("10.0.0.1:1234")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected status %d, got %d", i, http.StatusOK, w.Code)
+		}
+
+		if got := w.Header().Get("RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("Request %d: RateLimit-Remaining = %q, want %q", i, got, wantRemaining)
+		}
+
+		if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+			t.Errorf("Request %d: RateLimit-Limit = %q, want 2", i, got)
+		}
+	}
+
+	w := serve("10.0.0.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+
+	if got := w.Header().Get("Retry-After"); got != "10" {
+		t.Errorf("Retry-After = %q, want 10", got)
+	}
+
+	if got := w.Header().Get("Content-Type"); got != response.ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, response.ContentType)
+	}
+
+	// Інший клієнт має власне відро
+	if w = serve("10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("Expected another client to pass, got %d", w.Code)
+	}
+}
+
+func TestRateLimitMiddleware_SpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	server, err := httpserver.NewServer()
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	router := server.Engine
+	router.Use(RateLimitMiddleware(ratelimit.NewMemory(), "test", ratelimit.PerMinute(1, 1), ClientIPKey,
+		logger.New("debug")))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// Без довірених проксі новий X-Forwarded-For у кожному запиті не дає нового відра
+	codes := make([]int, 0, 2)
+	for _, forwarded := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", forwarded)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("Expected statuses [200 429] for spoofed X-Forwarded-For, got %v", codes)
+	}
+}
+
+func TestRateLimitMiddleware_FailOpen(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RateLimitMiddleware(failingStore{}, "test", ratelimit.PerMinute(1, 1), ClientIPKey,
+		logger.New("debug")))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected request to pass when store fails, got %d", w.Code)
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SpaceLocator визначає простір, до якого належить запит.
+// nil означає, що запит не стосується простору і діє лише глобальна роль.
+type SpaceLocator func(ctx *gin.Context) (*uint, error)
+
+// RequireRole пропускає користувачів з глобальною роллю не нижче role.
+// Має стояти після JWTAuthMiddleware.
+func RequireRole(role models.Role, logger logger.Interface) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userRole, ok := authenticatedRole(ctx)
+		if !ok {
+			return
+		}
+
+		if !userRole.AtLeast(role) {
+			forbid(ctx, logger, string(role))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequirePermission пропускає користувачів, чия глобальна роль дозволяє permission.
+// Має стояти після JWTAuthMiddleware.
+func RequirePermission(permission models.Permission, logger logger.Interface) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userRole, ok := authenticatedRole(ctx)
+		if !ok {
+			return
+		}
+
+		if !userRole.Can(permission) {
+			forbid(ctx, logger, string(permission))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireSpacePermission як RequirePermission, але враховує роль учасника простору,
+// знайденого locate.
+func RequireSpacePermission(
+	access *services.AccessService,
+	permission models.Permission,
+	locate SpaceLocator,
+	logger logger.Interface,
+) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userRole, ok := authenticatedRole(ctx)
+		if !ok {
+			return
+		}
+
+		userID, _ := GetUserIDFromContext(ctx)
+
+		spaceID, err := locate(ctx)
+		if err == nil {
+			err = access.Authorize(ctx.Request.Context(), userID, userRole, permission, spaceID)
+		}
+
+		if err != nil {
+			if errors.Is(err, services.ErrForbidden) {
+				forbid(ctx, logger, string(permission))
+				return
+			}
+
+			response.Abort(ctx, fmt.Errorf("permission check: %w", err))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// SpaceParam бере id простору з параметра шляху name.
+// Некоректний id не є помилкою доступу - його відхилить обробник.
+func SpaceParam(name string) SpaceLocator {
+	return func(ctx *gin.Context) (*uint, error) {
+		id, err := strconv.ParseUint(ctx.Param(name), 10, 32)
+		if err != nil {
+			return nil, nil
+		}
+
+		spaceID := uint(id)
+
+		return &spaceID, nil
+	}
+}
+
+// ArticleSpaceParam визначає простір статті, id якої в параметрі шляху name
+func ArticleSpaceParam(access *services.AccessService, name string) SpaceLocator {
+	return func(ctx *gin.Context) (*uint, error) {
+		id, err := strconv.ParseUint(ctx.Param(name), 10, 32)
+		if err != nil {
+			return nil, nil
+		}
+
+		return access.ArticleSpaceID(ctx.Request.Context(), uint(id))
+	}
+}
+
+func authenticatedRole(ctx *gin.Context) (models.Role, bool) {
+	role, ok := GetRoleFromContext(ctx)
+	if !ok {
+		response.Abort(ctx, ErrNotAuthenticated)
+		return "", false
+	}
+
+	return role, true
+}
+
+func forbid(ctx *gin.Context, logger logger.Interface, required string) {
+	userID, _ := GetUserIDFromContext(ctx)
+	RequestLogger(ctx, logger).Info("User %d denied %s %s: requires %s",
+		userID, ctx.Request.Method, ctx.FullPath(), required)
+
+	response.Abort(ctx, services.ErrForbidden)
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireRoleAndPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtService := getTestJWTService()
+	l := logger.New("debug")
+
+	router := gin.New()
+	router.Use(JWTAuthMiddleware(jwtService, l))
+	router.GET("/editor", RequireRole(models.RoleEditor, l), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/users", RequirePermission(models.PermissionManageUsers, l), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name string
+		role models.Role
+		path string
+		want int
+	}{
+		{"viewer below editor", models.RoleViewer, "/editor", http.StatusForbidden},
+		{"editor", models.RoleEditor, "/editor", http.StatusOK},
+		{"admin above editor", models.RoleAdmin, "/editor", http.StatusOK},
+		{"token without role", "", "/editor", http.StatusForbidden},
+		{"editor cannot manage users", models.RoleEditor, "/users", http.StatusForbidden},
+		{"admin manages users", models.RoleAdmin, "/users", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenPair, err := jwtService.GenerateSessionTokenPair("", 1, "testuser", "test@example.com", tt.role)
+			if err != nil {
+				t.Fatalf("Failed to generate token: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestRequirePermission_Unauthenticated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/", RequirePermission(models.PermissionReadArticles, logger.New("debug")), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestRequireSpacePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "owner", Email: "owner@example.com"})
+	mockRepo.AddUser(&models.User{ID: 2, Username: "member", Email: "member@example.com"})
+	mockRepo.AddUser(&models.User{ID: 3, Username: "outsider", Email: "outsider@example.com"})
+
+	space := &models.Space{Key: "docs", Name: "Docs", CreatedBy: 1}
+	if err := mockRepo.Space().CreateSpace(ctx, space); err != nil {
+		t.Fatalf("CreateSpace() error = %v", err)
+	}
+
+	err := mockRepo.SpaceMember().SetSpaceMember(ctx, &models.SpaceMember{SpaceID: space.ID, UserID: 2, Role: models.RoleEditor})
+	if err != nil {
+		t.Fatalf("SetSpaceMember() error = %v", err)
+	}
+
+	access := services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article())
+	jwtService := getTestJWTService()
+	l := logger.New("debug")
+
+	router := gin.New()
+	router.Use(JWTAuthMiddleware(jwtService, l))
+	router.PUT("/spaces/:id", RequireSpacePermission(access, models.PermissionManageSpaces, SpaceParam("id"), l),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/spaces/:id/pages", RequireSpacePermission(access, models.PermissionWriteArticles, SpaceParam("id"), l),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name   string
+		userID uint
+		role   models.Role
+		method string
+		path   string
+		want   int
+	}{
+		{"space admin manages", 1, models.RoleViewer, http.MethodPut, "/spaces/1", http.StatusOK},
+		{"space editor cannot manage", 2, models.RoleViewer, http.MethodPut, "/spaces/1", http.StatusForbidden},
+		{"space editor writes", 2, models.RoleViewer, http.MethodPost, "/spaces/1/pages", http.StatusOK},
+		{"outsider viewer cannot write", 3, models.RoleViewer, http.MethodPost, "/spaces/1/pages", http.StatusForbidden},
+		{"global editor writes anywhere", 3, models.RoleEditor, http.MethodPost, "/spaces/1/pages", http.StatusOK},
+		{"membership in other space", 2, models.RoleViewer, http.MethodPost, "/spaces/2/pages", http.StatusForbidden},
+		{"global admin manages", 3, models.RoleAdmin, http.MethodPut, "/spaces/1", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenPair, err := jwtService.GenerateSessionTokenPair("", tt.userID, "user", "user@example.com", tt.role)
+			if err != nil {
+				t.Fatalf("Failed to generate token: %v", err)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
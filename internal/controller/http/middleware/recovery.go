@@ -2,35 +2,21 @@ package middleware
 
 import (
 	"fmt"
-	"net/http"
 	"runtime/debug"
-	"strings"
 
+	"KnowledgeHub/internal/controller/http/response"
 	"KnowledgeHub/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
-func buildPanicMessage(ctx *gin.Context, err interface{}) string {
-	var result strings.Builder
-
-	result.WriteString(ctx.ClientIP())
-	result.WriteString(" - ")
-	result.WriteString(ctx.Request.Method)
-	result.WriteString(" ")
-	result.WriteString(ctx.Request.RequestURI)
-	result.WriteString(" PANIC DETECTED: ")
-	result.WriteString(fmt.Sprintf("%v\n%s\n", err, debug.Stack()))
-
-	return result.String()
-}
-
 func RecoveryMiddleware(l logger.Interface) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				l.Error(buildPanicMessage(ctx, err))
-				ctx.AbortWithStatus(http.StatusInternalServerError)
+				RequestLogger(ctx, l).With(logger.Fields{"stack": string(debug.Stack())}).
+					Error("Panic in %s %s: %v", ctx.Request.Method, ctx.Request.URL.Path, err)
+				response.Abort(ctx, fmt.Errorf("panic: %v", err))
 			}
 		}()
 
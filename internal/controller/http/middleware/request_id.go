@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"KnowledgeHub/internal/controller/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	_requestIDKey       = "request_id"
+	_maxRequestIDLength = 128
+)
+
+// RequestIDMiddleware присвоює запиту ідентифікатор: бере коректний X-Request-ID клієнта
+// або проксі, інакше генерує новий. Ідентифікатор повертається в заголовку відповіді.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(response.RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		ctx.Set(_requestIDKey, requestID)
+		ctx.Header(response.RequestIDHeader, requestID)
+
+		ctx.Next()
+	}
+}
+
+// GetRequestIDFromContext повертає ідентифікатор поточного запиту
+func GetRequestIDFromContext(ctx *gin.Context) (string, bool) {
+	requestID, exists := ctx.Get(_requestIDKey)
+	if !exists {
+		return "", false
+	}
+
+	id, ok := requestID.(string)
+	return id, ok
+}
+
+// validRequestID приймає лише короткі ідентифікатори з безпечних символів,
+// щоб чужий заголовок не зламав журнали
+func validRequestID(id string) bool {
+	if id == "" || len(id) > _maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
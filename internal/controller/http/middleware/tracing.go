@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+
+	"KnowledgeHub/pkg/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware відкриває серверний спан на запит. Якщо викликач передав traceparent,
+// спан продовжує його трасу. Контекст зі спаном кладеться в запит, тож сервіси та запити
+// до бази, що отримують c.Request.Context(), стають його дочірніми спанами.
+func TracingMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(),
+			propagation.HeaderCarrier(ctx.Request.Header))
+
+		route := ctx.FullPath()
+		spanName := ctx.Request.Method
+		if route != "" {
+			spanName += " " + route
+		}
+
+		spanCtx, span := tracing.Tracer().Start(parent, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				semconv.ClientAddress(ctx.ClientIP()),
+				semconv.UserAgentOriginal(ctx.Request.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		if requestID, ok := GetRequestIDFromContext(ctx); ok {
+			span.SetAttributes(attribute.String("request_id", requestID))
+		}
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+		if userID, ok := GetUserIDFromContext(ctx); ok {
+			span.SetAttributes(tracing.ID("user_id", userID))
+		}
+
+		for _, err := range ctx.Errors {
+			span.RecordError(err.Err)
+		}
+
+		// Помилки клієнта (4xx) не є збоєм сервера
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"KnowledgeHub/internal/controller/http/response"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	var handlerSpan trace.SpanContext
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), TracingMiddleware())
+	router.GET("/items/:id", func(c *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(c.Request.Context())
+		response.Error(c, http.ErrAbortHandler)
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "GET /items/:id" || span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("Unexpected span %q of kind %v", span.Name(), span.SpanKind())
+	}
+
+	if span.SpanContext().TraceID().String() != traceID || span.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("Expected span to continue the incoming trace, got %v", span.SpanContext())
+	}
+
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("Expected handler context to carry the server span")
+	}
+
+	if span.Status().Code != codes.Error || len(span.Events()) != 1 {
+		t.Errorf("Expected failed span with recorded error, got %v and %d events", span.Status(), len(span.Events()))
+	}
+
+	attrs := map[string]string{}
+	for _, attr := range span.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+
+	for key, want := range map[string]string{
+		"http.route":                "/items/:id",
+		"http.response.status_code": "500",
+		"request_id":                w.Header().Get("X-Request-ID"),
+	} {
+		if attrs[key] != want {
+			t.Errorf("Attribute %s = %q, want %q", key, attrs[key], want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Package response формує відповіді з помилками у форматі RFC 7807 (application/problem+json).
+// Вид типізованої помилки (apperror.Kind) визначає статус, код помилки передається клієнту
+// для розбору без аналізу тексту. Помилки без типу вважаються внутрішніми: клієнт бачить
+// лише загальну відповідь 500, а сама помилка записується в gin.Context для журналу запитів.
+package response
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"KnowledgeHub/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ContentType - тип вмісту відповіді з помилкою
+	ContentType = "application/problem+json"
+	// RequestIDHeader - заголовок з ідентифікатором запиту
+	RequestIDHeader = "X-Request-ID"
+
+	_codeInternal = "internal_error"
+)
+
+// Problem - опис помилки за RFC 7807 з розширеннями code, request_id та errors
+type Problem struct {
+	// Type - URI типу помилки; about:blank означає, що тип визначає статус
+	Type  string `json:"type" example:"about:blank"`
+	Title string `json:"title" example:"Not Found"`
+	// Status - HTTP статус відповіді
+	Status int    `json:"status" example:"404"`
+	Detail string `json:"detail,omitempty" example:"article not found"`
+	// Instance - шлях запиту, що спричинив помилку
+	Instance string `json:"instance,omitempty" example:"/v1/articles/42"`
+	// Code - стабільний машиночитний код помилки
+	Code      string `json:"code" example:"article_not_found"`
+	RequestID string `json:"request_id,omitempty" example:"6f1c2a9e8b7d4c3a"`
+	// Errors - помилки окремих полів запиту
+	Errors []apperror.FieldError `json:"errors,omitempty"`
+}
+
+var _statuses = map[apperror.Kind]int{
+	apperror.KindValidation:      http.StatusBadRequest,
+	apperror.KindUnauthorized:    http.StatusUnauthorized,
+	apperror.KindForbidden:       http.StatusForbidden,
+	apperror.KindNotFound:        http.StatusNotFound,
+	apperror.KindConflict:        http.StatusConflict,
+	apperror.KindTooLarge:        http.StatusRequestEntityTooLarge,
+	apperror.KindUnsupported:     http.StatusUnsupportedMediaType,
+	apperror.KindTooManyRequests: http.StatusTooManyRequests,
+}
+
+// Error відповідає на запит помилкою err
+func Error(c *gin.Context, err error) {
+	problem := newProblem(c, err)
+
+	if appErr, ok := apperror.As(err); ok && appErr.RetryAfter > 0 {
+		c.Header("Retry-After", RetryAfter(appErr.RetryAfter))
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// Abort відповідає помилкою err і зупиняє ланцюжок обробників; для middleware
+func Abort(c *gin.Context, err error) {
+	Error(c, err)
+	c.Abort()
+}
+
+// Status повертає статус відповіді для помилки err
+func Status(err error) int {
+	if status, ok := _statuses[apperror.KindOf(err)]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// RetryAfter - значення заголовка Retry-After: ціле число секунд, округлене вгору
+func RetryAfter(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+func newProblem(c *gin.Context, err error) Problem {
+	problem := Problem{
+		Type:      "about:blank",
+		Status:    Status(err),
+		Instance:  c.Request.URL.Path,
+		RequestID: c.Writer.Header().Get(RequestIDHeader),
+	}
+
+	problem.Title = http.StatusText(problem.Status)
+
+	appErr, ok := apperror.As(err)
+	if !ok || problem.Status == http.StatusInternalServerError {
+		_ = c.Error(err)
+		problem.Code = _codeInternal
+
+		return problem
+	}
+
+	problem.Code = appErr.Code
+	problem.Detail = appErr.Error()
+	problem.Errors = appErr.Fields
+
+	return problem
+}
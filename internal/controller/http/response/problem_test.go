@@ -0,0 +1,185 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"KnowledgeHub/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serveError(t *testing.T, handler gin.HandlerFunc, body string) (*httptest.ResponseRecorder, Problem, *gin.Context) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var handled *gin.Context
+
+	router := gin.New()
+	router.POST("/items", func(c *gin.Context) {
+		c.Header(RequestIDHeader, "req-1")
+		handler(c)
+		handled = c
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to unmarshal problem: %v", err)
+	}
+
+	return w, problem, handled
+}
+
+func TestError(t *testing.T) {
+	errNotFound := apperror.NotFound("item_not_found", "item not found")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"not found", errNotFound, http.StatusNotFound, "item_not_found", "item not found"},
+		{"wrapped", fmt.Errorf("ItemService - Get: %w", errNotFound), http.StatusNotFound, "item_not_found", "item not found"},
+		{
+			"derived", errNotFound.Withf("id %d", 7),
+			http.StatusNotFound, "item_not_found", "item not found: id 7",
+		},
+		{
+			"conflict", apperror.Conflict("item_exists", "item exists"),
+			http.StatusConflict, "item_exists", "item exists",
+		},
+		{"internal", errors.New("pq: connection refused"), http.StatusInternalServerError, "internal_error", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, problem, c := serveError(t, func(c *gin.Context) { Error(c, tt.err) }, "")
+
+			if w.Code != tt.wantStatus || problem.Status != tt.wantStatus {
+				t.Errorf("Status = %d (body %d), want %d", w.Code, problem.Status, tt.wantStatus)
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != ContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, ContentType)
+			}
+
+			if problem.Code != tt.wantCode || problem.Detail != tt.wantDetail {
+				t.Errorf("Problem = %+v, want code %q detail %q", problem, tt.wantCode, tt.wantDetail)
+			}
+
+			if problem.Title != http.StatusText(tt.wantStatus) || problem.Instance != "/items" {
+				t.Errorf("Unexpected title or instance: %+v", problem)
+			}
+
+			if problem.RequestID != "req-1" {
+				t.Errorf("RequestID = %q, want req-1", problem.RequestID)
+			}
+
+			// Внутрішні помилки не показуються клієнту, але потрапляють у журнал запиту
+			internal := tt.wantStatus == http.StatusInternalServerError
+			if internal != (len(c.Errors) == 1) {
+				t.Errorf("Expected recorded errors only for internal errors, got %v", c.Errors)
+			}
+
+			if internal && strings.Contains(w.Body.String(), "connection refused") {
+				t.Errorf("Internal error leaked to client: %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestError_RetryAfter(t *testing.T) {
+	err := apperror.New(apperror.KindTooManyRequests, "rate_limited", "too many requests").
+		WithRetryAfter(1500 * time.Millisecond)
+
+	w, problem, _ := serveError(t, func(c *gin.Context) { Error(c, err) }, "")
+
+	if w.Code != http.StatusTooManyRequests || problem.Code != "rate_limited" {
+		t.Errorf("Got status %d code %q, want 429 rate_limited", w.Code, problem.Code)
+	}
+
+	// Частки секунди округлюються вгору, щоб клієнт не повторив запит зарано
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want 2", got)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	type request struct {
+		Title string   `json:"title" binding:"required,max=5"`
+		Email string   `json:"email" binding:"omitempty,email"`
+		Count int      `json:"count"`
+		Tags  []string `json:"tags" binding:"max=2,dive,min=2"`
+	}
+
+	bind := func(c *gin.Context) {
+		var req request
+		if err := c.ShouldBindJSON(&req); err != nil {
+			Invalid(c, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantDetail string
+		wantFields []apperror.FieldError
+	}{
+		{
+			name:       "validation",
+			body:       `{"title":"too long","email":"nope","tags":["a"]}`,
+			wantDetail: "invalid request",
+			wantFields: []apperror.FieldError{
+				{Field: "title", Message: "must be at most 5 characters"},
+				{Field: "email", Message: "must be a valid email address"},
+				{Field: "tags[0]", Message: "must be at least 2 characters"},
+			},
+		},
+		{
+			name:       "type mismatch",
+			body:       `{"title":"ok","count":"many"}`,
+			wantDetail: "invalid request",
+			wantFields: []apperror.FieldError{{Field: "count", Message: "must be an integer"}},
+		},
+		{name: "malformed", body: `{"title":`, wantDetail: "invalid request: malformed JSON body"},
+		{name: "empty", body: "", wantDetail: "invalid request: request body is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, problem, _ := serveError(t, bind, tt.body)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Status = %d, want 400", w.Code)
+			}
+
+			if problem.Code != "invalid_request" || problem.Detail != tt.wantDetail {
+				t.Errorf("Problem = %+v, want detail %q", problem, tt.wantDetail)
+			}
+
+			if len(problem.Errors) != len(tt.wantFields) {
+				t.Fatalf("Errors = %v, want %v", problem.Errors, tt.wantFields)
+			}
+
+			for i, want := range tt.wantFields {
+				if problem.Errors[i] != want {
+					t.Errorf("Errors[%d] = %+v, want %+v", i, problem.Errors[i], want)
+				}
+			}
+		})
+	}
+}
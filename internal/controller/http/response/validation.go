@@ -0,0 +1,151 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"KnowledgeHub/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// ErrInvalidRequest - тіло або параметри запиту не пройшли розбір чи перевірку
+	ErrInvalidRequest = apperror.Validation("invalid_request", "invalid request")
+	// ErrInvalidID - ідентифікатор у шляху не є додатним числом
+	ErrInvalidID = apperror.Validation("invalid_id", "invalid id format")
+)
+
+// Помилки полів називають поля так, як їх бачить клієнт: за тегами json, form або uri
+func init() {
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// Invalid відповідає 400 на помилку прив'язки запиту (ShouldBindJSON, ShouldBindQuery...)
+// з переліком полів, що не пройшли перевірку
+func Invalid(c *gin.Context, err error) {
+	Error(c, BindError(err))
+}
+
+// BindError перетворює помилку прив'язки запиту на ErrInvalidRequest з помилками полів
+func BindError(err error) *apperror.Error {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+	)
+
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]apperror.FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, apperror.FieldError{
+				Field:   fieldPath(fieldErr),
+				Message: fieldMessage(fieldErr),
+			})
+		}
+
+		return ErrInvalidRequest.WithFields(fields...)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return ErrInvalidRequest.WithFields(apperror.FieldError{
+			Field:   typeErr.Field,
+			Message: "must be " + typeName(typeErr.Type),
+		})
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrInvalidRequest.Withf("malformed JSON body")
+	case errors.Is(err, io.EOF):
+		return ErrInvalidRequest.Withf("request body is empty")
+	default:
+		return ErrInvalidRequest
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// fieldPath - шлях поля без імені кореневої структури: "tags[0]", "parent.id"
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+
+	return path
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url", "http_url":
+		return "must be a valid URL"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "min", "gte":
+		return "must be at least " + param + lengthUnit(fieldErr)
+	case "max", "lte":
+		return "must be at most " + param + lengthUnit(fieldErr)
+	case "len":
+		return "must be exactly " + param + lengthUnit(fieldErr)
+	case "gt":
+		return "must be greater than " + param
+	case "lt":
+		return "must be less than " + param
+	default:
+		return fmt.Sprintf("failed %q validation", fieldErr.Tag())
+	}
+}
+
+// lengthUnit уточнює, що обмеження рядків і списків стосується довжини
+func lengthUnit(fieldErr validator.FieldError) string {
+	switch fieldErr.Kind() {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
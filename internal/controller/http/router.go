@@ -1,6 +1,7 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 
 	"KnowledgeHub/config"
@@ -8,8 +9,14 @@ import (
 	_ "KnowledgeHub/docs"
 	"KnowledgeHub/internal/controller/http/middleware"
 	v1 "KnowledgeHub/internal/controller/http/v1"
+	"KnowledgeHub/internal/metrics"
+	"KnowledgeHub/internal/repo"
 	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/health"
 	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/mailer"
+	"KnowledgeHub/pkg/ratelimit"
+	"KnowledgeHub/pkg/storage"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -27,33 +34,124 @@ import (
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
-func NewRouter(engine *gin.Engine, cfg *config.Config, l logger.Interface) {
+func NewRouter(
+	engine *gin.Engine,
+	cfg *config.Config,
+	l logger.Interface,
+	store repo.Store,
+	blobs storage.BlobStore,
+	mail mailer.Mailer,
+	readiness *health.Readiness,
+) error {
 	// Middleware
+	engine.Use(middleware.RequestIDMiddleware())
+	engine.Use(middleware.TracingMiddleware())
 	engine.Use(middleware.LoggerMiddleware(l))
 	engine.Use(middleware.RecoveryMiddleware(l))
 
+	if cfg.Metrics.Enabled {
+		engine.Use(middleware.MetricsMiddleware())
+		engine.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
+
 	// Створюємо сервіси
-	jwtService := services.NewJWTService(cfg)
+	jwtService, err := services.NewJWTService(cfg, services.WithRevocationStore(store.RevokedToken()))
+	if err != nil {
+		return fmt.Errorf("http - NewRouter - services.NewJWTService: %w", err)
+	}
+
+	lockout := services.LockoutPolicy{
+		MaxFailures: cfg.Lockout.MaxFailures,
+		Duration:    cfg.Lockout.Duration,
+		MaxDuration: cfg.Lockout.MaxDuration,
+	}
 
-	// TODO: Додати userService коли буде реалізований репозиторій
-	// userService := services.NewUserService(userRepo)
+	userService := services.NewUserService(store.User(), services.WithLockout(store.LoginLockout(), lockout))
+	sessionService := services.NewSessionService(jwtService, store.User(), store.RefreshToken())
+	accountService := services.NewAccountService(jwtService, sessionService, store.User(), store.UserToken(),
+		store.LoginLockout(), mail,
+		services.AccountConfig{
+			LinkBaseURL:      cfg.Account.LinkBaseURL,
+			VerificationTTL:  cfg.Account.VerificationTTL,
+			PasswordResetTTL: cfg.Account.PasswordResetTTL,
+		})
+	renderService := services.NewRenderService(store.Link(), cfg.Render.CacheSize)
+	searchService := services.NewSearchService(store.Search())
+	accessService := services.NewAccessService(store.SpaceMember(), store.Article())
+	articleService := services.NewArticleService(store.Article(), store.ArticleRevision(), store.Space(), accessService)
+	spaceService := services.NewSpaceService(store.Space(), store.Article(), store.PageTree(), store.SpaceMember(),
+		accessService)
+	tagService := services.NewTagService(store.Tag(), store.Article())
+	commentService := services.NewCommentService(store.Comment(), store.Article(), store.User(), accessService)
+	linkService := services.NewLinkService(store.Link(), store.Article())
+	attachmentService := services.NewAttachmentService(store.Attachment(), store.Article(), blobs,
+		services.WithMaxAttachmentSize(cfg.Attachments.MaxSize),
+		services.WithAllowedAttachmentTypes(cfg.Attachments.AllowedTypes),
+	)
+	exportService := services.NewExportService(store.Article(), store.Space(), store.PageTree(), store.Tag(),
+		store.Attachment(), store.User(), blobs)
+	importService := services.NewImportService(articleService, spaceService, tagService, attachmentService,
+		store.User(), store.Space())
 
 	//// Swagger
 	if cfg.Swagger.Enabled {
 		engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 
-	// K8s health probe
+	// K8s health probe: процес живий, залежності не перевіряються
 	engine.GET("/healthz", func(ctx *gin.Context) {
 		ctx.Status(http.StatusOK)
 	})
 
+	// K8s readiness probe: залежності доступні і сервер не зупиняється
+	engine.GET("/readyz", func(ctx *gin.Context) {
+		report, ready := readiness.Check(ctx.Request.Context())
+		if !ready {
+			ctx.JSON(http.StatusServiceUnavailable, report)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, report)
+	})
+
+	// Публічні ключі для перевірки наших JWT іншими сервісами
+	engine.GET("/.well-known/jwks.json", func(ctx *gin.Context) {
+		ctx.Header("Cache-Control", "public, max-age=300")
+		ctx.JSON(http.StatusOK, jwtService.JWKS())
+	})
+
 	// API v1 group
 	v1Group := engine.Group("/v1")
 	{
 		// Auth роути
-		v1.NewAuthRoutes(v1Group, jwtService, nil, l) // nil замість userService поки що
+		v1.NewAuthRoutes(v1Group, jwtService, userService, sessionService, accountService, authRateLimits(cfg), l)
+		v1.NewUserRoutes(v1Group, jwtService, userService, l)
+		v1.NewArticleRoutes(v1Group, jwtService, articleService, renderService, accessService, l)
+		v1.NewSearchRoutes(v1Group, jwtService, searchService, l)
+		v1.NewSpaceRoutes(v1Group, jwtService, spaceService, accessService, l)
+		v1.NewTagRoutes(v1Group, jwtService, tagService, accessService, l)
+		v1.NewCommentRoutes(v1Group, jwtService, commentService, accessService, l)
+		v1.NewAttachmentRoutes(v1Group, jwtService, attachmentService, accessService, cfg.HTTP.TransferTimeout, l)
+		v1.NewLinkRoutes(v1Group, jwtService, linkService, l)
+		v1.NewImportRoutes(v1Group, jwtService, importService, cfg.Import.MaxSize, cfg.HTTP.TransferTimeout, l)
+		v1.NewExportRoutes(v1Group, jwtService, exportService, accessService, cfg.HTTP.TransferTimeout, l)
 
 		v1.NewTranslationRoutes(v1Group, jwtService, l)
 	}
+
+	return nil
+}
+
+// authRateLimits - ліміти auth ендпоінтів у пам'яті процесу. Для кількох екземплярів
+// сервісу сюди підключається спільне сховище, що реалізує ratelimit.Store.
+func authRateLimits(cfg *config.Config) v1.AuthRateLimits {
+	if !cfg.RateLimit.Enabled {
+		return v1.AuthRateLimits{}
+	}
+
+	return v1.AuthRateLimits{
+		Store:   ratelimit.NewMemory(),
+		PerIP:   ratelimit.PerMinute(cfg.RateLimit.AuthPerMinute, cfg.RateLimit.AuthBurst),
+		PerUser: ratelimit.PerMinute(cfg.RateLimit.LoginPerMinute, cfg.RateLimit.LoginBurst),
+	}
 }
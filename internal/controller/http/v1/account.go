@@ -0,0 +1,191 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountHandler обробляє підтвердження email та скидання пароля
+type AccountHandler struct {
+	accountService *services.AccountService
+	userService    *services.UserService
+	logger         logger.Interface
+}
+
+// NewAccountHandler створює новий екземпляр AccountHandler
+func NewAccountHandler(
+	accountService *services.AccountService,
+	userService *services.UserService,
+	logger logger.Interface,
+) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		userService:    userService,
+		logger:         logger,
+	}
+}
+
+// TokenRequest - токен з посилання в листі
+type TokenRequest struct {
+	Token string `json:"token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// PasswordResetRequest - запит листа для скидання пароля
+type PasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email" example:"johndoe@example.com"`
+}
+
+// PasswordResetConfirmRequest - новий пароль і токен з листа
+type PasswordResetConfirmRequest struct {
+	Token    string `json:"token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Password string `json:"password" binding:"required,min=6" example:"newpassword123"`
+}
+
+// RequestEmailVerification godoc
+// @Summary      Resend email verification
+// @Description  Send the current user a new email verification link
+// @ID           request-email-verification
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      202 {object} MessageResponse
+// @Failure      401 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /auth/verify-email/request [post]
+func (h *AccountHandler) RequestEmailVerification(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		response.Error(c, middleware.ErrNotAuthenticated)
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err = h.accountService.SendEmailVerification(c.Request.Context(), user); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("Verification email sent to user %d", user.ID)
+
+	c.JSON(http.StatusAccepted, MessageResponse{
+		Message: "Verification email sent",
+	})
+}
+
+// ConfirmEmailVerification godoc
+// @Summary      Confirm email
+// @Description  Confirm the email address with the single-use token from the verification email
+// @ID           confirm-email-verification
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body TokenRequest true "Verification token"
+// @Success      200 {object} MessageResponse
+// @Failure      400 {object} response.Problem
+// @Failure      429 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /auth/verify-email/confirm [post]
+func (h *AccountHandler) ConfirmEmailVerification(c *gin.Context) {
+	var req TokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid email verification request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	user, err := h.accountService.VerifyEmail(c.Request.Context(), req.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidActionToken) {
+			middleware.RequestLogger(c, h.logger).Info("Invalid email verification token from %s", c.ClientIP())
+		}
+
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("User %d verified email", user.ID)
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Email verified",
+	})
+}
+
+// RequestPasswordReset godoc
+// @Summary      Request password reset
+// @Description  Email a password reset link if the address belongs to an account. The response is the same for unknown addresses.
+// @ID           request-password-reset
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body PasswordResetRequest true "Account email"
+// @Success      202 {object} MessageResponse
+// @Failure      400 {object} response.Problem
+// @Failure      429 {object} response.Problem
+// @Router       /auth/password-reset/request [post]
+func (h *AccountHandler) RequestPasswordReset(c *gin.Context) {
+	var req PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid password reset request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	// Помилка не повертається клієнту: інакше відповідь видавала б, що адреса зареєстрована
+	if err := h.accountService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		middleware.RequestLogger(c, h.logger).Error("Failed to send password reset email: %v", err)
+	}
+
+	c.JSON(http.StatusAccepted, MessageResponse{
+		Message: "If the address belongs to an account, a password reset email has been sent",
+	})
+}
+
+// ConfirmPasswordReset godoc
+// @Summary      Reset password
+// @Description  Set a new password with the single-use token from the password reset email. All sessions of the user are ended.
+// @ID           confirm-password-reset
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body PasswordResetConfirmRequest true "Reset token and new password"
+// @Success      200 {object} MessageResponse
+// @Failure      400 {object} response.Problem
+// @Failure      429 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /auth/password-reset/confirm [post]
+func (h *AccountHandler) ConfirmPasswordReset(c *gin.Context) {
+	var req PasswordResetConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid password reset confirmation: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	if err := h.accountService.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		if errors.Is(err, services.ErrInvalidActionToken) {
+			middleware.RequestLogger(c, h.logger).Info("Invalid password reset token from %s", c.ClientIP())
+		}
+
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("Password reset from %s", c.ClientIP())
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Password has been reset",
+	})
+}
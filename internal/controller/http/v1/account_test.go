@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"KnowledgeHub/config"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/mailer"
+
+	"github.com/gin-gonic/gin"
+)
+
+// outbox запам'ятовує надіслані листи
+type outbox struct {
+	sent []mailer.Message
+}
+
+func (o *outbox) Send(_ context.Context, msg mailer.Message) error {
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+var _emailToken = regexp.MustCompile(`\?token=(\S+)`)
+
+func (o *outbox) lastToken(t *testing.T) string {
+	t.Helper()
+
+	if len(o.sent) == 0 {
+		t.Fatal("Expected an email to be sent")
+	}
+
+	match := _emailToken.FindStringSubmatch(o.sent[len(o.sent)-1].Text)
+	if match == nil {
+		t.Fatalf("No token in email:\n%s", o.sent[len(o.sent)-1].Text)
+	}
+
+	token, _ := url.QueryUnescape(match[1])
+
+	return token
+}
+
+func setupAccountRouter(t *testing.T) (*gin.Engine, *mocks.Mocks, *outbox) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		JWT: config.JWT{
+			Secret:           "test_secret_key_for_testing_purposes_only",
+			AccessTokenTTL:   900,
+			RefreshTokenTTL:  604800,
+			SigningAlgorithm: "HS256",
+		},
+	}
+
+	mockRepo := mocks.NewRepository()
+
+	jwtService, err := services.NewJWTService(cfg, services.WithRevocationStore(mockRepo.RevokedToken()))
+	if err != nil {
+		t.Fatalf("Failed to create JWT service: %v", err)
+	}
+
+	mail := &outbox{}
+	userService := services.NewUserService(mockRepo.User())
+	sessionService := services.NewSessionService(jwtService, mockRepo.User(), mockRepo.RefreshToken())
+	accountService := services.NewAccountService(jwtService, sessionService, mockRepo.User(), mockRepo.UserToken(),
+		mockRepo.LoginLockout(), mail, services.AccountConfig{
+			LinkBaseURL:      "https://kb.example.com",
+			VerificationTTL:  time.Hour,
+			PasswordResetTTL: time.Hour,
+		})
+
+	router := gin.New()
+	NewAuthRoutes(router.Group("/v1"), jwtService, userService, sessionService, accountService, AuthRateLimits{},
+		logger.New("debug"))
+
+	return router, mockRepo, mail
+}
+
+func postJSON(router *gin.Engine, path string, body any) *httptest.ResponseRecorder {
+	jsonData, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAccountHandler_VerifyEmail(t *testing.T) {
+	router, mockRepo, mail := setupAccountRouter(t)
+
+	w := postJSON(router, "/v1/auth/register", RegisterRequest{
+		Username: "newuser",
+		Email:    "new@example.com",
+		Password: "password123",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Register: expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	if len(mail.sent) != 1 || mail.sent[0].To != "new@example.com" {
+		t.Fatalf("Expected verification email to new@example.com, got %+v", mail.sent)
+	}
+
+	token := mail.lastToken(t)
+
+	if w = postJSON(router, "/v1/auth/verify-email/confirm", TokenRequest{Token: token}); w.Code != http.StatusOK {
+		t.Fatalf("Confirm: expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	user, _ := mockRepo.User().GetUserByEmail(context.Background(), "new@example.com")
+	if user == nil || user.EmailVerifiedAt == nil {
+		t.Errorf("Expected email to be verified, got %+v", user)
+	}
+
+	w = postJSON(router, "/v1/auth/verify-email/confirm", TokenRequest{Token: token})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Reuse: expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var problem response.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || problem.Code != "invalid_action_token" {
+		t.Errorf("Expected invalid_action_token problem, got %s", w.Body.String())
+	}
+}
+
+func TestAccountHandler_PasswordReset(t *testing.T) {
+	router, mockRepo, mail := setupAccountRouter(t)
+
+	hash, _ := services.HashPassword("password")
+	mockRepo.AddUser(&models.User{ID: 1, Username: "admin", Email: "admin@example.com", PasswordHash: hash})
+
+	// Відповідь для невідомої адреси та сама
+	w := postJSON(router, "/v1/auth/password-reset/request", PasswordResetRequest{Email: "nobody@example.com"})
+	if w.Code != http.StatusAccepted || len(mail.sent) != 0 {
+		t.Fatalf("Unknown email: expected 202 without email, got %d and %d emails", w.Code, len(mail.sent))
+	}
+
+	w = postJSON(router, "/v1/auth/password-reset/request", PasswordResetRequest{Email: "admin@example.com"})
+	if w.Code != http.StatusAccepted || len(mail.sent) != 1 {
+		t.Fatalf("Expected 202 with one email, got %d and %d emails", w.Code, len(mail.sent))
+	}
+
+	w = postJSON(router, "/v1/auth/password-reset/confirm", PasswordResetConfirmRequest{
+		Token:    mail.lastToken(t),
+		Password: "short",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Short password: expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w = postJSON(router, "/v1/auth/password-reset/confirm", PasswordResetConfirmRequest{
+		Token:    mail.lastToken(t),
+		Password: "newpassword123",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Confirm: expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	if w = postJSON(router, "/v1/auth/login", LoginRequest{Username: "admin", Password: "password"}); w.Code != http.StatusUnauthorized {
+		t.Errorf("Old password: expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	if w = postJSON(router, "/v1/auth/login", LoginRequest{Username: "admin", Password: "newpassword123"}); w.Code != http.StatusOK {
+		t.Errorf("New password: expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
@@ -0,0 +1,297 @@
+package v1
+
+import (
+	"net/http"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ArticleHandler обробляє CRUD запити статей
+type ArticleHandler struct {
+	articleService *services.ArticleService
+	renderService  *services.RenderService
+	logger         logger.Interface
+}
+
+// NewArticleHandler створює новий екземпляр ArticleHandler
+func NewArticleHandler(
+	articleService *services.ArticleService,
+	renderService *services.RenderService,
+	logger logger.Interface,
+) *ArticleHandler {
+	return &ArticleHandler{
+		articleService: articleService,
+		renderService:  renderService,
+		logger:         logger,
+	}
+}
+
+// ArticleRequest представляє запит на створення або оновлення статті.
+// Language - мова стемінгу для пошуку (simple, english, russian, ...).
+// SpaceID та ParentID задають місце нової статті і ігноруються при оновленні.
+type ArticleRequest struct {
+	Title    string               `json:"title" binding:"required,max=255" example:"Getting started"`
+	Slug     string               `json:"slug" binding:"max=200" example:"getting-started"`
+	Body     string               `json:"body" example:"# Getting started"`
+	Status   models.ArticleStatus `json:"status" binding:"omitempty,oneof=draft published archived" example:"draft"`
+	Language string               `json:"language" binding:"max=32" example:"english"`
+	SpaceID  *uint                `json:"space_id" example:"1"`
+	ParentID *uint                `json:"parent_id" example:"2"`
+}
+
+// ArticleListQuery представляє параметри фільтрації та сторінки списку статей
+type ArticleListQuery struct {
+	pagination.Query
+	SpaceID  uint                 `form:"space_id"`
+	Status   models.ArticleStatus `form:"status" binding:"omitempty,oneof=draft published archived"`
+	AuthorID uint                 `form:"author_id"`
+	Tag      string               `form:"tag" binding:"max=64"`
+}
+
+// ArticleFormatQuery представляє формат тіла статті у відповіді
+type ArticleFormatQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=markdown html"`
+}
+
+// ArticleResponse представляє відповідь з однією статтею
+type ArticleResponse struct {
+	Data models.Article `json:"data"`
+}
+
+// RenderedArticleResponse представляє статтю з HTML тілом та змістом
+type RenderedArticleResponse struct {
+	Data models.RenderedArticle `json:"data"`
+}
+
+// ArticlePageResponse представляє сторінку списку статей; next_cursor порожній на останній сторінці
+type ArticlePageResponse struct {
+	Data       []*models.Article `json:"data"`
+	NextCursor string            `json:"next_cursor" example:"eyJzIjoiLXVwZGF0ZWRfYXQsLWlkIiwidiI6W119"`
+}
+
+// CreateArticle godoc
+// @Summary      Create article
+// @Description  Create a knowledge article authored by the current user. The slug is generated from the title when omitted. A new page is appended to its parent (or to the space root); the space is taken from the parent when only parent_id is given.
+// @Description  Requires permission to write articles in the target space: a space editor can create pages there with a global viewer role.
+// @ID           create-article
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body ArticleRequest true "Article data"
+// @Success      201 {object} ArticleResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles [post]
+func (h *ArticleHandler) CreateArticle(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var req ArticleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid article request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	article, err := h.articleService.Create(c.Request.Context(), actor, req.toInput())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("Article %d created by user %d", article.ID, actor.UserID)
+
+	c.JSON(http.StatusCreated, ArticleResponse{Data: *article})
+}
+
+// ListArticles godoc
+// @Summary      List articles
+// @Description  List articles, by default from the last updated. space_id, status, author_id and tag are shortcuts for the most common filters.
+// @ID           list-articles
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        space_id  query int    false "Space ID"
+// @Param        status    query string false "Article status" Enums(draft, published, archived)
+// @Param        author_id query int    false "Author ID"
+// @Param        tag       query string false "Tag name"
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: title, slug, created_at, updated_at (default -updated_at)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, title, slug, status, language, author_id, created_at, updated_at" collectionFormat(multi)
+// @Success      200 {object} ArticlePageResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles [get]
+func (h *ArticleHandler) ListArticles(c *gin.Context) {
+	var query ArticleListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.articleService.List(c.Request.Context(), models.ArticleFilter{
+		SpaceID:  query.SpaceID,
+		AuthorID: query.AuthorID,
+		Status:   query.Status,
+		Tag:      query.Tag,
+	}, query.Query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ArticlePageResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// GetArticle godoc
+// @Summary      Get article by ID
+// @Description  Get article details by ID. With format=html the response also contains the body rendered to sanitized HTML (`html`) and the table of contents built from its headings (`toc`, see RenderedArticleResponse); heading ids in the HTML match the toc anchors.
+// @ID           get-article
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path  int    true  "Article ID"
+// @Param        format query string false "Body format" Enums(markdown, html)
+// @Success      200 {object} ArticleResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id} [get]
+func (h *ArticleHandler) GetArticle(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query ArticleFormatQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	article, err := h.articleService.Get(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if query.Format != "html" {
+		c.JSON(http.StatusOK, ArticleResponse{Data: *article})
+		return
+	}
+
+	rendered, err := h.renderService.Render(c.Request.Context(), article)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RenderedArticleResponse{Data: *rendered})
+}
+
+// UpdateArticle godoc
+// @Summary      Update article
+// @Description  Replace article title and body and record a new revision. Slug and status are changed only when provided.
+// @ID           update-article
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int            true "Article ID"
+// @Param        request body ArticleRequest true "Article data"
+// @Success      200 {object} ArticleResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id} [put]
+func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req ArticleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid article request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	article, err := h.articleService.Update(c.Request.Context(), id, userID, req.toInput())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ArticleResponse{Data: *article})
+}
+
+// DeleteArticle godoc
+// @Summary      Delete article
+// @Description  Permanently delete an article. Pages with child pages cannot be deleted.
+// @ID           delete-article
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Article ID"
+// @Success      204
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id} [delete]
+func (h *ArticleHandler) DeleteArticle(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.articleService.Delete(c.Request.Context(), id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+	middleware.RequestLogger(c, h.logger).Info("Article %d deleted by user %d", id, userID)
+
+	c.Status(http.StatusNoContent)
+}
+
+func (r ArticleRequest) toInput() services.ArticleInput {
+	return services.ArticleInput{
+		Title:    r.Title,
+		Slug:     r.Slug,
+		Body:     r.Body,
+		Status:   r.Status,
+		Language: r.Language,
+		SpaceID:  r.SpaceID,
+		ParentID: r.ParentID,
+	}
+}
@@ -0,0 +1,205 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidRevision = apperror.Validation("invalid_revision", "revision must be a positive number")
+
+// RevisionDiffQuery представляє пару ревізій для порівняння
+type RevisionDiffQuery struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+// RevisionResponse представляє відповідь з однією ревізією
+type RevisionResponse struct {
+	Data models.ArticleRevision `json:"data"`
+}
+
+// RevisionListResponse представляє сторінку історії ревізій
+type RevisionListResponse struct {
+	Data       []*models.ArticleRevision `json:"data"`
+	NextCursor string                    `json:"next_cursor" example:"eyJzIjoiLXZlcnNpb24iLCJ2IjpbIjMiXX0"`
+}
+
+// RevisionDiffResponse представляє відповідь з різницею ревізій
+type RevisionDiffResponse struct {
+	Data services.RevisionDiff `json:"data"`
+}
+
+// ListRevisions godoc
+// @Summary      List article revisions
+// @Description  List revisions of an article, by default from the newest
+// @ID           list-article-revisions
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  int      true  "Article ID"
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: version, created_at (default -version)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: version, editor_id, created_at" collectionFormat(multi)
+// @Success      200 {object} RevisionListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/revisions [get]
+func (h *ArticleHandler) ListRevisions(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.articleService.ListRevisions(c.Request.Context(), id, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RevisionListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// GetRevision godoc
+// @Summary      Get article revision
+// @Description  Get a specific revision of an article
+// @ID           get-article-revision
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int true "Article ID"
+// @Param        version path int true "Revision number"
+// @Success      200 {object} RevisionResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/revisions/{version} [get]
+func (h *ArticleHandler) GetRevision(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := h.revisionVersion(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.articleService.GetRevision(c.Request.Context(), id, version)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RevisionResponse{Data: *revision})
+}
+
+// DiffRevisions godoc
+// @Summary      Diff article revisions
+// @Description  Line-level diff of the article body between two revisions
+// @ID           diff-article-revisions
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  int true "Article ID"
+// @Param        from query int true "Base revision number"
+// @Param        to   query int true "Target revision number"
+// @Success      200 {object} RevisionDiffResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/diff [get]
+func (h *ArticleHandler) DiffRevisions(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query RevisionDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	revisionDiff, err := h.articleService.DiffRevisions(c.Request.Context(), id, query.From, query.To)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RevisionDiffResponse{Data: *revisionDiff})
+}
+
+// RestoreRevision godoc
+// @Summary      Restore article revision
+// @Description  Restore title and body of an old revision. The restore is recorded as a new revision; history is never rewritten.
+// @ID           restore-article-revision
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int true "Article ID"
+// @Param        version path int true "Revision number to restore"
+// @Success      200 {object} ArticleResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/revisions/{version}/restore [post]
+func (h *ArticleHandler) RestoreRevision(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := h.revisionVersion(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	article, err := h.articleService.RestoreRevision(c.Request.Context(), id, version, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("Article %d restored to revision %d by user %d", id, version, userID)
+
+	c.JSON(http.StatusOK, ArticleResponse{Data: *article})
+}
+
+// revisionVersion розбирає :version з шляху; при помилці вже відповідає 400
+func (h *ArticleHandler) revisionVersion(c *gin.Context) (int, bool) {
+	version, err := strconv.ParseUint(c.Param("version"), 10, 31)
+	if err != nil || version == 0 {
+		response.Error(c, errInvalidRevision)
+		return 0, false
+	}
+
+	return int(version), true
+}
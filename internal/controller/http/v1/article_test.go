@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getTestArticleRouter реєструє маршрути статей; автентифікацію імітує
+// middleware, що кладе user_id і role у контекст так само, як JWTAuthMiddleware
+func getTestArticleRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	articleHandler := NewArticleHandler(articleService, services.NewRenderService(mockRepo.Link(), 0), logger.New("debug"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("role", models.RoleEditor)
+		c.Next()
+	})
+
+	router.POST("/articles", articleHandler.CreateArticle)
+	router.GET("/articles", articleHandler.ListArticles)
+	router.GET("/articles/:id", articleHandler.GetArticle)
+	router.PUT("/articles/:id", articleHandler.UpdateArticle)
+	router.DELETE("/articles/:id", articleHandler.DeleteArticle)
+	router.GET("/articles/:id/revisions", articleHandler.ListRevisions)
+	router.GET("/articles/:id/revisions/:version", articleHandler.GetRevision)
+	router.POST("/articles/:id/revisions/:version/restore", articleHandler.RestoreRevision)
+	router.GET("/articles/:id/diff", articleHandler.DiffRevisions)
+
+	return router
+}
+
+func doArticleRequest(router *gin.Engine, method, url string, body any) *httptest.ResponseRecorder {
+	var payload bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&payload).Encode(body)
+	}
+
+	req := httptest.NewRequest(method, url, &payload)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestArticleHandler_CRUD(t *testing.T) {
+	router := getTestArticleRouter()
+
+	w := doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "Getting Started", Body: "# Hi"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var created ArticleResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if created.Data.Slug != "getting-started" || created.Data.AuthorID != 1 {
+		t.Errorf("Unexpected article: %+v", created.Data)
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/1", nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	w = doArticleRequest(router, http.MethodPut, "/articles/1", ArticleRequest{Title: "Updated", Status: "published"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var updated ArticleResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if updated.Data.Title != "Updated" || updated.Data.Status != "published" {
+		t.Errorf("Unexpected article after update: %+v", updated.Data)
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles?status=published", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var list ArticlePageResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(list.Data) != 1 || list.NextCursor != "" {
+		t.Errorf("Expected 1 published article without next cursor, got %d, %q", len(list.Data), list.NextCursor)
+	}
+
+	w = doArticleRequest(router, http.MethodDelete, "/articles/1", nil)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/1", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestArticleHandler_RenderHTML(t *testing.T) {
+	router := getTestArticleRouter()
+
+	body := "# Setup\n\n<script>alert(1)</script>\n\n## Install"
+
+	w := doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "Guide", Body: body})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/1?format=html", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var rendered RenderedArticleResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &rendered); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if rendered.Data.Title != "Guide" || rendered.Data.Body != body {
+		t.Errorf("Unexpected article: %+v", rendered.Data.Article)
+	}
+
+	if strings.Contains(rendered.Data.HTML, "<script") || !strings.Contains(rendered.Data.HTML, `<h1 id="setup">`) {
+		t.Errorf("Unexpected HTML: %s", rendered.Data.HTML)
+	}
+
+	if len(rendered.Data.TOC) != 2 || rendered.Data.TOC[1].Anchor != "install" {
+		t.Errorf("Unexpected TOC: %+v", rendered.Data.TOC)
+	}
+
+	// Без format відповідь лишається markdown
+	w = doArticleRequest(router, http.MethodGet, "/articles/1?format=markdown", nil)
+	if strings.Contains(w.Body.String(), `"html"`) {
+		t.Errorf("Markdown response contains HTML: %s", w.Body.String())
+	}
+}
+
+func TestArticleHandler_Errors(t *testing.T) {
+	router := getTestArticleRouter()
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       any
+		wantStatus int
+	}{
+		{"Missing title", http.MethodPost, "/articles", ArticleRequest{Body: "text"}, http.StatusBadRequest},
+		{"Invalid status", http.MethodPost, "/articles", ArticleRequest{Title: "x", Status: "deleted"}, http.StatusBadRequest},
+		{"Invalid id", http.MethodGet, "/articles/abc", nil, http.StatusBadRequest},
+		{"Unknown article", http.MethodPut, "/articles/42", ArticleRequest{Title: "x"}, http.StatusNotFound},
+		{"Invalid list filter", http.MethodGet, "/articles?limit=1000", nil, http.StatusBadRequest},
+		{"Unknown sort field", http.MethodGet, "/articles?sort=body", nil, http.StatusBadRequest},
+		{"Invalid filter expression", http.MethodGet, "/articles?filter=author_id:like:1", nil, http.StatusBadRequest},
+		{"Invalid format", http.MethodGet, "/articles/1?format=pdf", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(router, tt.method, tt.url, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// Повторний явний slug дає конфлікт
+	doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "One", Slug: "same"})
+
+	w := doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "Two", Slug: "same"})
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestArticleHandler_Revisions(t *testing.T) {
+	router := getTestArticleRouter()
+
+	doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "Guide", Body: "one\ntwo\n"})
+	doArticleRequest(router, http.MethodPut, "/articles/1", ArticleRequest{Title: "Guide", Body: "one\nthree\n"})
+
+	w := doArticleRequest(router, http.MethodGet, "/articles/1/revisions", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var list RevisionListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(list.Data) != 2 {
+		t.Fatalf("Expected 2 revisions, got %d", len(list.Data))
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/1/diff?from=1&to=2", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var diffResponse RevisionDiffResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &diffResponse); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	want := "--- v1\n+++ v2\n@@ -1,2 +1,2 @@\n one\n-two\n+three\n"
+	if diffResponse.Data.Unified != want {
+		t.Errorf("Unexpected unified diff:\n%s", diffResponse.Data.Unified)
+	}
+
+	w = doArticleRequest(router, http.MethodPost, "/articles/1/revisions/1/restore", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var restored ArticleResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &restored); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if restored.Data.Version != 3 || restored.Data.Body != "one\ntwo\n" {
+		t.Errorf("Unexpected restored article: %+v", restored.Data)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		wantStatus int
+	}{
+		{"Unknown revision", http.MethodGet, "/articles/1/revisions/9", http.StatusNotFound},
+		{"Invalid revision", http.MethodGet, "/articles/1/revisions/0", http.StatusBadRequest},
+		{"Diff without range", http.MethodGet, "/articles/1/diff", http.StatusBadRequest},
+		{"Restore unknown revision", http.MethodPost, "/articles/1/revisions/9/restore", http.StatusNotFound},
+		{"Revisions of unknown article", http.MethodGet, "/articles/7/revisions", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(router, tt.method, tt.url, nil)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// _multipartOverhead - запас на межі та заголовки multipart поверх розміру файлу
+	_multipartOverhead = 64 << 10
+	_attachmentField   = "file"
+)
+
+var (
+	errMissingAttachment = apperror.Validation("missing_file", "multipart field \"file\" is required")
+	errInvalidMultipart  = apperror.Validation("invalid_multipart", "invalid multipart body")
+)
+
+// AttachmentHandler обробляє запити вкладень статей
+type AttachmentHandler struct {
+	attachmentService *services.AttachmentService
+	logger            logger.Interface
+}
+
+// NewAttachmentHandler створює новий екземпляр AttachmentHandler
+func NewAttachmentHandler(attachmentService *services.AttachmentService, logger logger.Interface) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		logger:            logger,
+	}
+}
+
+// AttachmentResponse представляє відповідь з одним вкладенням
+type AttachmentResponse struct {
+	Data models.Attachment `json:"data"`
+}
+
+// AttachmentListResponse представляє сторінку вкладень статті
+type AttachmentListResponse struct {
+	Data       []*models.Attachment `json:"data"`
+	NextCursor string               `json:"next_cursor" example:"eyJzIjoiY3JlYXRlZF9hdCxpZCIsInYiOltdfQ"`
+}
+
+// AttachmentPurgeResult представляє результат очистки сховища
+type AttachmentPurgeResult struct {
+	Deleted int `json:"deleted" example:"3"`
+}
+
+// AttachmentPurgeResponse представляє відповідь на очистку сховища
+type AttachmentPurgeResponse struct {
+	Data AttachmentPurgeResult `json:"data"`
+}
+
+// ListAttachments godoc
+// @Summary      List article attachments
+// @Description  List files attached to an article, by default from the oldest
+// @ID           list-attachments
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  int      true  "Article ID"
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: id, filename, size, created_at (default created_at)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, filename, content_type, size, uploaded_by, created_at" collectionFormat(multi)
+// @Success      200 {object} AttachmentListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	articleID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.attachmentService.List(c.Request.Context(), articleID, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, AttachmentListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// UploadAttachment godoc
+// @Summary      Upload attachment
+// @Description  Attach a file to an article. The type is detected from the file content and must be allowed by the server (images and PDF by default). Identical files are stored once.
+// @ID           upload-attachment
+// @Tags         attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path     int  true "Article ID"
+// @Param        file formData file true "File to attach"
+// @Success      201 {object} AttachmentResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      413 {object} response.Problem
+// @Failure      415 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		response.Error(c, middleware.ErrNotAuthenticated)
+		return
+	}
+
+	articleID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	// Файл читається потоком з тіла запиту, без буферизації всієї форми
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachmentService.MaxSize()+_multipartOverhead)
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		response.Error(c, errInvalidMultipart.Withf("expected multipart/form-data request"))
+		return
+	}
+
+	for {
+		part, partErr := reader.NextPart()
+		if partErr != nil {
+			response.Error(c, multipartError(partErr, services.ErrAttachmentTooLarge))
+			return
+		}
+
+		if part.FormName() != _attachmentField {
+			continue
+		}
+
+		attachment, uploadErr := h.attachmentService.Upload(c.Request.Context(), userID, articleID,
+			part.FileName(), part)
+		if uploadErr != nil {
+			response.Error(c, bodyTooLarge(uploadErr, services.ErrAttachmentTooLarge))
+			return
+		}
+
+		middleware.RequestLogger(c, h.logger).Info("Attachment %d (%s) uploaded to article %d by user %d",
+			attachment.ID, attachment.SHA256, articleID, userID)
+
+		c.JSON(http.StatusCreated, AttachmentResponse{Data: *attachment})
+
+		return
+	}
+}
+
+// DownloadAttachment godoc
+// @Summary      Download attachment
+// @Description  Download the content of an attachment. Images and PDF are served inline, other types as a download. Responds 304 when If-None-Match matches the ETag (the SHA-256 of the content).
+// @ID           download-attachment
+// @Tags         attachments
+// @Produce      application/octet-stream
+// @Security     BearerAuth
+// @Param        id            path   int    true  "Article ID"
+// @Param        attachment_id path   int    true  "Attachment ID"
+// @Param        If-None-Match header string false "ETag from a previous response"
+// @Success      200 {file} file
+// @Success      304
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/attachments/{attachment_id} [get]
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	articleID, attachmentID, ok := h.attachmentTarget(c)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.attachmentService.Get(c.Request.Context(), articleID, attachmentID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	// Вміст за хешем незмінний, тому ETag - сам хеш
+	etag := `"` + attachment.SHA256 + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, max-age=86400")
+
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	content, err := h.attachmentService.Content(c.Request.Context(), attachment)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    contentDisposition(attachment),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment godoc
+// @Summary      Delete attachment
+// @Description  Delete an attachment; its content is removed from storage when no other attachment uses it
+// @ID           delete-attachment
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path int true "Article ID"
+// @Param        attachment_id path int true "Attachment ID"
+// @Success      204
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	articleID, attachmentID, ok := h.attachmentTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentService.Delete(c.Request.Context(), articleID, attachmentID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PurgeAttachments godoc
+// @Summary      Purge unused attachment content
+// @Description  Remove stored content that no attachment references anymore, e.g. after articles were deleted. Administrators only.
+// @ID           purge-attachments
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} AttachmentPurgeResponse
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /attachments/purge [post]
+func (h *AttachmentHandler) PurgeAttachments(c *gin.Context) {
+	deleted, err := h.attachmentService.PurgeOrphans(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("Purged %d unused attachment blobs", deleted)
+
+	c.JSON(http.StatusOK, AttachmentPurgeResponse{Data: AttachmentPurgeResult{Deleted: deleted}})
+}
+
+// attachmentTarget розбирає :id статті та :attachment_id
+func (h *AttachmentHandler) attachmentTarget(c *gin.Context) (uint, uint, bool) {
+	articleID, ok := pathID(c, "id")
+	if !ok {
+		return 0, 0, false
+	}
+
+	attachmentID, ok := pathID(c, "attachment_id")
+	if !ok {
+		return 0, 0, false
+	}
+
+	return articleID, attachmentID, true
+}
+
+// multipartError відрізняє відсутній файл і перевищення розміру (tooLarge) від пошкодженої форми
+func multipartError(err, tooLarge error) error {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return errMissingAttachment
+	case errors.As(err, &maxBytesErr):
+		return tooLarge
+	default:
+		return errInvalidMultipart
+	}
+}
+
+// bodyTooLarge замінює помилку ліміту http.MaxBytesReader на tooLarge
+func bodyTooLarge(err, tooLarge error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return tooLarge
+	}
+
+	return err
+}
+
+// contentDisposition показує зображення та PDF у браузері, решту - завантажує
+func contentDisposition(attachment *models.Attachment) string {
+	disposition := "attachment"
+
+	mediaType, _, _ := mime.ParseMediaType(attachment.ContentType)
+	if strings.HasPrefix(mediaType, "image/") || mediaType == "application/pdf" {
+		disposition = "inline"
+	}
+
+	// FormatMediaType кодує не-ASCII імена за RFC 2231 (filename*=utf-8''...)
+	formatted := mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename})
+	if formatted != "" {
+		return formatted
+	}
+
+	return disposition
+}
@@ -0,0 +1,176 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func getTestAttachmentRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	blobs, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	mockRepo := mocks.NewRepository()
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	attachmentService := services.NewAttachmentService(mockRepo.Attachment(), mockRepo.Article(), blobs,
+		services.WithMaxAttachmentSize(1024))
+	articleHandler := NewArticleHandler(articleService, services.NewRenderService(mockRepo.Link(), 0), logger.New("debug"))
+	attachmentHandler := NewAttachmentHandler(attachmentService, logger.New("debug"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("role", models.RoleEditor)
+		c.Next()
+	})
+
+	router.POST("/articles", articleHandler.CreateArticle)
+	router.GET("/articles/:id/attachments", attachmentHandler.ListAttachments)
+	router.POST("/articles/:id/attachments", attachmentHandler.UploadAttachment)
+	router.GET("/articles/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+	router.DELETE("/articles/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachment)
+
+	return router
+}
+
+// doUpload надсилає content як multipart поле field з іменем файлу filename
+func doUpload(router *gin.Engine, url, field, filename string, content []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField("comment", "ignored")
+	part, _ := form.CreateFormFile(field, filename)
+	_, _ = part.Write(content)
+	_ = form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, url, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAttachmentHandler(t *testing.T) {
+	router := getTestAttachmentRouter(t)
+
+	w := doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "Runbook"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create article status = %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doUpload(router, "/articles/1/attachments", "file", "схема.png", _testPNG)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Upload status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var uploaded AttachmentResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &uploaded); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if uploaded.Data.ContentType != "image/png" || uploaded.Data.Filename != "схема.png" {
+		t.Errorf("Unexpected attachment: %+v", uploaded.Data)
+	}
+
+	uploadTests := []struct {
+		name     string
+		url      string
+		field    string
+		content  []byte
+		wantCode int
+	}{
+		{"missing file field", "/articles/1/attachments", "upload", _testPNG, http.StatusBadRequest},
+		{"empty file", "/articles/1/attachments", "file", nil, http.StatusBadRequest},
+		{"too large", "/articles/1/attachments", "file", bytes.Repeat(_testPNG, 100), http.StatusRequestEntityTooLarge},
+		{"html", "/articles/1/attachments", "file", []byte("<!DOCTYPE html><p>hi"), http.StatusUnsupportedMediaType},
+		{"unknown article", "/articles/9/attachments", "file", _testPNG, http.StatusNotFound},
+		{"invalid id", "/articles/x/attachments", "file", _testPNG, http.StatusBadRequest},
+	}
+
+	for _, tt := range uploadTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doUpload(router, tt.url, tt.field, "a.png", tt.content); w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/articles/1/attachments", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for JSON upload, got %d", w.Code)
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/1/attachments", nil)
+
+	var list AttachmentListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Data) != 1 || list.NextCursor != "" {
+		t.Fatalf("List = %d %s", w.Code, w.Body.String())
+	}
+
+	if w = doArticleRequest(router, http.MethodGet, "/articles/1/attachments?sort=sha256", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unsupported sort, got %d", w.Code)
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/1/attachments/1", nil)
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), _testPNG) {
+		t.Fatalf("Download = %d %q", w.Code, w.Body.String())
+	}
+
+	wantDisposition := "inline; filename*=utf-8''%D1%81%D1%85%D0%B5%D0%BC%D0%B0.png"
+	if got := w.Header().Get("Content-Disposition"); got != wantDisposition {
+		t.Errorf("Content-Disposition = %q, want %q", got, wantDisposition)
+	}
+
+	if w.Header().Get("Content-Type") != "image/png" || w.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("Unexpected download headers: %v", w.Header())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/articles/1/attachments/1", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("Conditional download = %d with %d bytes", w.Code, w.Body.Len())
+	}
+
+	if w = doArticleRequest(router, http.MethodGet, "/articles/2/attachments/1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for attachment of another article, got %d", w.Code)
+	}
+
+	if w = doArticleRequest(router, http.MethodDelete, "/articles/1/attachments/1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("Delete status = %d: %s", w.Code, w.Body.String())
+	}
+
+	if w = doArticleRequest(router, http.MethodGet, "/articles/1/attachments/1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 after delete, got %d", w.Code)
+	}
+}
@@ -1,47 +1,54 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/metrics"
+	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/apperror"
 	"KnowledgeHub/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
-const (
-	adminUsername = "admin"
-	adminPassword = "password"
-	adminEmail    = "admin@example.com"
-)
-
 // AuthHandler обробляє запити аутентифікації
 type AuthHandler struct {
-	jwtService  *services.JWTService
-	userService *services.UserService
-	logger      logger.Interface
-	validator   *validator.Validate
+	jwtService     *services.JWTService
+	userService    *services.UserService
+	sessionService *services.SessionService
+	accountService *services.AccountService
+	logger         logger.Interface
+	validator      *validator.Validate
 }
 
 // NewAuthHandler створює новий екземпляр AuthHandler
 func NewAuthHandler(
 	jwtService *services.JWTService,
 	userService *services.UserService,
+	sessionService *services.SessionService,
+	accountService *services.AccountService,
 	logger logger.Interface,
 ) *AuthHandler {
 	return &AuthHandler{
-		jwtService:  jwtService,
-		userService: userService,
-		logger:      logger,
-		validator:   validator.New(validator.WithRequiredStructEnabled()),
+		jwtService:     jwtService,
+		userService:    userService,
+		sessionService: sessionService,
+		accountService: accountService,
+		logger:         logger,
+		validator:      validator.New(validator.WithRequiredStructEnabled()),
 	}
 }
 
 // LoginRequest представляє запит на логін
 type LoginRequest struct {
-	Username string `json:"username" binding:"required" example:"johndoe"`
+	Username string `json:"username" binding:"required,max=50" example:"johndoe"`
 	Password string `json:"password" binding:"required" example:"password123"`
 }
 
@@ -62,9 +69,10 @@ type AuthResponse struct {
 
 // UserInfo представляє інформацію про користувача
 type UserInfo struct {
-	ID       uint   `json:"id" example:"1"`
-	Username string `json:"username" example:"johndoe"`
-	Email    string `json:"email" example:"johndoe@example.com"`
+	ID       uint        `json:"id" example:"1"`
+	Username string      `json:"username" example:"johndoe"`
+	Email    string      `json:"email" example:"johndoe@example.com"`
+	Role     models.Role `json:"role" example:"editor"`
 }
 
 // RefreshRequest представляє запит на оновлення токена
@@ -72,11 +80,6 @@ type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
 
-// ErrorResponse представляє структуру помилки
-type ErrorResponse struct {
-	Error string `json:"error" example:"Invalid request"`
-}
-
 // Login godoc
 // @Summary      User login
 // @Description  Authenticate user and return JWT tokens
@@ -86,169 +89,176 @@ type ErrorResponse struct {
 // @Produce      json
 // @Param        request body LoginRequest true "Login credentials"
 // @Success      200 {object} AuthResponse
-// @Failure      400 {object} ErrorResponse
-// @Failure      401 {object} ErrorResponse
-// @Failure      500 {object} ErrorResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      429 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error("Invalid login request: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request format",
-		})
+	// Тіло могло бути прочитане раніше ключем ліміту loginUsernameKey
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid login request: %v", err)
+		response.Invalid(c, err)
 		return
 	}
 
-	// TODO: Тут буде логіка перевірки користувача в базі даних
-	// Поки що заглушку для демонстрації
-	if req.Username != adminUsername || req.Password != adminPassword {
-		h.logger.Info("Failed login attempt for username: %s from %s", req.Username, c.ClientIP())
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid username or password",
-		})
+	user, err := h.userService.Authenticate(c.Request.Context(), req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			middleware.RequestLogger(c, h.logger).Info("Failed login attempt for username: %s from %s",
+				req.Username, c.ClientIP())
+			metrics.ObserveLogin(metrics.LoginFailure)
+		} else if errors.Is(err, services.ErrAccountLocked) {
+			middleware.RequestLogger(c, h.logger).Warn("Login attempt for locked username: %s from %s",
+				req.Username, c.ClientIP())
+			metrics.ObserveLogin(metrics.LoginLocked)
+		}
+
+		response.Error(c, err)
 		return
 	}
 
-	tokenPair, err := h.jwtService.GenerateTokenPair(1, req.Username, adminEmail)
+	tokenPair, err := h.sessionService.StartSession(c.Request.Context(), user)
 	if err != nil {
-		h.logger.Error("Failed to generate tokens: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to generate authentication tokens",
-		})
+		response.Error(c, err)
 		return
 	}
 
-	h.logger.Info("User %s logged in successfully from %s", req.Username, c.ClientIP())
+	middleware.RequestLogger(c, h.logger).Info("User %s logged in successfully from %s", user.Username, c.ClientIP())
+	metrics.ObserveLogin(metrics.LoginSuccess)
 
 	c.JSON(http.StatusOK, AuthResponse{
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresAt:    tokenPair.ExpiresAt,
 		User: UserInfo{
-			ID:       1,
-			Username: req.Username,
-			Email:    adminEmail,
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
 		},
 	})
 }
 
+// loginUsernameKey - ключ ліміту входів за username, щоб перебір паролів
+// одного облікового запису з різних IP теж обмежувався
+func loginUsernameKey(c *gin.Context) string {
+	var req LoginRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(req.Username))
+}
+
 // Register godoc
 // @Summary      User registration
-// @Description  Register a new user and return JWT tokens
+// @Description  Register a new user, send an email verification link and return JWT tokens
 // @ID           register
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request body RegisterRequest true "Registration data"
 // @Success      201 {object} AuthResponse
-// @Failure      400 {object} ErrorResponse
-// @Failure      409 {object} ErrorResponse
-// @Failure      500 {object} ErrorResponse
+// @Failure      400 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      429 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error("Invalid registration request: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request format",
-		})
+		middleware.RequestLogger(c, h.logger).Warn("Invalid registration request: %v", err)
+		response.Invalid(c, err)
 		return
 	}
 
-	// TODO: Тут буде логіка створення користувача в базі даних
-	// Поки що заглушку для демонстрації
-	if req.Username == adminUsername {
-		h.logger.Info("Registration attempt with existing username: %s from %s", req.Username, c.ClientIP())
-		c.JSON(http.StatusConflict, gin.H{
-			"error": "Username already exists",
-		})
+	user, err := h.userService.Register(c.Request.Context(), req.Username, req.Email, req.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrUserAlreadyExists) {
+			middleware.RequestLogger(c, h.logger).Info("Registration attempt with existing username or email: %s from %s",
+				req.Username, c.ClientIP())
+		}
+
+		response.Error(c, err)
 		return
 	}
 
-	userID := uint(2) // Заглушка для ID нового користувача
-	tokenPair, err := h.jwtService.GenerateTokenPair(userID, req.Username, req.Email)
+	tokenPair, err := h.sessionService.StartSession(c.Request.Context(), user)
 	if err != nil {
-		h.logger.Error("Failed to generate tokens for new user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to generate authentication tokens",
-		})
+		response.Error(c, err)
 		return
 	}
 
-	h.logger.Info("User %s registered successfully from %s", req.Username, c.ClientIP())
+	middleware.RequestLogger(c, h.logger).Info("User %s registered successfully from %s", user.Username, c.ClientIP())
+
+	// Лист можна запросити повторно, тому збій надсилання не скасовує реєстрацію
+	if err = h.accountService.SendEmailVerification(c.Request.Context(), user); err != nil {
+		middleware.RequestLogger(c, h.logger).Error("Failed to send verification email to user %d: %v", user.ID, err)
+	}
 
 	c.JSON(http.StatusCreated, AuthResponse{
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresAt:    tokenPair.ExpiresAt,
 		User: UserInfo{
-			ID:       userID,
-			Username: req.Username,
-			Email:    req.Email,
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
 		},
 	})
 }
 
 // RefreshToken godoc
 // @Summary      Refresh access token
-// @Description  Refresh access token using refresh token
+// @Description  Exchange a single-use refresh token for a new token pair. Reusing a refresh token revokes its whole session.
 // @ID           refresh-token
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request body RefreshRequest true "Refresh token"
 // @Success      200 {object} AuthResponse
-// @Failure      400 {object} ErrorResponse
-// @Failure      401 {object} ErrorResponse
-// @Failure      500 {object} ErrorResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      429 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req RefreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error("Invalid refresh request: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request format",
-		})
-		return
-	}
-
-	// Валідуємо refresh токен
-	_, err := h.jwtService.ValidateRefreshToken(req.RefreshToken)
-	if err != nil {
-		h.logger.Info("Invalid refresh token from %s: %v", c.ClientIP(), err)
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid or expired refresh token",
-		})
+		middleware.RequestLogger(c, h.logger).Warn("Invalid refresh request: %v", err)
+		response.Invalid(c, err)
 		return
 	}
 
-	// TODO: Тут буде логіка отримання користувача з бази даних за ID з claims
-	// Поки що використовуємо заглушку
-	userID := uint(1)
-	username := adminUsername
-	email := adminEmail
-
-	// Генеруємо нові токени
-	tokenPair, err := h.jwtService.RefreshTokens(req.RefreshToken, userID, username, email)
+	tokenPair, user, err := h.sessionService.RefreshSession(c.Request.Context(), req.RefreshToken)
 	if err != nil {
-		h.logger.Error("Failed to refresh tokens: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to refresh tokens",
-		})
+		switch {
+		case errors.Is(err, services.ErrRefreshTokenReused):
+			middleware.RequestLogger(c, h.logger).Warn("Refresh token reuse detected from %s, token family revoked",
+				c.ClientIP())
+		case apperror.KindOf(err) == apperror.KindUnauthorized:
+			middleware.RequestLogger(c, h.logger).Info("Invalid refresh token from %s: %v", c.ClientIP(), err)
+		}
+
+		response.Error(c, err)
 		return
 	}
 
-	h.logger.Info("Tokens refreshed successfully for user %s from %s", username, c.ClientIP())
+	middleware.RequestLogger(c, h.logger).Info("Tokens refreshed successfully for user %s from %s",
+		user.Username, c.ClientIP())
 
 	c.JSON(http.StatusOK, AuthResponse{
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresAt:    tokenPair.ExpiresAt,
 		User: UserInfo{
-			ID:       userID,
-			Username: username,
-			Email:    email,
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
 		},
 	})
 }
@@ -260,32 +270,66 @@ type MessageResponse struct {
 
 // Logout godoc
 // @Summary      User logout
-// @Description  Logout user (invalidate tokens on client side)
+// @Description  Revoke the current access token and the refresh tokens of its session
 // @ID           logout
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200 {object} MessageResponse
-// @Failure      401 {object} ErrorResponse
+// @Failure      401 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// Отримуємо інформацію про користувача з контексту
-	username, exists := middleware.GetUsernameFromContext(c)
+	claims, exists := middleware.GetJWTClaimsFromContext(c)
 	if !exists {
-		username = "unknown"
+		response.Error(c, middleware.ErrNotAuthenticated)
+		return
 	}
 
-	h.logger.Info("User %s logged out from %s", username, c.ClientIP())
+	if err := h.sessionService.EndSession(c.Request.Context(), claims); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("User %s logged out from %s", claims.Username, c.ClientIP())
 
-	// В JWT архітектурі logout зазвичай обробляється на клієнті
-	// шляхом видалення токенів з локального сховища
-	// Для серверного logout можна використовувати blacklist токенів
 	c.JSON(http.StatusOK, MessageResponse{
 		Message: "Successfully logged out",
 	})
 }
 
+// LogoutAll godoc
+// @Summary      Logout from all sessions
+// @Description  Revoke every access and refresh token issued to the current user
+// @ID           logout-all
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} MessageResponse
+// @Failure      401 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		response.Error(c, middleware.ErrNotAuthenticated)
+		return
+	}
+
+	if err := h.sessionService.EndAllSessions(c.Request.Context(), userID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("User %d logged out from all sessions from %s", userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Successfully logged out from all sessions",
+	})
+}
+
 // Me godoc
 // @Summary      Get current user info
 // @Description  Get information about currently authenticated user
@@ -295,24 +339,24 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200 {object} UserInfo
-// @Failure      401 {object} ErrorResponse
+// @Failure      401 {object} response.Problem
 // @Router       /auth/me [get]
 func (h *AuthHandler) Me(c *gin.Context) {
 
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		response.Error(c, middleware.ErrNotAuthenticated)
 		return
 	}
 
 	username, _ := middleware.GetUsernameFromContext(c)
 	email, _ := middleware.GetEmailFromContext(c)
+	role, _ := middleware.GetRoleFromContext(c)
 
 	c.JSON(http.StatusOK, UserInfo{
 		ID:       userID,
 		Username: username,
 		Email:    email,
+		Role:     role,
 	})
 }
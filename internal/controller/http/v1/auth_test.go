@@ -5,14 +5,19 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"KnowledgeHub/config"
 	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/repo/mocks"
 	"KnowledgeHub/internal/services"
 	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/mailer"
+	"KnowledgeHub/pkg/ratelimit"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,12 +32,35 @@ func getTestAuthHandler() (*AuthHandler, *services.JWTService) {
 		},
 	}
 
-	jwtService := services.NewJWTService(cfg)
 	mockRepo := mocks.NewRepository()
+	jwtService, err := services.NewJWTService(cfg, services.WithRevocationStore(mockRepo.RevokedToken()))
+	if err != nil {
+		panic(err)
+	}
+
+	passwordHash, err := services.HashPassword("password")
+	if err != nil {
+		panic(err)
+	}
+
+	mockRepo.AddUser(&models.User{
+		ID:           1,
+		Username:     "admin",
+		Email:        "admin@example.com",
+		PasswordHash: passwordHash,
+	})
+
 	userService := services.NewUserService(mockRepo.User())
+	sessionService := services.NewSessionService(jwtService, mockRepo.User(), mockRepo.RefreshToken())
 	logger := logger.New("debug")
-
-	return NewAuthHandler(jwtService, userService, logger), jwtService
+	accountService := services.NewAccountService(jwtService, sessionService, mockRepo.User(), mockRepo.UserToken(),
+		mockRepo.LoginLockout(), mailer.NewLog(logger), services.AccountConfig{
+			LinkBaseURL:      "https://kb.example.com",
+			VerificationTTL:  time.Hour,
+			PasswordResetTTL: time.Hour,
+		})
+
+	return NewAuthHandler(jwtService, userService, sessionService, accountService, logger), jwtService
 }
 
 func TestAuthHandler_Login_Success(t *testing.T) {
@@ -103,6 +131,44 @@ func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
 	}
 }
 
+func TestAuthHandler_Login_RateLimitedPerUsername(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authHandler, _ := getTestAuthHandler()
+
+	// Ключ ліміту читає тіло запиту, і обробник має отримати його повторно
+	router := gin.New()
+	router.POST("/auth/login",
+		middleware.RateLimitMiddleware(ratelimit.NewMemory(), "login", ratelimit.PerMinute(1, 1), loginUsernameKey,
+			logger.New("debug")),
+		authHandler.Login)
+
+	login := func(username, remoteAddr string) int {
+		jsonData, _ := json.Marshal(LoginRequest{Username: username, Password: "password"})
+		req := httptest.NewRequest("POST", "/auth/login", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w.Code
+	}
+
+	if code := login("admin", "10.0.0.1:1234"); code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, code)
+	}
+
+	// Той самий username з іншого IP і в іншому регістрі
+	if code := login("ADMIN", "10.0.0.2:1234"); code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, code)
+	}
+
+	if code := login("nobody", "10.0.0.1:1234"); code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for another username, got %d", http.StatusUnauthorized, code)
+	}
+}
+
 func TestAuthHandler_Login_InvalidRequest(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -111,15 +177,28 @@ func TestAuthHandler_Login_InvalidRequest(t *testing.T) {
 	router := gin.New()
 	router.POST("/auth/login", authHandler.Login)
 
-	// Відправляємо невалідний JSON
-	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBuffer([]byte("invalid json")))
-	req.Header.Set("Content-Type", "application/json")
+	longUsername, _ := json.Marshal(LoginRequest{Username: strings.Repeat("a", 51), Password: "password123"})
 
-	w := httptest.NewRecorder()
-	router.ServeHTTP(w, req)
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{"Invalid JSON", []byte("invalid json")},
+		{"Username too long", longUsername},
+	}
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/auth/login", bytes.NewBuffer(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
 	}
 }
 
@@ -189,40 +268,160 @@ func TestAuthHandler_Register_ExistingUser(t *testing.T) {
 	}
 }
 
-func TestAuthHandler_RefreshToken_Success(t *testing.T) {
+func TestAuthHandler_Register_ExistingEmail(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authHandler, jwtService := getTestAuthHandler()
+	authHandler, _ := getTestAuthHandler()
 
-	// Генеруємо токени
-	tokenPair, err := jwtService.GenerateTokenPair(1, "admin", "admin@example.com")
-	if err != nil {
-		t.Fatalf("Failed to generate tokens: %v", err)
+	router := gin.New()
+	router.POST("/auth/register", authHandler.Register)
+
+	registerReq := RegisterRequest{
+		Username: "anotheruser",
+		Email:    "ADMIN@example.com", // Email існуючого користувача в іншому регістрі
+		Password: "password123",
 	}
 
-	// Додаємо затримку для забезпечення різних timestamp
-	time.Sleep(time.Millisecond)
+	jsonData, _ := json.Marshal(registerReq)
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestAuthHandler_Register_PasswordTooLong(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authHandler, _ := getTestAuthHandler()
 
 	router := gin.New()
-	router.POST("/auth/refresh", authHandler.RefreshToken)
+	router.POST("/auth/register", authHandler.Register)
 
-	refreshReq := RefreshRequest{
-		RefreshToken: tokenPair.RefreshToken,
+	registerReq := RegisterRequest{
+		Username: "longpass",
+		Email:    "longpass@example.com",
+		Password: strings.Repeat("a", services.MaxPasswordBytes+1),
 	}
 
-	jsonData, _ := json.Marshal(refreshReq)
+	jsonData, _ := json.Marshal(registerReq)
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var problem response.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || problem.Code != "password_too_long" {
+		t.Errorf("Expected password_too_long problem, got %s", w.Body.String())
+	}
+}
+
+func TestAuthHandler_Register_ThenLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authHandler, _ := getTestAuthHandler()
+
+	router := gin.New()
+	router.POST("/auth/register", authHandler.Register)
+	router.POST("/auth/login", authHandler.Login)
+
+	jsonData, _ := json.Marshal(RegisterRequest{
+		Username: "newuser",
+		Email:    "newuser@example.com",
+		Password: "password123",
+	})
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	if bytes.Contains(w.Body.Bytes(), []byte("password123")) {
+		t.Error("Response must not contain the plaintext password")
+	}
+
+	jsonData, _ = json.Marshal(LoginRequest{Username: "newuser", Password: "password123"})
+	req = httptest.NewRequest("POST", "/auth/login", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+// loginForTest виконує логін через хендлер і повертає видані токени
+func loginForTest(t *testing.T, authHandler *AuthHandler) AuthResponse {
+	t.Helper()
+
+	router := gin.New()
+	router.POST("/auth/login", authHandler.Login)
+
+	jsonData, _ := json.Marshal(LoginRequest{Username: "admin", Password: "password"})
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Login failed with status %d", w.Code)
+	}
+
+	var response AuthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	return response
+}
+
+func refreshForTest(router *gin.Engine, refreshToken string) *httptest.ResponseRecorder {
+	jsonData, _ := json.Marshal(RefreshRequest{RefreshToken: refreshToken})
 	req := httptest.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(jsonData))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
+	return w
+}
+
+func TestAuthHandler_RefreshToken_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authHandler, _ := getTestAuthHandler()
+	tokens := loginForTest(t, authHandler)
+
+	// Додаємо затримку для забезпечення різних timestamp
+	time.Sleep(time.Millisecond)
+
+	router := gin.New()
+	router.POST("/auth/refresh", authHandler.RefreshToken)
+
+	w := refreshForTest(router, tokens.RefreshToken)
+
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
 	}
 
 	var response AuthResponse
-	err = json.Unmarshal(w.Body.Bytes(), &response)
+	err := json.Unmarshal(w.Body.Bytes(), &response)
 	if err != nil {
 		t.Fatalf("Failed to unmarshal response: %v", err)
 	}
@@ -231,9 +430,65 @@ func TestAuthHandler_RefreshToken_Success(t *testing.T) {
 		t.Error("Expected new access token, got empty string")
 	}
 
-	if response.AccessToken == tokenPair.AccessToken {
+	if response.AccessToken == tokens.AccessToken {
 		t.Error("Expected new access token to be different from original")
 	}
+
+	if response.User.Username != "admin" {
+		t.Errorf("Expected username 'admin', got '%s'", response.User.Username)
+	}
+}
+
+func TestAuthHandler_RefreshToken_ReuseRevokesFamily(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authHandler, _ := getTestAuthHandler()
+	tokens := loginForTest(t, authHandler)
+
+	router := gin.New()
+	router.POST("/auth/refresh", authHandler.RefreshToken)
+
+	w := refreshForTest(router, tokens.RefreshToken)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var rotated AuthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &rotated); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	// Повторне використання старого токена
+	w = refreshForTest(router, tokens.RefreshToken)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d on reuse, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	// Після виявлення повтору новий токен тієї ж сім'ї теж недійсний
+	w = refreshForTest(router, rotated.RefreshToken)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for revoked family, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestAuthHandler_RefreshToken_UnknownToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authHandler, jwtService := getTestAuthHandler()
+
+	// Підписаний, але не виданий сервером токен
+	tokenPair, err := jwtService.GenerateTokenPair(1, "admin", "admin@example.com")
+	if err != nil {
+		t.Fatalf("Failed to generate tokens: %v", err)
+	}
+
+	router := gin.New()
+	router.POST("/auth/refresh", authHandler.RefreshToken)
+
+	w := refreshForTest(router, tokenPair.RefreshToken)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
 }
 
 func TestAuthHandler_RefreshToken_InvalidToken(t *testing.T) {
@@ -287,6 +542,105 @@ func TestAuthHandler_Logout(t *testing.T) {
 	}
 }
 
+func TestAuthHandler_Logout_RevokesSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authHandler, jwtService := getTestAuthHandler()
+	logger := logger.New("debug")
+	tokens := loginForTest(t, authHandler)
+
+	router := gin.New()
+	router.POST("/auth/refresh", authHandler.RefreshToken)
+
+	protected := router.Group("/auth")
+	protected.Use(middleware.JWTAuthMiddleware(jwtService, logger))
+	protected.POST("/logout", authHandler.Logout)
+	protected.GET("/me", authHandler.Me)
+
+	req := httptest.NewRequest("POST", "/auth/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	// Access токен після logout відхиляється
+	req = httptest.NewRequest("GET", "/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for revoked token, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	// Refresh токен цієї сесії теж відкликано
+	w = refreshForTest(router, tokens.RefreshToken)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for refresh after logout, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestAuthHandler_LogoutAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authHandler, jwtService := getTestAuthHandler()
+	logger := logger.New("debug")
+
+	first := loginForTest(t, authHandler)
+	second := loginForTest(t, authHandler)
+
+	router := gin.New()
+	router.POST("/auth/refresh", authHandler.RefreshToken)
+
+	protected := router.Group("/auth")
+	protected.Use(middleware.JWTAuthMiddleware(jwtService, logger))
+	protected.POST("/logout-all", authHandler.LogoutAll)
+	protected.GET("/me", authHandler.Me)
+
+	req := httptest.NewRequest("POST", "/auth/logout-all", nil)
+	req.Header.Set("Authorization", "Bearer "+first.AccessToken)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+second.AccessToken)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for token of another session, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	w = refreshForTest(router, second.RefreshToken)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for refresh after logout-all, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	// Новий вхід одразу після logout-all, у ту ж секунду, дає робочий токен
+	third := loginForTest(t, authHandler)
+
+	req = httptest.NewRequest("GET", "/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+third.AccessToken)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d for token issued after logout-all, got %d", http.StatusOK, w.Code)
+	}
+}
+
 func TestAuthHandler_Me(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -0,0 +1,284 @@
+package v1
+
+import (
+	"net/http"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentHandler обробляє запити обговорення статей
+type CommentHandler struct {
+	commentService *services.CommentService
+	logger         logger.Interface
+}
+
+// NewCommentHandler створює новий екземпляр CommentHandler
+func NewCommentHandler(commentService *services.CommentService, logger logger.Interface) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		logger:         logger,
+	}
+}
+
+// CommentRequest представляє новий коментар; з parent_id - відповідь на коментар
+type CommentRequest struct {
+	Body     string `json:"body" binding:"required,max=10000" example:"@johndoe could you check the rollback section?"`
+	ParentID *uint  `json:"parent_id" example:"1"`
+}
+
+// UpdateCommentRequest представляє новий текст коментаря
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=10000" example:"Fixed the typo, thanks @johndoe"`
+}
+
+// CommentResponse представляє відповідь з одним коментарем
+type CommentResponse struct {
+	Data models.Comment `json:"data"`
+}
+
+// CommentListResponse представляє сторінку гілок
+type CommentListResponse struct {
+	Data       []*models.Comment `json:"data"`
+	NextCursor string            `json:"next_cursor" example:"eyJzIjoiY3JlYXRlZF9hdCxpZCIsInYiOltdfQ"`
+}
+
+// ListComments godoc
+// @Summary      List article comments
+// @Description  List comment threads of an article, by default from the oldest. Pagination, sorting and filters apply to threads; every thread includes all its replies nested by parent. Deleted comments stay in place with an empty body.
+// @ID           list-comments
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  int      true  "Article ID"
+// @Param        limit     query int      false "Page size (number of threads, default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: created_at, updated_at, id (default created_at)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, author_id, created_at, updated_at" collectionFormat(multi)
+// @Success      200 {object} CommentListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/comments [get]
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	articleID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.commentService.List(c.Request.Context(), articleID, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CommentListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// CreateComment godoc
+// @Summary      Create comment
+// @Description  Start a thread on an article or reply to a comment. Mentions written as @username are resolved to users; unknown names are ignored.
+// @ID           create-comment
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int            true "Article ID"
+// @Param        request body CommentRequest true "Comment"
+// @Success      201 {object} CommentResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	articleID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid comment request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	comment, err := h.commentService.Create(c.Request.Context(), actor, articleID, req.ParentID, req.Body)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CommentResponse{Data: *comment})
+}
+
+// UpdateComment godoc
+// @Summary      Update comment
+// @Description  Replace the body of the current user's comment; mentions are parsed again
+// @ID           update-comment
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path int                  true "Article ID"
+// @Param        comment_id path int                  true "Comment ID"
+// @Param        request    body UpdateCommentRequest true "Comment"
+// @Success      200 {object} CommentResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/comments/{comment_id} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	actor, articleID, commentID, ok := h.commentTarget(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid comment request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	comment, err := h.commentService.Update(c.Request.Context(), actor, articleID, commentID, req.Body)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CommentResponse{Data: *comment})
+}
+
+// DeleteComment godoc
+// @Summary      Delete comment
+// @Description  Soft delete a comment: replies stay in the thread and the body is cleared. Other users' comments can be deleted by administrators of the article's space.
+// @ID           delete-comment
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path int true "Article ID"
+// @Param        comment_id path int true "Comment ID"
+// @Success      204
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/comments/{comment_id} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	actor, articleID, commentID, ok := h.commentTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.Delete(c.Request.Context(), actor, articleID, commentID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("Comment %d deleted by user %d", commentID, actor.UserID)
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResolveComment godoc
+// @Summary      Resolve thread
+// @Description  Mark a thread as resolved. Allowed for the thread author and editors of the article.
+// @ID           resolve-comment
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path int true "Article ID"
+// @Param        comment_id path int true "Thread root comment ID"
+// @Success      200 {object} CommentResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/comments/{comment_id}/resolve [post]
+func (h *CommentHandler) ResolveComment(c *gin.Context) {
+	h.setResolved(c, true)
+}
+
+// ReopenComment godoc
+// @Summary      Reopen thread
+// @Description  Mark a resolved thread as open again. Allowed for the thread author and editors of the article.
+// @ID           reopen-comment
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path int true "Article ID"
+// @Param        comment_id path int true "Thread root comment ID"
+// @Success      200 {object} CommentResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/comments/{comment_id}/resolve [delete]
+func (h *CommentHandler) ReopenComment(c *gin.Context) {
+	h.setResolved(c, false)
+}
+
+func (h *CommentHandler) setResolved(c *gin.Context, resolved bool) {
+	actor, articleID, commentID, ok := h.commentTarget(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.commentService.Resolve(c.Request.Context(), actor, articleID, commentID, resolved)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CommentResponse{Data: *comment})
+}
+
+// commentTarget розбирає поточного користувача, :id статті та :comment_id
+func (h *CommentHandler) commentTarget(c *gin.Context) (services.Actor, uint, uint, bool) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return services.Actor{}, 0, 0, false
+	}
+
+	articleID, ok := pathID(c, "id")
+	if !ok {
+		return services.Actor{}, 0, 0, false
+	}
+
+	commentID, ok := pathID(c, "comment_id")
+	if !ok {
+		return services.Actor{}, 0, 0, false
+	}
+
+	return actor, articleID, commentID, true
+}
@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTestCommentRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "owner", Email: "owner@example.com"})
+	mockRepo.AddUser(&models.User{ID: 2, Username: "reviewer", Email: "reviewer@example.com"})
+
+	access := services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article())
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(), access)
+	commentService := services.NewCommentService(mockRepo.Comment(), mockRepo.Article(), mockRepo.User(), access)
+	articleHandler := NewArticleHandler(articleService, services.NewRenderService(mockRepo.Link(), 0), logger.New("debug"))
+	commentHandler := NewCommentHandler(commentService, logger.New("debug"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("role", models.RoleEditor)
+		c.Next()
+	})
+
+	router.POST("/articles", articleHandler.CreateArticle)
+	router.GET("/articles/:id/comments", commentHandler.ListComments)
+	router.POST("/articles/:id/comments", commentHandler.CreateComment)
+	router.PUT("/articles/:id/comments/:comment_id", commentHandler.UpdateComment)
+	router.DELETE("/articles/:id/comments/:comment_id", commentHandler.DeleteComment)
+	router.POST("/articles/:id/comments/:comment_id/resolve", commentHandler.ResolveComment)
+	router.DELETE("/articles/:id/comments/:comment_id/resolve", commentHandler.ReopenComment)
+
+	return router
+}
+
+func TestCommentHandler(t *testing.T) {
+	router := getTestCommentRouter()
+
+	w := doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "Runbook"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create article status = %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doArticleRequest(router, http.MethodPost, "/articles/1/comments", CommentRequest{Body: "Please check @reviewer"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create comment status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var created CommentResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(created.Data.Mentions) != 1 || created.Data.Mentions[0].UserID != 2 {
+		t.Errorf("Expected reviewer to be mentioned, got %+v", created.Data.Mentions)
+	}
+
+	reply := CommentRequest{Body: "Done", ParentID: uintPtr(1)}
+	orphan := CommentRequest{Body: "Hi", ParentID: uintPtr(99)}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		want   int
+	}{
+		{"reply", http.MethodPost, "/articles/1/comments", reply, http.StatusCreated},
+		{"empty body", http.MethodPost, "/articles/1/comments", CommentRequest{Body: "  "}, http.StatusBadRequest},
+		{"unknown article", http.MethodPost, "/articles/99/comments", CommentRequest{Body: "Hi"}, http.StatusNotFound},
+		{"unknown parent", http.MethodPost, "/articles/1/comments", orphan, http.StatusNotFound},
+		{"edit", http.MethodPut, "/articles/1/comments/1", UpdateCommentRequest{Body: "Edited"}, http.StatusOK},
+		{"edit invalid id", http.MethodPut, "/articles/1/comments/abc", nil, http.StatusBadRequest},
+		{"resolve reply", http.MethodPost, "/articles/1/comments/2/resolve", nil, http.StatusBadRequest},
+		{"resolve", http.MethodPost, "/articles/1/comments/1/resolve", nil, http.StatusOK},
+		{"reopen", http.MethodDelete, "/articles/1/comments/1/resolve", nil, http.StatusOK},
+		{"list", http.MethodGet, "/articles/1/comments?limit=10", nil, http.StatusOK},
+		{"list invalid limit", http.MethodGet, "/articles/1/comments?limit=500", nil, http.StatusBadRequest},
+		{"list invalid cursor", http.MethodGet, "/articles/1/comments?cursor=abc", nil, http.StatusBadRequest},
+		{"delete", http.MethodDelete, "/articles/1/comments/2", nil, http.StatusNoContent},
+		{"delete again", http.MethodDelete, "/articles/1/comments/2", nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(router, tt.method, tt.url, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Status code = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/1/comments", nil)
+
+	var list CommentListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(list.Data) != 1 || len(list.Data[0].Replies) != 1 || list.Data[0].Replies[0].DeletedAt == nil {
+		t.Errorf("Expected thread with a deleted reply, got %+v", list.Data)
+	}
+}
@@ -25,7 +25,7 @@ type V1 struct {
 // @Accept      json
 // @Produce     json
 // @Success     200 {object} models.Entity
-// @Failure     500 {object} response.Error
+// @Failure     500 {object} response.Problem
 // @Router      /translation/history [get]
 func (r *V1) history(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportHandler обробляє експорт просторів та всієї бази знань
+type ExportHandler struct {
+	exportService *services.ExportService
+	logger        logger.Interface
+}
+
+// NewExportHandler створює новий екземпляр ExportHandler
+func NewExportHandler(exportService *services.ExportService, logger logger.Interface) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// ExportQuery представляє параметри експорту
+type ExportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=markdown html json"`
+}
+
+// ExportSpace godoc
+// @Summary      Export space
+// @Description  Download a space as a zip archive: markdown - Markdown files with front matter and attachments in the layout the importer reads; html - a self-contained static site with navigation; json - a versioned dump (knowledgehub.json plus attachment content) that POST /admin/import restores.
+// @ID           export-space
+// @Tags         export
+// @Produce      application/zip
+// @Security     BearerAuth
+// @Param        id     path  int    true  "Space ID"
+// @Param        format query string false "Archive format (default markdown)" Enums(markdown, html, json)
+// @Success      200 {file} file
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces/{id}/export [get]
+func (h *ExportHandler) ExportSpace(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	h.export(c, id, fmt.Sprintf("knowledgehub-space-%d", id))
+}
+
+// ExportAll godoc
+// @Summary      Export knowledge base
+// @Description  Download all spaces and articles, including articles outside spaces, as a zip archive. Formats are the same as for the space export.
+// @ID           export-all
+// @Tags         export
+// @Produce      application/zip
+// @Security     BearerAuth
+// @Param        format query string false "Archive format (default markdown)" Enums(markdown, html, json)
+// @Success      200 {file} file
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /admin/export [get]
+func (h *ExportHandler) ExportAll(c *gin.Context) {
+	h.export(c, 0, "knowledgehub")
+}
+
+func (h *ExportHandler) export(c *gin.Context, spaceID uint, name string) {
+	var query ExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	format := services.ExportFormat(query.Format)
+	if format == "" {
+		format = services.ExportFormatMarkdown
+	}
+
+	w := &archiveWriter{c: c, filename: fmt.Sprintf("%s-%s.zip", name, format)}
+
+	err := h.exportService.Export(c.Request.Context(), w, services.ExportOptions{SpaceID: spaceID, Format: format})
+	if err == nil {
+		return
+	}
+
+	if w.started {
+		// Заголовки вже надіслано, клієнт отримає обірваний архів
+		middleware.RequestLogger(c, h.logger).Error("Failed to export archive: %v", err)
+		return
+	}
+
+	response.Error(c, err)
+}
+
+// archiveWriter надсилає заголовки zip файлу лише з першим записом,
+// щоб помилку до початку архіву можна було повернути як JSON
+type archiveWriter struct {
+	c        *gin.Context
+	filename string
+	started  bool
+}
+
+func (w *archiveWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", "application/zip")
+		w.c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": w.filename}))
+		w.c.Status(http.StatusOK)
+	}
+
+	return w.c.Writer.Write(p)
+}
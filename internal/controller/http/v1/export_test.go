@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTestExportRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	blobs, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "admin"})
+
+	ctx := context.Background()
+	spaceService := services.NewSpaceService(mockRepo.Space(), mockRepo.Article(), mockRepo.PageTree(),
+		mockRepo.SpaceMember(), services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+
+	space, err := spaceService.Create(ctx, 1, services.SpaceInput{Key: "ops", Name: "Operations"})
+	if err != nil {
+		t.Fatalf("Create space: %v", err)
+	}
+
+	_, err = articleService.Create(ctx, services.Actor{UserID: 1, Role: models.RoleEditor}, services.ArticleInput{
+		Title: "Guide", Slug: "guide", Body: "# Guide", SpaceID: &space.ID,
+	})
+	if err != nil {
+		t.Fatalf("Create article: %v", err)
+	}
+
+	exportService := services.NewExportService(mockRepo.Article(), mockRepo.Space(), mockRepo.PageTree(),
+		mockRepo.Tag(), mockRepo.Attachment(), mockRepo.User(), blobs)
+	exportHandler := NewExportHandler(exportService, logger.New("debug"))
+
+	router := gin.New()
+	router.GET("/spaces/:id/export", exportHandler.ExportSpace)
+	router.GET("/admin/export", exportHandler.ExportAll)
+
+	return router
+}
+
+func TestExportHandler(t *testing.T) {
+	router := getTestExportRouter(t)
+
+	tests := []struct {
+		name        string
+		url         string
+		wantStatus  int
+		wantFile    string
+		wantArchive string
+	}{
+		{
+			name:        "space markdown by default",
+			url:         "/spaces/1/export",
+			wantStatus:  http.StatusOK,
+			wantFile:    "knowledgehub-space-1-markdown.zip",
+			wantArchive: "ops/guide.md",
+		},
+		{
+			name:        "space html",
+			url:         "/spaces/1/export?format=html",
+			wantStatus:  http.StatusOK,
+			wantFile:    "knowledgehub-space-1-html.zip",
+			wantArchive: "pages/guide.html",
+		},
+		{
+			name:        "hub json",
+			url:         "/admin/export?format=json",
+			wantStatus:  http.StatusOK,
+			wantFile:    "knowledgehub-json.zip",
+			wantArchive: services.ExportDumpFile,
+		},
+		{
+			name:       "unknown format",
+			url:        "/admin/export?format=pdf",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid id",
+			url:        "/spaces/abc/export",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing space",
+			url:        "/spaces/42/export",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.url, http.NoBody)
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if got := w.Header().Get("Content-Type"); got != "application/zip" {
+				t.Errorf("Content-Type = %q", got)
+			}
+
+			if got, want := w.Header().Get("Content-Disposition"), "attachment; filename="+tt.wantFile; got != want {
+				t.Errorf("Content-Disposition = %q, want %q", got, want)
+			}
+
+			archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+			if err != nil {
+				t.Fatalf("zip.NewReader: %v", err)
+			}
+
+			if _, err = archive.Open(tt.wantArchive); err != nil {
+				t.Errorf("archive has no %s: %v", tt.wantArchive, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errInvalidArchive  = apperror.Validation("invalid_archive", "file is not a valid zip archive")
+	errArchiveTooLarge = apperror.New(apperror.KindTooLarge, "archive_too_large", "archive is too large")
+)
+
+// ImportHandler обробляє імпорт бази знань з zip архіву markdown файлів
+type ImportHandler struct {
+	importService *services.ImportService
+	maxSize       int64
+	logger        logger.Interface
+}
+
+// NewImportHandler створює новий екземпляр ImportHandler; maxSize - максимальний розмір архіву в байтах
+func NewImportHandler(importService *services.ImportService, maxSize int64, logger logger.Interface) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+		maxSize:       maxSize,
+		logger:        logger,
+	}
+}
+
+// ImportResponse представляє відповідь зі звітом імпорту
+type ImportResponse struct {
+	Data *services.ImportReport `json:"data"`
+}
+
+// Import godoc
+// @Summary      Import markdown archive
+// @Description  Import a zip archive of markdown files. The archive root is the knowledge base root: top-level directories become spaces, README.md or index.md becomes the parent page of its directory, YAML front matter sets title, slug, author, status and tags. Relative links to documents become [[wiki links]], links and images pointing to other files become attachments. An archive with knowledgehub.json in the root is restored from the JSON export instead. An archive with entries outside its root (../ or absolute paths) is rejected as a whole. Without commit=true nothing is created and the response is a dry-run report.
+// @ID           import-archive
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file   formData file true  "Zip archive"
+// @Param        commit query    bool false "Create spaces and articles (default false - dry run)"
+// @Success      200 {object} ImportResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      413 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /admin/import [post]
+func (h *ImportHandler) Import(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		response.Error(c, middleware.ErrNotAuthenticated)
+		return
+	}
+
+	commit, err := strconv.ParseBool(c.DefaultQuery("commit", "false"))
+	if err != nil {
+		response.Error(c, response.ErrInvalidRequest.WithFields(apperror.FieldError{
+			Field:   "commit",
+			Message: "must be a boolean",
+		}))
+		return
+	}
+
+	archive, err := h.receiveArchive(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	defer func() {
+		_ = archive.Close()
+		_ = os.Remove(archive.Name())
+	}()
+
+	info, err := archive.Stat()
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	fsys, err := zip.NewReader(archive, info.Size())
+	if err != nil {
+		response.Error(c, errInvalidArchive)
+		return
+	}
+
+	// zip.NewReader відхиляє небезпечні шляхи лише з GODEBUG=zipinsecurepath=0, а fs.FS архіву
+	// мовчки їх виправляє, тож архів з ../ чи абсолютними шляхами не імпортується навіть частково
+	if err = checkArchivePaths(fsys); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	report, err := h.importService.Import(c.Request.Context(), fsys, services.ImportOptions{
+		UserID: userID,
+		Commit: commit,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if report.Committed {
+		middleware.RequestLogger(c, h.logger).Info("Imported %d articles into %d new spaces by user %d",
+			report.Summary.Articles, report.Summary.Spaces, userID)
+	}
+
+	c.JSON(http.StatusOK, ImportResponse{Data: report})
+}
+
+// checkArchivePaths перевіряє, що кожен запис архіву лежить усередині його кореня
+func checkArchivePaths(archive *zip.Reader) error {
+	for _, file := range archive.File {
+		name := strings.TrimSuffix(file.Name, "/")
+		if !fs.ValidPath(name) || strings.Contains(name, `\`) {
+			return errInvalidArchive.WithFields(apperror.FieldError{
+				Field:   _attachmentField,
+				Message: fmt.Sprintf("entry %q is outside the archive root", file.Name),
+			})
+		}
+	}
+
+	return nil
+}
+
+// receiveArchive зберігає поле "file" у тимчасовий файл: zip читається з кінця,
+// тому потоком його не розібрати
+func (h *ImportHandler) receiveArchive(c *gin.Context) (*os.File, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+_multipartOverhead)
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, errInvalidMultipart
+	}
+
+	for {
+		part, partErr := reader.NextPart()
+		if partErr != nil {
+			return nil, multipartError(partErr, errArchiveTooLarge)
+		}
+
+		if part.FormName() != _attachmentField {
+			continue
+		}
+
+		archive, err := os.CreateTemp("", "knowledgehub-import-*.zip")
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = io.Copy(archive, part); err != nil {
+			_ = archive.Close()
+			_ = os.Remove(archive.Name())
+
+			return nil, bodyTooLarge(err, errArchiveTooLarge)
+		}
+
+		return archive, nil
+	}
+}
@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTestImportRouter(t *testing.T) (*gin.Engine, *services.ArticleService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	blobs, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "admin"})
+
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	importService := services.NewImportService(
+		articleService,
+		services.NewSpaceService(mockRepo.Space(), mockRepo.Article(), mockRepo.PageTree(), mockRepo.SpaceMember(),
+			services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article())),
+		services.NewTagService(mockRepo.Tag(), mockRepo.Article()),
+		services.NewAttachmentService(mockRepo.Attachment(), mockRepo.Article(), blobs),
+		mockRepo.User(),
+		mockRepo.Space(),
+	)
+	importHandler := NewImportHandler(importService, 4096, logger.New("debug"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("role", models.RoleAdmin)
+		c.Next()
+	})
+
+	router.POST("/admin/import", importHandler.Import)
+
+	return router, articleService
+}
+
+func testZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	archive := zip.NewWriter(&buf)
+
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("zip.Create: %v", err)
+		}
+
+		_, _ = w.Write([]byte(content))
+	}
+
+	if err := archive.Close(); err != nil {
+		t.Fatalf("zip.Close: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestImportHandler(t *testing.T) {
+	router, articles := getTestImportRouter(t)
+
+	archive := testZip(t, map[string]string{
+		"Guides/README.md":  "---\ntitle: Guides\n---\nSee [setup](setup.md)\n",
+		"Guides/setup.md":   "# Setup\n\n![logo](logo.png)\n",
+		"Guides/logo.png":   string(_testPNG),
+		"Guides/.hidden.md": "ignored",
+	})
+
+	w := doUpload(router, "/admin/import", "file", "kb.zip", archive)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Dry run status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp ImportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if resp.Data.Committed || resp.Data.Summary.Articles != 2 || resp.Data.Summary.Spaces != 1 ||
+		resp.Data.Summary.Attachments != 1 || resp.Data.Summary.Links != 1 {
+		t.Fatalf("Unexpected dry run: %+v", resp.Data)
+	}
+
+	if _, err := articles.GetBySlug(context.Background(), "guides"); err == nil {
+		t.Fatal("Dry run created an article")
+	}
+
+	w = doUpload(router, "/admin/import?commit=true", "file", "kb.zip", archive)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Commit status = %d: %s", w.Code, w.Body.String())
+	}
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || !resp.Data.Committed {
+		t.Fatalf("Unexpected commit: %s", w.Body.String())
+	}
+
+	guides, err := articles.GetBySlug(context.Background(), "guides")
+	if err != nil || guides.Body != "See [[setup|setup]]\n" {
+		t.Errorf("GetBySlug() = %+v, %v", guides, err)
+	}
+}
+
+func TestImportHandler_Errors(t *testing.T) {
+	router, _ := getTestImportRouter(t)
+
+	tests := []struct {
+		name    string
+		url     string
+		field   string
+		content []byte
+		want    int
+	}{
+		{"invalid commit", "/admin/import?commit=maybe", "file", []byte("x"), http.StatusBadRequest},
+		{"missing file", "/admin/import", "archive", []byte("x"), http.StatusBadRequest},
+		{"not a zip", "/admin/import", "file", []byte("not a zip"), http.StatusBadRequest},
+		{"no documents", "/admin/import", "file", testZip(t, map[string]string{"a.txt": "x"}), http.StatusBadRequest},
+		{"too large", "/admin/import", "file", bytes.Repeat([]byte("x"), 128<<10), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doUpload(router, tt.url, tt.field, "kb.zip", tt.content); w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestImportHandler_UnsafePaths(t *testing.T) {
+	router, articles := getTestImportRouter(t)
+
+	for _, name := range []string{"../evil.md", "/etc/evil.md", "Guides/../../evil.md", `..\evil.md`} {
+		t.Run(name, func(t *testing.T) {
+			archive := testZip(t, map[string]string{
+				"Guides/README.md": "# Guides\n",
+				name:               "# Evil\n",
+			})
+
+			w := doUpload(router, "/admin/import?commit=true", "file", "kb.zip", archive)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body.String())
+			}
+
+			var problem response.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || problem.Code != "invalid_archive" {
+				t.Errorf("Expected invalid_archive, got %s", w.Body.String())
+			}
+
+			// Безпечні записи того ж архіву теж не імпортуються
+			if _, err := articles.GetBySlug(context.Background(), "guides"); err == nil {
+				t.Error("Archive with an unsafe path was partially imported")
+			}
+		})
+	}
+}
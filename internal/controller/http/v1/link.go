@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"net/http"
+
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LinkHandler обробляє запити графа wiki-посилань між статтями
+type LinkHandler struct {
+	linkService *services.LinkService
+	logger      logger.Interface
+}
+
+// NewLinkHandler створює новий екземпляр LinkHandler
+func NewLinkHandler(linkService *services.LinkService, logger logger.Interface) *LinkHandler {
+	return &LinkHandler{
+		linkService: linkService,
+		logger:      logger,
+	}
+}
+
+// ArticleLinkListResponse представляє сторінку wiki-посилань статті
+type ArticleLinkListResponse struct {
+	Data       []*models.ArticleLink `json:"data"`
+	NextCursor string                `json:"next_cursor" example:"eyJzIjoidGFyZ2V0X3NsdWciLCJ2IjpbXX0"`
+}
+
+// BrokenLinkListResponse представляє сторінку звіту про биті посилання
+type BrokenLinkListResponse struct {
+	Data       []*models.ArticleLink `json:"data"`
+	NextCursor string                `json:"next_cursor" example:"eyJzIjoic291cmNlX2lkLHRhcmdldF9zbHVnIiwidiI6W119"`
+}
+
+// ListArticleLinks godoc
+// @Summary      List article links
+// @Description  List [[wiki links]] of an article, by default ordered by target slug. A link targets the article whose slug is derived from the link text; broken links have no target_id.
+// @ID           list-article-links
+// @Tags         links
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  int      true  "Article ID"
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: target_slug (default target_slug)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: target_slug" collectionFormat(multi)
+// @Success      200 {object} ArticleLinkListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/links [get]
+func (h *LinkHandler) ListArticleLinks(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.linkService.ArticleLinks(c.Request.Context(), id, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ArticleLinkListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// ListBacklinks godoc
+// @Summary      List backlinks
+// @Description  List other articles that link to the article, by default ordered by title. Renaming the article slug or deleting it breaks these links.
+// @ID           list-backlinks
+// @Tags         links
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  int      true  "Article ID"
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: id, title, slug, created_at, updated_at (default title)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, title, slug, status, language, author_id, created_at, updated_at" collectionFormat(multi)
+// @Success      200 {object} ArticlePageResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/backlinks [get]
+func (h *LinkHandler) ListBacklinks(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.linkService.Backlinks(c.Request.Context(), id, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ArticlePageResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// ListBrokenLinks godoc
+// @Summary      List broken links
+// @Description  List wiki links that point to articles which do not exist, by default ordered by source article
+// @ID           list-broken-links
+// @Tags         links
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit     query int      false "Page size (default 50, max 200)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: source_id, target_slug (default source_id)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: source_id, target_slug" collectionFormat(multi)
+// @Success      200 {object} BrokenLinkListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /links/broken [get]
+func (h *LinkHandler) ListBrokenLinks(c *gin.Context) {
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.linkService.BrokenLinks(c.Request.Context(), query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, BrokenLinkListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
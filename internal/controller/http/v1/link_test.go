@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTestLinkRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	articleHandler := NewArticleHandler(articleService, services.NewRenderService(mockRepo.Link(), 0), logger.New("debug"))
+	linkHandler := NewLinkHandler(services.NewLinkService(mockRepo.Link(), mockRepo.Article()), logger.New("debug"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("role", models.RoleEditor)
+		c.Next()
+	})
+
+	router.POST("/articles", articleHandler.CreateArticle)
+	router.GET("/articles/:id/links", linkHandler.ListArticleLinks)
+	router.GET("/articles/:id/backlinks", linkHandler.ListBacklinks)
+	router.GET("/links/broken", linkHandler.ListBrokenLinks)
+
+	return router
+}
+
+func TestLinkHandler(t *testing.T) {
+	router := getTestLinkRouter()
+
+	for _, req := range []ArticleRequest{
+		{Title: "Index", Body: "See [[Guide|the guide]] and [[Roadmap]]"},
+		{Title: "Guide"},
+	} {
+		if w := doArticleRequest(router, http.MethodPost, "/articles", req); w.Code != http.StatusCreated {
+			t.Fatalf("Create article status = %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := doArticleRequest(router, http.MethodGet, "/articles/1/links", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var links ArticleLinkListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &links); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(links.Data) != 2 || links.Data[0].TargetID == nil || *links.Data[0].TargetID != 2 || !links.Data[1].Broken {
+		t.Errorf("Unexpected links: %+v", links.Data)
+	}
+
+	// Сторінка з одного посилання і курсор на друге
+	w = doArticleRequest(router, http.MethodGet, "/articles/1/links?limit=1", nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &links); err != nil || len(links.Data) != 1 || links.NextCursor == "" {
+		t.Fatalf("Expected first page with next cursor, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/1/links?limit=1&cursor="+links.NextCursor, nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &links); err != nil || len(links.Data) != 1 ||
+		links.Data[0].TargetSlug != "roadmap" || links.NextCursor != "" {
+		t.Errorf("Expected last page with roadmap, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/2/backlinks", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var backlinks ArticlePageResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &backlinks); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(backlinks.Data) != 1 || backlinks.Data[0].Title != "Index" {
+		t.Errorf("Unexpected backlinks: %+v", backlinks.Data)
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/links/broken", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var broken ArticleLinkListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &broken); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(broken.Data) != 1 || broken.Data[0].TargetSlug != "roadmap" || broken.Data[0].SourceID != 1 {
+		t.Errorf("Unexpected broken links: %+v", broken.Data)
+	}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{"Invalid id", "/articles/abc/backlinks", http.StatusBadRequest},
+		{"Unknown article links", "/articles/42/links", http.StatusNotFound},
+		{"Unknown article backlinks", "/articles/42/backlinks", http.StatusNotFound},
+		{"Invalid limit", "/links/broken?limit=1000", http.StatusBadRequest},
+		{"Invalid links limit", "/articles/1/links?limit=1000", http.StatusBadRequest},
+		{"Invalid backlinks sort", "/articles/2/backlinks?sort=body", http.StatusBadRequest},
+		{"Invalid backlinks cursor", "/articles/2/backlinks?cursor=abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(router, http.MethodGet, tt.url, nil)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"strconv"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pathID розбирає id з параметра шляху name; при помилці вже відповідає 400
+func pathID(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		response.Error(c, response.ErrInvalidID.Withf("parameter %q must be a number", name))
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+// currentActor повертає поточного користувача; якщо його немає, вже відповідає 401
+func currentActor(c *gin.Context) (services.Actor, bool) {
+	userID, hasUser := middleware.GetUserIDFromContext(c)
+	role, hasRole := middleware.GetRoleFromContext(c)
+
+	if !hasUser || !hasRole {
+		response.Error(c, middleware.ErrNotAuthenticated)
+		return services.Actor{}, false
+	}
+
+	return services.Actor{UserID: userID, Role: role}, true
+}
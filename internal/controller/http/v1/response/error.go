@@ -1,5 +0,0 @@
-package response
-
-type Error struct {
-	Error string `json:"error" example:"message"`
-}
@@ -1,11 +1,15 @@
 package v1
 
 import (
+	"time"
+
 	// Swagger documentation
 	_ "KnowledgeHub/docs"
 	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/services"
 	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/ratelimit"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -22,26 +26,333 @@ func NewTranslationRoutes(apiV1Group *gin.RouterGroup, jwtService *services.JWTS
 	}
 }
 
+// AuthRateLimits - обмеження частоти запитів до публічних auth ендпоінтів.
+// PerIP діє на всі публічні ендпоінти /auth, PerUser - додатково на login за username.
+// Без Store обмеження вимкнені.
+type AuthRateLimits struct {
+	Store   ratelimit.Store
+	PerIP   ratelimit.Limit
+	PerUser ratelimit.Limit
+}
+
 func NewAuthRoutes(
 	apiV1Group *gin.RouterGroup,
 	jwtService *services.JWTService,
 	userService *services.UserService,
+	sessionService *services.SessionService,
+	accountService *services.AccountService,
+	limits AuthRateLimits,
 	l logger.Interface,
 ) {
 
-	authHandler := NewAuthHandler(jwtService, userService, l)
+	authHandler := NewAuthHandler(jwtService, userService, sessionService, accountService, l)
+	accountHandler := NewAccountHandler(accountService, userService, l)
+
+	var perIP, perUser []gin.HandlerFunc
+	if limits.Store != nil {
+		perIP = append(perIP, middleware.RateLimitMiddleware(limits.Store, "auth", limits.PerIP, middleware.ClientIPKey, l))
+		perUser = append(perUser, middleware.RateLimitMiddleware(limits.Store, "login", limits.PerUser, loginUsernameKey, l))
+	}
 
 	authGroup := apiV1Group.Group("/auth")
+	authGroup.Use(perIP...)
 	{
-		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/login", append(perUser, authHandler.Login)...)
 		authGroup.POST("/register", authHandler.Register)
 		authGroup.POST("/refresh", authHandler.RefreshToken)
+		authGroup.POST("/verify-email/confirm", accountHandler.ConfirmEmailVerification)
+		authGroup.POST("/password-reset/request", accountHandler.RequestPasswordReset)
+		authGroup.POST("/password-reset/confirm", accountHandler.ConfirmPasswordReset)
 	}
 
 	protectedAuthGroup := apiV1Group.Group("/auth")
 	protectedAuthGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
 	{
 		protectedAuthGroup.POST("/logout", authHandler.Logout)
+		protectedAuthGroup.POST("/logout-all", authHandler.LogoutAll)
 		protectedAuthGroup.GET("/me", authHandler.Me)
+		protectedAuthGroup.POST("/verify-email/request", accountHandler.RequestEmailVerification)
+	}
+}
+
+func NewUserRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	userService *services.UserService,
+	l logger.Interface,
+) {
+	userHandler := NewUserHandler(userService, jwtService, l)
+
+	userGroup := apiV1Group.Group("/users")
+	userGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	{
+		userGroup.GET("", middleware.RequirePermission(models.PermissionManageUsers, l), userHandler.ListUsers)
+		userGroup.GET("/:id", userHandler.GetUser)
+		userGroup.PUT("/:id/role", middleware.RequirePermission(models.PermissionManageUsers, l), userHandler.SetUserRole)
+	}
+}
+
+// NewArticleRoutes реєструє статті: читання доступне всім ролям, зміни - редакторам
+// глобально або в просторі статті. Нова стаття перевіряється лише за глобальною роллю.
+func NewArticleRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	articleService *services.ArticleService,
+	renderService *services.RenderService,
+	accessService *services.AccessService,
+	l logger.Interface,
+) {
+	articleHandler := NewArticleHandler(articleService, renderService, l)
+
+	canWrite := middleware.RequireSpacePermission(accessService, models.PermissionWriteArticles,
+		middleware.ArticleSpaceParam(accessService, "id"), l)
+
+	articleGroup := apiV1Group.Group("/articles")
+	articleGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	articleGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		// Право писати перевіряє ArticleService.Create для простору, куди потрапляє стаття
+		articleGroup.POST("", articleHandler.CreateArticle)
+		articleGroup.GET("", articleHandler.ListArticles)
+		articleGroup.GET("/:id", articleHandler.GetArticle)
+		articleGroup.PUT("/:id", canWrite, articleHandler.UpdateArticle)
+		articleGroup.DELETE("/:id", canWrite, articleHandler.DeleteArticle)
+
+		articleGroup.GET("/:id/revisions", articleHandler.ListRevisions)
+		articleGroup.GET("/:id/revisions/:version", articleHandler.GetRevision)
+		articleGroup.POST("/:id/revisions/:version/restore", canWrite, articleHandler.RestoreRevision)
+		articleGroup.GET("/:id/diff", articleHandler.DiffRevisions)
+	}
+}
+
+func NewSearchRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	searchService *services.SearchService,
+	l logger.Interface,
+) {
+	searchHandler := NewSearchHandler(searchService, l)
+
+	searchGroup := apiV1Group.Group("/search")
+	searchGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	searchGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		searchGroup.GET("", searchHandler.Search)
+	}
+}
+
+// NewSpaceRoutes реєструє простори; керування простором дозволене його адміністраторам
+func NewSpaceRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	spaceService *services.SpaceService,
+	accessService *services.AccessService,
+	l logger.Interface,
+) {
+	spaceHandler := NewSpaceHandler(spaceService, l)
+
+	canManage := middleware.RequireSpacePermission(accessService, models.PermissionManageSpaces,
+		middleware.SpaceParam("id"), l)
+
+	spaceGroup := apiV1Group.Group("/spaces")
+	spaceGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	spaceGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		spaceGroup.POST("", middleware.RequirePermission(models.PermissionCreateSpaces, l), spaceHandler.CreateSpace)
+		spaceGroup.GET("", spaceHandler.ListSpaces)
+		spaceGroup.GET("/:id", spaceHandler.GetSpace)
+		spaceGroup.PUT("/:id", canManage, spaceHandler.UpdateSpace)
+		spaceGroup.DELETE("/:id", canManage, spaceHandler.DeleteSpace)
+		spaceGroup.GET("/:id/tree", spaceHandler.GetSpaceTree)
+
+		spaceGroup.GET("/:id/members", spaceHandler.ListMembers)
+		spaceGroup.PUT("/:id/members/:user_id", canManage, spaceHandler.SetMember)
+		spaceGroup.DELETE("/:id/members/:user_id", canManage, spaceHandler.RemoveMember)
+	}
+
+	pageGroup := apiV1Group.Group("/articles")
+	pageGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	pageGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		pageGroup.POST("/:id/move", middleware.RequireSpacePermission(accessService, models.PermissionWriteArticles,
+			middleware.ArticleSpaceParam(accessService, "id"), l), spaceHandler.MovePage)
+		pageGroup.GET("/:id/breadcrumbs", spaceHandler.GetBreadcrumbs)
+	}
+}
+
+// NewTagRoutes реєструє теги: змінювати теги статті можуть її редактори,
+// перейменування та об'єднання тегів доступні лише адміністраторам.
+func NewTagRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	tagService *services.TagService,
+	accessService *services.AccessService,
+	l logger.Interface,
+) {
+	tagHandler := NewTagHandler(tagService, l)
+
+	canWrite := middleware.RequireSpacePermission(accessService, models.PermissionWriteArticles,
+		middleware.ArticleSpaceParam(accessService, "id"), l)
+	canManage := middleware.RequirePermission(models.PermissionManageTags, l)
+
+	tagGroup := apiV1Group.Group("/tags")
+	tagGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	tagGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		tagGroup.GET("", tagHandler.ListPopularTags)
+		tagGroup.GET("/autocomplete", tagHandler.AutocompleteTags)
+		tagGroup.POST("/merge", canManage, tagHandler.MergeTags)
+		tagGroup.GET("/:name", tagHandler.GetTag)
+		tagGroup.PUT("/:name", canManage, tagHandler.RenameTag)
+	}
+
+	articleTagGroup := apiV1Group.Group("/articles")
+	articleTagGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	articleTagGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		articleTagGroup.GET("/:id/tags", tagHandler.ListArticleTags)
+		articleTagGroup.POST("/:id/tags", canWrite, tagHandler.AddArticleTags)
+		articleTagGroup.DELETE("/:id/tags/:name", canWrite, tagHandler.RemoveArticleTag)
+	}
+}
+
+// NewCommentRoutes реєструє обговорення статей: коментувати можуть усі ролі
+// в межах простору статті, власника та модераторів перевіряє CommentService.
+func NewCommentRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	commentService *services.CommentService,
+	accessService *services.AccessService,
+	l logger.Interface,
+) {
+	commentHandler := NewCommentHandler(commentService, l)
+
+	canComment := middleware.RequireSpacePermission(accessService, models.PermissionWriteComments,
+		middleware.ArticleSpaceParam(accessService, "id"), l)
+
+	commentGroup := apiV1Group.Group("/articles/:id/comments")
+	commentGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	commentGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		commentGroup.GET("", commentHandler.ListComments)
+		commentGroup.POST("", canComment, commentHandler.CreateComment)
+		commentGroup.PUT("/:comment_id", canComment, commentHandler.UpdateComment)
+		commentGroup.DELETE("/:comment_id", canComment, commentHandler.DeleteComment)
+		commentGroup.POST("/:comment_id/resolve", canComment, commentHandler.ResolveComment)
+		commentGroup.DELETE("/:comment_id/resolve", canComment, commentHandler.ReopenComment)
+	}
+}
+
+// NewAttachmentRoutes реєструє вкладення статей: завантажувати й видаляти файли можуть
+// редактори статті, очищувати сховище від невикористаного вмісту - адміністратори.
+// Передача файлів обмежена transferTimeout замість загальних таймаутів сервера.
+func NewAttachmentRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	attachmentService *services.AttachmentService,
+	accessService *services.AccessService,
+	transferTimeout time.Duration,
+	l logger.Interface,
+) {
+	attachmentHandler := NewAttachmentHandler(attachmentService, l)
+	transfer := middleware.TransferDeadline(transferTimeout, l)
+
+	canWrite := middleware.RequireSpacePermission(accessService, models.PermissionWriteArticles,
+		middleware.ArticleSpaceParam(accessService, "id"), l)
+
+	articleAttachmentGroup := apiV1Group.Group("/articles/:id/attachments")
+	articleAttachmentGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	articleAttachmentGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		articleAttachmentGroup.GET("", attachmentHandler.ListAttachments)
+		articleAttachmentGroup.POST("", canWrite, transfer, attachmentHandler.UploadAttachment)
+		articleAttachmentGroup.GET("/:attachment_id", transfer, attachmentHandler.DownloadAttachment)
+		articleAttachmentGroup.DELETE("/:attachment_id", canWrite, attachmentHandler.DeleteAttachment)
+	}
+
+	attachmentGroup := apiV1Group.Group("/attachments")
+	attachmentGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	attachmentGroup.Use(middleware.RequireRole(models.RoleAdmin, l))
+	{
+		attachmentGroup.POST("/purge", attachmentHandler.PurgeAttachments)
+	}
+}
+
+// NewLinkRoutes реєструє граф wiki-посилань; посилання статті змінюються разом з її текстом,
+// тому всі маршрути лише для читання
+func NewLinkRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	linkService *services.LinkService,
+	l logger.Interface,
+) {
+	linkHandler := NewLinkHandler(linkService, l)
+
+	articleLinkGroup := apiV1Group.Group("/articles")
+	articleLinkGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	articleLinkGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		articleLinkGroup.GET("/:id/links", linkHandler.ListArticleLinks)
+		articleLinkGroup.GET("/:id/backlinks", linkHandler.ListBacklinks)
+	}
+
+	linkGroup := apiV1Group.Group("/links")
+	linkGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	linkGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		linkGroup.GET("/broken", linkHandler.ListBrokenLinks)
+	}
+}
+
+// NewImportRoutes реєструє імпорт бази знань; імпорт створює простори від імені
+// будь-яких авторів, тому доступний лише адміністраторам. Архів до maxSize байт
+// завантажується з обмеженням transferTimeout.
+func NewImportRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	importService *services.ImportService,
+	maxSize int64,
+	transferTimeout time.Duration,
+	l logger.Interface,
+) {
+	importHandler := NewImportHandler(importService, maxSize, l)
+
+	adminGroup := apiV1Group.Group("/admin")
+	adminGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	adminGroup.Use(middleware.RequireRole(models.RoleAdmin, l))
+	{
+		adminGroup.POST("/import", middleware.TransferDeadline(transferTimeout, l), importHandler.Import)
+	}
+}
+
+// NewExportRoutes реєструє експорт: простір вивантажують його адміністратори,
+// всю базу знань - лише глобальні адміністратори. Архів передається потоком, тому запис
+// відповіді обмежений transferTimeout.
+func NewExportRoutes(
+	apiV1Group *gin.RouterGroup,
+	jwtService *services.JWTService,
+	exportService *services.ExportService,
+	accessService *services.AccessService,
+	transferTimeout time.Duration,
+	l logger.Interface,
+) {
+	exportHandler := NewExportHandler(exportService, l)
+	transfer := middleware.TransferDeadline(transferTimeout, l)
+
+	canManage := middleware.RequireSpacePermission(accessService, models.PermissionManageSpaces,
+		middleware.SpaceParam("id"), l)
+
+	spaceExportGroup := apiV1Group.Group("/spaces")
+	spaceExportGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	spaceExportGroup.Use(middleware.RequirePermission(models.PermissionReadArticles, l))
+	{
+		spaceExportGroup.GET("/:id/export", canManage, transfer, exportHandler.ExportSpace)
+	}
+
+	adminGroup := apiV1Group.Group("/admin")
+	adminGroup.Use(middleware.JWTAuthMiddleware(jwtService, l))
+	adminGroup.Use(middleware.RequireRole(models.RoleAdmin, l))
+	{
+		adminGroup.GET("/export", transfer, exportHandler.ExportAll)
 	}
 }
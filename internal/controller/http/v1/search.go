@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler обробляє повнотекстовий пошук
+type SearchHandler struct {
+	searchService *services.SearchService
+	logger        logger.Interface
+}
+
+// NewSearchHandler створює новий екземпляр SearchHandler
+func NewSearchHandler(searchService *services.SearchService, logger logger.Interface) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		logger:        logger,
+	}
+}
+
+// SearchRequest представляє параметри пошуку та сторінки; дати у форматі YYYY-MM-DD,
+// to включно, теги - через кому
+type SearchRequest struct {
+	pagination.Query
+	Text     string               `form:"q" binding:"required"`
+	Language string               `form:"lang"`
+	SpaceID  uint                 `form:"space_id"`
+	AuthorID uint                 `form:"author_id"`
+	Status   models.ArticleStatus `form:"status" binding:"omitempty,oneof=draft published archived"`
+	Tags     string               `form:"tags"`
+	From     time.Time            `form:"from" time_format:"2006-01-02"`
+	To       time.Time            `form:"to" time_format:"2006-01-02"`
+}
+
+// SearchResponse представляє сторінку результатів пошуку; total - кількість усіх збігів
+type SearchResponse struct {
+	Data       []*models.SearchHit `json:"data"`
+	NextCursor string              `json:"next_cursor" example:"eyJzIjoiLXJhbmssLXVwZGF0ZWRfYXQsLWlkIiwidiI6W119"`
+	Total      int                 `json:"total" example:"42"`
+}
+
+// Search godoc
+// @Summary      Search articles
+// @Description  Full-text search over article titles, bodies and tag names. Results are ranked by relevance (title matches weigh more than body matches, body matches more than tags) and include highlighted snippets. Supports "phrases", OR and -exclusions.
+// @ID           search
+// @Tags         search
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q         query string true  "Search query"
+// @Param        lang      query string false "Stemming language (simple, english, russian, ...)"
+// @Param        space_id  query int    false "Space ID"
+// @Param        author_id query int    false "Author ID"
+// @Param        status    query string false "Article status" Enums(draft, published, archived)
+// @Param        tags      query string false "Comma-separated tag names; articles must have all of them"
+// @Param        from      query string false "Updated on or after (YYYY-MM-DD)"
+// @Param        to        query string false "Updated on or before (YYYY-MM-DD)"
+// @Param        limit     query int    false "Page size (default 20, max 100)"
+// @Param        cursor    query string false "next_cursor of the previous page"
+// @Param        sort      query string false "Comma-separated sort fields, - for descending: rank, updated_at (default -rank,-updated_at)"
+// @Success      200 {object} SearchResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var req SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	query := models.SearchQuery{
+		Text:     req.Text,
+		Language: req.Language,
+		SpaceID:  req.SpaceID,
+		AuthorID: req.AuthorID,
+		Status:   req.Status,
+	}
+
+	if req.Tags != "" {
+		query.Tags = strings.Split(req.Tags, ",")
+	}
+
+	if !req.From.IsZero() {
+		query.UpdatedFrom = &req.From
+	}
+
+	if !req.To.IsZero() {
+		// Дата "to" включна, тому межею є початок наступного дня
+		to := req.To.AddDate(0, 0, 1)
+		query.UpdatedTo = &to
+	}
+
+	result, err := h.searchService.Search(c.Request.Context(), query, req.Query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SearchResponse{
+		Data:       result.Hits,
+		NextCursor: result.NextCursor,
+		Total:      result.Total,
+	})
+}
@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSearchHandler_Search(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+
+	for _, title := range []string{"Deploy guide", "Release checklist"} {
+		if _, err := articleService.Create(context.Background(), services.Actor{UserID: 1, Role: models.RoleEditor}, services.ArticleInput{Title: title}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if err := mockRepo.Tag().AddArticleTags(context.Background(), 1, []string{"ops"}); err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	searchHandler := NewSearchHandler(services.NewSearchService(mockRepo.Search()), logger.New("debug"))
+
+	router := gin.New()
+	router.GET("/search", searchHandler.Search)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantTotal  int
+	}{
+		{"Match", "/search?q=deploy", http.StatusOK, 1},
+		{"No match", "/search?q=kubernetes", http.StatusOK, 0},
+		{"Date range includes today", "/search?q=guide&from=2000-01-01&to=2999-12-31", http.StatusOK, 1},
+		{"Tag name match", "/search?q=ops", http.StatusOK, 1},
+		{"Tag filter", "/search?q=deploy&tags=OPS", http.StatusOK, 1},
+		{"Tag filter requires all tags", "/search?q=deploy&tags=ops,release", http.StatusOK, 0},
+		{"Missing query", "/search", http.StatusBadRequest, 0},
+		{"Invalid tag", "/search?q=deploy&tags=ops,,release", http.StatusBadRequest, 0},
+		{"Invalid date", "/search?q=deploy&from=yesterday", http.StatusBadRequest, 0},
+		{"Unknown language", "/search?q=deploy&lang=klingon", http.StatusBadRequest, 0},
+		{"Inverted range", "/search?q=deploy&from=2025-02-01&to=2025-01-01", http.StatusBadRequest, 0},
+		{"Invalid limit", "/search?q=deploy&limit=1000", http.StatusBadRequest, 0},
+		{"Invalid cursor", "/search?q=deploy&cursor=abc", http.StatusBadRequest, 0},
+		{"Unsupported sort", "/search?q=deploy&sort=title", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var response SearchResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if response.Total != tt.wantTotal || len(response.Data) != tt.wantTotal {
+				t.Errorf("Expected %d results, got %d (total %d)", tt.wantTotal, len(response.Data), response.Total)
+			}
+		})
+	}
+}
+
+func TestSearchHandler_Cursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+
+	for _, title := range []string{"Deploy guide", "Deploy checklist", "Deploy rollback"} {
+		if _, err := articleService.Create(context.Background(), services.Actor{UserID: 1, Role: models.RoleEditor}, services.ArticleInput{Title: title}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	searchHandler := NewSearchHandler(services.NewSearchService(mockRepo.Search()), logger.New("debug"))
+
+	router := gin.New()
+	router.GET("/search", searchHandler.Search)
+
+	seen := make(map[uint]bool)
+	url := "/search?q=deploy&limit=2"
+
+	for _, wantHits := range []int{2, 1} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+		var response SearchResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		if len(response.Data) != wantHits || response.Total != 3 {
+			t.Fatalf("Expected %d hits of 3, got %d (total %d)", wantHits, len(response.Data), response.Total)
+		}
+
+		for _, hit := range response.Data {
+			seen[hit.ArticleID] = true
+		}
+
+		url = "/search?q=deploy&limit=2&cursor=" + response.NextCursor
+		if wantHits == 1 && response.NextCursor != "" {
+			t.Errorf("Expected no next cursor on the last page, got %q", response.NextCursor)
+		}
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("Expected every article once across pages, got %v", seen)
+	}
+}
@@ -0,0 +1,504 @@
+package v1
+
+import (
+	"math"
+	"net/http"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SpaceHandler обробляє запити просторів і структури сторінок
+type SpaceHandler struct {
+	spaceService *services.SpaceService
+	logger       logger.Interface
+}
+
+// NewSpaceHandler створює новий екземпляр SpaceHandler
+func NewSpaceHandler(spaceService *services.SpaceService, logger logger.Interface) *SpaceHandler {
+	return &SpaceHandler{
+		spaceService: spaceService,
+		logger:       logger,
+	}
+}
+
+// SpaceRequest представляє запит на створення або оновлення простору.
+// Key генерується з Name, якщо не переданий.
+type SpaceRequest struct {
+	Key         string `json:"key" binding:"max=100" example:"engineering"`
+	Name        string `json:"name" binding:"required,max=255" example:"Engineering"`
+	Description string `json:"description" example:"Team handbook and runbooks"`
+}
+
+// MovePageRequest представляє нове місце сторінки. Без parent_id сторінка стає кореневою,
+// без position - останньою серед нових сусідів.
+type MovePageRequest struct {
+	SpaceID  uint  `json:"space_id" binding:"required" example:"1"`
+	ParentID *uint `json:"parent_id" example:"2"`
+	Position *int  `json:"position" binding:"omitempty,min=0" example:"0"`
+}
+
+// MemberRequest представляє роль учасника простору
+type MemberRequest struct {
+	Role models.Role `json:"role" binding:"required,oneof=viewer editor admin" example:"editor"`
+}
+
+// MemberResponse представляє відповідь з одним учасником
+type MemberResponse struct {
+	Data models.SpaceMember `json:"data"`
+}
+
+// MemberListResponse представляє сторінку учасників простору
+type MemberListResponse struct {
+	Data       []*models.SpaceMember `json:"data"`
+	NextCursor string                `json:"next_cursor" example:"eyJzIjoiY3JlYXRlZF9hdCx1c2VyX2lkIiwidiI6W119"`
+}
+
+// SpaceResponse представляє відповідь з одним простором
+type SpaceResponse struct {
+	Data models.Space `json:"data"`
+}
+
+// SpaceListResponse представляє сторінку списку просторів
+type SpaceListResponse struct {
+	Data       []*models.Space `json:"data"`
+	NextCursor string          `json:"next_cursor" example:"eyJzIjoibmFtZSxpZCIsInYiOltdfQ"`
+}
+
+// SpaceTreeResponse представляє простір з деревом сторінок
+type SpaceTreeResponse struct {
+	Data services.SpaceTree `json:"data"`
+}
+
+// BreadcrumbsResponse представляє шлях до сторінки
+type BreadcrumbsResponse struct {
+	Data services.Breadcrumbs `json:"data"`
+}
+
+// CreateSpace godoc
+// @Summary      Create space
+// @Description  Create a space to organize articles into a page tree. The key is generated from the name when omitted.
+// @ID           create-space
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body SpaceRequest true "Space data"
+// @Success      201 {object} SpaceResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces [post]
+func (h *SpaceHandler) CreateSpace(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		response.Error(c, middleware.ErrNotAuthenticated)
+		return
+	}
+
+	var req SpaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid space request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	space, err := h.spaceService.Create(c.Request.Context(), userID, req.toInput())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	middleware.RequestLogger(c, h.logger).Info("Space %d created by user %d", space.ID, userID)
+
+	c.JSON(http.StatusCreated, SpaceResponse{Data: *space})
+}
+
+// ListSpaces godoc
+// @Summary      List spaces
+// @Description  List spaces, by default ordered by name
+// @ID           list-spaces
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: key, name, created_at (default name)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, key, name, created_by, created_at" collectionFormat(multi)
+// @Success      200 {object} SpaceListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces [get]
+func (h *SpaceHandler) ListSpaces(c *gin.Context) {
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.spaceService.List(c.Request.Context(), query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SpaceListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// GetSpace godoc
+// @Summary      Get space by ID
+// @Description  Get space details by ID
+// @ID           get-space
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Space ID"
+// @Success      200 {object} SpaceResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces/{id} [get]
+func (h *SpaceHandler) GetSpace(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	space, err := h.spaceService.Get(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SpaceResponse{Data: *space})
+}
+
+// UpdateSpace godoc
+// @Summary      Update space
+// @Description  Replace space name and description. The key is changed only when provided.
+// @ID           update-space
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int          true "Space ID"
+// @Param        request body SpaceRequest true "Space data"
+// @Success      200 {object} SpaceResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces/{id} [put]
+func (h *SpaceHandler) UpdateSpace(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req SpaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid space request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	space, err := h.spaceService.Update(c.Request.Context(), id, req.toInput())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SpaceResponse{Data: *space})
+}
+
+// DeleteSpace godoc
+// @Summary      Delete space
+// @Description  Delete an empty space. Spaces that still contain articles cannot be deleted.
+// @ID           delete-space
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Space ID"
+// @Success      204
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces/{id} [delete]
+func (h *SpaceHandler) DeleteSpace(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.spaceService.Delete(c.Request.Context(), id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+	middleware.RequestLogger(c, h.logger).Info("Space %d deleted by user %d", id, userID)
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSpaceTree godoc
+// @Summary      Get space page tree
+// @Description  Get the space together with all its pages as a nested tree ordered by position
+// @ID           get-space-tree
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Space ID"
+// @Success      200 {object} SpaceTreeResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces/{id}/tree [get]
+func (h *SpaceHandler) GetSpaceTree(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	tree, err := h.spaceService.Tree(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SpaceTreeResponse{Data: *tree})
+}
+
+// MovePage godoc
+// @Summary      Move page
+// @Description  Move an article with its subtree to another parent or space, or reorder it among its siblings.
+// @Description  Requires permission to write articles in both the current and the target space.
+// @ID           move-page
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int             true "Article ID"
+// @Param        request body MovePageRequest true "New placement"
+// @Success      200 {object} ArticleResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/move [post]
+func (h *SpaceHandler) MovePage(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req MovePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid move request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	position := math.MaxInt32
+	if req.Position != nil {
+		position = *req.Position
+	}
+
+	article, err := h.spaceService.MovePage(c.Request.Context(), actor, models.PageMove{
+		ArticleID: id,
+		SpaceID:   req.SpaceID,
+		ParentID:  req.ParentID,
+		Position:  position,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ArticleResponse{Data: *article})
+}
+
+// GetBreadcrumbs godoc
+// @Summary      Get page breadcrumbs
+// @Description  Get the path to an article: its space and the pages from the space root down to the article itself
+// @ID           get-breadcrumbs
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Article ID"
+// @Success      200 {object} BreadcrumbsResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/breadcrumbs [get]
+func (h *SpaceHandler) GetBreadcrumbs(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	breadcrumbs, err := h.spaceService.Breadcrumbs(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, BreadcrumbsResponse{Data: *breadcrumbs})
+}
+
+// ListMembers godoc
+// @Summary      List space members
+// @Description  List users with a role in the space, by default in the order they were added. Members act with the higher of their global and space role inside the space.
+// @ID           list-space-members
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  int      true  "Space ID"
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: user_id, created_at (default created_at)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: user_id, role, created_at" collectionFormat(multi)
+// @Success      200 {object} MemberListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces/{id}/members [get]
+func (h *SpaceHandler) ListMembers(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.spaceService.ListMembers(c.Request.Context(), id, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MemberListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// SetMember godoc
+// @Summary      Set space member
+// @Description  Add a user to the space or change their role in it
+// @ID           set-space-member
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int           true "Space ID"
+// @Param        user_id path int           true "User ID"
+// @Param        request body MemberRequest true "Member role"
+// @Success      200 {object} MemberResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces/{id}/members/{user_id} [put]
+func (h *SpaceHandler) SetMember(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	userID, ok := pathID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	var req MemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	member, err := h.spaceService.SetMember(c.Request.Context(), id, userID, req.Role)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MemberResponse{Data: *member})
+}
+
+// RemoveMember godoc
+// @Summary      Remove space member
+// @Description  Remove a user's role in the space; their global role still applies
+// @ID           remove-space-member
+// @Tags         spaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int true "Space ID"
+// @Param        user_id path int true "User ID"
+// @Success      204
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /spaces/{id}/members/{user_id} [delete]
+func (h *SpaceHandler) RemoveMember(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	userID, ok := pathID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if err := h.spaceService.RemoveMember(c.Request.Context(), id, userID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (r SpaceRequest) toInput() services.SpaceInput {
+	return services.SpaceInput{
+		Key:         r.Key,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
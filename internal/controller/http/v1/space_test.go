@@ -0,0 +1,239 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTestSpaceRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "owner", Email: "owner@example.com"})
+	mockRepo.AddUser(&models.User{ID: 2, Username: "member", Email: "member@example.com"})
+
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	spaceService := services.NewSpaceService(mockRepo.Space(), mockRepo.Article(), mockRepo.PageTree(), mockRepo.SpaceMember(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	articleHandler := NewArticleHandler(articleService, services.NewRenderService(mockRepo.Link(), 0), logger.New("debug"))
+	spaceHandler := NewSpaceHandler(spaceService, logger.New("debug"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("role", models.RoleEditor)
+		c.Next()
+	})
+
+	router.POST("/articles", articleHandler.CreateArticle)
+	router.DELETE("/articles/:id", articleHandler.DeleteArticle)
+	router.POST("/articles/:id/move", spaceHandler.MovePage)
+	router.GET("/articles/:id/breadcrumbs", spaceHandler.GetBreadcrumbs)
+	router.POST("/spaces", spaceHandler.CreateSpace)
+	router.GET("/spaces", spaceHandler.ListSpaces)
+	router.GET("/spaces/:id", spaceHandler.GetSpace)
+	router.PUT("/spaces/:id", spaceHandler.UpdateSpace)
+	router.DELETE("/spaces/:id", spaceHandler.DeleteSpace)
+	router.GET("/spaces/:id/tree", spaceHandler.GetSpaceTree)
+	router.GET("/spaces/:id/members", spaceHandler.ListMembers)
+	router.PUT("/spaces/:id/members/:user_id", spaceHandler.SetMember)
+	router.DELETE("/spaces/:id/members/:user_id", spaceHandler.RemoveMember)
+
+	return router
+}
+
+func TestSpaceHandler_CRUD(t *testing.T) {
+	router := getTestSpaceRouter()
+
+	w := doArticleRequest(router, http.MethodPost, "/spaces", SpaceRequest{Name: "Engineering"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var created SpaceResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if created.Data.Key != "engineering" || created.Data.CreatedBy != 1 {
+		t.Errorf("Unexpected space: %+v", created.Data)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		want   int
+	}{
+		{"duplicate key", http.MethodPost, "/spaces", SpaceRequest{Name: "Engineering"}, http.StatusConflict},
+		{"missing name", http.MethodPost, "/spaces", SpaceRequest{Key: "ops"}, http.StatusBadRequest},
+		{"list", http.MethodGet, "/spaces", nil, http.StatusOK},
+		{"get", http.MethodGet, "/spaces/1", nil, http.StatusOK},
+		{"get unknown", http.MethodGet, "/spaces/99", nil, http.StatusNotFound},
+		{"invalid id", http.MethodGet, "/spaces/abc", nil, http.StatusBadRequest},
+		{"update", http.MethodPut, "/spaces/1", SpaceRequest{Name: "Eng"}, http.StatusOK},
+		{"article in space", http.MethodPost, "/articles", ArticleRequest{Title: "Intro", SpaceID: uintPtr(1)}, http.StatusCreated},
+		{"article in unknown space", http.MethodPost, "/articles", ArticleRequest{Title: "X", SpaceID: uintPtr(99)}, http.StatusNotFound},
+		{"delete non-empty", http.MethodDelete, "/spaces/1", nil, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(router, tt.method, tt.url, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSpaceHandler_TreeMoveBreadcrumbs(t *testing.T) {
+	router := getTestSpaceRouter()
+
+	doArticleRequest(router, http.MethodPost, "/spaces", SpaceRequest{Name: "Docs"})
+	doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "Guide", SpaceID: uintPtr(1)})
+	doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "Install", ParentID: uintPtr(1)})
+	doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: "FAQ", SpaceID: uintPtr(1)})
+
+	w := doArticleRequest(router, http.MethodGet, "/spaces/1/tree", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var tree SpaceTreeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &tree); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(tree.Data.Pages) != 2 || len(tree.Data.Pages[0].Children) != 1 || tree.Data.Pages[0].Children[0].Title != "Install" {
+		t.Fatalf("Unexpected tree: %s", w.Body.String())
+	}
+
+	// Без position сторінка стає останньою серед нових сусідів
+	w = doArticleRequest(router, http.MethodPost, "/articles/3/move", MovePageRequest{SpaceID: 1, ParentID: uintPtr(1)})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var moved ArticleResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &moved); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if moved.Data.ParentID == nil || *moved.Data.ParentID != 1 || moved.Data.Position != 1 {
+		t.Errorf("Unexpected moved article: %+v", moved.Data)
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/3/breadcrumbs", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var breadcrumbs BreadcrumbsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &breadcrumbs); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if breadcrumbs.Data.Space == nil || len(breadcrumbs.Data.Pages) != 2 || breadcrumbs.Data.Pages[0].Title != "Guide" {
+		t.Errorf("Unexpected breadcrumbs: %s", w.Body.String())
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		body any
+		want int
+	}{
+		{"cycle", "/articles/1/move", MovePageRequest{SpaceID: 1, ParentID: uintPtr(2)}, http.StatusConflict},
+		{"unknown parent", "/articles/1/move", MovePageRequest{SpaceID: 1, ParentID: uintPtr(99)}, http.StatusBadRequest},
+		{"unknown article", "/articles/99/move", MovePageRequest{SpaceID: 1}, http.StatusNotFound},
+		{"missing space", "/articles/1/move", map[string]any{"position": 0}, http.StatusBadRequest},
+		{"negative position", "/articles/1/move", map[string]any{"space_id": 1, "position": -1}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(router, http.MethodPost, tt.url, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w = doArticleRequest(router, http.MethodDelete, "/articles/1", nil)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d for page with children, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestSpaceHandler_Members(t *testing.T) {
+	router := getTestSpaceRouter()
+
+	doArticleRequest(router, http.MethodPost, "/spaces", SpaceRequest{Name: "Docs"})
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		want   int
+	}{
+		{"add member", http.MethodPut, "/spaces/1/members/2", MemberRequest{Role: models.RoleEditor}, http.StatusOK},
+		{"invalid role", http.MethodPut, "/spaces/1/members/2", MemberRequest{Role: "owner"}, http.StatusBadRequest},
+		{"unknown user", http.MethodPut, "/spaces/1/members/99", MemberRequest{Role: models.RoleEditor}, http.StatusNotFound},
+		{"unknown space", http.MethodPut, "/spaces/99/members/2", MemberRequest{Role: models.RoleEditor}, http.StatusNotFound},
+		{"invalid user id", http.MethodPut, "/spaces/1/members/abc", MemberRequest{Role: models.RoleEditor}, http.StatusBadRequest},
+		{"list", http.MethodGet, "/spaces/1/members", nil, http.StatusOK},
+		{"list invalid limit", http.MethodGet, "/spaces/1/members?limit=1000", nil, http.StatusBadRequest},
+		{"list invalid filter", http.MethodGet, "/spaces/1/members?filter=space_id:eq:1", nil, http.StatusBadRequest},
+		{"remove", http.MethodDelete, "/spaces/1/members/2", nil, http.StatusNoContent},
+		{"remove again", http.MethodDelete, "/spaces/1/members/2", nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(router, tt.method, tt.url, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w := doArticleRequest(router, http.MethodGet, "/spaces/1/members", nil)
+
+	var members MemberListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &members); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(members.Data) != 1 || members.Data[0].UserID != 1 || members.Data[0].Role != models.RoleAdmin {
+		t.Errorf("Expected only the creator as admin, got %+v", members.Data)
+	}
+
+	doArticleRequest(router, http.MethodPut, "/spaces/1/members/2", MemberRequest{Role: models.RoleViewer})
+
+	w = doArticleRequest(router, http.MethodGet, "/spaces/1/members?limit=1", nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &members); err != nil || len(members.Data) != 1 || members.NextCursor == "" {
+		t.Fatalf("Expected first page with next cursor, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/spaces/1/members?limit=1&cursor="+members.NextCursor, nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &members); err != nil || len(members.Data) != 1 ||
+		members.Data[0].UserID != 2 || members.NextCursor != "" {
+		t.Errorf("Expected last page with the new member, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
@@ -0,0 +1,337 @@
+package v1
+
+import (
+	"net/http"
+
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler обробляє запити тегів статей
+type TagHandler struct {
+	tagService *services.TagService
+	logger     logger.Interface
+}
+
+// NewTagHandler створює новий екземпляр TagHandler
+func NewTagHandler(tagService *services.TagService, logger logger.Interface) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+		logger:     logger,
+	}
+}
+
+// ArticleTagsRequest представляє теги, що додаються до статті
+type ArticleTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1,max=20,dive,max=64" example:"kubernetes,how-to"`
+}
+
+// TagAutocompleteQuery представляє префікс і параметри сторінки автодоповнення тегів
+type TagAutocompleteQuery struct {
+	pagination.Query
+	Prefix string `form:"q" binding:"max=64"`
+}
+
+// RenameTagRequest представляє нову назву тегу
+type RenameTagRequest struct {
+	Name string `json:"name" binding:"required,max=64" example:"k8s"`
+}
+
+// MergeTagsRequest представляє теги Sources, статті яких переходять до Target
+type MergeTagsRequest struct {
+	Sources []string `json:"sources" binding:"required,min=1,max=100,dive,max=64" example:"k8s,kube"`
+	Target  string   `json:"target" binding:"required,max=64" example:"kubernetes"`
+}
+
+// TagResponse представляє відповідь з одним тегом
+type TagResponse struct {
+	Data models.Tag `json:"data"`
+}
+
+// TagListResponse представляє список тегів; next_cursor порожній на останній сторінці
+// і у відповіді на додавання тегів, яка містить усі теги статті
+type TagListResponse struct {
+	Data       []*models.Tag `json:"data"`
+	NextCursor string        `json:"next_cursor" example:"eyJzIjoibmFtZSxpZCIsInYiOltdfQ"`
+}
+
+// ListArticleTags godoc
+// @Summary      List article tags
+// @Description  List tags of an article, by default in alphabetical order
+// @ID           list-article-tags
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  int      true  "Article ID"
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: name, created_at (default name)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, name, created_at" collectionFormat(multi)
+// @Success      200 {object} TagListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/tags [get]
+func (h *TagHandler) ListArticleTags(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.tagService.ArticleTags(c.Request.Context(), id, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TagListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// AddArticleTags godoc
+// @Summary      Add tags to article
+// @Description  Attach tags to an article, creating new tags as needed. Names are lowercased and spaces are replaced with "-"; tags already on the article are skipped. Returns all tags of the article.
+// @ID           add-article-tags
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                true "Article ID"
+// @Param        request body ArticleTagsRequest true "Tags"
+// @Success      200 {object} TagListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/tags [post]
+func (h *TagHandler) AddArticleTags(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req ArticleTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid article tags request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	tags, err := h.tagService.AddArticleTags(c.Request.Context(), id, req.Tags)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TagListResponse{Data: tags})
+}
+
+// RemoveArticleTag godoc
+// @Summary      Remove tag from article
+// @Description  Detach a tag from an article
+// @ID           remove-article-tag
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path int    true "Article ID"
+// @Param        name path string true "Tag name"
+// @Success      204
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /articles/{id}/tags/{name} [delete]
+func (h *TagHandler) RemoveArticleTag(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.tagService.RemoveArticleTag(c.Request.Context(), id, c.Param("name")); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPopularTags godoc
+// @Summary      List popular tags
+// @Description  List tags used by at least one article, by default ordered by article count. Use GET /articles?tag= to browse articles by tag.
+// @ID           list-popular-tags
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: name, article_count, created_at (default -article_count,name)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, name, article_count, created_at" collectionFormat(multi)
+// @Success      200 {object} TagListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /tags [get]
+func (h *TagHandler) ListPopularTags(c *gin.Context) {
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.tagService.Popular(c.Request.Context(), query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TagListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// AutocompleteTags godoc
+// @Summary      Autocomplete tags
+// @Description  List popular tags whose name starts with the given prefix. An empty prefix returns an empty list.
+// @ID           autocomplete-tags
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q         query string   false "Tag name prefix"
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: name, article_count, created_at (default -article_count,name)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, name, article_count, created_at" collectionFormat(multi)
+// @Success      200 {object} TagListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /tags/autocomplete [get]
+func (h *TagHandler) AutocompleteTags(c *gin.Context) {
+	var query TagAutocompleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.tagService.Autocomplete(c.Request.Context(), query.Prefix, query.Query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TagListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
+// GetTag godoc
+// @Summary      Get tag
+// @Description  Get a tag by name together with its article count
+// @ID           get-tag
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name path string true "Tag name"
+// @Success      200 {object} TagResponse
+// @Failure      401 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /tags/{name} [get]
+func (h *TagHandler) GetTag(c *gin.Context) {
+	tag, err := h.tagService.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TagResponse{Data: *tag})
+}
+
+// RenameTag godoc
+// @Summary      Rename tag
+// @Description  Rename a tag keeping its articles. Fails with 409 when a tag with the new name exists; merge the tags instead. Requires admin role.
+// @ID           rename-tag
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name    path string           true "Tag name"
+// @Param        request body RenameTagRequest true "New name"
+// @Success      200 {object} TagResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /tags/{name} [put]
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	var req RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid rename tag request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	tag, err := h.tagService.Rename(c.Request.Context(), c.Param("name"), req.Name)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+	middleware.RequestLogger(c, h.logger).Info("Tag %q renamed to %q by user %d", c.Param("name"), tag.Name, userID)
+
+	c.JSON(http.StatusOK, TagResponse{Data: *tag})
+}
+
+// MergeTags godoc
+// @Summary      Merge tags
+// @Description  Move all articles from the source tags to the target tag and delete the sources in one transaction. The target is created when missing. Requires admin role.
+// @ID           merge-tags
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body MergeTagsRequest true "Tags to merge"
+// @Success      200 {object} TagResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /tags/merge [post]
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	var req MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RequestLogger(c, h.logger).Warn("Invalid merge tags request: %v", err)
+		response.Invalid(c, err)
+		return
+	}
+
+	tag, err := h.tagService.Merge(c.Request.Context(), req.Sources, req.Target)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+	middleware.RequestLogger(c, h.logger).Info("Tags %v merged into %q by user %d", req.Sources, tag.Name, userID)
+
+	c.JSON(http.StatusOK, TagResponse{Data: *tag})
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/internal/services"
+	"KnowledgeHub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTestTagRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+
+	articleService := services.NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		services.NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	tagService := services.NewTagService(mockRepo.Tag(), mockRepo.Article())
+	articleHandler := NewArticleHandler(articleService, services.NewRenderService(mockRepo.Link(), 0), logger.New("debug"))
+	tagHandler := NewTagHandler(tagService, logger.New("debug"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("role", models.RoleEditor)
+		c.Next()
+	})
+
+	router.POST("/articles", articleHandler.CreateArticle)
+	router.GET("/articles", articleHandler.ListArticles)
+	router.GET("/articles/:id/tags", tagHandler.ListArticleTags)
+	router.POST("/articles/:id/tags", tagHandler.AddArticleTags)
+	router.DELETE("/articles/:id/tags/:name", tagHandler.RemoveArticleTag)
+	router.GET("/tags", tagHandler.ListPopularTags)
+	router.GET("/tags/autocomplete", tagHandler.AutocompleteTags)
+	router.POST("/tags/merge", tagHandler.MergeTags)
+	router.GET("/tags/:name", tagHandler.GetTag)
+	router.PUT("/tags/:name", tagHandler.RenameTag)
+
+	return router
+}
+
+func tagsRequest(names ...string) ArticleTagsRequest {
+	return ArticleTagsRequest{Tags: names}
+}
+
+func TestTagHandler(t *testing.T) {
+	router := getTestTagRouter()
+
+	for _, title := range []string{"First", "Second"} {
+		w := doArticleRequest(router, http.MethodPost, "/articles", ArticleRequest{Title: title})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Create article status = %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	mergeUnknown := MergeTagsRequest{Sources: []string{"k8s"}, Target: "go"}
+	merge := MergeTagsRequest{Sources: []string{"kubernetes"}, Target: "golang"}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		want   int
+	}{
+		{"add tags", http.MethodPost, "/articles/1/tags", tagsRequest("Go", "k8s"), http.StatusOK},
+		{"add tags second", http.MethodPost, "/articles/2/tags", tagsRequest("go"), http.StatusOK},
+		{"add empty", http.MethodPost, "/articles/1/tags", ArticleTagsRequest{}, http.StatusBadRequest},
+		{"add invalid", http.MethodPost, "/articles/1/tags", tagsRequest("a/b"), http.StatusBadRequest},
+		{"add unknown article", http.MethodPost, "/articles/99/tags", tagsRequest("go"), http.StatusNotFound},
+		{"list article tags", http.MethodGet, "/articles/1/tags", nil, http.StatusOK},
+		{"list article tags invalid limit", http.MethodGet, "/articles/1/tags?limit=1000", nil, http.StatusBadRequest},
+		{"popular", http.MethodGet, "/tags", nil, http.StatusOK},
+		{"popular invalid cursor", http.MethodGet, "/tags?cursor=abc", nil, http.StatusBadRequest},
+		{"autocomplete", http.MethodGet, "/tags/autocomplete?q=g", nil, http.StatusOK},
+		{"autocomplete invalid sort", http.MethodGet, "/tags/autocomplete?q=g&sort=id", nil, http.StatusBadRequest},
+		{"get tag", http.MethodGet, "/tags/go", nil, http.StatusOK},
+		{"get unknown tag", http.MethodGet, "/tags/rust", nil, http.StatusNotFound},
+		{"browse by tag", http.MethodGet, "/articles?tag=go", nil, http.StatusOK},
+		{"browse invalid tag", http.MethodGet, "/articles?tag=a%2Fb", nil, http.StatusBadRequest},
+		{"rename to existing", http.MethodPut, "/tags/k8s", RenameTagRequest{Name: "go"}, http.StatusConflict},
+		{"rename", http.MethodPut, "/tags/k8s", RenameTagRequest{Name: "kubernetes"}, http.StatusOK},
+		{"merge unknown", http.MethodPost, "/tags/merge", mergeUnknown, http.StatusNotFound},
+		{"merge", http.MethodPost, "/tags/merge", merge, http.StatusOK},
+		{"remove tag", http.MethodDelete, "/articles/1/tags/golang", nil, http.StatusNoContent},
+		{"remove missing tag", http.MethodDelete, "/articles/1/tags/golang", nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(router, tt.method, tt.url, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Status code = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	w := doArticleRequest(router, http.MethodGet, "/tags", nil)
+
+	var popular TagListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &popular); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(popular.Data) != 1 || popular.Data[0].Name != "go" || popular.Data[0].ArticleCount != 2 {
+		t.Errorf("Unexpected popular tags: %+v", popular.Data)
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles?tag=Go", nil)
+
+	var list ArticlePageResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(list.Data) != 2 {
+		t.Errorf("Expected 2 articles tagged go, got %d", len(list.Data))
+	}
+
+	doArticleRequest(router, http.MethodPost, "/articles/2/tags", tagsRequest("ops", "release"))
+
+	var tags TagListResponse
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/2/tags?limit=2", nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &tags); err != nil || len(tags.Data) != 2 || tags.NextCursor == "" {
+		t.Fatalf("Expected first page of article tags with next cursor, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doArticleRequest(router, http.MethodGet, "/articles/2/tags?limit=2&cursor="+tags.NextCursor, nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &tags); err != nil || len(tags.Data) != 1 ||
+		tags.Data[0].Name != "release" || tags.NextCursor != "" {
+		t.Errorf("Expected last page with release, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -2,26 +2,80 @@ package v1
 
 import (
 	"net/http"
-	"strconv"
 
+	"KnowledgeHub/internal/controller/http/middleware"
+	"KnowledgeHub/internal/controller/http/response"
+	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/services"
 	"KnowledgeHub/pkg/logger"
+	"KnowledgeHub/pkg/pagination"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
 	userService *services.UserService
+	jwtService  *services.JWTService
 	logger      logger.Interface
 }
 
-func NewUserHandler(userService *services.UserService, logger logger.Interface) *UserHandler {
+func NewUserHandler(
+	userService *services.UserService,
+	jwtService *services.JWTService,
+	logger logger.Interface,
+) *UserHandler {
 	return &UserHandler{
 		userService: userService,
+		jwtService:  jwtService,
 		logger:      logger,
 	}
 }
 
+// RoleRequest представляє запит на зміну ролі
+type RoleRequest struct {
+	Role models.Role `json:"role" binding:"required,oneof=viewer editor admin" example:"editor"`
+}
+
+// UserListResponse представляє сторінку списку користувачів
+type UserListResponse struct {
+	Data       []*models.User `json:"data"`
+	NextCursor string         `json:"next_cursor" example:"eyJzIjoidXNlcm5hbWUsaWQiLCJ2IjpbXX0"`
+}
+
+// ListUsers godoc
+// @Summary      List users
+// @Description  List active users, by default ordered by username
+// @ID           list-users
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit     query int      false "Page size (default 20, max 100)"
+// @Param        cursor    query string   false "next_cursor of the previous page"
+// @Param        sort      query string   false "Comma-separated sort fields, - for descending: id, username, created_at (default username)"
+// @Param        filter    query []string false "Filter expression field:operator:value; operators eq, ne, lt, lte, gt, gte, in (comma-separated values), prefix. Fields: id, username, email, role, created_at" collectionFormat(multi)
+// @Success      200 {object} UserListResponse
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	var query pagination.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Invalid(c, err)
+		return
+	}
+
+	page, err := h.userService.List(c.Request.Context(), query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, UserListResponse{Data: page.Items, NextCursor: page.NextCursor})
+}
+
 // GetUser godoc
 // @Summary      Get user by ID
 // @Description  Get user details by ID
@@ -29,30 +83,78 @@ func NewUserHandler(userService *services.UserService, logger logger.Interface)
 // @Tags         users
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      int  true  "User ID"
 // @Success      200  {object}  models.User
-// @Failure      404  {object}  response.Error
-// @Failure      500  {object}  response.Error
+// @Failure      400  {object}  response.Problem
+// @Failure      401  {object}  response.Problem
+// @Failure      404  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
 // @Router       /users/{id} [get]
 func (h *UserHandler) GetUser(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), id)
 	if err != nil {
-		h.logger.Error(err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": user})
+}
+
+// SetUserRole godoc
+// @Summary      Set user role
+// @Description  Change the global role of a user. Access tokens issued to the user before the change are revoked, so the new role applies after the next token refresh. If the tokens cannot be revoked the request fails with 500 and should be repeated.
+// @ID           set-user-role
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int         true "User ID"
+// @Param        request body RoleRequest true "New role"
+// @Success      200 {object} UserInfo
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /users/{id}/role [put]
+func (h *UserHandler) SetUserRole(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Invalid(c, err)
 		return
 	}
 
-	user, err := h.userService.GetUser(uint(id))
+	user, err := h.userService.SetRole(c.Request.Context(), id, req.Role)
 	if err != nil {
-		h.logger.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		response.Error(c, err)
 		return
 	}
 
-	if user == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	// Стара роль лишається в уже виданих токенах, тож без їх відкликання зміна не завершена.
+	// Повторний запит із тією ж роллю знову відкличе токени.
+	if err = h.jwtService.RevokeUserAccessTokens(c.Request.Context(), user.ID); err != nil {
+		response.Error(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": user})
+	adminID, _ := middleware.GetUserIDFromContext(c)
+	middleware.RequestLogger(c, h.logger).Info("User %d role set to %s by user %d", user.ID, user.Role, adminID)
+
+	c.JSON(http.StatusOK, UserInfo{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Role:     user.Role,
+	})
 }
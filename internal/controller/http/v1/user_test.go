@@ -1,11 +1,14 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"KnowledgeHub/config"
 	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/repo/mocks"
 	"KnowledgeHub/internal/services"
@@ -36,7 +39,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 	r := gin.New()
 
 	// Створення контролера
-	userHandler := NewUserHandler(userService, l)
+	userHandler := NewUserHandler(userService, nil, l)
 
 	// Реєстрація маршруту
 	r.GET("/users/:id", userHandler.GetUser)
@@ -65,7 +68,16 @@ func TestUserHandler_GetUser(t *testing.T) {
 			url:        "/users/999",
 			wantStatus: http.StatusNotFound,
 			wantResponse: map[string]interface{}{
-				"error": "user not found",
+				"code":   "user_not_found",
+				"detail": "user not found",
+			},
+		},
+		{
+			name:       "Invalid id",
+			url:        "/users/abc",
+			wantStatus: http.StatusBadRequest,
+			wantResponse: map[string]interface{}{
+				"code": "invalid_id",
 			},
 		},
 	}
@@ -108,6 +120,172 @@ func TestUserHandler_GetUser(t *testing.T) {
 				} else {
 					t.Errorf("Response does not contain data field")
 				}
+
+				return
+			}
+
+			// Помилки повертаються у форматі problem+json
+			if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want application/problem+json", ct)
+			}
+
+			for key, want := range tt.wantResponse {
+				if response[key] != want {
+					t.Errorf("Response %s = %v, want %v", key, response[key], want)
+				}
+			}
+
+			if response["status"] != float64(tt.wantStatus) {
+				t.Errorf("Response status = %v, want %d", response["status"], tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestUserHandler_SetUserRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "testuser", Email: "test@example.com", Role: models.RoleEditor})
+
+	jwtService, err := services.NewJWTService(&config.Config{
+		JWT: config.JWT{
+			Secret:           "test_secret_key_for_testing_purposes_only",
+			AccessTokenTTL:   900,
+			RefreshTokenTTL:  604800,
+			SigningAlgorithm: "HS256",
+		},
+	}, services.WithRevocationStore(mockRepo.RevokedToken()))
+	if err != nil {
+		t.Fatalf("NewJWTService() error = %v", err)
+	}
+
+	tokenPair, err := jwtService.GenerateSessionTokenPair("", 1, "testuser", "test@example.com", models.RoleEditor)
+	if err != nil {
+		t.Fatalf("GenerateSessionTokenPair() error = %v", err)
+	}
+
+	userHandler := NewUserHandler(services.NewUserService(mockRepo.User()), jwtService, logger.New("debug"))
+
+	r := gin.New()
+	r.PUT("/users/:id/role", userHandler.SetUserRole)
+
+	tests := []struct {
+		name string
+		url  string
+		body any
+		want int
+	}{
+		{"invalid role", "/users/1/role", RoleRequest{Role: "owner"}, http.StatusBadRequest},
+		{"unknown user", "/users/99/role", RoleRequest{Role: models.RoleViewer}, http.StatusNotFound},
+		{"promote", "/users/1/role", RoleRequest{Role: models.RoleAdmin}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doArticleRequest(r, http.MethodPut, tt.url, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Status code = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	// Токен зі старою роллю більше не приймається
+	claims, err := jwtService.ValidateAccessToken(tokenPair.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+
+	if err = jwtService.CheckRevoked(context.Background(), claims); !errors.Is(err, services.ErrRevokedToken) {
+		t.Errorf("Expected token issued before role change to be revoked, got %v", err)
+	}
+}
+
+func TestUserHandler_SetUserRole_RevocationFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "testuser", Email: "test@example.com", Role: models.RoleAdmin})
+
+	// Без сховища відкликань токени з роллю адміністратора відкликати неможливо
+	jwtService, err := services.NewJWTService(&config.Config{
+		JWT: config.JWT{
+			Secret:           "test_secret_key_for_testing_purposes_only",
+			AccessTokenTTL:   900,
+			RefreshTokenTTL:  604800,
+			SigningAlgorithm: "HS256",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewJWTService() error = %v", err)
+	}
+
+	userHandler := NewUserHandler(services.NewUserService(mockRepo.User()), jwtService, logger.New("debug"))
+
+	r := gin.New()
+	r.PUT("/users/:id/role", userHandler.SetUserRole)
+
+	w := doArticleRequest(r, http.MethodPut, "/users/1/role", RoleRequest{Role: models.RoleViewer})
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Status code = %d, want %d: %s", w.Code, http.StatusInternalServerError, w.Body.String())
+	}
+}
+
+func TestUserHandler_ListUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := mocks.NewRepository()
+	for i, name := range []string{"carol", "alice", "bob"} {
+		mockRepo.AddUser(&models.User{ID: uint(i + 1), Username: name, Email: name + "@example.com", Role: models.RoleViewer})
+	}
+
+	userHandler := NewUserHandler(services.NewUserService(mockRepo.User()), nil, logger.New("debug"))
+
+	r := gin.New()
+	r.GET("/users", userHandler.ListUsers)
+
+	// Перша сторінка за замовчуванням упорядкована за username
+	w := doArticleRequest(r, http.MethodGet, "/users?limit=2", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status code = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var page UserListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(page.Data) != 2 || page.Data[0].Username != "alice" || page.Data[1].Username != "bob" ||
+		page.NextCursor == "" {
+		t.Fatalf("Unexpected first page: %+v", page)
+	}
+
+	w = doArticleRequest(r, http.MethodGet, "/users?limit=2&cursor="+page.NextCursor, nil)
+
+	page = UserListResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(page.Data) != 1 || page.Data[0].Username != "carol" || page.NextCursor != "" {
+		t.Errorf("Unexpected last page: %+v", page)
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{"filter", "/users?filter=username:prefix:b&sort=-id", http.StatusOK},
+		{"unknown sort field", "/users?sort=password_hash", http.StatusBadRequest},
+		{"malformed cursor", "/users?cursor=not-a-cursor", http.StatusBadRequest},
+		{"limit too large", "/users?limit=1000", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doArticleRequest(r, http.MethodGet, tt.url, nil); w.Code != tt.want {
+				t.Errorf("Status code = %d, want %d: %s", w.Code, tt.want, w.Body.String())
 			}
 		})
 	}
@@ -0,0 +1,100 @@
+// Package metrics збирає метрики Prometheus: HTTP запити, пул з'єднань з Postgres,
+// результати автентифікації та метрики середовища Go. Метрики реєструються у власному
+// реєстрі пакету і віддаються через Handler, який підключається лише при METRICS_ENABLED.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	_namespace = "knowledgehub"
+
+	// LoginSuccess, LoginFailure, LoginLocked - значення мітки result лічильника входів
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+	LoginLocked  = "locked"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: _namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: _namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method", "route", "status"})
+
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: _namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Login attempts by result.",
+	}, []string{"result"})
+
+	tokenFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: _namespace,
+		Subsystem: "auth",
+		Name:      "token_validation_failures_total",
+		Help:      "Rejected access tokens by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		logins,
+		tokenFailures,
+	)
+}
+
+// Handler віддає метрики у форматі Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Register додає до реєстру зовнішній збирач, наприклад статистику пулу з'єднань
+func Register(collector prometheus.Collector) error {
+	return registry.Register(collector)
+}
+
+// ObserveHTTPRequest враховує оброблений запит. route - шаблон маршруту ("/v1/articles/:id"),
+// а не шлях запиту, щоб кількість рядів не залежала від ідентифікаторів
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+
+	code := strconv.Itoa(status)
+
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// ObserveLogin враховує спробу входу з результатом LoginSuccess, LoginFailure або LoginLocked
+func ObserveLogin(result string) {
+	logins.WithLabelValues(result).Inc()
+}
+
+// ObserveTokenValidationFailure враховує відхилений токен доступу; reason - код помилки
+func ObserveTokenValidationFailure(reason string) {
+	tokenFailures.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserve(t *testing.T) {
+	ObserveHTTPRequest(http.MethodGet, "/v1/articles/:id", http.StatusOK, 20*time.Millisecond)
+	ObserveHTTPRequest(http.MethodGet, "/v1/articles/:id", http.StatusOK, 30*time.Millisecond)
+	ObserveHTTPRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+	ObserveLogin(LoginFailure)
+	ObserveTokenValidationFailure("expired_token")
+
+	if got := testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/v1/articles/:id", "200")); got != 2 {
+		t.Errorf("Expected 2 requests for the route, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "unmatched", "404")); got != 1 {
+		t.Errorf("Expected unmatched route to be counted, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(logins.WithLabelValues(LoginFailure)); got != 1 {
+		t.Errorf("Expected 1 failed login, got %v", got)
+	}
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`knowledgehub_http_request_duration_seconds_count{method="GET",route="/v1/articles/:id",status="200"} 2`,
+		`knowledgehub_auth_token_validation_failures_total{reason="expired_token"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics output to contain %q", want)
+		}
+	}
+}
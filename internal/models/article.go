@@ -0,0 +1,110 @@
+package models
+
+import "time"
+
+type ArticleStatus string
+
+const (
+	ArticleStatusDraft     ArticleStatus = "draft"
+	ArticleStatusPublished ArticleStatus = "published"
+	ArticleStatusArchived  ArticleStatus = "archived"
+)
+
+// IsValid перевіряє, що статус належить до відомих значень
+func (s ArticleStatus) IsValid() bool {
+	switch s {
+	case ArticleStatusDraft, ArticleStatusPublished, ArticleStatusArchived:
+		return true
+	default:
+		return false
+	}
+}
+
+// ArticleLanguageDefault - мова без стемінгу; пошук працює лише за точними словами
+const ArticleLanguageDefault = "simple"
+
+// ArticleLanguages - конфігурації текстового пошуку, що входять до стандартної поставки Postgres
+var ArticleLanguages = []string{
+	ArticleLanguageDefault,
+	"danish", "dutch", "english", "finnish", "french", "german", "hungarian", "italian",
+	"norwegian", "portuguese", "romanian", "russian", "spanish", "swedish", "turkish",
+}
+
+// IsArticleLanguage перевіряє, що мова входить до ArticleLanguages
+func IsArticleLanguage(language string) bool {
+	for _, supported := range ArticleLanguages {
+		if language == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Article - стаття бази знань. Version - номер поточної ревізії,
+// UpdatedBy - автор останньої зміни.
+type Article struct {
+	ID          uint          `json:"id" example:"1"`
+	Title       string        `json:"title" example:"Getting started"`
+	Slug        string        `json:"slug" example:"getting-started"`
+	Body        string        `json:"body" example:"# Getting started"`
+	AuthorID    uint          `json:"author_id" example:"1"`
+	Status      ArticleStatus `json:"status" example:"draft"`
+	Language    string        `json:"language" example:"english"`
+	SpaceID     *uint         `json:"space_id,omitempty" example:"1"`
+	ParentID    *uint         `json:"parent_id,omitempty" example:"1"`
+	Position    int           `json:"position" example:"0"`
+	Version     int           `json:"version" example:"3"`
+	UpdatedBy   uint          `json:"updated_by" example:"1"`
+	PublishedAt *time.Time    `json:"published_at,omitempty" example:"2025-01-01T00:00:00Z"`
+	CreatedAt   time.Time     `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt   time.Time     `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+	// Links - wiki-посилання з Body; зберігаються разом зі статтею, при читанні не заповнюються
+	Links []*ArticleLink `json:"-"`
+}
+
+// ArticleLink - посилання [[Target]] зі статті SourceID на іншу сторінку.
+// Ціль визначається за TargetSlug під час читання; TargetID nil і Broken true,
+// якщо статті з таким slug немає.
+type ArticleLink struct {
+	SourceID   uint   `json:"source_id" example:"1"`
+	Target     string `json:"target" example:"Getting Started"`
+	TargetSlug string `json:"target_slug" example:"getting-started"`
+	TargetID   *uint  `json:"target_id,omitempty" example:"2"`
+	Broken     bool   `json:"broken" example:"false"`
+}
+
+// RenderedArticle - стаття разом з HTML, отриманим з markdown тіла, та змістом
+type RenderedArticle struct {
+	Article
+	HTML string     `json:"html" example:"<h1 id=\"getting-started\">Getting started</h1>"`
+	TOC  []TOCEntry `json:"toc"`
+}
+
+// TOCEntry - заголовок статті в змісті; Anchor - id заголовка в HTML
+type TOCEntry struct {
+	Level  int    `json:"level" example:"1"`
+	Text   string `json:"text" example:"Getting started"`
+	Anchor string `json:"anchor" example:"getting-started"`
+}
+
+// ArticleRevision - незмінний знімок статті після створення або оновлення
+type ArticleRevision struct {
+	ID        uint          `json:"id" example:"10"`
+	ArticleID uint          `json:"article_id" example:"1"`
+	Version   int           `json:"version" example:"3"`
+	Title     string        `json:"title" example:"Getting started"`
+	Slug      string        `json:"slug" example:"getting-started"`
+	Body      string        `json:"body" example:"# Getting started"`
+	Status    ArticleStatus `json:"status" example:"published"`
+	EditorID  uint          `json:"editor_id" example:"1"`
+	CreatedAt time.Time     `json:"created_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// ArticleFilter - параметри вибірки списку статей; нульові значення не фільтрують
+type ArticleFilter struct {
+	SpaceID  uint
+	AuthorID uint
+	Status   ArticleStatus
+	Tag      string
+}
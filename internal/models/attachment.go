@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AttachmentFilenameMaxLength - максимальна довжина імені файлу вкладення в символах
+const AttachmentFilenameMaxLength = 255
+
+// Attachment - файл, прикріплений до статті. Вміст зберігається один раз
+// для всіх вкладень з однаковим SHA256.
+type Attachment struct {
+	ID          uint      `json:"id" example:"1"`
+	ArticleID   uint      `json:"article_id" example:"1"`
+	Filename    string    `json:"filename" example:"architecture.png"`
+	ContentType string    `json:"content_type" example:"image/png"`
+	Size        int64     `json:"size" example:"48213"`
+	SHA256      string    `json:"sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	UploadedBy  uint      `json:"uploaded_by" example:"1"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-01-01T00:00:00Z"`
+}
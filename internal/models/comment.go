@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Comment - коментар до статті. Відповіді посилаються на ParentID, а ThreadID
+// вказує на кореневий коментар гілки; у кореня обидва поля nil.
+// Вирішеною позначається лише гілка, тобто кореневий коментар.
+// Видалений коментар лишається в гілці з порожнім Body.
+type Comment struct {
+	ID         uint              `json:"id" example:"1"`
+	ArticleID  uint              `json:"article_id" example:"1"`
+	ParentID   *uint             `json:"parent_id,omitempty" example:"1"`
+	ThreadID   *uint             `json:"thread_id,omitempty" example:"1"`
+	AuthorID   uint              `json:"author_id" example:"1"`
+	Body       string            `json:"body" example:"@johndoe could you check the rollback section?"`
+	Mentions   []*CommentMention `json:"mentions"`
+	ResolvedAt *time.Time        `json:"resolved_at,omitempty" example:"2025-01-01T00:00:00Z"`
+	ResolvedBy *uint             `json:"resolved_by,omitempty" example:"2"`
+	CreatedAt  time.Time         `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt  time.Time         `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+	DeletedAt  *time.Time        `json:"deleted_at,omitempty" example:"2025-01-01T00:00:00Z"`
+	// Replies - прямі відповіді в порядку створення; заповнює сервіс при побудові гілок
+	Replies []*Comment `json:"replies"`
+}
+
+// CommentMention - користувач, згаданий у коментарі через @username
+type CommentMention struct {
+	UserID   uint   `json:"user_id" example:"2"`
+	Username string `json:"username" example:"johndoe"`
+}
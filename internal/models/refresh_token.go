@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// RefreshToken - серверний запис виданого refresh токена
+type RefreshToken struct {
+	ID        uint
+	UserID    uint
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// Role - роль користувача глобально або в межах простору
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// RoleDefault - роль нових користувачів. Права на редагування надає адміністратор
+// глобально або в окремих просторах.
+const RoleDefault = RoleViewer
+
+// Permission - дія, яку дозволяє роль
+type Permission string
+
+const (
+	PermissionReadArticles  Permission = "articles:read"
+	PermissionWriteArticles Permission = "articles:write"
+	PermissionCreateSpaces  Permission = "spaces:create"
+	PermissionManageSpaces  Permission = "spaces:manage"
+	PermissionManageUsers   Permission = "users:manage"
+	PermissionManageTags    Permission = "tags:manage"
+	// Коментувати можуть усі ролі; модератори видаляють чужі коментарі
+	PermissionWriteComments    Permission = "comments:write"
+	PermissionModerateComments Permission = "comments:moderate"
+)
+
+var _rolePermissions = map[Role][]Permission{
+	RoleViewer: {PermissionReadArticles, PermissionWriteComments},
+	RoleEditor: {PermissionReadArticles, PermissionWriteArticles, PermissionCreateSpaces, PermissionWriteComments},
+	RoleAdmin: {
+		PermissionReadArticles, PermissionWriteArticles, PermissionCreateSpaces,
+		PermissionManageSpaces, PermissionManageUsers, PermissionManageTags,
+		PermissionWriteComments, PermissionModerateComments,
+	},
+}
+
+var _roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// IsValid перевіряє, що роль належить до відомих значень
+func (r Role) IsValid() bool {
+	_, ok := _roleRanks[r]
+	return ok
+}
+
+// AtLeast повертає true, якщо роль не нижча за other: admin > editor > viewer
+func (r Role) AtLeast(other Role) bool {
+	return r.IsValid() && _roleRanks[r] >= _roleRanks[other]
+}
+
+// Can перевіряє, що роль дозволяє permission
+func (r Role) Can(permission Permission) bool {
+	for _, granted := range _rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Max повертає вищу з двох ролей
+func (r Role) Max(other Role) Role {
+	if other.IsValid() && !r.AtLeast(other) {
+		return other
+	}
+
+	return r
+}
+
+// SpaceMember - роль користувача в просторі; діє разом з глобальною роллю,
+// перевага за вищою з них.
+type SpaceMember struct {
+	SpaceID   uint      `json:"space_id" example:"1"`
+	UserID    uint      `json:"user_id" example:"2"`
+	Role      Role      `json:"role" example:"editor"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-01T00:00:00Z"`
+}
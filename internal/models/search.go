@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// SearchQuery - параметри повнотекстового пошуку статей; нульові значення не фільтрують
+type SearchQuery struct {
+	// Text - запит у синтаксисі websearch_to_tsquery: слова, "фрази", OR, -виключення
+	Text string
+	// Language - мова стемінгу запиту; порожня означає пошук лише за точними словами
+	Language string
+	SpaceID  uint
+	AuthorID uint
+	Status   ArticleStatus
+	// Tags - назви тегів; стаття має мати всі з них
+	Tags []string
+	// UpdatedFrom та UpdatedTo обмежують дату останньої зміни статті [from, to)
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+}
+
+// SearchHit - знайдена стаття з релевантністю та фрагментом тексту
+type SearchHit struct {
+	ArticleID uint          `json:"article_id" example:"1"`
+	Title     string        `json:"title" example:"Getting started"`
+	Slug      string        `json:"slug" example:"getting-started"`
+	Status    ArticleStatus `json:"status" example:"published"`
+	SpaceID   *uint         `json:"space_id,omitempty" example:"1"`
+	AuthorID  uint          `json:"author_id" example:"1"`
+	Language  string        `json:"language" example:"english"`
+	UpdatedAt time.Time     `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+	Rank      float64       `json:"rank" example:"0.42"`
+	// Snippet - фрагмент тексту, де збіги обгорнуто в <mark></mark>
+	Snippet string `json:"snippet" example:"How to <mark>deploy</mark> the service"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Space - простір бази знань, що об'єднує дерево сторінок-статей
+type Space struct {
+	ID          uint      `json:"id" example:"1"`
+	Key         string    `json:"key" example:"engineering"`
+	Name        string    `json:"name" example:"Engineering"`
+	Description string    `json:"description" example:"Runbooks and architecture notes"`
+	CreatedBy   uint      `json:"created_by" example:"1"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// PageNode - сторінка в дереві простору. Репозиторій повертає вузли плоским
+// списком з ParentID, Children заповнює сервіс при побудові дерева.
+type PageNode struct {
+	ID       uint          `json:"id" example:"2"`
+	Title    string        `json:"title" example:"Deploy guide"`
+	Slug     string        `json:"slug" example:"deploy-guide"`
+	Status   ArticleStatus `json:"status" example:"published"`
+	ParentID *uint         `json:"parent_id,omitempty" example:"1"`
+	Position int           `json:"position" example:"0"`
+	Children []*PageNode   `json:"children"`
+}
+
+// PageMove - нове місце сторінки: простір, батьківська сторінка (nil - корінь простору)
+// та позиція серед сусідів, починаючи з 0
+type PageMove struct {
+	ArticleID uint
+	SpaceID   uint
+	ParentID  *uint
+	Position  int
+}
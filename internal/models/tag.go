@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// TagNameMaxLength - максимальна довжина назви тегу в символах
+const TagNameMaxLength = 64
+
+// Tag - мітка для навігації статтями. ArticleCount заповнюється лише
+// у списках тегів і дорівнює кількості статей з цим тегом.
+type Tag struct {
+	ID           uint      `json:"id" example:"1"`
+	Name         string    `json:"name" example:"kubernetes"`
+	ArticleCount int       `json:"article_count,omitempty" example:"12"`
+	CreatedAt    time.Time `json:"created_at" example:"2025-01-01T00:00:00Z"`
+}
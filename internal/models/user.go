@@ -1,8 +1,25 @@
 package models
 
+import "time"
+
 type User struct {
 	ID       uint   `json:"id" example:"1"`
 	Username string `json:"username" example:"johndoe"`
 	Email    string `json:"email" example:"johndoe@example.com"`
-	Password string `json:"password" example:"password"`
+	Role     Role   `json:"role" example:"editor"`
+	// PasswordHash - bcrypt-хеш пароля, ніколи не серіалізується у відповідях
+	PasswordHash string `json:"-"`
+	// EmailVerifiedAt - коли користувач підтвердив поточний email; nil - не підтверджено
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" example:"2025-01-01T00:00:00Z"`
+	CreatedAt       time.Time  `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt       time.Time  `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+	DeletedAt       *time.Time `json:"-"`
+}
+
+// LoginLockout - невдалі спроби входу поспіль за нормалізованим username
+type LoginLockout struct {
+	Username string
+	Failures int
+	// LockedUntil - до якого часу вхід заблоковано; nil - не блокувався
+	LockedUntil *time.Time
 }
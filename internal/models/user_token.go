@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// TokenPurpose - дія, яку дозволяє одноразовий токен
+type TokenPurpose string
+
+const (
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+)
+
+// UserToken - серверний запис виданого одноразового токена дії
+type UserToken struct {
+	ID        uint
+	UserID    uint
+	Purpose   TokenPurpose
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package repo
+
+import "KnowledgeHub/pkg/apperror"
+
+var (
+	// ErrAlreadyExists - порушено унікальність (наприклад, username або email вже зайняті).
+	ErrAlreadyExists = apperror.Conflict("already_exists", "entity already exists")
+	// ErrNotFound - сутність для оновлення чи видалення не знайдено.
+	ErrNotFound = apperror.NotFound("not_found", "entity not found")
+	// ErrInUse - на сутність посилаються інші (наприклад, простір зі статтями).
+	ErrInUse = apperror.Conflict("in_use", "entity is referenced by other entities")
+	// ErrCycle - переміщення зробило б сторінку нащадком самої себе.
+	ErrCycle = apperror.Conflict("cycle", "move would create a cycle")
+)
@@ -0,0 +1,171 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockArticleRepository реалізує інтерфейс ArticleRepository для тестування
+type MockArticleRepository struct {
+	store *Mocks
+}
+
+func (m *MockArticleRepository) CreateArticle(_ context.Context, article *models.Article) error {
+	if m.slugTaken(article) {
+		return repo.ErrAlreadyExists
+	}
+
+	now := time.Now()
+	m.store.lastArticleID++
+	article.ID = m.store.lastArticleID
+	article.Version = 1
+	article.Position = 0
+	if article.SpaceID != nil {
+		article.Position = len(m.store.siblings(*article.SpaceID, article.ParentID, article.ID))
+	}
+	article.CreatedAt = now
+	article.UpdatedAt = now
+
+	m.store.articles[article.ID] = m.store.saveLinks(article)
+	m.addRevision(article)
+
+	return nil
+}
+
+func (m *MockArticleRepository) GetArticleByID(_ context.Context, id uint) (*models.Article, error) {
+	article, exists := m.store.articles[id]
+	if !exists {
+		return nil, nil
+	}
+
+	found := *article
+
+	return &found, nil
+}
+
+func (m *MockArticleRepository) GetArticleBySlug(_ context.Context, slug string) (*models.Article, error) {
+	for _, article := range m.store.articles {
+		if article.Slug == slug {
+			found := *article
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MockArticleRepository) ListArticles(
+	_ context.Context,
+	filter models.ArticleFilter,
+	page pagination.Params,
+) (*pagination.Page[*models.Article], error) {
+	articles := make([]*models.Article, 0)
+
+	for _, article := range m.store.articles {
+		if filter.SpaceID != 0 && (article.SpaceID == nil || *article.SpaceID != filter.SpaceID) {
+			continue
+		}
+
+		if filter.AuthorID != 0 && article.AuthorID != filter.AuthorID {
+			continue
+		}
+
+		if filter.Status != "" && article.Status != filter.Status {
+			continue
+		}
+
+		if filter.Tag != "" && !m.store.hasTag(article.ID, filter.Tag) {
+			continue
+		}
+
+		found := *article
+		articles = append(articles, &found)
+	}
+
+	return repo.ArticlePages.Slice(articles, page), nil
+}
+
+func (m *MockArticleRepository) UpdateArticle(_ context.Context, article *models.Article) error {
+	existing, exists := m.store.articles[article.ID]
+	if !exists {
+		return repo.ErrNotFound
+	}
+
+	if m.slugTaken(article) {
+		return repo.ErrAlreadyExists
+	}
+
+	// Розташування в дереві змінює лише MovePage
+	article.AuthorID = existing.AuthorID
+	article.SpaceID = existing.SpaceID
+	article.ParentID = existing.ParentID
+	article.Position = existing.Position
+	article.Version = existing.Version + 1
+	article.CreatedAt = existing.CreatedAt
+	article.UpdatedAt = time.Now()
+
+	m.store.articles[article.ID] = m.store.saveLinks(article)
+	m.addRevision(article)
+
+	return nil
+}
+
+func (m *MockArticleRepository) DeleteArticle(_ context.Context, id uint) error {
+	if _, exists := m.store.articles[id]; !exists {
+		return repo.ErrNotFound
+	}
+
+	for _, other := range m.store.articles {
+		if other.ParentID != nil && *other.ParentID == id {
+			return repo.ErrInUse
+		}
+	}
+
+	delete(m.store.articles, id)
+	delete(m.store.revisions, id)
+	delete(m.store.articleTags, id)
+	delete(m.store.links, id)
+
+	for commentID, comment := range m.store.comments {
+		if comment.ArticleID == id {
+			delete(m.store.comments, commentID)
+		}
+	}
+
+	// Як і каскад у БД, вміст вкладень лишається до очистки DeleteOrphanBlobs
+	for attachmentID, attachment := range m.store.attachments {
+		if attachment.ArticleID == id {
+			delete(m.store.attachments, attachmentID)
+		}
+	}
+
+	return nil
+}
+
+func (m *MockArticleRepository) slugTaken(article *models.Article) bool {
+	for _, other := range m.store.articles {
+		if other.ID != article.ID && other.Slug == article.Slug {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockArticleRepository) addRevision(article *models.Article) {
+	m.store.lastRevisionID++
+	m.store.revisions[article.ID] = append(m.store.revisions[article.ID], &models.ArticleRevision{
+		ID:        m.store.lastRevisionID,
+		ArticleID: article.ID,
+		Version:   article.Version,
+		Title:     article.Title,
+		Slug:      article.Slug,
+		Body:      article.Body,
+		Status:    article.Status,
+		EditorID:  article.UpdatedBy,
+		CreatedAt: article.UpdatedAt,
+	})
+}
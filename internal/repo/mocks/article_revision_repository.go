@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockArticleRevisionRepository реалізує інтерфейс ArticleRevisionRepository для тестування.
+// Ревізії записує MockArticleRepository при створенні та оновленні статей.
+type MockArticleRevisionRepository struct {
+	store *Mocks
+}
+
+func (m *MockArticleRevisionRepository) ListRevisions(
+	_ context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.ArticleRevision], error) {
+	stored := m.store.revisions[articleID]
+	revisions := make([]*models.ArticleRevision, 0, len(stored))
+
+	for _, item := range stored {
+		revision := *item
+		revisions = append(revisions, &revision)
+	}
+
+	return repo.RevisionPages.Slice(revisions, page), nil
+}
+
+func (m *MockArticleRevisionRepository) GetRevision(
+	_ context.Context,
+	articleID uint,
+	version int,
+) (*models.ArticleRevision, error) {
+	for _, stored := range m.store.revisions[articleID] {
+		if stored.Version == version {
+			revision := *stored
+			return &revision, nil
+		}
+	}
+
+	return nil, nil
+}
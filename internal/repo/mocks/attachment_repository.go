@@ -0,0 +1,131 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockAttachmentRepository реалізує інтерфейс AttachmentRepository для тестування
+type MockAttachmentRepository struct {
+	store *Mocks
+}
+
+func (m *MockAttachmentRepository) CreateAttachment(
+	ctx context.Context,
+	attachment *models.Attachment,
+	upload repo.BlobFunc,
+) error {
+	if _, exists := m.store.articles[attachment.ArticleID]; !exists {
+		return repo.ErrNotFound
+	}
+
+	if !m.store.blobs[attachment.SHA256] {
+		if err := upload(ctx, attachment.SHA256); err != nil {
+			return err
+		}
+
+		m.store.blobs[attachment.SHA256] = true
+	}
+
+	m.store.lastAttachmentID++
+	attachment.ID = m.store.lastAttachmentID
+	attachment.CreatedAt = time.Now()
+
+	stored := *attachment
+	m.store.attachments[attachment.ID] = &stored
+
+	return nil
+}
+
+func (m *MockAttachmentRepository) GetAttachmentByID(_ context.Context, id uint) (*models.Attachment, error) {
+	attachment, exists := m.store.attachments[id]
+	if !exists {
+		return nil, nil
+	}
+
+	result := *attachment
+
+	return &result, nil
+}
+
+func (m *MockAttachmentRepository) ListAttachments(
+	_ context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.Attachment], error) {
+	attachments := make([]*models.Attachment, 0)
+
+	for _, attachment := range m.store.attachments {
+		if attachment.ArticleID == articleID {
+			result := *attachment
+			attachments = append(attachments, &result)
+		}
+	}
+
+	return repo.AttachmentPages.Slice(attachments, page), nil
+}
+
+func (m *MockAttachmentRepository) DeleteAttachment(ctx context.Context, id uint, remove repo.BlobFunc) error {
+	attachment, exists := m.store.attachments[id]
+	if !exists {
+		return repo.ErrNotFound
+	}
+
+	if !m.store.blobInUse(attachment.SHA256, id) {
+		if err := remove(ctx, attachment.SHA256); err != nil {
+			return err
+		}
+
+		delete(m.store.blobs, attachment.SHA256)
+	}
+
+	delete(m.store.attachments, id)
+
+	return nil
+}
+
+func (m *MockAttachmentRepository) DeleteOrphanBlobs(
+	ctx context.Context,
+	limit uint64,
+	remove repo.BlobFunc,
+) (int, error) {
+	orphans := make([]string, 0)
+
+	for sha256 := range m.store.blobs {
+		if !m.store.blobInUse(sha256, 0) {
+			orphans = append(orphans, sha256)
+		}
+	}
+
+	sort.Strings(orphans)
+
+	if uint64(len(orphans)) > limit {
+		orphans = orphans[:limit]
+	}
+
+	for i, sha256 := range orphans {
+		if err := remove(ctx, sha256); err != nil {
+			return i, err
+		}
+
+		delete(m.store.blobs, sha256)
+	}
+
+	return len(orphans), nil
+}
+
+// blobInUse перевіряє, чи посилається на вміст якесь вкладення, крім except
+func (m *Mocks) blobInUse(sha256 string, except uint) bool {
+	for id, attachment := range m.attachments {
+		if id != except && attachment.SHA256 == sha256 {
+			return true
+		}
+	}
+
+	return false
+}
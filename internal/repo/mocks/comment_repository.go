@@ -0,0 +1,153 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockCommentRepository реалізує інтерфейс CommentRepository для тестування
+type MockCommentRepository struct {
+	store *Mocks
+}
+
+func (m *MockCommentRepository) CreateComment(_ context.Context, comment *models.Comment) error {
+	if _, exists := m.store.articles[comment.ArticleID]; !exists {
+		return repo.ErrNotFound
+	}
+
+	if comment.ParentID != nil {
+		if _, exists := m.store.comments[*comment.ParentID]; !exists {
+			return repo.ErrNotFound
+		}
+	}
+
+	m.store.lastCommentID++
+	comment.ID = m.store.lastCommentID
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = comment.CreatedAt
+
+	m.store.comments[comment.ID] = m.store.storedComment(comment)
+
+	return nil
+}
+
+func (m *MockCommentRepository) GetCommentByID(_ context.Context, id uint) (*models.Comment, error) {
+	comment, exists := m.store.comments[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return m.store.loadedComment(comment), nil
+}
+
+func (m *MockCommentRepository) ListThreads(
+	_ context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.Comment], error) {
+	threads := m.store.findComments(func(comment *models.Comment) bool {
+		return comment.ArticleID == articleID && comment.ParentID == nil
+	})
+
+	return repo.CommentThreadPages.Slice(threads, page), nil
+}
+
+func (m *MockCommentRepository) ListReplies(_ context.Context, threadIDs []uint) ([]*models.Comment, error) {
+	threads := make(map[uint]bool, len(threadIDs))
+	for _, id := range threadIDs {
+		threads[id] = true
+	}
+
+	return m.store.findComments(func(comment *models.Comment) bool {
+		return comment.ThreadID != nil && threads[*comment.ThreadID]
+	}), nil
+}
+
+func (m *MockCommentRepository) UpdateComment(_ context.Context, comment *models.Comment) error {
+	existing, exists := m.store.comments[comment.ID]
+	if !exists || existing.DeletedAt != nil {
+		return repo.ErrNotFound
+	}
+
+	comment.UpdatedAt = existing.UpdatedAt
+	if comment.Body != existing.Body {
+		comment.UpdatedAt = time.Now()
+	}
+
+	existing.Body = comment.Body
+	existing.ResolvedAt = comment.ResolvedAt
+	existing.ResolvedBy = comment.ResolvedBy
+	existing.UpdatedAt = comment.UpdatedAt
+	existing.Mentions = m.store.storedComment(comment).Mentions
+
+	return nil
+}
+
+func (m *MockCommentRepository) DeleteComment(_ context.Context, id uint) error {
+	comment, exists := m.store.comments[id]
+	if !exists || comment.DeletedAt != nil {
+		return repo.ErrNotFound
+	}
+
+	now := time.Now()
+	comment.Body = ""
+	comment.DeletedAt = &now
+	comment.Mentions = nil
+
+	return nil
+}
+
+// storedComment повертає копію коментаря, в якій зі згадок збережено лише UserID
+func (m *Mocks) storedComment(comment *models.Comment) *models.Comment {
+	stored := *comment
+	stored.Replies = nil
+	stored.Mentions = make([]*models.CommentMention, 0, len(comment.Mentions))
+
+	for _, mention := range comment.Mentions {
+		stored.Mentions = append(stored.Mentions, &models.CommentMention{UserID: mention.UserID})
+	}
+
+	return &stored
+}
+
+// loadedComment повертає копію коментаря з іменами згаданих користувачів
+func (m *Mocks) loadedComment(comment *models.Comment) *models.Comment {
+	found := *comment
+	found.Mentions = make([]*models.CommentMention, 0, len(comment.Mentions))
+
+	for _, mention := range comment.Mentions {
+		if user, exists := m.users[mention.UserID]; exists && user.DeletedAt == nil {
+			found.Mentions = append(found.Mentions, &models.CommentMention{UserID: user.ID, Username: user.Username})
+		}
+	}
+
+	sort.Slice(found.Mentions, func(i, j int) bool {
+		return found.Mentions[i].Username < found.Mentions[j].Username
+	})
+
+	return &found
+}
+
+func (m *Mocks) findComments(match func(comment *models.Comment) bool) []*models.Comment {
+	comments := make([]*models.Comment, 0)
+
+	for _, comment := range m.comments {
+		if match(comment) {
+			comments = append(comments, m.loadedComment(comment))
+		}
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+
+	return comments
+}
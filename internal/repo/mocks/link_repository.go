@@ -0,0 +1,117 @@
+package mocks
+
+import (
+	"context"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockLinkRepository реалізує інтерфейс LinkRepository для тестування
+type MockLinkRepository struct {
+	store *Mocks
+}
+
+func (m *MockLinkRepository) ListArticleLinks(
+	_ context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.ArticleLink], error) {
+	links := make([]*models.ArticleLink, 0)
+
+	for _, link := range m.store.links[articleID] {
+		links = append(links, m.store.resolveLink(link))
+	}
+
+	return repo.ArticleLinkPages.Slice(links, page), nil
+}
+
+func (m *MockLinkRepository) ListBacklinks(
+	_ context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.Article], error) {
+	articles := make([]*models.Article, 0)
+
+	target, exists := m.store.articles[articleID]
+	if !exists {
+		return repo.BacklinkPages.Slice(articles, page), nil
+	}
+
+	for sourceID, links := range m.store.links {
+		if sourceID == articleID {
+			continue
+		}
+
+		for _, link := range links {
+			if link.TargetSlug == target.Slug {
+				found := *m.store.articles[sourceID]
+				articles = append(articles, &found)
+
+				break
+			}
+		}
+	}
+
+	return repo.BacklinkPages.Slice(articles, page), nil
+}
+
+func (m *MockLinkRepository) ListBrokenLinks(
+	_ context.Context,
+	page pagination.Params,
+) (*pagination.Page[*models.ArticleLink], error) {
+	links := make([]*models.ArticleLink, 0)
+
+	for _, sourceLinks := range m.store.links {
+		for _, link := range sourceLinks {
+			if resolved := m.store.resolveLink(link); resolved.Broken {
+				links = append(links, resolved)
+			}
+		}
+	}
+
+	return repo.BrokenLinkPages.Slice(links, page), nil
+}
+
+// saveLinks замінює посилання статті на article.Links і повертає копію статті для збереження;
+// як і в БД, при читанні статті посилання не повертаються
+func (m *Mocks) saveLinks(article *models.Article) *models.Article {
+	links := make([]*models.ArticleLink, 0, len(article.Links))
+	seen := make(map[string]bool)
+
+	for _, link := range article.Links {
+		if seen[link.TargetSlug] {
+			continue
+		}
+
+		seen[link.TargetSlug] = true
+		links = append(links, &models.ArticleLink{SourceID: article.ID, Target: link.Target, TargetSlug: link.TargetSlug})
+	}
+
+	m.links[article.ID] = links
+
+	stored := *article
+	stored.Links = nil
+
+	return &stored
+}
+
+// resolveLink повертає копію посилання зі статтею-ціллю за поточним slug
+func (m *Mocks) resolveLink(link *models.ArticleLink) *models.ArticleLink {
+	resolved := *link
+	resolved.TargetID = nil
+	resolved.Broken = true
+
+	for _, article := range m.articles {
+		if article.Slug == link.TargetSlug {
+			id := article.ID
+			resolved.TargetID = &id
+			resolved.Broken = false
+
+			break
+		}
+	}
+
+	return &resolved
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"KnowledgeHub/internal/models"
+)
+
+// MockLoginLockoutRepository реалізує інтерфейс LoginLockoutRepository для тестування
+type MockLoginLockoutRepository struct {
+	store *Mocks
+}
+
+func (m *MockLoginLockoutRepository) GetLoginLockout(_ context.Context, username string) (*models.LoginLockout, error) {
+	lockout, exists := m.store.loginLockouts[username]
+	if !exists {
+		return nil, nil
+	}
+
+	copied := *lockout
+
+	return &copied, nil
+}
+
+func (m *MockLoginLockoutRepository) RecordLoginFailure(_ context.Context, username string) (int, error) {
+	lockout, exists := m.store.loginLockouts[username]
+	if !exists {
+		lockout = &models.LoginLockout{Username: username}
+		m.store.loginLockouts[username] = lockout
+	}
+
+	lockout.Failures++
+
+	return lockout.Failures, nil
+}
+
+func (m *MockLoginLockoutRepository) LockLogin(_ context.Context, username string, until time.Time) error {
+	lockout, exists := m.store.loginLockouts[username]
+	if !exists {
+		lockout = &models.LoginLockout{Username: username}
+		m.store.loginLockouts[username] = lockout
+	}
+
+	lockout.LockedUntil = &until
+
+	return nil
+}
+
+func (m *MockLoginLockoutRepository) ResetLoginFailures(_ context.Context, username string) error {
+	delete(m.store.loginLockouts, username)
+	return nil
+}
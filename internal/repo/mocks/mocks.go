@@ -1,24 +1,82 @@
 package mocks
 
 import (
+	"time"
+
 	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/repo"
 )
 
 type Mocks struct {
-	users              map[uint]*models.User
-	mockUserRepository *MockUserRepository
+	users                      map[uint]*models.User
+	lastUserID                 uint
+	refreshTokens              map[uint]*models.RefreshToken
+	lastRefreshTokenID         uint
+	revokedTokens              map[string]time.Time
+	userRevocations            map[uint]time.Time
+	loginLockouts              map[string]*models.LoginLockout
+	userTokens                 map[uint]*models.UserToken
+	lastUserTokenID            uint
+	articles                   map[uint]*models.Article
+	lastArticleID              uint
+	revisions                  map[uint][]*models.ArticleRevision
+	lastRevisionID             uint
+	spaces                     map[uint]*models.Space
+	lastSpaceID                uint
+	spaceMembers               map[uint]map[uint]*models.SpaceMember
+	tags                       map[uint]*models.Tag
+	lastTagID                  uint
+	articleTags                map[uint]map[uint]bool
+	comments                   map[uint]*models.Comment
+	lastCommentID              uint
+	attachments                map[uint]*models.Attachment
+	lastAttachmentID           uint
+	blobs                      map[string]bool
+	links                      map[uint][]*models.ArticleLink
+	mockUserRepository         *MockUserRepository
+	mockRefreshTokenRepository *MockRefreshTokenRepository
+	mockRevokedTokenRepository *MockRevokedTokenRepository
+	mockLoginLockoutRepository *MockLoginLockoutRepository
+	mockUserTokenRepository    *MockUserTokenRepository
+	mockArticleRepository      *MockArticleRepository
+	mockRevisionRepository     *MockArticleRevisionRepository
+	mockSearchRepository       *MockSearchRepository
+	mockSpaceRepository        *MockSpaceRepository
+	mockPageTreeRepository     *MockPageTreeRepository
+	mockSpaceMemberRepository  *MockSpaceMemberRepository
+	mockTagRepository          *MockTagRepository
+	mockCommentRepository      *MockCommentRepository
+	mockAttachmentRepository   *MockAttachmentRepository
+	mockLinkRepository         *MockLinkRepository
 }
 
 func NewRepository() *Mocks {
 	return &Mocks{
-		users: make(map[uint]*models.User),
+		users:           make(map[uint]*models.User),
+		refreshTokens:   make(map[uint]*models.RefreshToken),
+		revokedTokens:   make(map[string]time.Time),
+		userRevocations: make(map[uint]time.Time),
+		loginLockouts:   make(map[string]*models.LoginLockout),
+		userTokens:      make(map[uint]*models.UserToken),
+		articles:        make(map[uint]*models.Article),
+		revisions:       make(map[uint][]*models.ArticleRevision),
+		spaces:          make(map[uint]*models.Space),
+		spaceMembers:    make(map[uint]map[uint]*models.SpaceMember),
+		tags:            make(map[uint]*models.Tag),
+		articleTags:     make(map[uint]map[uint]bool),
+		comments:        make(map[uint]*models.Comment),
+		attachments:     make(map[uint]*models.Attachment),
+		blobs:           make(map[string]bool),
+		links:           make(map[uint][]*models.ArticleLink),
 	}
 }
 
 // Допоміжні методи для тестування
 func (m *Mocks) AddUser(user *models.User) {
 	m.users[user.ID] = user
+	if user.ID > m.lastUserID {
+		m.lastUserID = user.ID
+	}
 }
 
 func (m *Mocks) User() repo.UserRepository {
@@ -32,3 +90,171 @@ func (m *Mocks) User() repo.UserRepository {
 
 	return m.mockUserRepository
 }
+
+func (m *Mocks) RefreshToken() repo.RefreshTokenRepository {
+	if m.mockRefreshTokenRepository != nil {
+		return m.mockRefreshTokenRepository
+	}
+
+	m.mockRefreshTokenRepository = &MockRefreshTokenRepository{
+		store: m,
+	}
+
+	return m.mockRefreshTokenRepository
+}
+
+func (m *Mocks) RevokedToken() repo.RevokedTokenRepository {
+	if m.mockRevokedTokenRepository != nil {
+		return m.mockRevokedTokenRepository
+	}
+
+	m.mockRevokedTokenRepository = &MockRevokedTokenRepository{
+		store: m,
+	}
+
+	return m.mockRevokedTokenRepository
+}
+
+func (m *Mocks) LoginLockout() repo.LoginLockoutRepository {
+	if m.mockLoginLockoutRepository != nil {
+		return m.mockLoginLockoutRepository
+	}
+
+	m.mockLoginLockoutRepository = &MockLoginLockoutRepository{
+		store: m,
+	}
+
+	return m.mockLoginLockoutRepository
+}
+
+func (m *Mocks) UserToken() repo.UserTokenRepository {
+	if m.mockUserTokenRepository != nil {
+		return m.mockUserTokenRepository
+	}
+
+	m.mockUserTokenRepository = &MockUserTokenRepository{
+		store: m,
+	}
+
+	return m.mockUserTokenRepository
+}
+
+func (m *Mocks) Article() repo.ArticleRepository {
+	if m.mockArticleRepository != nil {
+		return m.mockArticleRepository
+	}
+
+	m.mockArticleRepository = &MockArticleRepository{
+		store: m,
+	}
+
+	return m.mockArticleRepository
+}
+
+func (m *Mocks) ArticleRevision() repo.ArticleRevisionRepository {
+	if m.mockRevisionRepository != nil {
+		return m.mockRevisionRepository
+	}
+
+	m.mockRevisionRepository = &MockArticleRevisionRepository{
+		store: m,
+	}
+
+	return m.mockRevisionRepository
+}
+
+func (m *Mocks) Search() repo.SearchRepository {
+	if m.mockSearchRepository != nil {
+		return m.mockSearchRepository
+	}
+
+	m.mockSearchRepository = &MockSearchRepository{
+		store: m,
+	}
+
+	return m.mockSearchRepository
+}
+
+func (m *Mocks) Space() repo.SpaceRepository {
+	if m.mockSpaceRepository != nil {
+		return m.mockSpaceRepository
+	}
+
+	m.mockSpaceRepository = &MockSpaceRepository{
+		store: m,
+	}
+
+	return m.mockSpaceRepository
+}
+
+func (m *Mocks) PageTree() repo.PageTreeRepository {
+	if m.mockPageTreeRepository != nil {
+		return m.mockPageTreeRepository
+	}
+
+	m.mockPageTreeRepository = &MockPageTreeRepository{
+		store: m,
+	}
+
+	return m.mockPageTreeRepository
+}
+
+func (m *Mocks) SpaceMember() repo.SpaceMemberRepository {
+	if m.mockSpaceMemberRepository != nil {
+		return m.mockSpaceMemberRepository
+	}
+
+	m.mockSpaceMemberRepository = &MockSpaceMemberRepository{
+		store: m,
+	}
+
+	return m.mockSpaceMemberRepository
+}
+
+func (m *Mocks) Tag() repo.TagRepository {
+	if m.mockTagRepository != nil {
+		return m.mockTagRepository
+	}
+
+	m.mockTagRepository = &MockTagRepository{
+		store: m,
+	}
+
+	return m.mockTagRepository
+}
+
+func (m *Mocks) Comment() repo.CommentRepository {
+	if m.mockCommentRepository != nil {
+		return m.mockCommentRepository
+	}
+
+	m.mockCommentRepository = &MockCommentRepository{
+		store: m,
+	}
+
+	return m.mockCommentRepository
+}
+
+func (m *Mocks) Attachment() repo.AttachmentRepository {
+	if m.mockAttachmentRepository != nil {
+		return m.mockAttachmentRepository
+	}
+
+	m.mockAttachmentRepository = &MockAttachmentRepository{
+		store: m,
+	}
+
+	return m.mockAttachmentRepository
+}
+
+func (m *Mocks) Link() repo.LinkRepository {
+	if m.mockLinkRepository != nil {
+		return m.mockLinkRepository
+	}
+
+	m.mockLinkRepository = &MockLinkRepository{
+		store: m,
+	}
+
+	return m.mockLinkRepository
+}
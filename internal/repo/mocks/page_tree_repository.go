@@ -0,0 +1,161 @@
+package mocks
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+)
+
+// MockPageTreeRepository реалізує інтерфейс PageTreeRepository для тестування
+type MockPageTreeRepository struct {
+	store *Mocks
+}
+
+func (m *MockPageTreeRepository) ListSpacePages(_ context.Context, spaceID uint) ([]*models.PageNode, error) {
+	pages := make([]*models.PageNode, 0)
+
+	for _, article := range m.store.articles {
+		if article.SpaceID != nil && *article.SpaceID == spaceID {
+			pages = append(pages, pageNode(article))
+		}
+	}
+
+	sort.Slice(pages, func(i, j int) bool {
+		if pages[i].Position != pages[j].Position {
+			return pages[i].Position < pages[j].Position
+		}
+		return pages[i].ID < pages[j].ID
+	})
+
+	return pages, nil
+}
+
+func (m *MockPageTreeRepository) GetPageAncestors(_ context.Context, articleID uint) ([]*models.PageNode, error) {
+	ancestors := make([]*models.PageNode, 0)
+
+	article, exists := m.store.articles[articleID]
+	if !exists {
+		return ancestors, nil
+	}
+
+	for parentID := article.ParentID; parentID != nil; {
+		parent, found := m.store.articles[*parentID]
+		if !found {
+			break
+		}
+
+		ancestors = append(ancestors, pageNode(parent))
+		parentID = parent.ParentID
+	}
+
+	slices.Reverse(ancestors)
+
+	return ancestors, nil
+}
+
+func (m *MockPageTreeRepository) MovePage(_ context.Context, move models.PageMove) error {
+	article, exists := m.store.articles[move.ArticleID]
+	if !exists {
+		return repo.ErrNotFound
+	}
+
+	if _, found := m.store.spaces[move.SpaceID]; !found {
+		return repo.ErrNotFound
+	}
+
+	if move.ParentID != nil {
+		parent, found := m.store.articles[*move.ParentID]
+		if !found || parent.SpaceID == nil || *parent.SpaceID != move.SpaceID {
+			return repo.ErrNotFound
+		}
+
+		for id := move.ParentID; id != nil; id = m.store.articles[*id].ParentID {
+			if *id == move.ArticleID {
+				return repo.ErrCycle
+			}
+		}
+	}
+
+	if article.SpaceID != nil {
+		m.store.renumber(m.store.siblings(*article.SpaceID, article.ParentID, article.ID))
+	}
+
+	if article.SpaceID == nil || *article.SpaceID != move.SpaceID {
+		m.store.setSubtreeSpace(article.ID, move.SpaceID)
+	}
+
+	spaceID, parentID := move.SpaceID, move.ParentID
+	article.SpaceID = &spaceID
+	article.ParentID = parentID
+
+	siblings := m.store.siblings(move.SpaceID, move.ParentID, article.ID)
+	position := min(max(move.Position, 0), len(siblings))
+	m.store.renumber(slices.Insert(siblings, position, article))
+
+	return nil
+}
+
+// siblings повертає сторінки з тим самим батьком у поточному порядку, крім exclude
+func (m *Mocks) siblings(spaceID uint, parentID *uint, exclude uint) []*models.Article {
+	siblings := make([]*models.Article, 0)
+
+	for _, article := range m.articles {
+		if article.ID == exclude || article.SpaceID == nil || *article.SpaceID != spaceID {
+			continue
+		}
+
+		if !sameParent(article.ParentID, parentID) {
+			continue
+		}
+
+		siblings = append(siblings, article)
+	}
+
+	sort.Slice(siblings, func(i, j int) bool {
+		if siblings[i].Position != siblings[j].Position {
+			return siblings[i].Position < siblings[j].Position
+		}
+		return siblings[i].ID < siblings[j].ID
+	})
+
+	return siblings
+}
+
+func (m *Mocks) renumber(articles []*models.Article) {
+	for i, article := range articles {
+		article.Position = i
+	}
+}
+
+func (m *Mocks) setSubtreeSpace(articleID, spaceID uint) {
+	for _, article := range m.articles {
+		if article.ParentID != nil && *article.ParentID == articleID {
+			id := spaceID
+			article.SpaceID = &id
+			m.setSubtreeSpace(article.ID, spaceID)
+		}
+	}
+}
+
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func pageNode(article *models.Article) *models.PageNode {
+	return &models.PageNode{
+		ID:       article.ID,
+		Title:    article.Title,
+		Slug:     article.Slug,
+		Status:   article.Status,
+		ParentID: article.ParentID,
+		Position: article.Position,
+		Children: make([]*models.PageNode, 0),
+	}
+}
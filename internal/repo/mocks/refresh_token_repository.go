@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"KnowledgeHub/internal/models"
+)
+
+// MockRefreshTokenRepository реалізує інтерфейс RefreshTokenRepository для тестування
+type MockRefreshTokenRepository struct {
+	store *Mocks
+}
+
+func (m *MockRefreshTokenRepository) CreateRefreshToken(_ context.Context, token *models.RefreshToken) error {
+	m.store.lastRefreshTokenID++
+	token.ID = m.store.lastRefreshTokenID
+	token.CreatedAt = time.Now()
+
+	stored := *token
+	m.store.refreshTokens[token.ID] = &stored
+
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) GetRefreshTokenByHash(_ context.Context, hash string) (*models.RefreshToken, error) {
+	for _, token := range m.store.refreshTokens {
+		if token.TokenHash == hash {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockRefreshTokenRepository) MarkRefreshTokenUsed(_ context.Context, id uint) (bool, error) {
+	token, exists := m.store.refreshTokens[id]
+	if !exists || token.UsedAt != nil || token.RevokedAt != nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	token.UsedAt = &now
+
+	return true, nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeRefreshTokenFamily(_ context.Context, familyID string) error {
+	now := time.Now()
+	for _, token := range m.store.refreshTokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeUserRefreshTokens(_ context.Context, userID uint) error {
+	now := time.Now()
+	for _, token := range m.store.refreshTokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
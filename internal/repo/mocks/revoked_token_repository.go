@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+	"time"
+)
+
+// MockRevokedTokenRepository реалізує інтерфейс RevokedTokenRepository для тестування
+type MockRevokedTokenRepository struct {
+	store *Mocks
+}
+
+func (m *MockRevokedTokenRepository) RevokeToken(_ context.Context, jti string, expiresAt time.Time) error {
+	m.store.revokedTokens[jti] = expiresAt
+	return nil
+}
+
+func (m *MockRevokedTokenRepository) RevokeUserTokens(_ context.Context, userID uint, before time.Time) error {
+	if current, exists := m.store.userRevocations[userID]; !exists || before.After(current) {
+		m.store.userRevocations[userID] = before
+	}
+	return nil
+}
+
+func (m *MockRevokedTokenRepository) IsTokenRevoked(
+	_ context.Context,
+	jti string,
+	userID uint,
+	issuedAt time.Time,
+) (bool, error) {
+	if _, exists := m.store.revokedTokens[jti]; exists {
+		return true, nil
+	}
+
+	before, exists := m.store.userRevocations[userID]
+
+	return exists && !before.Before(issuedAt), nil
+}
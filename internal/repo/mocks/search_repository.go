@@ -0,0 +1,110 @@
+package mocks
+
+import (
+	"context"
+	"strings"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockSearchRepository реалізує інтерфейс SearchRepository для тестування.
+// Замість tsvector стаття знаходиться, якщо містить усі слова запиту без урахування регістру;
+// збіг у заголовку важить більше, ніж у тексті, а в тексті - більше, ніж у тегах.
+type MockSearchRepository struct {
+	store *Mocks
+}
+
+func (m *MockSearchRepository) SearchArticles(
+	_ context.Context,
+	query models.SearchQuery,
+	page pagination.Params,
+) (*pagination.Page[*models.SearchHit], int, error) {
+	words := strings.Fields(strings.ToLower(query.Text))
+	hits := make([]*models.SearchHit, 0)
+
+	for _, article := range m.store.articles {
+		if !m.matchesSearchFilters(article, query) {
+			continue
+		}
+
+		rank, ok := rankArticle(article, m.articleTagNames(article.ID), words)
+		if !ok {
+			continue
+		}
+
+		hits = append(hits, &models.SearchHit{
+			ArticleID: article.ID,
+			Title:     article.Title,
+			Slug:      article.Slug,
+			Status:    article.Status,
+			SpaceID:   article.SpaceID,
+			AuthorID:  article.AuthorID,
+			Language:  article.Language,
+			UpdatedAt: article.UpdatedAt,
+			Rank:      rank,
+			Snippet:   article.Body,
+		})
+	}
+
+	result := repo.SearchPages.Slice(hits, page)
+	if len(result.Items) == 0 {
+		return result, 0, nil
+	}
+
+	return result, len(hits), nil
+}
+
+func (m *MockSearchRepository) matchesSearchFilters(article *models.Article, query models.SearchQuery) bool {
+	for _, tag := range query.Tags {
+		if !m.store.hasTag(article.ID, tag) {
+			return false
+		}
+	}
+
+	switch {
+	case query.SpaceID != 0 && (article.SpaceID == nil || *article.SpaceID != query.SpaceID):
+		return false
+	case query.AuthorID != 0 && article.AuthorID != query.AuthorID:
+		return false
+	case query.Status != "" && article.Status != query.Status:
+		return false
+	case query.UpdatedFrom != nil && article.UpdatedAt.Before(*query.UpdatedFrom):
+		return false
+	case query.UpdatedTo != nil && !article.UpdatedAt.Before(*query.UpdatedTo):
+		return false
+	default:
+		return true
+	}
+}
+
+func (m *MockSearchRepository) articleTagNames(articleID uint) string {
+	names := make([]string, 0, len(m.store.articleTags[articleID]))
+	for tagID := range m.store.articleTags[articleID] {
+		names = append(names, m.store.tags[tagID].Name)
+	}
+
+	return strings.Join(names, " ")
+}
+
+func rankArticle(article *models.Article, tags string, words []string) (float64, bool) {
+	title := strings.ToLower(article.Title)
+	body := strings.ToLower(article.Body)
+	rank := 0.0
+
+	for _, word := range words {
+		switch {
+		case strings.Contains(title, word):
+			rank += 1
+		case strings.Contains(body, word):
+			rank += 0.4
+		case strings.Contains(tags, word):
+			rank += 0.2
+		default:
+			return 0, false
+		}
+	}
+
+	return rank, len(words) > 0
+}
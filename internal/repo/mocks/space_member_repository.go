@@ -0,0 +1,80 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockSpaceMemberRepository реалізує інтерфейс SpaceMemberRepository для тестування
+type MockSpaceMemberRepository struct {
+	store *Mocks
+}
+
+func (m *MockSpaceMemberRepository) SetSpaceMember(_ context.Context, member *models.SpaceMember) error {
+	if _, exists := m.store.spaces[member.SpaceID]; !exists {
+		return repo.ErrNotFound
+	}
+
+	if user, exists := m.store.users[member.UserID]; !exists || user.DeletedAt != nil {
+		return repo.ErrNotFound
+	}
+
+	members := m.store.spaceMembers[member.SpaceID]
+	if members == nil {
+		members = make(map[uint]*models.SpaceMember)
+		m.store.spaceMembers[member.SpaceID] = members
+	}
+
+	member.CreatedAt = time.Now()
+	if existing, exists := members[member.UserID]; exists {
+		member.CreatedAt = existing.CreatedAt
+	}
+
+	stored := *member
+	members[member.UserID] = &stored
+
+	return nil
+}
+
+func (m *MockSpaceMemberRepository) GetSpaceMember(
+	_ context.Context,
+	spaceID, userID uint,
+) (*models.SpaceMember, error) {
+	member, exists := m.store.spaceMembers[spaceID][userID]
+	if !exists {
+		return nil, nil
+	}
+
+	found := *member
+
+	return &found, nil
+}
+
+func (m *MockSpaceMemberRepository) ListSpaceMembers(
+	_ context.Context,
+	spaceID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.SpaceMember], error) {
+	members := make([]*models.SpaceMember, 0)
+
+	for _, member := range m.store.spaceMembers[spaceID] {
+		found := *member
+		members = append(members, &found)
+	}
+
+	return repo.SpaceMemberPages.Slice(members, page), nil
+}
+
+func (m *MockSpaceMemberRepository) RemoveSpaceMember(_ context.Context, spaceID, userID uint) error {
+	if _, exists := m.store.spaceMembers[spaceID][userID]; !exists {
+		return repo.ErrNotFound
+	}
+
+	delete(m.store.spaceMembers[spaceID], userID)
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockSpaceRepository реалізує інтерфейс SpaceRepository для тестування
+type MockSpaceRepository struct {
+	store *Mocks
+}
+
+func (m *MockSpaceRepository) CreateSpace(_ context.Context, space *models.Space) error {
+	if m.keyTaken(space) {
+		return repo.ErrAlreadyExists
+	}
+
+	now := time.Now()
+	m.store.lastSpaceID++
+	space.ID = m.store.lastSpaceID
+	space.CreatedAt = now
+	space.UpdatedAt = now
+
+	stored := *space
+	m.store.spaces[space.ID] = &stored
+	m.store.spaceMembers[space.ID] = map[uint]*models.SpaceMember{
+		space.CreatedBy: {SpaceID: space.ID, UserID: space.CreatedBy, Role: models.RoleAdmin, CreatedAt: now},
+	}
+
+	return nil
+}
+
+func (m *MockSpaceRepository) GetSpaceByID(_ context.Context, id uint) (*models.Space, error) {
+	space, exists := m.store.spaces[id]
+	if !exists {
+		return nil, nil
+	}
+
+	found := *space
+
+	return &found, nil
+}
+
+func (m *MockSpaceRepository) GetSpaceByKey(_ context.Context, key string) (*models.Space, error) {
+	for _, space := range m.store.spaces {
+		if space.Key == key {
+			found := *space
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MockSpaceRepository) ListSpaces(
+	_ context.Context,
+	page pagination.Params,
+) (*pagination.Page[*models.Space], error) {
+	spaces := make([]*models.Space, 0, len(m.store.spaces))
+
+	for _, space := range m.store.spaces {
+		found := *space
+		spaces = append(spaces, &found)
+	}
+
+	return repo.SpacePages.Slice(spaces, page), nil
+}
+
+func (m *MockSpaceRepository) UpdateSpace(_ context.Context, space *models.Space) error {
+	existing, exists := m.store.spaces[space.ID]
+	if !exists {
+		return repo.ErrNotFound
+	}
+
+	if m.keyTaken(space) {
+		return repo.ErrAlreadyExists
+	}
+
+	space.CreatedBy = existing.CreatedBy
+	space.CreatedAt = existing.CreatedAt
+	space.UpdatedAt = time.Now()
+
+	stored := *space
+	m.store.spaces[space.ID] = &stored
+
+	return nil
+}
+
+func (m *MockSpaceRepository) DeleteSpace(_ context.Context, id uint) error {
+	if _, exists := m.store.spaces[id]; !exists {
+		return repo.ErrNotFound
+	}
+
+	for _, article := range m.store.articles {
+		if article.SpaceID != nil && *article.SpaceID == id {
+			return repo.ErrInUse
+		}
+	}
+
+	delete(m.store.spaces, id)
+	delete(m.store.spaceMembers, id)
+
+	return nil
+}
+
+func (m *MockSpaceRepository) keyTaken(space *models.Space) bool {
+	for _, other := range m.store.spaces {
+		if other.ID != space.ID && other.Key == space.Key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MockTagRepository реалізує інтерфейс TagRepository для тестування
+type MockTagRepository struct {
+	store *Mocks
+}
+
+func (m *MockTagRepository) AddArticleTags(_ context.Context, articleID uint, names []string) error {
+	if _, exists := m.store.articles[articleID]; !exists {
+		return repo.ErrNotFound
+	}
+
+	tagIDs := m.store.articleTags[articleID]
+	if tagIDs == nil {
+		tagIDs = make(map[uint]bool)
+		m.store.articleTags[articleID] = tagIDs
+	}
+
+	for _, name := range names {
+		tagIDs[m.store.ensureTag(name).ID] = true
+	}
+
+	return nil
+}
+
+func (m *MockTagRepository) RemoveArticleTag(_ context.Context, articleID uint, name string) error {
+	tag := m.store.tagByName(name)
+	if tag == nil || !m.store.articleTags[articleID][tag.ID] {
+		return repo.ErrNotFound
+	}
+
+	delete(m.store.articleTags[articleID], tag.ID)
+
+	return nil
+}
+
+func (m *MockTagRepository) ListArticleTags(
+	_ context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.Tag], error) {
+	tags := make([]*models.Tag, 0)
+
+	for tagID := range m.store.articleTags[articleID] {
+		found := *m.store.tags[tagID]
+		tags = append(tags, &found)
+	}
+
+	return repo.ArticleTagPages.Slice(tags, page), nil
+}
+
+func (m *MockTagRepository) ListTags(_ context.Context, page pagination.Params) (*pagination.Page[*models.Tag], error) {
+	tags := make([]*models.Tag, 0)
+
+	for _, tag := range m.store.tags {
+		if found := m.store.countedTag(tag); found.ArticleCount > 0 {
+			tags = append(tags, found)
+		}
+	}
+
+	return repo.TagPages.Slice(tags, page), nil
+}
+
+func (m *MockTagRepository) GetTagByName(_ context.Context, name string) (*models.Tag, error) {
+	tag := m.store.tagByName(name)
+	if tag == nil {
+		return nil, nil
+	}
+
+	return m.store.countedTag(tag), nil
+}
+
+func (m *MockTagRepository) RenameTag(_ context.Context, name, newName string) (*models.Tag, error) {
+	tag := m.store.tagByName(name)
+	if tag == nil {
+		return nil, repo.ErrNotFound
+	}
+
+	if other := m.store.tagByName(newName); other != nil && other.ID != tag.ID {
+		return nil, repo.ErrAlreadyExists
+	}
+
+	tag.Name = newName
+
+	return m.store.countedTag(tag), nil
+}
+
+func (m *MockTagRepository) MergeTags(_ context.Context, sources []string, target string) (*models.Tag, error) {
+	sourceTags := make([]*models.Tag, 0, len(sources))
+
+	for _, name := range sources {
+		if name == target {
+			continue
+		}
+
+		tag := m.store.tagByName(name)
+		if tag == nil {
+			return nil, repo.ErrNotFound
+		}
+
+		sourceTags = append(sourceTags, tag)
+	}
+
+	merged := m.store.ensureTag(target)
+
+	for _, tagIDs := range m.store.articleTags {
+		for _, source := range sourceTags {
+			if tagIDs[source.ID] {
+				delete(tagIDs, source.ID)
+				tagIDs[merged.ID] = true
+			}
+		}
+	}
+
+	for _, source := range sourceTags {
+		delete(m.store.tags, source.ID)
+	}
+
+	return m.store.countedTag(merged), nil
+}
+
+func (m *Mocks) tagByName(name string) *models.Tag {
+	for _, tag := range m.tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+
+	return nil
+}
+
+func (m *Mocks) ensureTag(name string) *models.Tag {
+	if tag := m.tagByName(name); tag != nil {
+		return tag
+	}
+
+	m.lastTagID++
+	tag := &models.Tag{ID: m.lastTagID, Name: name, CreatedAt: time.Now()}
+	m.tags[tag.ID] = tag
+
+	return tag
+}
+
+// countedTag повертає копію тегу з кількістю статей
+func (m *Mocks) countedTag(tag *models.Tag) *models.Tag {
+	found := *tag
+	found.ArticleCount = 0
+
+	for _, tagIDs := range m.articleTags {
+		if tagIDs[tag.ID] {
+			found.ArticleCount++
+		}
+	}
+
+	return &found
+}
+
+func (m *Mocks) hasTag(articleID uint, name string) bool {
+	tag := m.tagByName(name)
+
+	return tag != nil && m.articleTags[articleID][tag.ID]
+}
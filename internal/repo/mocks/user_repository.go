@@ -1,9 +1,13 @@
 package mocks
 
 import (
-	"errors"
+	"context"
+	"strings"
+	"time"
 
 	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
 )
 
 // MockUserRepository реалізує інтерфейс UserRepository для тестування
@@ -11,26 +15,140 @@ type MockUserRepository struct {
 	store *Mocks
 }
 
-func (m *MockUserRepository) CreateUser() error {
+func (m *MockUserRepository) CreateUser(_ context.Context, user *models.User) error {
+	if m.conflicts(user) {
+		return repo.ErrAlreadyExists
+	}
+
+	if user.Role == "" {
+		user.Role = models.RoleDefault
+	}
+
+	now := time.Now()
+	m.store.lastUserID++
+	user.ID = m.store.lastUserID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	stored := *user
+	m.store.users[user.ID] = &stored
+
 	return nil
 }
 
-func (m *MockUserRepository) GetUserByID(id uint) (*models.User, error) {
+func (m *MockUserRepository) GetUserByID(_ context.Context, id uint) (*models.User, error) {
 	user, exists := m.store.users[id]
-	if !exists {
+	if !exists || user.DeletedAt != nil {
 		return nil, nil // або повернути помилку "не знайдено"
 	}
 	return user, nil
 }
 
-func (m *MockUserRepository) UpdateUser() error {
+func (m *MockUserRepository) GetUserByUsername(_ context.Context, username string) (*models.User, error) {
+	for _, user := range m.store.users {
+		if user.DeletedAt == nil && strings.EqualFold(user.Username, username) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockUserRepository) GetUserByEmail(_ context.Context, email string) (*models.User, error) {
+	for _, user := range m.store.users {
+		if user.DeletedAt == nil && strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockUserRepository) UpdateUser(_ context.Context, user *models.User) error {
+	existing, exists := m.store.users[user.ID]
+	if !exists || existing.DeletedAt != nil {
+		return repo.ErrNotFound
+	}
+
+	if m.conflicts(user) {
+		return repo.ErrAlreadyExists
+	}
+
+	if !strings.EqualFold(existing.Email, user.Email) {
+		user.EmailVerifiedAt = nil
+	} else {
+		user.EmailVerifiedAt = existing.EmailVerifiedAt
+	}
+
+	user.UpdatedAt = time.Now()
+	stored := *user
+	m.store.users[user.ID] = &stored
+
+	return nil
+}
+
+func (m *MockUserRepository) MarkEmailVerified(_ context.Context, id uint, email string) error {
+	user, exists := m.store.users[id]
+	if !exists || user.DeletedAt != nil || !strings.EqualFold(user.Email, email) {
+		return repo.ErrNotFound
+	}
+
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	return nil
+}
+
+func (m *MockUserRepository) SetUserRole(_ context.Context, id uint, role models.Role) error {
+	user, exists := m.store.users[id]
+	if !exists || user.DeletedAt != nil {
+		return repo.ErrNotFound
+	}
+
+	user.Role = role
+	user.UpdatedAt = time.Now()
+
 	return nil
 }
 
-func (m *MockUserRepository) DeleteUser(id int) error {
-	if id < 0 {
-		return errors.New("invalid user ID")
+func (m *MockUserRepository) DeleteUser(_ context.Context, id uint) error {
+	user, exists := m.store.users[id]
+	if !exists || user.DeletedAt != nil {
+		return repo.ErrNotFound
 	}
-	delete(m.store.users, uint(id))
+
+	now := time.Now()
+	user.DeletedAt = &now
+
 	return nil
 }
+
+func (m *MockUserRepository) ListUsers(
+	_ context.Context,
+	page pagination.Params,
+) (*pagination.Page[*models.User], error) {
+	users := make([]*models.User, 0, len(m.store.users))
+
+	for _, user := range m.store.users {
+		if user.DeletedAt == nil {
+			found := *user
+			users = append(users, &found)
+		}
+	}
+
+	return repo.UserPages.Slice(users, page), nil
+}
+
+// conflicts перевіряє унікальність username та email серед активних користувачів
+func (m *MockUserRepository) conflicts(user *models.User) bool {
+	for _, other := range m.store.users {
+		if other.ID == user.ID || other.DeletedAt != nil {
+			continue
+		}
+
+		if strings.EqualFold(other.Username, user.Username) || strings.EqualFold(other.Email, user.Email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"KnowledgeHub/internal/models"
+)
+
+// MockUserTokenRepository реалізує інтерфейс UserTokenRepository для тестування
+type MockUserTokenRepository struct {
+	store *Mocks
+}
+
+func (m *MockUserTokenRepository) CreateUserToken(_ context.Context, token *models.UserToken) error {
+	m.store.lastUserTokenID++
+	token.ID = m.store.lastUserTokenID
+	token.CreatedAt = time.Now()
+
+	stored := *token
+	m.store.userTokens[token.ID] = &stored
+
+	return nil
+}
+
+func (m *MockUserTokenRepository) ConsumeUserToken(
+	_ context.Context,
+	purpose models.TokenPurpose,
+	hash string,
+) (*models.UserToken, error) {
+	now := time.Now()
+
+	for _, token := range m.store.userTokens {
+		if token.TokenHash == hash && token.Purpose == purpose && token.UsedAt == nil && now.Before(token.ExpiresAt) {
+			token.UsedAt = &now
+			found := *token
+
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MockUserTokenRepository) InvalidateUserTokens(
+	_ context.Context,
+	userID uint,
+	purpose models.TokenPurpose,
+) error {
+	now := time.Now()
+	for _, token := range m.store.userTokens {
+		if token.UserID == userID && token.Purpose == purpose && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
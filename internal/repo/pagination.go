@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// Специфікації списків: поля, за якими клієнт може сортувати (sort) і фільтрувати (filter),
+// та порядок за замовчуванням. Column - колонки таблиць PostgreSQL-репозиторію.
+
+// UserPages - список користувачів
+var UserPages = pagination.NewSpec([]pagination.Field[*models.User]{
+	{Name: "id", Column: "id", Type: pagination.Uint, Sort: true, Filter: true,
+		Value: func(u *models.User) any { return u.ID }},
+	{Name: "username", Column: "username", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(u *models.User) any { return u.Username }},
+	{Name: "email", Column: "email", Type: pagination.String, Filter: true,
+		Value: func(u *models.User) any { return u.Email }},
+	{Name: "role", Column: "role", Type: pagination.String, Filter: true,
+		Value: func(u *models.User) any { return string(u.Role) }},
+	{Name: "created_at", Column: "created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(u *models.User) any { return u.CreatedAt }},
+}, pagination.DefaultSort("username"))
+
+// _articleFields - поля статей для списків, що вибирають колонки таблиці articles без псевдоніма
+var _articleFields = []pagination.Field[*models.Article]{
+	{Name: "id", Column: "id", Type: pagination.Uint, Sort: true, Filter: true,
+		Value: func(a *models.Article) any { return a.ID }},
+	{Name: "title", Column: "title", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(a *models.Article) any { return a.Title }},
+	{Name: "slug", Column: "slug", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(a *models.Article) any { return a.Slug }},
+	{Name: "status", Column: "status", Type: pagination.String, Filter: true,
+		Value: func(a *models.Article) any { return string(a.Status) }},
+	{Name: "language", Column: "language", Type: pagination.String, Filter: true,
+		Value: func(a *models.Article) any { return a.Language }},
+	{Name: "author_id", Column: "author_id", Type: pagination.Uint, Filter: true,
+		Value: func(a *models.Article) any { return a.AuthorID }},
+	{Name: "created_at", Column: "created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(a *models.Article) any { return a.CreatedAt }},
+	{Name: "updated_at", Column: "updated_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(a *models.Article) any { return a.UpdatedAt }},
+}
+
+// ArticlePages - список статей
+var ArticlePages = pagination.NewSpec(_articleFields, pagination.DefaultSort("-updated_at"))
+
+// BacklinkPages - статті, що посилаються на статтю
+var BacklinkPages = pagination.NewSpec(_articleFields, pagination.DefaultSort("title"))
+
+// RevisionPages - історія ревізій статті; версія унікальна в межах статті
+var RevisionPages = pagination.NewSpec([]pagination.Field[*models.ArticleRevision]{
+	{Name: "version", Column: "version", Type: pagination.Int, Sort: true, Filter: true,
+		Value: func(r *models.ArticleRevision) any { return r.Version }},
+	{Name: "editor_id", Column: "editor_id", Type: pagination.Uint, Filter: true,
+		Value: func(r *models.ArticleRevision) any { return r.EditorID }},
+	{Name: "created_at", Column: "created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(r *models.ArticleRevision) any { return r.CreatedAt }},
+}, pagination.Key("version"), pagination.DefaultSort("-version"))
+
+// CommentThreadPages - кореневі коментарі статті; відповіді гілки йдуть разом з коренем
+var CommentThreadPages = pagination.NewSpec([]pagination.Field[*models.Comment]{
+	{Name: "id", Column: "id", Type: pagination.Uint, Sort: true, Filter: true,
+		Value: func(c *models.Comment) any { return c.ID }},
+	{Name: "author_id", Column: "author_id", Type: pagination.Uint, Filter: true,
+		Value: func(c *models.Comment) any { return c.AuthorID }},
+	{Name: "created_at", Column: "created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(c *models.Comment) any { return c.CreatedAt }},
+	{Name: "updated_at", Column: "updated_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(c *models.Comment) any { return c.UpdatedAt }},
+}, pagination.DefaultSort("created_at"))
+
+// SpacePages - список просторів
+var SpacePages = pagination.NewSpec([]pagination.Field[*models.Space]{
+	{Name: "id", Column: "id", Type: pagination.Uint, Sort: true, Filter: true,
+		Value: func(s *models.Space) any { return s.ID }},
+	{Name: "key", Column: "key", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(s *models.Space) any { return s.Key }},
+	{Name: "name", Column: "name", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(s *models.Space) any { return s.Name }},
+	{Name: "created_by", Column: "created_by", Type: pagination.Uint, Filter: true,
+		Value: func(s *models.Space) any { return s.CreatedBy }},
+	{Name: "created_at", Column: "created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(s *models.Space) any { return s.CreatedAt }},
+}, pagination.DefaultSort("name"))
+
+// BrokenLinkPages - посилання на неіснуючі статті; посилання визначає пара джерело - slug цілі
+var BrokenLinkPages = pagination.NewSpec([]pagination.Field[*models.ArticleLink]{
+	{Name: "source_id", Column: "l.source_id", Type: pagination.Uint, Sort: true, Filter: true,
+		Value: func(l *models.ArticleLink) any { return l.SourceID }},
+	{Name: "target_slug", Column: "l.target_slug", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(l *models.ArticleLink) any { return l.TargetSlug }},
+}, pagination.Key("source_id", "target_slug"), pagination.DefaultSort("source_id"), pagination.Limits(50, 200))
+
+// ArticleLinkPages - вихідні посилання статті; slug цілі унікальний в межах статті
+var ArticleLinkPages = pagination.NewSpec([]pagination.Field[*models.ArticleLink]{
+	{Name: "target_slug", Column: "l.target_slug", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(l *models.ArticleLink) any { return l.TargetSlug }},
+}, pagination.Key("target_slug"), pagination.DefaultSort("target_slug"))
+
+// AttachmentPages - вкладення статті
+var AttachmentPages = pagination.NewSpec([]pagination.Field[*models.Attachment]{
+	{Name: "id", Column: "id", Type: pagination.Uint, Sort: true, Filter: true,
+		Value: func(a *models.Attachment) any { return a.ID }},
+	{Name: "filename", Column: "filename", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(a *models.Attachment) any { return a.Filename }},
+	{Name: "content_type", Column: "content_type", Type: pagination.String, Filter: true,
+		Value: func(a *models.Attachment) any { return a.ContentType }},
+	{Name: "size", Column: "size", Type: pagination.Int, Sort: true, Filter: true,
+		Value: func(a *models.Attachment) any { return a.Size }},
+	{Name: "uploaded_by", Column: "uploaded_by", Type: pagination.Uint, Filter: true,
+		Value: func(a *models.Attachment) any { return a.UploadedBy }},
+	{Name: "created_at", Column: "created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(a *models.Attachment) any { return a.CreatedAt }},
+}, pagination.DefaultSort("created_at"))
+
+// SpaceMemberPages - учасники простору; користувач входить до простору один раз
+var SpaceMemberPages = pagination.NewSpec([]pagination.Field[*models.SpaceMember]{
+	{Name: "user_id", Column: "user_id", Type: pagination.Uint, Sort: true, Filter: true,
+		Value: func(m *models.SpaceMember) any { return m.UserID }},
+	{Name: "role", Column: "role", Type: pagination.String, Filter: true,
+		Value: func(m *models.SpaceMember) any { return string(m.Role) }},
+	{Name: "created_at", Column: "created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(m *models.SpaceMember) any { return m.CreatedAt }},
+}, pagination.Key("user_id"), pagination.DefaultSort("created_at"))
+
+// TagPages - теги з кількістю статей; колонки - вкладеного запиту з групуванням
+var TagPages = pagination.NewSpec([]pagination.Field[*models.Tag]{
+	{Name: "id", Column: "id", Type: pagination.Uint, Filter: true,
+		Value: func(t *models.Tag) any { return t.ID }},
+	{Name: "name", Column: "name", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(t *models.Tag) any { return t.Name }},
+	{Name: "article_count", Column: "article_count", Type: pagination.Int, Sort: true, Filter: true,
+		Value: func(t *models.Tag) any { return t.ArticleCount }},
+	{Name: "created_at", Column: "created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(t *models.Tag) any { return t.CreatedAt }},
+}, pagination.DefaultSort("-article_count,name"))
+
+// ArticleTagPages - теги статті
+var ArticleTagPages = pagination.NewSpec([]pagination.Field[*models.Tag]{
+	{Name: "id", Column: "t.id", Type: pagination.Uint, Filter: true,
+		Value: func(t *models.Tag) any { return t.ID }},
+	{Name: "name", Column: "t.name", Type: pagination.String, Sort: true, Filter: true,
+		Value: func(t *models.Tag) any { return t.Name }},
+	{Name: "created_at", Column: "t.created_at", Type: pagination.Time, Sort: true, Filter: true,
+		Value: func(t *models.Tag) any { return t.CreatedAt }},
+}, pagination.DefaultSort("name"))
+
+// SearchPages - результати пошуку; колонки - вкладеного запиту зі збігами та їх rank
+var SearchPages = pagination.NewSpec([]pagination.Field[*models.SearchHit]{
+	{Name: "id", Column: "id", Type: pagination.Uint,
+		Value: func(h *models.SearchHit) any { return h.ArticleID }},
+	{Name: "rank", Column: "rank", Type: pagination.Float, Sort: true,
+		Value: func(h *models.SearchHit) any { return h.Rank }},
+	{Name: "updated_at", Column: "updated_at", Type: pagination.Time, Sort: true,
+		Value: func(h *models.SearchHit) any { return h.UpdatedAt }},
+}, pagination.DefaultSort("-rank,-updated_at"))
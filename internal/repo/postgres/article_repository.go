@@ -0,0 +1,276 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _articlesTable = "articles"
+
+var _articleColumns = []string{
+	"id", "title", "slug", "body", "author_id", "status", "language", "space_id", "parent_id", "position",
+	"version", "updated_by", "published_at", "created_at", "updated_at",
+}
+
+// _nextPositionExpr ставить нову сторінку в кінець списку її сусідів
+const _nextPositionExpr = "(SELECT COALESCE(MAX(position) + 1, 0) FROM articles " +
+	"WHERE space_id = ? AND parent_id IS NOT DISTINCT FROM ?)"
+
+type ArticleRepo struct {
+	store *Repository
+}
+
+func (a ArticleRepo) CreateArticle(ctx context.Context, article *models.Article) error {
+	sql, args, err := a.store.db.Builder.
+		Insert(_articlesTable).
+		Columns("title", "slug", "body", "author_id", "updated_by", "status", "language",
+			"space_id", "parent_id", "position", "published_at").
+		Values(article.Title, article.Slug, article.Body, article.AuthorID, article.UpdatedBy,
+			article.Status, article.Language, article.SpaceID, article.ParentID,
+			squirrel.Expr(_nextPositionExpr, article.SpaceID, article.ParentID),
+			article.PublishedAt).
+		Suffix("RETURNING id, position, version, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ArticleRepo - CreateArticle - Builder: %w", err)
+	}
+
+	err = pgx.BeginFunc(ctx, a.store.db.Pool, func(tx pgx.Tx) error {
+		scanErr := tx.QueryRow(ctx, sql, args...).
+			Scan(&article.ID, &article.Position, &article.Version, &article.CreatedAt, &article.UpdatedAt)
+		if scanErr != nil {
+			return scanErr
+		}
+
+		if revisionErr := a.insertRevision(ctx, tx, article); revisionErr != nil {
+			return revisionErr
+		}
+
+		return insertLinks(ctx, tx, article)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("ArticleRepo - CreateArticle: %w", repo.ErrAlreadyExists)
+		}
+
+		return fmt.Errorf("ArticleRepo - CreateArticle - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (a ArticleRepo) GetArticleByID(ctx context.Context, id uint) (*models.Article, error) {
+	return a.getArticle(ctx, squirrel.Eq{"id": id})
+}
+
+func (a ArticleRepo) GetArticleBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	return a.getArticle(ctx, squirrel.Eq{"slug": slug})
+}
+
+func (a ArticleRepo) ListArticles(
+	ctx context.Context,
+	filter models.ArticleFilter,
+	page pagination.Params,
+) (*pagination.Page[*models.Article], error) {
+	query := a.store.db.Builder.
+		Select(_articleColumns...).
+		From(_articlesTable)
+
+	if filter.SpaceID != 0 {
+		query = query.Where(squirrel.Eq{"space_id": filter.SpaceID})
+	}
+
+	if filter.AuthorID != 0 {
+		query = query.Where(squirrel.Eq{"author_id": filter.AuthorID})
+	}
+
+	if filter.Status != "" {
+		query = query.Where(squirrel.Eq{"status": filter.Status})
+	}
+
+	if filter.Tag != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM article_tags tg JOIN tags t ON t.id = tg.tag_id "+
+				"WHERE tg.article_id = articles.id AND t.name = ?)",
+			filter.Tag,
+		)
+	}
+
+	sql, args, err := repo.ArticlePages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("ArticleRepo - ListArticles - Builder: %w", err)
+	}
+
+	rows, err := a.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ArticleRepo - ListArticles - Query: %w", err)
+	}
+	defer rows.Close()
+
+	articles := make([]*models.Article, 0)
+
+	for rows.Next() {
+		article, scanErr := scanArticle(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("ArticleRepo - ListArticles - Scan: %w", scanErr)
+		}
+
+		articles = append(articles, article)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ArticleRepo - ListArticles - rows.Err: %w", err)
+	}
+
+	return repo.ArticlePages.Page(articles, page), nil
+}
+
+func (a ArticleRepo) UpdateArticle(ctx context.Context, article *models.Article) error {
+	sql, args, err := a.store.db.Builder.
+		Update(_articlesTable).
+		Set("title", article.Title).
+		Set("slug", article.Slug).
+		Set("body", article.Body).
+		Set("status", article.Status).
+		Set("language", article.Language).
+		Set("published_at", article.PublishedAt).
+		Set("updated_by", article.UpdatedBy).
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": article.ID}).
+		Suffix("RETURNING version, updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ArticleRepo - UpdateArticle - Builder: %w", err)
+	}
+
+	// Блокування рядка статті впорядковує паралельні оновлення, тому номери ревізій не конфліктують
+	err = pgx.BeginFunc(ctx, a.store.db.Pool, func(tx pgx.Tx) error {
+		scanErr := tx.QueryRow(ctx, sql, args...).Scan(&article.Version, &article.UpdatedAt)
+		if scanErr != nil {
+			return scanErr
+		}
+
+		if revisionErr := a.insertRevision(ctx, tx, article); revisionErr != nil {
+			return revisionErr
+		}
+
+		if _, execErr := tx.Exec(ctx, "DELETE FROM article_links WHERE source_id = $1", article.ID); execErr != nil {
+			return execErr
+		}
+
+		return insertLinks(ctx, tx, article)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return fmt.Errorf("ArticleRepo - UpdateArticle: %w", repo.ErrNotFound)
+		case isUniqueViolation(err):
+			return fmt.Errorf("ArticleRepo - UpdateArticle: %w", repo.ErrAlreadyExists)
+		default:
+			return fmt.Errorf("ArticleRepo - UpdateArticle - Tx: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (a ArticleRepo) DeleteArticle(ctx context.Context, id uint) error {
+	sql, args, err := a.store.db.Builder.
+		Delete(_articlesTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ArticleRepo - DeleteArticle - Builder: %w", err)
+	}
+
+	tag, err := a.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("ArticleRepo - DeleteArticle: %w", repo.ErrInUse)
+		}
+
+		return fmt.Errorf("ArticleRepo - DeleteArticle - Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("ArticleRepo - DeleteArticle: %w", repo.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (a ArticleRepo) getArticle(ctx context.Context, pred squirrel.Sqlizer) (*models.Article, error) {
+	sql, args, err := a.store.db.Builder.
+		Select(_articleColumns...).
+		From(_articlesTable).
+		Where(pred).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("ArticleRepo - getArticle - Builder: %w", err)
+	}
+
+	article, err := scanArticle(a.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("ArticleRepo - getArticle - QueryRow: %w", err)
+	}
+
+	return article, nil
+}
+
+// insertRevision записує знімок поточного стану статті
+func (a ArticleRepo) insertRevision(ctx context.Context, tx pgx.Tx, article *models.Article) error {
+	sql, args, err := a.store.db.Builder.
+		Insert(_articleRevisionsTable).
+		Columns("article_id", "version", "title", "slug", "body", "status", "editor_id", "created_at").
+		Values(article.ID, article.Version, article.Title, article.Slug, article.Body,
+			article.Status, article.UpdatedBy, article.UpdatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+
+	return err
+}
+
+// scanArticle читає рядок у порядку _articleColumns
+func scanArticle(row pgx.Row) (*models.Article, error) {
+	article := &models.Article{}
+
+	err := row.Scan(
+		&article.ID,
+		&article.Title,
+		&article.Slug,
+		&article.Body,
+		&article.AuthorID,
+		&article.Status,
+		&article.Language,
+		&article.SpaceID,
+		&article.ParentID,
+		&article.Position,
+		&article.Version,
+		&article.UpdatedBy,
+		&article.PublishedAt,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return article, nil
+}
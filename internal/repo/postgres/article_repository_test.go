@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestArticleRepo_CRUD(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	author := &models.User{Username: "author", Email: "author@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, author); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	articles := store.Article()
+
+	article := &models.Article{
+		Title:     "Getting started",
+		Slug:      "getting-started",
+		Body:      "# Hi",
+		AuthorID:  author.ID,
+		UpdatedBy: author.ID,
+		Status:    models.ArticleStatusDraft,
+	}
+
+	if err := articles.CreateArticle(ctx, article); err != nil {
+		t.Fatalf("CreateArticle() error = %v", err)
+	}
+
+	if article.ID == 0 {
+		t.Fatal("Expected article ID to be set after create")
+	}
+
+	duplicate := *article
+	if err := articles.CreateArticle(ctx, &duplicate); !errors.Is(err, repo.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists for duplicate slug, got %v", err)
+	}
+
+	got, err := articles.GetArticleBySlug(ctx, "getting-started")
+	if err != nil || got == nil || got.ID != article.ID {
+		t.Fatalf("GetArticleBySlug() = %v, %v", got, err)
+	}
+
+	now := time.Now()
+	article.Status = models.ArticleStatusPublished
+	article.PublishedAt = &now
+
+	if err = articles.UpdateArticle(ctx, article); err != nil {
+		t.Fatalf("UpdateArticle() error = %v", err)
+	}
+
+	list, err := articles.ListArticles(ctx, models.ArticleFilter{Status: models.ArticleStatusPublished},
+		pagination.Params{})
+	if err != nil {
+		t.Fatalf("ListArticles() error = %v", err)
+	}
+
+	if len(list.Items) != 1 || list.Items[0].PublishedAt == nil {
+		t.Errorf("Expected one published article, got %+v", list.Items)
+	}
+
+	list, err = articles.ListArticles(ctx, models.ArticleFilter{AuthorID: author.ID + 1}, pagination.Params{})
+	if err != nil {
+		t.Fatalf("ListArticles() error = %v", err)
+	}
+
+	if len(list.Items) != 0 {
+		t.Errorf("Expected no articles for other author, got %v", list.Items)
+	}
+
+	// Сторінка з одного елемента: друга стаття лишається для наступної сторінки
+	second := &models.Article{Title: "Second", Slug: "second", AuthorID: author.ID, UpdatedBy: author.ID,
+		Status: models.ArticleStatusDraft}
+	if err = articles.CreateArticle(ctx, second); err != nil {
+		t.Fatalf("CreateArticle() error = %v", err)
+	}
+
+	params, err := repo.ArticlePages.Params(pagination.Query{Limit: 1, Sort: "title"})
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	list, err = articles.ListArticles(ctx, models.ArticleFilter{}, params)
+	if err != nil || len(list.Items) != 1 || list.Items[0].ID != article.ID || list.NextCursor == "" {
+		t.Fatalf("ListArticles(first page) = %+v, %v", list, err)
+	}
+
+	params, err = repo.ArticlePages.Params(pagination.Query{Limit: 1, Sort: "title", Cursor: list.NextCursor})
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	list, err = articles.ListArticles(ctx, models.ArticleFilter{}, params)
+	if err != nil || len(list.Items) != 1 || list.Items[0].ID != second.ID || list.NextCursor != "" {
+		t.Fatalf("ListArticles(second page) = %+v, %v", list, err)
+	}
+
+	if err = articles.DeleteArticle(ctx, second.ID); err != nil {
+		t.Fatalf("DeleteArticle() error = %v", err)
+	}
+
+	if err = articles.DeleteArticle(ctx, article.ID); err != nil {
+		t.Fatalf("DeleteArticle() error = %v", err)
+	}
+
+	got, err = articles.GetArticleByID(ctx, article.ID)
+	if err != nil || got != nil {
+		t.Errorf("Expected (nil, nil) after delete, got %v, %v", got, err)
+	}
+
+	if err = articles.DeleteArticle(ctx, article.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if err = articles.UpdateArticle(ctx, article); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestArticleRepo_Revisions(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	author := &models.User{Username: "author", Email: "author@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, author); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	article := &models.Article{
+		Title:     "Guide",
+		Slug:      "guide",
+		Body:      "v1",
+		AuthorID:  author.ID,
+		UpdatedBy: author.ID,
+		Status:    models.ArticleStatusDraft,
+	}
+
+	if err := store.Article().CreateArticle(ctx, article); err != nil {
+		t.Fatalf("CreateArticle() error = %v", err)
+	}
+
+	article.Body = "v2"
+	if err := store.Article().UpdateArticle(ctx, article); err != nil {
+		t.Fatalf("UpdateArticle() error = %v", err)
+	}
+
+	if article.Version != 2 {
+		t.Errorf("Expected version 2 after update, got %d", article.Version)
+	}
+
+	page, err := store.ArticleRevision().ListRevisions(ctx, article.ID, pagination.Params{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListRevisions() error = %v", err)
+	}
+
+	revisions := page.Items
+	if len(revisions) != 2 || revisions[0].Body != "v2" || revisions[1].Body != "v1" {
+		t.Fatalf("Unexpected revisions: %+v", revisions)
+	}
+
+	revision, err := store.ArticleRevision().GetRevision(ctx, article.ID, 1)
+	if err != nil || revision == nil || revision.EditorID != author.ID {
+		t.Fatalf("GetRevision() = %+v, %v", revision, err)
+	}
+
+	revision, err = store.ArticleRevision().GetRevision(ctx, article.ID, 3)
+	if err != nil || revision != nil {
+		t.Errorf("Expected (nil, nil) for missing revision, got %+v, %v", revision, err)
+	}
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _articleRevisionsTable = "article_revisions"
+
+var _articleRevisionColumns = []string{
+	"id", "article_id", "version", "title", "slug", "body", "status", "editor_id", "created_at",
+}
+
+type ArticleRevisionRepo struct {
+	store *Repository
+}
+
+func (r ArticleRevisionRepo) ListRevisions(
+	ctx context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.ArticleRevision], error) {
+	query := r.store.db.Builder.
+		Select(_articleRevisionColumns...).
+		From(_articleRevisionsTable).
+		Where(squirrel.Eq{"article_id": articleID})
+
+	sql, args, err := repo.RevisionPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("ArticleRevisionRepo - ListRevisions - Builder: %w", err)
+	}
+
+	rows, err := r.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ArticleRevisionRepo - ListRevisions - Query: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := make([]*models.ArticleRevision, 0)
+
+	for rows.Next() {
+		revision, scanErr := scanArticleRevision(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("ArticleRevisionRepo - ListRevisions - Scan: %w", scanErr)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ArticleRevisionRepo - ListRevisions - rows.Err: %w", err)
+	}
+
+	return repo.RevisionPages.Page(revisions, page), nil
+}
+
+func (r ArticleRevisionRepo) GetRevision(ctx context.Context, articleID uint, version int) (*models.ArticleRevision, error) {
+	sql, args, err := r.store.db.Builder.
+		Select(_articleRevisionColumns...).
+		From(_articleRevisionsTable).
+		Where(squirrel.Eq{"article_id": articleID, "version": version}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("ArticleRevisionRepo - GetRevision - Builder: %w", err)
+	}
+
+	revision, err := scanArticleRevision(r.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("ArticleRevisionRepo - GetRevision - QueryRow: %w", err)
+	}
+
+	return revision, nil
+}
+
+// scanArticleRevision читає рядок у порядку _articleRevisionColumns
+func scanArticleRevision(row pgx.Row) (*models.ArticleRevision, error) {
+	revision := &models.ArticleRevision{}
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.ArticleID,
+		&revision.Version,
+		&revision.Title,
+		&revision.Slug,
+		&revision.Body,
+		&revision.Status,
+		&revision.EditorID,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
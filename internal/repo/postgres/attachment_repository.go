@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	_attachmentsTable = "attachments"
+	_blobsTable       = "blobs"
+)
+
+var _attachmentColumns = []string{
+	"id", "article_id", "filename", "content_type", "size", "sha256", "uploaded_by", "created_at",
+}
+
+type AttachmentRepo struct {
+	store *Repository
+}
+
+func (a AttachmentRepo) CreateAttachment(
+	ctx context.Context,
+	attachment *models.Attachment,
+	upload repo.BlobFunc,
+) error {
+	sql, args, err := a.store.db.Builder.
+		Insert(_attachmentsTable).
+		Columns("article_id", "sha256", "filename", "content_type", "size", "uploaded_by").
+		Values(attachment.ArticleID, attachment.SHA256, attachment.Filename, attachment.ContentType,
+			attachment.Size, attachment.UploadedBy).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("AttachmentRepo - CreateAttachment - Builder: %w", err)
+	}
+
+	err = pgx.BeginFunc(ctx, a.store.db.Pool, func(tx pgx.Tx) error {
+		// DO UPDATE блокує наявний запис до кінця транзакції, тож паралельне видалення
+		// вмісту дочекається вкладення; xmax = 0 лише у щойно вставленого рядка
+		var created bool
+
+		scanErr := tx.QueryRow(ctx,
+			"INSERT INTO blobs (sha256, size) VALUES ($1, $2) "+
+				"ON CONFLICT (sha256) DO UPDATE SET size = EXCLUDED.size RETURNING xmax = 0",
+			attachment.SHA256, attachment.Size,
+		).Scan(&created)
+		if scanErr != nil {
+			return scanErr
+		}
+
+		scanErr = tx.QueryRow(ctx, sql, args...).Scan(&attachment.ID, &attachment.CreatedAt)
+		if scanErr != nil {
+			return scanErr
+		}
+
+		if !created {
+			return nil
+		}
+
+		return upload(ctx, attachment.SHA256)
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("AttachmentRepo - CreateAttachment: %w", repo.ErrNotFound)
+		}
+
+		return fmt.Errorf("AttachmentRepo - CreateAttachment - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (a AttachmentRepo) GetAttachmentByID(ctx context.Context, id uint) (*models.Attachment, error) {
+	sql, args, err := a.store.db.Builder.
+		Select(_attachmentColumns...).
+		From(_attachmentsTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentRepo - GetAttachmentByID - Builder: %w", err)
+	}
+
+	attachment, err := scanAttachment(a.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("AttachmentRepo - GetAttachmentByID - QueryRow: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (a AttachmentRepo) ListAttachments(
+	ctx context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.Attachment], error) {
+	query := a.store.db.Builder.
+		Select(_attachmentColumns...).
+		From(_attachmentsTable).
+		Where(squirrel.Eq{"article_id": articleID})
+
+	sql, args, err := repo.AttachmentPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentRepo - ListAttachments - Builder: %w", err)
+	}
+
+	rows, err := a.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentRepo - ListAttachments - Query: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := make([]*models.Attachment, 0)
+
+	for rows.Next() {
+		attachment, scanErr := scanAttachment(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("AttachmentRepo - ListAttachments - Scan: %w", scanErr)
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("AttachmentRepo - ListAttachments - rows.Err: %w", err)
+	}
+
+	return repo.AttachmentPages.Page(attachments, page), nil
+}
+
+func (a AttachmentRepo) DeleteAttachment(ctx context.Context, id uint, remove repo.BlobFunc) error {
+	err := pgx.BeginFunc(ctx, a.store.db.Pool, func(tx pgx.Tx) error {
+		var sha256 string
+
+		scanErr := tx.QueryRow(ctx, "DELETE FROM attachments WHERE id = $1 RETURNING sha256", id).Scan(&sha256)
+		if scanErr != nil {
+			return scanErr
+		}
+
+		_, releaseErr := releaseBlob(ctx, tx, sha256, remove)
+
+		return releaseErr
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("AttachmentRepo - DeleteAttachment: %w", repo.ErrNotFound)
+		}
+
+		return fmt.Errorf("AttachmentRepo - DeleteAttachment - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (a AttachmentRepo) DeleteOrphanBlobs(ctx context.Context, limit uint64, remove repo.BlobFunc) (int, error) {
+	sql, args, err := a.store.db.Builder.
+		Select("b.sha256").
+		From(_blobsTable + " b").
+		Where("NOT EXISTS (SELECT 1 FROM attachments a WHERE a.sha256 = b.sha256)").
+		OrderBy("b.created_at").
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("AttachmentRepo - DeleteOrphanBlobs - Builder: %w", err)
+	}
+
+	rows, err := a.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("AttachmentRepo - DeleteOrphanBlobs - Query: %w", err)
+	}
+
+	candidates, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("AttachmentRepo - DeleteOrphanBlobs - CollectRows: %w", err)
+	}
+
+	deleted := 0
+
+	// Кожен вміст видаляється окремою транзакцією: збій сховища на одному
+	// не повертає записи вже видалених об'єктів
+	for _, sha256 := range candidates {
+		err = pgx.BeginFunc(ctx, a.store.db.Pool, func(tx pgx.Tx) error {
+			released, releaseErr := releaseBlob(ctx, tx, sha256, remove)
+			if released {
+				deleted++
+			}
+
+			return releaseErr
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("AttachmentRepo - DeleteOrphanBlobs - Tx: %w", err)
+		}
+	}
+
+	return deleted, nil
+}
+
+// releaseBlob блокує запис вмісту і, якщо на нього не посилається жодне вкладення,
+// видаляє запис і викликає remove. Повертає true, якщо вміст видалено.
+func releaseBlob(ctx context.Context, tx pgx.Tx, sha256 string, remove repo.BlobFunc) (bool, error) {
+	tag, err := tx.Exec(ctx, "SELECT 1 FROM blobs WHERE sha256 = $1 FOR UPDATE", sha256)
+	if err != nil || tag.RowsAffected() == 0 {
+		return false, err
+	}
+
+	// Окремий запит бачить вкладення, створені до отримання блокування
+	var inUse bool
+
+	err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM attachments WHERE sha256 = $1)", sha256).Scan(&inUse)
+	if err != nil || inUse {
+		return false, err
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM blobs WHERE sha256 = $1", sha256); err != nil {
+		return false, err
+	}
+
+	// Об'єкт видаляється останнім: якщо сховище поверне помилку, запис відкотиться
+	if err = remove(ctx, sha256); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func scanAttachment(row pgx.Row) (*models.Attachment, error) {
+	attachment := &models.Attachment{}
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.ArticleID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.SHA256,
+		&attachment.UploadedBy,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
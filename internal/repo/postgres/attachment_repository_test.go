@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestAttachmentRepo(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	author := &models.User{Username: "author", Email: "author@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, author); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	first := newTaggedArticle(t, store, author.ID, "first")
+	second := newTaggedArticle(t, store, author.ID, "second")
+	attachments := store.Attachment()
+
+	uploads := make([]string, 0)
+	upload := func(_ context.Context, sha256 string) error {
+		uploads = append(uploads, sha256)
+		return nil
+	}
+
+	removed := make([]string, 0)
+	remove := func(_ context.Context, sha256 string) error {
+		removed = append(removed, sha256)
+		return nil
+	}
+
+	hash := strings.Repeat("a", 64)
+	newAttachment := func(articleID uint) *models.Attachment {
+		return &models.Attachment{
+			ArticleID:   articleID,
+			Filename:    "diagram.png",
+			ContentType: "image/png",
+			Size:        42,
+			SHA256:      hash,
+			UploadedBy:  author.ID,
+		}
+	}
+
+	// Однаковий вміст завантажується в сховище лише раз
+	original := newAttachment(first.ID)
+	duplicate := newAttachment(second.ID)
+
+	for _, attachment := range []*models.Attachment{original, duplicate} {
+		if err := attachments.CreateAttachment(ctx, attachment, upload); err != nil {
+			t.Fatalf("CreateAttachment() error = %v", err)
+		}
+	}
+
+	if len(uploads) != 1 {
+		t.Errorf("Expected one upload for duplicate content, got %v", uploads)
+	}
+
+	missing := newAttachment(first.ID + 100)
+	missing.SHA256 = strings.Repeat("b", 64)
+
+	if err := attachments.CreateAttachment(ctx, missing, upload); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown article, got %v", err)
+	}
+
+	// Помилка сховища скасовує створення вкладення та запису вмісту
+	failing := newAttachment(first.ID)
+	failing.SHA256 = strings.Repeat("c", 64)
+	storageErr := errors.New("storage is down")
+
+	err := attachments.CreateAttachment(ctx, failing, func(context.Context, string) error { return storageErr })
+	if !errors.Is(err, storageErr) {
+		t.Errorf("Expected storage error, got %v", err)
+	}
+
+	list, err := attachments.ListAttachments(ctx, first.ID, pagination.Params{})
+	if err != nil || len(list.Items) != 1 || list.Items[0].ID != original.ID {
+		t.Fatalf("ListAttachments() = %v, %v", list, err)
+	}
+
+	got, err := attachments.GetAttachmentByID(ctx, duplicate.ID)
+	if err != nil || got == nil || got.ArticleID != second.ID || got.SHA256 != hash {
+		t.Fatalf("GetAttachmentByID() = %+v, %v", got, err)
+	}
+
+	// Вміст лишається, поки на нього посилається інше вкладення
+	if err = attachments.DeleteAttachment(ctx, original.ID, remove); err != nil {
+		t.Fatalf("DeleteAttachment() error = %v", err)
+	}
+
+	if len(removed) != 0 {
+		t.Errorf("Shared content was removed: %v", removed)
+	}
+
+	if err = attachments.DeleteAttachment(ctx, original.ID, remove); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for deleted attachment, got %v", err)
+	}
+
+	// Після видалення статті вміст стає сиротою і прибирається очисткою
+	if err = store.Article().DeleteArticle(ctx, second.ID); err != nil {
+		t.Fatalf("DeleteArticle() error = %v", err)
+	}
+
+	deleted, err := attachments.DeleteOrphanBlobs(ctx, 10, remove)
+	if err != nil || deleted != 1 || len(removed) != 1 || removed[0] != hash {
+		t.Fatalf("DeleteOrphanBlobs() = %d, %v; removed %v", deleted, err, removed)
+	}
+
+	if deleted, err = attachments.DeleteOrphanBlobs(ctx, 10, remove); err != nil || deleted != 0 {
+		t.Errorf("Second DeleteOrphanBlobs() = %d, %v", deleted, err)
+	}
+}
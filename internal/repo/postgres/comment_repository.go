@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	_commentsTable        = "comments"
+	_commentMentionsTable = "comment_mentions"
+)
+
+var _commentColumns = []string{
+	"id", "article_id", "parent_id", "thread_id", "author_id", "body",
+	"resolved_at", "resolved_by", "created_at", "updated_at", "deleted_at",
+}
+
+type CommentRepo struct {
+	store *Repository
+}
+
+func (c CommentRepo) CreateComment(ctx context.Context, comment *models.Comment) error {
+	sql, args, err := c.store.db.Builder.
+		Insert(_commentsTable).
+		Columns("article_id", "parent_id", "thread_id", "author_id", "body").
+		Values(comment.ArticleID, comment.ParentID, comment.ThreadID, comment.AuthorID, comment.Body).
+		Suffix("RETURNING id, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CommentRepo - CreateComment - Builder: %w", err)
+	}
+
+	err = pgx.BeginFunc(ctx, c.store.db.Pool, func(tx pgx.Tx) error {
+		scanErr := tx.QueryRow(ctx, sql, args...).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
+		if scanErr != nil {
+			return scanErr
+		}
+
+		return insertMentions(ctx, tx, comment)
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("CommentRepo - CreateComment: %w", repo.ErrNotFound)
+		}
+
+		return fmt.Errorf("CommentRepo - CreateComment - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (c CommentRepo) GetCommentByID(ctx context.Context, id uint) (*models.Comment, error) {
+	sql, args, err := c.store.db.Builder.
+		Select(_commentColumns...).
+		From(_commentsTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CommentRepo - GetCommentByID - Builder: %w", err)
+	}
+
+	comment, err := scanComment(c.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("CommentRepo - GetCommentByID - QueryRow: %w", err)
+	}
+
+	if err = c.loadMentions(ctx, []*models.Comment{comment}); err != nil {
+		return nil, fmt.Errorf("CommentRepo - GetCommentByID - loadMentions: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (c CommentRepo) ListThreads(
+	ctx context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.Comment], error) {
+	query := c.store.db.Builder.
+		Select(_commentColumns...).
+		From(_commentsTable).
+		Where(squirrel.Eq{"article_id": articleID, "parent_id": nil})
+
+	sql, args, err := repo.CommentThreadPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CommentRepo - ListThreads - Builder: %w", err)
+	}
+
+	comments, err := c.queryComments(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("CommentRepo - ListThreads - Query: %w", err)
+	}
+
+	return repo.CommentThreadPages.Page(comments, page), nil
+}
+
+func (c CommentRepo) ListReplies(ctx context.Context, threadIDs []uint) ([]*models.Comment, error) {
+	if len(threadIDs) == 0 {
+		return make([]*models.Comment, 0), nil
+	}
+
+	sql, args, err := c.store.db.Builder.
+		Select(_commentColumns...).
+		From(_commentsTable).
+		Where(squirrel.Eq{"thread_id": threadIDs}).
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CommentRepo - ListReplies - Builder: %w", err)
+	}
+
+	comments, err := c.queryComments(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("CommentRepo - ListReplies - Query: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (c CommentRepo) UpdateComment(ctx context.Context, comment *models.Comment) error {
+	// updated_at відображає зміну тексту, а не вирішення гілки
+	sql, args, err := c.store.db.Builder.
+		Update(_commentsTable).
+		Set("updated_at", squirrel.Expr("CASE WHEN body <> ? THEN NOW() ELSE updated_at END", comment.Body)).
+		Set("body", comment.Body).
+		Set("resolved_at", comment.ResolvedAt).
+		Set("resolved_by", comment.ResolvedBy).
+		Where(squirrel.Eq{"id": comment.ID, "deleted_at": nil}).
+		Suffix("RETURNING updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CommentRepo - UpdateComment - Builder: %w", err)
+	}
+
+	err = pgx.BeginFunc(ctx, c.store.db.Pool, func(tx pgx.Tx) error {
+		if scanErr := tx.QueryRow(ctx, sql, args...).Scan(&comment.UpdatedAt); scanErr != nil {
+			return scanErr
+		}
+
+		if _, execErr := tx.Exec(ctx, "DELETE FROM comment_mentions WHERE comment_id = $1", comment.ID); execErr != nil {
+			return execErr
+		}
+
+		return insertMentions(ctx, tx, comment)
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isForeignKeyViolation(err) {
+			return fmt.Errorf("CommentRepo - UpdateComment: %w", repo.ErrNotFound)
+		}
+
+		return fmt.Errorf("CommentRepo - UpdateComment - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (c CommentRepo) DeleteComment(ctx context.Context, id uint) error {
+	sql, args, err := c.store.db.Builder.
+		Update(_commentsTable).
+		Set("body", "").
+		Set("deleted_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CommentRepo - DeleteComment - Builder: %w", err)
+	}
+
+	err = pgx.BeginFunc(ctx, c.store.db.Pool, func(tx pgx.Tx) error {
+		tag, execErr := tx.Exec(ctx, sql, args...)
+		if execErr != nil {
+			return execErr
+		}
+
+		if tag.RowsAffected() == 0 {
+			return repo.ErrNotFound
+		}
+
+		_, execErr = tx.Exec(ctx, "DELETE FROM comment_mentions WHERE comment_id = $1", id)
+
+		return execErr
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return fmt.Errorf("CommentRepo - DeleteComment: %w", err)
+		}
+
+		return fmt.Errorf("CommentRepo - DeleteComment - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (c CommentRepo) queryComments(ctx context.Context, sql string, args ...any) ([]*models.Comment, error) {
+	rows, err := c.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]*models.Comment, 0)
+
+	for rows.Next() {
+		comment, scanErr := scanComment(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err = c.loadMentions(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// loadMentions одним запитом заповнює згадки для всіх коментарів
+func (c CommentRepo) loadMentions(ctx context.Context, comments []*models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	byID := make(map[uint]*models.Comment, len(comments))
+	ids := make([]uint, 0, len(comments))
+
+	for _, comment := range comments {
+		comment.Mentions = make([]*models.CommentMention, 0)
+		byID[comment.ID] = comment
+		ids = append(ids, comment.ID)
+	}
+
+	sql, args, err := c.store.db.Builder.
+		Select("cm.comment_id", "u.id", "u.username").
+		From(_commentMentionsTable + " cm").
+		Join(_usersTable + " u ON u.id = cm.user_id").
+		Where(squirrel.Eq{"cm.comment_id": ids, "u.deleted_at": nil}).
+		OrderBy("u.username").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := c.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID uint
+
+		mention := &models.CommentMention{}
+
+		if err = rows.Scan(&commentID, &mention.UserID, &mention.Username); err != nil {
+			return err
+		}
+
+		comment := byID[commentID]
+		comment.Mentions = append(comment.Mentions, mention)
+	}
+
+	return rows.Err()
+}
+
+func insertMentions(ctx context.Context, tx pgx.Tx, comment *models.Comment) error {
+	if len(comment.Mentions) == 0 {
+		return nil
+	}
+
+	userIDs := make([]int64, 0, len(comment.Mentions))
+	for _, mention := range comment.Mentions {
+		userIDs = append(userIDs, int64(mention.UserID))
+	}
+
+	_, err := tx.Exec(ctx,
+		"INSERT INTO comment_mentions (comment_id, user_id) SELECT $1, unnest($2::bigint[]) ON CONFLICT DO NOTHING",
+		comment.ID, userIDs,
+	)
+
+	return err
+}
+
+func scanComment(row pgx.Row) (*models.Comment, error) {
+	comment := &models.Comment{}
+
+	err := row.Scan(
+		&comment.ID,
+		&comment.ArticleID,
+		&comment.ParentID,
+		&comment.ThreadID,
+		&comment.AuthorID,
+		&comment.Body,
+		&comment.ResolvedAt,
+		&comment.ResolvedBy,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
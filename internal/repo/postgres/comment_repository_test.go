@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestCommentRepo(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	author := &models.User{Username: "author", Email: "author@example.com", PasswordHash: "hash"}
+	reviewer := &models.User{Username: "reviewer", Email: "reviewer@example.com", PasswordHash: "hash"}
+
+	for _, user := range []*models.User{author, reviewer} {
+		if err := store.User().CreateUser(ctx, user); err != nil {
+			t.Fatalf("CreateUser() error = %v", err)
+		}
+	}
+
+	article := newTaggedArticle(t, store, author.ID, "runbook")
+	comments := store.Comment()
+
+	root := &models.Comment{
+		ArticleID: article.ID,
+		AuthorID:  reviewer.ID,
+		Body:      "@author step 3 is outdated",
+		Mentions:  []*models.CommentMention{{UserID: author.ID}},
+	}
+	if err := comments.CreateComment(ctx, root); err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+
+	reply := &models.Comment{
+		ArticleID: article.ID,
+		ParentID:  &root.ID,
+		ThreadID:  &root.ID,
+		AuthorID:  author.ID,
+		Body:      "Fixed",
+	}
+	if err := comments.CreateComment(ctx, reply); err != nil {
+		t.Fatalf("CreateComment(reply) error = %v", err)
+	}
+
+	orphanParent := root.ID + 100
+	orphan := *reply
+	orphan.ParentID = &orphanParent
+	orphan.ThreadID = &orphanParent
+
+	if err := comments.CreateComment(ctx, &orphan); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown parent, got %v", err)
+	}
+
+	got, err := comments.GetCommentByID(ctx, root.ID)
+	if err != nil || got == nil || len(got.Mentions) != 1 || got.Mentions[0].Username != "author" {
+		t.Fatalf("GetCommentByID() = %+v, %v", got, err)
+	}
+
+	threads, err := comments.ListThreads(ctx, article.ID, pagination.Params{Limit: 10})
+	if err != nil || len(threads.Items) != 1 || threads.Items[0].ID != root.ID {
+		t.Fatalf("ListThreads() = %+v, %v", threads, err)
+	}
+
+	replies, err := comments.ListReplies(ctx, []uint{root.ID})
+	if err != nil || len(replies) != 1 || replies[0].ID != reply.ID {
+		t.Fatalf("ListReplies() = %v, %v", replies, err)
+	}
+
+	// Вирішення гілки не вважається редагуванням тексту
+	now := time.Now()
+	got.ResolvedAt = &now
+	got.ResolvedBy = &author.ID
+	got.Mentions = nil
+
+	if err = comments.UpdateComment(ctx, got); err != nil {
+		t.Fatalf("UpdateComment() error = %v", err)
+	}
+
+	if !got.UpdatedAt.Equal(root.UpdatedAt) {
+		t.Errorf("Expected updated_at to stay %v, got %v", root.UpdatedAt, got.UpdatedAt)
+	}
+
+	got, err = comments.GetCommentByID(ctx, root.ID)
+	if err != nil || got.ResolvedBy == nil || *got.ResolvedBy != author.ID || len(got.Mentions) != 0 {
+		t.Errorf("Expected resolved comment without mentions, got %+v, %v", got, err)
+	}
+
+	if err = comments.DeleteComment(ctx, root.ID); err != nil {
+		t.Fatalf("DeleteComment() error = %v", err)
+	}
+
+	if err = comments.DeleteComment(ctx, root.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for deleted comment, got %v", err)
+	}
+
+	if err = comments.UpdateComment(ctx, got); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound when updating deleted comment, got %v", err)
+	}
+
+	got, err = comments.GetCommentByID(ctx, root.ID)
+	if err != nil || got.DeletedAt == nil || got.Body != "" {
+		t.Errorf("Expected soft-deleted comment, got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _articleLinksTable = "article_links"
+
+var _linkColumns = []string{"l.source_id", "l.target", "l.target_slug", "t.id"}
+
+type LinkRepo struct {
+	store *Repository
+}
+
+func (l LinkRepo) ListArticleLinks(
+	ctx context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.ArticleLink], error) {
+	query := l.store.db.Builder.
+		Select(_linkColumns...).
+		From(_articleLinksTable + " l").
+		LeftJoin(_articlesTable + " t ON t.slug = l.target_slug").
+		Where(squirrel.Eq{"l.source_id": articleID})
+
+	sql, args, err := repo.ArticleLinkPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("LinkRepo - ListArticleLinks - Builder: %w", err)
+	}
+
+	links, err := l.queryLinks(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("LinkRepo - ListArticleLinks - Query: %w", err)
+	}
+
+	return repo.ArticleLinkPages.Page(links, page), nil
+}
+
+func (l LinkRepo) ListBacklinks(
+	ctx context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.Article], error) {
+	query := l.store.db.Builder.
+		Select(_articleColumns...).
+		From(_articlesTable).
+		Where(squirrel.NotEq{"id": articleID}).
+		Where("id IN (SELECT l.source_id FROM article_links l JOIN articles t ON t.slug = l.target_slug "+
+			"WHERE t.id = ?)", articleID)
+
+	sql, args, err := repo.BacklinkPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("LinkRepo - ListBacklinks - Builder: %w", err)
+	}
+
+	rows, err := l.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("LinkRepo - ListBacklinks - Query: %w", err)
+	}
+	defer rows.Close()
+
+	articles := make([]*models.Article, 0)
+
+	for rows.Next() {
+		article, scanErr := scanArticle(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("LinkRepo - ListBacklinks - Scan: %w", scanErr)
+		}
+
+		articles = append(articles, article)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("LinkRepo - ListBacklinks - rows.Err: %w", err)
+	}
+
+	return repo.BacklinkPages.Page(articles, page), nil
+}
+
+func (l LinkRepo) ListBrokenLinks(
+	ctx context.Context,
+	page pagination.Params,
+) (*pagination.Page[*models.ArticleLink], error) {
+	query := l.store.db.Builder.
+		Select(_linkColumns...).
+		From(_articleLinksTable + " l").
+		LeftJoin(_articlesTable + " t ON t.slug = l.target_slug").
+		Where(squirrel.Eq{"t.id": nil})
+
+	sql, args, err := repo.BrokenLinkPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("LinkRepo - ListBrokenLinks - Builder: %w", err)
+	}
+
+	links, err := l.queryLinks(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("LinkRepo - ListBrokenLinks - Query: %w", err)
+	}
+
+	return repo.BrokenLinkPages.Page(links, page), nil
+}
+
+func (l LinkRepo) queryLinks(ctx context.Context, sql string, args ...any) ([]*models.ArticleLink, error) {
+	rows, err := l.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make([]*models.ArticleLink, 0)
+
+	for rows.Next() {
+		link := &models.ArticleLink{}
+		if err = rows.Scan(&link.SourceID, &link.Target, &link.TargetSlug, &link.TargetID); err != nil {
+			return nil, err
+		}
+
+		link.Broken = link.TargetID == nil
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// insertLinks записує wiki-посилання статті; попередні посилання видаляє викликач
+func insertLinks(ctx context.Context, tx pgx.Tx, article *models.Article) error {
+	if len(article.Links) == 0 {
+		return nil
+	}
+
+	slugs := make([]string, 0, len(article.Links))
+	targets := make([]string, 0, len(article.Links))
+
+	for _, link := range article.Links {
+		slugs = append(slugs, link.TargetSlug)
+		targets = append(targets, link.Target)
+	}
+
+	_, err := tx.Exec(ctx,
+		"INSERT INTO article_links (source_id, target_slug, target) "+
+			"SELECT $1, * FROM unnest($2::text[], $3::text[]) ON CONFLICT DO NOTHING",
+		article.ID, slugs, targets,
+	)
+
+	return err
+}
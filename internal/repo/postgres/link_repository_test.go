@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestLinkRepo(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	author := &models.User{Username: "author", Email: "author@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, author); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	target := newTaggedArticle(t, store, author.ID, "target")
+
+	source := &models.Article{
+		Title:     "source",
+		Slug:      "source",
+		AuthorID:  author.ID,
+		UpdatedBy: author.ID,
+		Status:    models.ArticleStatusDraft,
+		Language:  models.ArticleLanguageDefault,
+		Links: []*models.ArticleLink{
+			{Target: "Target", TargetSlug: "target"},
+			{Target: "Missing", TargetSlug: "missing"},
+			{Target: "Source", TargetSlug: "source"},
+		},
+	}
+	if err := store.Article().CreateArticle(ctx, source); err != nil {
+		t.Fatalf("CreateArticle() error = %v", err)
+	}
+
+	links := store.Link()
+
+	outgoing, err := links.ListArticleLinks(ctx, source.ID, pagination.Params{})
+	if err != nil || len(outgoing.Items) != 3 {
+		t.Fatalf("ListArticleLinks() = %v, %v", outgoing, err)
+	}
+
+	if outgoing.Items[0].TargetSlug != "missing" || !outgoing.Items[0].Broken ||
+		outgoing.Items[2].TargetID == nil || *outgoing.Items[2].TargetID != target.ID {
+		t.Errorf("Unexpected links: %+v, %+v", outgoing.Items[0], outgoing.Items[2])
+	}
+
+	params, err := repo.ArticleLinkPages.Params(pagination.Query{Limit: 2})
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	first, err := links.ListArticleLinks(ctx, source.ID, params)
+	if err != nil || len(first.Items) != 2 || first.NextCursor == "" {
+		t.Fatalf("ListArticleLinks(limit) = %+v, %v", first, err)
+	}
+
+	if params, err = repo.ArticleLinkPages.Params(pagination.Query{Limit: 2, Cursor: first.NextCursor}); err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	rest, err := links.ListArticleLinks(ctx, source.ID, params)
+	if err != nil || len(rest.Items) != 1 || rest.Items[0].TargetSlug != outgoing.Items[2].TargetSlug {
+		t.Errorf("ListArticleLinks(cursor) = %+v, %v", rest, err)
+	}
+
+	// Посилання статті на себе не є зворотним
+	backlinks, err := links.ListBacklinks(ctx, target.ID, pagination.Params{})
+	if err != nil || len(backlinks.Items) != 1 || backlinks.Items[0].ID != source.ID {
+		t.Errorf("ListBacklinks() = %v, %v", backlinks, err)
+	}
+
+	if self, _ := links.ListBacklinks(ctx, source.ID, pagination.Params{}); len(self.Items) != 0 {
+		t.Errorf("Expected no backlinks for self link, got %v", self)
+	}
+
+	broken, err := links.ListBrokenLinks(ctx, pagination.Params{Limit: 10})
+	if err != nil || len(broken.Items) != 1 || broken.Items[0].Target != "Missing" || broken.Items[0].SourceID != source.ID {
+		t.Errorf("ListBrokenLinks() = %+v, %v", broken, err)
+	}
+
+	// Оновлення замінює посилання, а зміна slug цілі робить посилання на неї битими
+	source.Links = []*models.ArticleLink{{Target: "Target", TargetSlug: "target"}}
+	if err = store.Article().UpdateArticle(ctx, source); err != nil {
+		t.Fatalf("UpdateArticle() error = %v", err)
+	}
+
+	target.Slug = "renamed"
+	if err = store.Article().UpdateArticle(ctx, target); err != nil {
+		t.Fatalf("UpdateArticle() error = %v", err)
+	}
+
+	broken, err = links.ListBrokenLinks(ctx, pagination.Params{Limit: 10})
+	if err != nil || len(broken.Items) != 1 || broken.Items[0].TargetSlug != "target" {
+		t.Errorf("ListBrokenLinks() after rename = %+v, %v", broken, err)
+	}
+
+	if backlinks, _ = links.ListBacklinks(ctx, target.ID, pagination.Params{}); len(backlinks.Items) != 0 {
+		t.Errorf("Expected no backlinks after rename, got %v", backlinks)
+	}
+
+	// Видалення джерела видаляє його посилання
+	if err = store.Article().DeleteArticle(ctx, source.ID); err != nil {
+		t.Fatalf("DeleteArticle() error = %v", err)
+	}
+
+	if broken, err = links.ListBrokenLinks(ctx, pagination.Params{}); err != nil || len(broken.Items) != 0 {
+		t.Errorf("Expected no links after delete, got %+v, %v", broken, err)
+	}
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"KnowledgeHub/internal/models"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _loginLockoutsTable = "login_lockouts"
+
+type LoginLockoutRepo struct {
+	store *Repository
+}
+
+func (r LoginLockoutRepo) GetLoginLockout(ctx context.Context, username string) (*models.LoginLockout, error) {
+	sql, args, err := r.store.db.Builder.
+		Select("username", "failures", "locked_until").
+		From(_loginLockoutsTable).
+		Where(squirrel.Eq{"username": username}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("LoginLockoutRepo - GetLoginLockout - Builder: %w", err)
+	}
+
+	var lockout models.LoginLockout
+
+	err = r.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&lockout.Username, &lockout.Failures, &lockout.LockedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("LoginLockoutRepo - GetLoginLockout - QueryRow: %w", err)
+	}
+
+	return &lockout, nil
+}
+
+func (r LoginLockoutRepo) RecordLoginFailure(ctx context.Context, username string) (int, error) {
+	sql, args, err := r.store.db.Builder.
+		Insert(_loginLockoutsTable).
+		Columns("username", "failures").
+		Values(username, 1).
+		Suffix("ON CONFLICT (username) DO UPDATE SET " +
+			"failures = login_lockouts.failures + 1, updated_at = NOW() RETURNING failures").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("LoginLockoutRepo - RecordLoginFailure - Builder: %w", err)
+	}
+
+	var failures int
+	if err = r.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&failures); err != nil {
+		return 0, fmt.Errorf("LoginLockoutRepo - RecordLoginFailure - QueryRow: %w", err)
+	}
+
+	return failures, nil
+}
+
+func (r LoginLockoutRepo) LockLogin(ctx context.Context, username string, until time.Time) error {
+	sql, args, err := r.store.db.Builder.
+		Insert(_loginLockoutsTable).
+		Columns("username", "locked_until").
+		Values(username, until).
+		Suffix("ON CONFLICT (username) DO UPDATE SET locked_until = EXCLUDED.locked_until, updated_at = NOW()").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("LoginLockoutRepo - LockLogin - Builder: %w", err)
+	}
+
+	if _, err = r.store.db.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("LoginLockoutRepo - LockLogin - Exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r LoginLockoutRepo) ResetLoginFailures(ctx context.Context, username string) error {
+	sql, args, err := r.store.db.Builder.
+		Delete(_loginLockoutsTable).
+		Where(squirrel.Eq{"username": username}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("LoginLockoutRepo - ResetLoginFailures - Builder: %w", err)
+	}
+
+	if _, err = r.store.db.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("LoginLockoutRepo - ResetLoginFailures - Exec: %w", err)
+	}
+
+	return nil
+}
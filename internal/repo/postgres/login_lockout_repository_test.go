@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLoginLockoutRepo(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	// Лічильник не залежить від існування користувача
+	const username = "testuser"
+
+	lockouts := store.LoginLockout()
+
+	lockout, err := lockouts.GetLoginLockout(ctx, username)
+	if err != nil || lockout != nil {
+		t.Fatalf("Expected no lockout, got %+v, %v", lockout, err)
+	}
+
+	for want := 1; want <= 2; want++ {
+		failures, err := lockouts.RecordLoginFailure(ctx, username)
+		if err != nil || failures != want {
+			t.Fatalf("RecordLoginFailure() = %d, %v, want %d", failures, err, want)
+		}
+	}
+
+	until := time.Now().Add(time.Minute).Truncate(time.Microsecond)
+	if err = lockouts.LockLogin(ctx, username, until); err != nil {
+		t.Fatalf("LockLogin() error = %v", err)
+	}
+
+	lockout, err = lockouts.GetLoginLockout(ctx, username)
+	if err != nil || lockout == nil || lockout.Failures != 2 || lockout.LockedUntil == nil || !lockout.LockedUntil.Equal(until) {
+		t.Fatalf("Expected 2 failures locked until %v, got %+v, %v", until, lockout, err)
+	}
+
+	if err = lockouts.ResetLoginFailures(ctx, username); err != nil {
+		t.Fatalf("ResetLoginFailures() error = %v", err)
+	}
+
+	lockout, err = lockouts.GetLoginLockout(ctx, username)
+	if err != nil || lockout != nil {
+		t.Errorf("Expected lockout to be reset, got %+v, %v", lockout, err)
+	}
+}
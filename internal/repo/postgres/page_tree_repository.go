@@ -0,0 +1,287 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// _maxTreeDepth захищає рекурсивні запити від зациклення при пошкоджених даних
+const _maxTreeDepth = 100
+
+const _ancestorsQuery = `
+WITH RECURSIVE ancestors AS (
+    SELECT p.id, p.title, p.slug, p.status, p.parent_id, p.position, 0 AS depth
+    FROM articles p
+    WHERE p.id = (SELECT parent_id FROM articles WHERE id = $1)
+  UNION ALL
+    SELECT a.id, a.title, a.slug, a.status, a.parent_id, a.position, an.depth + 1
+    FROM articles a
+    JOIN ancestors an ON a.id = an.parent_id
+    WHERE an.depth < $2
+)
+SELECT id, title, slug, status, parent_id, position FROM ancestors ORDER BY depth DESC`
+
+// _isAncestorQuery перевіряє, чи є $2 серед сторінки $1 та її предків
+const _isAncestorQuery = `
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id, 0 AS depth FROM articles WHERE id = $1
+  UNION ALL
+    SELECT a.id, a.parent_id, an.depth + 1
+    FROM articles a
+    JOIN ancestors an ON a.id = an.parent_id
+    WHERE an.depth < $3
+)
+SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)`
+
+const _moveSubtreeQuery = `
+WITH RECURSIVE subtree AS (
+    SELECT id, 0 AS depth FROM articles WHERE parent_id = $1
+  UNION ALL
+    SELECT a.id, s.depth + 1
+    FROM articles a
+    JOIN subtree s ON a.parent_id = s.id
+    WHERE s.depth < $3
+)
+UPDATE articles SET space_id = $2 WHERE id IN (SELECT id FROM subtree)`
+
+const _renumberQuery = `
+UPDATE articles SET position = v.position
+FROM unnest($1::bigint[], $2::int[]) AS v(id, position)
+WHERE articles.id = v.id`
+
+var _pageNodeColumns = []string{"id", "title", "slug", "status", "parent_id", "position"}
+
+type PageTreeRepo struct {
+	store *Repository
+}
+
+func (p PageTreeRepo) ListSpacePages(ctx context.Context, spaceID uint) ([]*models.PageNode, error) {
+	sql, args, err := p.store.db.Builder.
+		Select(_pageNodeColumns...).
+		From(_articlesTable).
+		Where(squirrel.Eq{"space_id": spaceID}).
+		OrderBy("parent_id NULLS FIRST", "position", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PageTreeRepo - ListSpacePages - Builder: %w", err)
+	}
+
+	pages, err := p.queryPageNodes(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PageTreeRepo - ListSpacePages - Query: %w", err)
+	}
+
+	return pages, nil
+}
+
+func (p PageTreeRepo) GetPageAncestors(ctx context.Context, articleID uint) ([]*models.PageNode, error) {
+	pages, err := p.queryPageNodes(ctx, _ancestorsQuery, articleID, _maxTreeDepth)
+	if err != nil {
+		return nil, fmt.Errorf("PageTreeRepo - GetPageAncestors - Query: %w", err)
+	}
+
+	return pages, nil
+}
+
+func (p PageTreeRepo) MovePage(ctx context.Context, move models.PageMove) error {
+	err := pgx.BeginFunc(ctx, p.store.db.Pool, func(tx pgx.Tx) error {
+		return p.movePage(ctx, tx, move)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) || errors.Is(err, repo.ErrCycle) {
+			return fmt.Errorf("PageTreeRepo - MovePage: %w", err)
+		}
+
+		return fmt.Errorf("PageTreeRepo - MovePage - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (p PageTreeRepo) movePage(ctx context.Context, tx pgx.Tx, move models.PageMove) error {
+	var oldSpaceID, oldParentID *uint
+
+	err := tx.QueryRow(ctx,
+		"SELECT space_id, parent_id FROM articles WHERE id = $1 FOR UPDATE", move.ArticleID,
+	).Scan(&oldSpaceID, &oldParentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repo.ErrNotFound
+		}
+
+		return err
+	}
+
+	// Блокування просторів серіалізує зміни їхніх дерев; порядок id запобігає deadlock
+	spaceIDs := []int64{int64(move.SpaceID)}
+	if oldSpaceID != nil && *oldSpaceID != move.SpaceID {
+		spaceIDs = append(spaceIDs, int64(*oldSpaceID))
+	}
+
+	tag, err := tx.Exec(ctx,
+		"SELECT id FROM spaces WHERE id = ANY($1) ORDER BY id FOR UPDATE", spaceIDs,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() != int64(len(spaceIDs)) {
+		return repo.ErrNotFound
+	}
+
+	if move.ParentID != nil {
+		if err = p.checkParent(ctx, tx, move); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		"UPDATE articles SET space_id = $2, parent_id = $3 WHERE id = $1",
+		move.ArticleID, move.SpaceID, move.ParentID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if oldSpaceID == nil || *oldSpaceID != move.SpaceID {
+		if _, err = tx.Exec(ctx, _moveSubtreeQuery, move.ArticleID, move.SpaceID, _maxTreeDepth); err != nil {
+			return err
+		}
+	}
+
+	// Закриваємо пропуск на старому місці
+	if oldSpaceID != nil {
+		oldSiblings, siblingsErr := p.siblingIDs(ctx, tx, *oldSpaceID, oldParentID, move.ArticleID)
+		if siblingsErr != nil {
+			return siblingsErr
+		}
+
+		if err = renumber(ctx, tx, oldSiblings); err != nil {
+			return err
+		}
+	}
+
+	siblings, err := p.siblingIDs(ctx, tx, move.SpaceID, move.ParentID, move.ArticleID)
+	if err != nil {
+		return err
+	}
+
+	position := min(max(move.Position, 0), len(siblings))
+	siblings = slices.Insert(siblings, position, int64(move.ArticleID))
+
+	return renumber(ctx, tx, siblings)
+}
+
+// checkParent перевіряє, що батько існує в цільовому просторі і не є нащадком сторінки
+func (p PageTreeRepo) checkParent(ctx context.Context, tx pgx.Tx, move models.PageMove) error {
+	if *move.ParentID == move.ArticleID {
+		return repo.ErrCycle
+	}
+
+	var parentSpaceID *uint
+
+	err := tx.QueryRow(ctx, "SELECT space_id FROM articles WHERE id = $1", *move.ParentID).Scan(&parentSpaceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repo.ErrNotFound
+		}
+
+		return err
+	}
+
+	if parentSpaceID == nil || *parentSpaceID != move.SpaceID {
+		return repo.ErrNotFound
+	}
+
+	var cycle bool
+
+	err = tx.QueryRow(ctx, _isAncestorQuery, *move.ParentID, move.ArticleID, _maxTreeDepth).Scan(&cycle)
+	if err != nil {
+		return err
+	}
+
+	if cycle {
+		return repo.ErrCycle
+	}
+
+	return nil
+}
+
+// siblingIDs повертає сторінки з тим самим батьком у поточному порядку, крім exclude
+func (p PageTreeRepo) siblingIDs(
+	ctx context.Context,
+	tx pgx.Tx,
+	spaceID uint,
+	parentID *uint,
+	exclude uint,
+) ([]int64, error) {
+	parent := squirrel.Eq{"parent_id": nil}
+	if parentID != nil {
+		parent = squirrel.Eq{"parent_id": *parentID}
+	}
+
+	sql, args, err := p.store.db.Builder.
+		Select("id").
+		From(_articlesTable).
+		Where(squirrel.Eq{"space_id": spaceID}).
+		Where(parent).
+		Where(squirrel.NotEq{"id": exclude}).
+		OrderBy("position", "id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+func (p PageTreeRepo) queryPageNodes(ctx context.Context, sql string, args ...any) ([]*models.PageNode, error) {
+	rows, err := p.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pages := make([]*models.PageNode, 0)
+
+	for rows.Next() {
+		page := &models.PageNode{Children: make([]*models.PageNode, 0)}
+
+		if err = rows.Scan(&page.ID, &page.Title, &page.Slug, &page.Status, &page.ParentID, &page.Position); err != nil {
+			return nil, err
+		}
+
+		pages = append(pages, page)
+	}
+
+	return pages, rows.Err()
+}
+
+// renumber присвоює сторінкам позиції 0..n-1 у порядку ids
+func renumber(ctx context.Context, tx pgx.Tx, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	positions := make([]int32, len(ids))
+	for i := range positions {
+		positions[i] = int32(i) //nolint:gosec // number of siblings is far below int32 range
+	}
+
+	_, err := tx.Exec(ctx, _renumberQuery, ids, positions)
+
+	return err
+}
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _refreshTokensTable = "refresh_tokens"
+
+type RefreshTokenRepo struct {
+	store *Repository
+}
+
+func (r RefreshTokenRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	sql, args, err := r.store.db.Builder.
+		Insert(_refreshTokensTable).
+		Columns("user_id", "family_id", "token_hash", "expires_at").
+		Values(token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepo - CreateRefreshToken - Builder: %w", err)
+	}
+
+	err = r.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepo - CreateRefreshToken - QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+func (r RefreshTokenRepo) GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	sql, args, err := r.store.db.Builder.
+		Select("id", "user_id", "family_id", "token_hash", "expires_at", "used_at", "revoked_at", "created_at").
+		From(_refreshTokensTable).
+		Where(squirrel.Eq{"token_hash": hash}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("RefreshTokenRepo - GetRefreshTokenByHash - Builder: %w", err)
+	}
+
+	token := &models.RefreshToken{}
+
+	err = r.store.db.Pool.QueryRow(ctx, sql, args...).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("RefreshTokenRepo - GetRefreshTokenByHash - QueryRow: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r RefreshTokenRepo) MarkRefreshTokenUsed(ctx context.Context, id uint) (bool, error) {
+	sql, args, err := r.store.db.Builder.
+		Update(_refreshTokensTable).
+		Set("used_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id, "used_at": nil, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("RefreshTokenRepo - MarkRefreshTokenUsed - Builder: %w", err)
+	}
+
+	tag, err := r.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("RefreshTokenRepo - MarkRefreshTokenUsed - Exec: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r RefreshTokenRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	sql, args, err := r.store.db.Builder.
+		Update(_refreshTokensTable).
+		Set("revoked_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"family_id": familyID, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepo - RevokeRefreshTokenFamily - Builder: %w", err)
+	}
+
+	_, err = r.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepo - RevokeRefreshTokenFamily - Exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r RefreshTokenRepo) RevokeUserRefreshTokens(ctx context.Context, userID uint) error {
+	sql, args, err := r.store.db.Builder.
+		Update(_refreshTokensTable).
+		Set("revoked_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"user_id": userID, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepo - RevokeUserRefreshTokens - Builder: %w", err)
+	}
+
+	_, err = r.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepo - RevokeUserRefreshTokens - Exec: %w", err)
+	}
+
+	return nil
+}
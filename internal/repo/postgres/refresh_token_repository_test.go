@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"KnowledgeHub/internal/models"
+)
+
+func TestRefreshTokenRepo_Rotation(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	user := &models.User{Username: "testuser", Email: "test@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	tokens := store.RefreshToken()
+	token := &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  "family",
+		TokenHash: "0000000000000000000000000000000000000000000000000000000000000001",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	if err := tokens.CreateRefreshToken(ctx, token); err != nil {
+		t.Fatalf("CreateRefreshToken() error = %v", err)
+	}
+
+	got, err := tokens.GetRefreshTokenByHash(ctx, token.TokenHash)
+	if err != nil || got == nil || got.ID != token.ID {
+		t.Fatalf("GetRefreshTokenByHash() = %v, %v", got, err)
+	}
+
+	marked, err := tokens.MarkRefreshTokenUsed(ctx, token.ID)
+	if err != nil || !marked {
+		t.Fatalf("MarkRefreshTokenUsed() = %v, %v", marked, err)
+	}
+
+	marked, err = tokens.MarkRefreshTokenUsed(ctx, token.ID)
+	if err != nil || marked {
+		t.Errorf("Expected second MarkRefreshTokenUsed to return false, got %v, %v", marked, err)
+	}
+
+	if err = tokens.RevokeRefreshTokenFamily(ctx, "family"); err != nil {
+		t.Fatalf("RevokeRefreshTokenFamily() error = %v", err)
+	}
+
+	got, err = tokens.GetRefreshTokenByHash(ctx, token.TokenHash)
+	if err != nil || got == nil || got.RevokedAt == nil {
+		t.Errorf("Expected token to be revoked, got %v, %v", got, err)
+	}
+}
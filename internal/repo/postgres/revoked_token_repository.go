@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	_revokedTokensTable        = "revoked_tokens"
+	_userTokenRevocationsTable = "user_token_revocations"
+)
+
+type RevokedTokenRepo struct {
+	store *Repository
+}
+
+func (r RevokedTokenRepo) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	sql, args, err := r.store.db.Builder.
+		Insert(_revokedTokensTable).
+		Columns("jti", "expires_at").
+		Values(jti, expiresAt).
+		Suffix("ON CONFLICT (jti) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("RevokedTokenRepo - RevokeToken - Builder: %w", err)
+	}
+
+	if _, err = r.store.db.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("RevokedTokenRepo - RevokeToken - Exec: %w", err)
+	}
+
+	// Записи про токени, що вже прострочились, більше не потрібні
+	sql, args, err = r.store.db.Builder.
+		Delete(_revokedTokensTable).
+		Where(squirrel.Lt{"expires_at": time.Now()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("RevokedTokenRepo - RevokeToken - Builder: %w", err)
+	}
+
+	if _, err = r.store.db.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("RevokedTokenRepo - RevokeToken - cleanup: %w", err)
+	}
+
+	return nil
+}
+
+func (r RevokedTokenRepo) RevokeUserTokens(ctx context.Context, userID uint, before time.Time) error {
+	sql, args, err := r.store.db.Builder.
+		Insert(_userTokenRevocationsTable).
+		Columns("user_id", "revoked_before").
+		Values(userID, before).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET revoked_before = " +
+			"GREATEST(user_token_revocations.revoked_before, EXCLUDED.revoked_before)").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("RevokedTokenRepo - RevokeUserTokens - Builder: %w", err)
+	}
+
+	if _, err = r.store.db.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("RevokedTokenRepo - RevokeUserTokens - Exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r RevokedTokenRepo) IsTokenRevoked(ctx context.Context, jti string, userID uint, issuedAt time.Time) (bool, error) {
+	// Підзапити будуються з плейсхолдерами "?", щоб зовнішній builder пронумерував їх наскрізно
+	sql, args, err := r.store.db.Builder.
+		Select().
+		Column(squirrel.Expr("EXISTS (?) OR EXISTS (?)",
+			squirrel.
+				Select("1").
+				From(_revokedTokensTable).
+				Where(squirrel.Eq{"jti": jti}),
+			squirrel.
+				Select("1").
+				From(_userTokenRevocationsTable).
+				Where(squirrel.Eq{"user_id": userID}).
+				// Токен, виданий у ту ж мікросекунду, що й відкликання, теж відкликаний
+				Where(squirrel.GtOrEq{"revoked_before": issuedAt}),
+		)).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("RevokedTokenRepo - IsTokenRevoked - Builder: %w", err)
+	}
+
+	var revoked bool
+	if err = r.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("RevokedTokenRepo - IsTokenRevoked - QueryRow: %w", err)
+	}
+
+	return revoked, nil
+}
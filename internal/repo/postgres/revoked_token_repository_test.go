@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"KnowledgeHub/internal/models"
+)
+
+func TestRevokedTokenRepo(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	user := &models.User{Username: "testuser", Email: "test@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	revoked := store.RevokedToken()
+	issuedAt := time.Now().Add(-time.Minute)
+
+	isRevoked, err := revoked.IsTokenRevoked(ctx, "jti-1", user.ID, issuedAt)
+	if err != nil || isRevoked {
+		t.Fatalf("Expected token not to be revoked, got %v, %v", isRevoked, err)
+	}
+
+	if err = revoked.RevokeToken(ctx, "jti-1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+
+	isRevoked, err = revoked.IsTokenRevoked(ctx, "jti-1", user.ID, issuedAt)
+	if err != nil || !isRevoked {
+		t.Errorf("Expected token to be revoked by jti, got %v, %v", isRevoked, err)
+	}
+
+	revokedAt := time.Now()
+	if err = revoked.RevokeUserTokens(ctx, user.ID, revokedAt); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+
+	// Межа зберігається з точністю до мікросекунди, а не до секунди iat
+	isRevoked, err = revoked.IsTokenRevoked(ctx, "jti-4", user.ID, revokedAt.Add(-time.Millisecond))
+	if err != nil || !isRevoked {
+		t.Errorf("Expected token issued just before revocation to be revoked, got %v, %v", isRevoked, err)
+	}
+
+	isRevoked, err = revoked.IsTokenRevoked(ctx, "jti-5", user.ID, revokedAt.Add(time.Millisecond))
+	if err != nil || isRevoked {
+		t.Errorf("Expected token issued just after revocation to stay valid, got %v, %v", isRevoked, err)
+	}
+
+	isRevoked, err = revoked.IsTokenRevoked(ctx, "jti-2", user.ID, issuedAt)
+	if err != nil || !isRevoked {
+		t.Errorf("Expected older token to be revoked for user, got %v, %v", isRevoked, err)
+	}
+
+	isRevoked, err = revoked.IsTokenRevoked(ctx, "jti-3", user.ID, time.Now().Add(time.Minute))
+	if err != nil || isRevoked {
+		t.Errorf("Expected newer token to stay valid, got %v, %v", isRevoked, err)
+	}
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// _headlineOptions - налаштування ts_headline для фрагментів у результатах пошуку
+const _headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, " +
+	"FragmentDelimiter=\" … \""
+
+type SearchRepo struct {
+	store *Repository
+}
+
+// SearchArticles шукає статті за search_vector (див. міграції 0007_articles_search
+// та 0016_articles_search_tags): заголовок, текст і назви тегів.
+// Запит розбирається і як точні слова ('simple'), і мовою query.Language, тому
+// стемінг працює, коли мова задана, а без неї знаходяться точні збіги.
+func (s SearchRepo) SearchArticles(
+	ctx context.Context,
+	query models.SearchQuery,
+	page pagination.Params,
+) (*pagination.Page[*models.SearchHit], int, error) {
+	language := query.Language
+	if language == "" {
+		language = models.ArticleLanguageDefault
+	}
+
+	// Вкладені запити з '?' - зовнішній Builder сам пронумерує параметри.
+	// total рахується до умови курсора, тому це кількість усіх збігів, а не решти.
+	matches := squirrel.
+		Select(
+			"a.id", "a.title", "a.slug", "a.body", "a.status", "a.space_id", "a.author_id", "a.language",
+			"a.updated_at",
+			"q.query",
+			"ts_rank_cd(a.search_vector, q.query) AS rank",
+			"COUNT(*) OVER () AS total",
+		).
+		From(_articlesTable+" a").
+		JoinClause(
+			"CROSS JOIN (SELECT websearch_to_tsquery('simple', ?) || websearch_to_tsquery(?::regconfig, ?) AS query) q",
+			query.Text, language, query.Text,
+		).
+		Where("a.search_vector @@ q.query")
+
+	if query.SpaceID != 0 {
+		matches = matches.Where(squirrel.Eq{"a.space_id": query.SpaceID})
+	}
+
+	if query.AuthorID != 0 {
+		matches = matches.Where(squirrel.Eq{"a.author_id": query.AuthorID})
+	}
+
+	if query.Status != "" {
+		matches = matches.Where(squirrel.Eq{"a.status": query.Status})
+	}
+
+	for _, tag := range query.Tags {
+		matches = matches.Where(
+			"EXISTS (SELECT 1 FROM article_tags tg JOIN tags t ON t.id = tg.tag_id "+
+				"WHERE tg.article_id = a.id AND t.name = ?)",
+			tag,
+		)
+	}
+
+	if query.UpdatedFrom != nil {
+		matches = matches.Where(squirrel.GtOrEq{"a.updated_at": *query.UpdatedFrom})
+	}
+
+	if query.UpdatedTo != nil {
+		matches = matches.Where(squirrel.Lt{"a.updated_at": *query.UpdatedTo})
+	}
+
+	hits := repo.SearchPages.Apply(squirrel.Select("*").FromSelect(matches, "m"), page)
+
+	// ts_headline дорогий, тому рахується лише для рядків поточної сторінки
+	sql, args, err := repo.SearchPages.Order(
+		s.store.db.Builder.
+			Select(
+				"id", "title", "slug", "status", "space_id", "author_id", "language", "updated_at", "rank", "total",
+				"ts_headline(language::regconfig, body, query, '"+_headlineOptions+"') AS snippet",
+			).
+			FromSelect(hits, "hits"),
+		page,
+	).ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("SearchRepo - SearchArticles - Builder: %w", err)
+	}
+
+	rows, err := s.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("SearchRepo - SearchArticles - Query: %w", err)
+	}
+	defer rows.Close()
+
+	found := make([]*models.SearchHit, 0)
+	total := 0
+
+	for rows.Next() {
+		hit := &models.SearchHit{}
+
+		var rank float32
+
+		scanErr := rows.Scan(
+			&hit.ArticleID,
+			&hit.Title,
+			&hit.Slug,
+			&hit.Status,
+			&hit.SpaceID,
+			&hit.AuthorID,
+			&hit.Language,
+			&hit.UpdatedAt,
+			&rank,
+			&total,
+			&hit.Snippet,
+		)
+		if scanErr != nil {
+			return nil, 0, fmt.Errorf("SearchRepo - SearchArticles - Scan: %w", scanErr)
+		}
+
+		hit.Rank = float64(rank)
+		found = append(found, hit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("SearchRepo - SearchArticles - rows.Err: %w", err)
+	}
+
+	return repo.SearchPages.Page(found, page), total, nil
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestSearchRepo_SearchArticles(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	author := &models.User{Username: "author", Email: "author@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, author); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	articles := []*models.Article{
+		{Title: "Deploying services", Slug: "deploying", Body: "We deploy every day with the pipeline", Language: "english"},
+		{Title: "Runbook", Slug: "runbook", Body: "If deploying fails, roll back", Language: "english"},
+		{Title: "Vacation", Slug: "vacation", Body: "Nothing to see here", Language: "simple"},
+	}
+
+	for _, article := range articles {
+		article.AuthorID = author.ID
+		article.UpdatedBy = author.ID
+		article.Status = models.ArticleStatusPublished
+
+		if err := store.Article().CreateArticle(ctx, article); err != nil {
+			t.Fatalf("CreateArticle() error = %v", err)
+		}
+	}
+
+	// Стемінг: "deployed" знаходить "deploy" та "deploying" лише з мовою english
+	hits, total, err := store.Search().SearchArticles(ctx, models.SearchQuery{Text: "deployed", Language: "english"}, pagination.Params{})
+	if err != nil {
+		t.Fatalf("SearchArticles() error = %v", err)
+	}
+
+	if total != 2 || len(hits.Items) != 2 {
+		t.Fatalf("Expected 2 hits, got %d (total %d)", len(hits.Items), total)
+	}
+
+	if hits.Items[0].Slug != "deploying" {
+		t.Errorf("Expected title match to rank first, got %q", hits.Items[0].Slug)
+	}
+
+	if !strings.Contains(hits.Items[0].Snippet, "<mark>") {
+		t.Errorf("Expected highlighted snippet, got %q", hits.Items[0].Snippet)
+	}
+
+	// Курсор наступної сторінки несе rank і не змінює total
+	params, err := repo.SearchPages.Params(pagination.Query{Limit: 1})
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	query := models.SearchQuery{Text: "deployed", Language: "english"}
+
+	first, _, err := store.Search().SearchArticles(ctx, query, params)
+	if err != nil || len(first.Items) != 1 || first.Items[0].Slug != "deploying" || first.NextCursor == "" {
+		t.Fatalf("SearchArticles(limit) = %+v, %v", first, err)
+	}
+
+	if params, err = repo.SearchPages.Params(pagination.Query{Limit: 1, Cursor: first.NextCursor}); err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	second, total, err := store.Search().SearchArticles(ctx, query, params)
+	if err != nil || total != 2 || len(second.Items) != 1 || second.Items[0].Slug != "runbook" || second.NextCursor != "" {
+		t.Errorf("SearchArticles(cursor) = %+v, %d, %v", second, total, err)
+	}
+
+	_, total, err = store.Search().SearchArticles(ctx, models.SearchQuery{Text: "deployed"}, pagination.Params{})
+	if err != nil || total != 0 {
+		t.Errorf("Expected no exact matches without language, got %d, %v", total, err)
+	}
+
+	hits, total, err = store.Search().SearchArticles(ctx, models.SearchQuery{Text: "deploy -runbook"}, pagination.Params{Limit: 1})
+	if err != nil || total != 1 || len(hits.Items) != 1 || hits.Items[0].Slug != "deploying" {
+		t.Errorf("Unexpected result for exclusion query: %+v, %d, %v", hits, total, err)
+	}
+
+	// Теги потрапляють у вектор, коли їх додають, і оновлюються при перейменуванні
+	if err = store.Tag().AddArticleTags(ctx, articles[2].ID, []string{"holidays"}); err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	hits, total, err = store.Search().SearchArticles(ctx, models.SearchQuery{Text: "holidays"}, pagination.Params{})
+	if err != nil || total != 1 || hits.Items[0].Slug != "vacation" {
+		t.Errorf("Expected tag name to match, got %+v, %d, %v", hits, total, err)
+	}
+
+	if _, err = store.Tag().RenameTag(ctx, "holidays", "time-off"); err != nil {
+		t.Fatalf("RenameTag() error = %v", err)
+	}
+
+	_, total, err = store.Search().SearchArticles(ctx, models.SearchQuery{Text: "holidays"}, pagination.Params{})
+	if err != nil || total != 0 {
+		t.Errorf("Expected old tag name not to match after rename, got %d, %v", total, err)
+	}
+
+	hits, total, err = store.Search().SearchArticles(ctx, models.SearchQuery{Text: "time-off"}, pagination.Params{})
+	if err != nil || total != 1 || hits.Items[0].Slug != "vacation" {
+		t.Errorf("Expected renamed tag to match, got %+v, %d, %v", hits, total, err)
+	}
+
+	if err = store.Tag().AddArticleTags(ctx, articles[0].ID, []string{"ci"}); err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	hits, total, err = store.Search().SearchArticles(ctx, models.SearchQuery{Text: "deploy", Tags: []string{"ci"}}, pagination.Params{})
+	if err != nil || total != 1 || hits.Items[0].Slug != "deploying" {
+		t.Errorf("Expected tag filter to keep only tagged article, got %+v, %d, %v", hits, total, err)
+	}
+
+	if err = store.Tag().RemoveArticleTag(ctx, articles[2].ID, "time-off"); err != nil {
+		t.Fatalf("RemoveArticleTag() error = %v", err)
+	}
+
+	_, total, err = store.Search().SearchArticles(ctx, models.SearchQuery{Text: "time-off"}, pagination.Params{})
+	if err != nil || total != 0 {
+		t.Errorf("Expected removed tag not to match, got %d, %v", total, err)
+	}
+}
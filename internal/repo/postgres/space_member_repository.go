@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _spaceMembersTable = "space_members"
+
+var _spaceMemberColumns = []string{"space_id", "user_id", "role", "created_at"}
+
+type SpaceMemberRepo struct {
+	store *Repository
+}
+
+func (s SpaceMemberRepo) SetSpaceMember(ctx context.Context, member *models.SpaceMember) error {
+	sql, args, err := s.store.db.Builder.
+		Insert(_spaceMembersTable).
+		Columns("space_id", "user_id", "role").
+		Values(member.SpaceID, member.UserID, member.Role).
+		Suffix("ON CONFLICT (space_id, user_id) DO UPDATE SET role = EXCLUDED.role RETURNING created_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("SpaceMemberRepo - SetSpaceMember - Builder: %w", err)
+	}
+
+	err = s.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&member.CreatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("SpaceMemberRepo - SetSpaceMember: %w", repo.ErrNotFound)
+		}
+
+		return fmt.Errorf("SpaceMemberRepo - SetSpaceMember - QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+func (s SpaceMemberRepo) GetSpaceMember(ctx context.Context, spaceID, userID uint) (*models.SpaceMember, error) {
+	sql, args, err := s.store.db.Builder.
+		Select(_spaceMemberColumns...).
+		From(_spaceMembersTable).
+		Where(squirrel.Eq{"space_id": spaceID, "user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("SpaceMemberRepo - GetSpaceMember - Builder: %w", err)
+	}
+
+	member, err := scanSpaceMember(s.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("SpaceMemberRepo - GetSpaceMember - QueryRow: %w", err)
+	}
+
+	return member, nil
+}
+
+func (s SpaceMemberRepo) ListSpaceMembers(
+	ctx context.Context,
+	spaceID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.SpaceMember], error) {
+	query := s.store.db.Builder.
+		Select(_spaceMemberColumns...).
+		From(_spaceMembersTable).
+		Where(squirrel.Eq{"space_id": spaceID})
+
+	sql, args, err := repo.SpaceMemberPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("SpaceMemberRepo - ListSpaceMembers - Builder: %w", err)
+	}
+
+	rows, err := s.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("SpaceMemberRepo - ListSpaceMembers - Query: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]*models.SpaceMember, 0)
+
+	for rows.Next() {
+		member, scanErr := scanSpaceMember(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("SpaceMemberRepo - ListSpaceMembers - Scan: %w", scanErr)
+		}
+
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("SpaceMemberRepo - ListSpaceMembers - rows.Err: %w", err)
+	}
+
+	return repo.SpaceMemberPages.Page(members, page), nil
+}
+
+func (s SpaceMemberRepo) RemoveSpaceMember(ctx context.Context, spaceID, userID uint) error {
+	sql, args, err := s.store.db.Builder.
+		Delete(_spaceMembersTable).
+		Where(squirrel.Eq{"space_id": spaceID, "user_id": userID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("SpaceMemberRepo - RemoveSpaceMember - Builder: %w", err)
+	}
+
+	tag, err := s.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("SpaceMemberRepo - RemoveSpaceMember - Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("SpaceMemberRepo - RemoveSpaceMember: %w", repo.ErrNotFound)
+	}
+
+	return nil
+}
+
+func scanSpaceMember(row pgx.Row) (*models.SpaceMember, error) {
+	member := &models.SpaceMember{}
+
+	err := row.Scan(&member.SpaceID, &member.UserID, &member.Role, &member.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _spacesTable = "spaces"
+
+var _spaceColumns = []string{"id", "key", "name", "description", "created_by", "created_at", "updated_at"}
+
+type SpaceRepo struct {
+	store *Repository
+}
+
+func (s SpaceRepo) CreateSpace(ctx context.Context, space *models.Space) error {
+	sql, args, err := s.store.db.Builder.
+		Insert(_spacesTable).
+		Columns("key", "name", "description", "created_by").
+		Values(space.Key, space.Name, space.Description, space.CreatedBy).
+		Suffix("RETURNING id, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("SpaceRepo - CreateSpace - Builder: %w", err)
+	}
+
+	err = pgx.BeginFunc(ctx, s.store.db.Pool, func(tx pgx.Tx) error {
+		scanErr := tx.QueryRow(ctx, sql, args...).Scan(&space.ID, &space.CreatedAt, &space.UpdatedAt)
+		if scanErr != nil {
+			return scanErr
+		}
+
+		_, execErr := tx.Exec(ctx,
+			"INSERT INTO space_members (space_id, user_id, role) VALUES ($1, $2, $3)",
+			space.ID, space.CreatedBy, models.RoleAdmin,
+		)
+
+		return execErr
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("SpaceRepo - CreateSpace: %w", repo.ErrAlreadyExists)
+		}
+
+		return fmt.Errorf("SpaceRepo - CreateSpace - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (s SpaceRepo) GetSpaceByID(ctx context.Context, id uint) (*models.Space, error) {
+	return s.getSpace(ctx, squirrel.Eq{"id": id})
+}
+
+func (s SpaceRepo) GetSpaceByKey(ctx context.Context, key string) (*models.Space, error) {
+	return s.getSpace(ctx, squirrel.Eq{"key": key})
+}
+
+func (s SpaceRepo) ListSpaces(ctx context.Context, page pagination.Params) (*pagination.Page[*models.Space], error) {
+	query := s.store.db.Builder.
+		Select(_spaceColumns...).
+		From(_spacesTable)
+
+	sql, args, err := repo.SpacePages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("SpaceRepo - ListSpaces - Builder: %w", err)
+	}
+
+	rows, err := s.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("SpaceRepo - ListSpaces - Query: %w", err)
+	}
+	defer rows.Close()
+
+	spaces := make([]*models.Space, 0)
+
+	for rows.Next() {
+		space, scanErr := scanSpace(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("SpaceRepo - ListSpaces - Scan: %w", scanErr)
+		}
+
+		spaces = append(spaces, space)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("SpaceRepo - ListSpaces - rows.Err: %w", err)
+	}
+
+	return repo.SpacePages.Page(spaces, page), nil
+}
+
+func (s SpaceRepo) UpdateSpace(ctx context.Context, space *models.Space) error {
+	sql, args, err := s.store.db.Builder.
+		Update(_spacesTable).
+		Set("key", space.Key).
+		Set("name", space.Name).
+		Set("description", space.Description).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": space.ID}).
+		Suffix("RETURNING updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("SpaceRepo - UpdateSpace - Builder: %w", err)
+	}
+
+	err = s.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&space.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return fmt.Errorf("SpaceRepo - UpdateSpace: %w", repo.ErrNotFound)
+		case isUniqueViolation(err):
+			return fmt.Errorf("SpaceRepo - UpdateSpace: %w", repo.ErrAlreadyExists)
+		default:
+			return fmt.Errorf("SpaceRepo - UpdateSpace - QueryRow: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s SpaceRepo) DeleteSpace(ctx context.Context, id uint) error {
+	sql, args, err := s.store.db.Builder.
+		Delete(_spacesTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("SpaceRepo - DeleteSpace - Builder: %w", err)
+	}
+
+	tag, err := s.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("SpaceRepo - DeleteSpace: %w", repo.ErrInUse)
+		}
+
+		return fmt.Errorf("SpaceRepo - DeleteSpace - Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("SpaceRepo - DeleteSpace: %w", repo.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (s SpaceRepo) getSpace(ctx context.Context, pred squirrel.Sqlizer) (*models.Space, error) {
+	sql, args, err := s.store.db.Builder.
+		Select(_spaceColumns...).
+		From(_spacesTable).
+		Where(pred).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("SpaceRepo - getSpace - Builder: %w", err)
+	}
+
+	space, err := scanSpace(s.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("SpaceRepo - getSpace - QueryRow: %w", err)
+	}
+
+	return space, nil
+}
+
+// scanSpace читає рядок у порядку _spaceColumns
+func scanSpace(row pgx.Row) (*models.Space, error) {
+	space := &models.Space{}
+
+	err := row.Scan(
+		&space.ID,
+		&space.Key,
+		&space.Name,
+		&space.Description,
+		&space.CreatedBy,
+		&space.CreatedAt,
+		&space.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return space, nil
+}
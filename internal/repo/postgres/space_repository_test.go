@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestSpaceRepo_CRUD(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	owner := &models.User{Username: "owner", Email: "owner@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, owner); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	spaces := store.Space()
+
+	space := &models.Space{Key: "docs", Name: "Docs", CreatedBy: owner.ID}
+	if err := spaces.CreateSpace(ctx, space); err != nil {
+		t.Fatalf("CreateSpace() error = %v", err)
+	}
+
+	duplicate := *space
+	if err := spaces.CreateSpace(ctx, &duplicate); !errors.Is(err, repo.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists for duplicate key, got %v", err)
+	}
+
+	got, err := spaces.GetSpaceByKey(ctx, "docs")
+	if err != nil || got == nil || got.ID != space.ID {
+		t.Fatalf("GetSpaceByKey() = %v, %v", got, err)
+	}
+
+	space.Name = "Documentation"
+	if err = spaces.UpdateSpace(ctx, space); err != nil {
+		t.Fatalf("UpdateSpace() error = %v", err)
+	}
+
+	list, err := spaces.ListSpaces(ctx, pagination.Params{})
+	if err != nil || len(list.Items) != 1 || list.Items[0].Name != "Documentation" {
+		t.Errorf("ListSpaces() = %+v, %v", list, err)
+	}
+
+	article := newTreeArticle(t, store, owner.ID, space.ID, nil, "intro")
+
+	if err = spaces.DeleteSpace(ctx, space.ID); !errors.Is(err, repo.ErrInUse) {
+		t.Errorf("Expected ErrInUse for non-empty space, got %v", err)
+	}
+
+	if err = store.Article().DeleteArticle(ctx, article.ID); err != nil {
+		t.Fatalf("DeleteArticle() error = %v", err)
+	}
+
+	if err = spaces.DeleteSpace(ctx, space.ID); err != nil {
+		t.Errorf("DeleteSpace() error = %v", err)
+	}
+
+	if err = spaces.DeleteSpace(ctx, space.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPageTreeRepo_Move(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	owner := &models.User{Username: "owner", Email: "owner@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, owner); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	docs := &models.Space{Key: "docs", Name: "Docs", CreatedBy: owner.ID}
+	archive := &models.Space{Key: "archive", Name: "Archive", CreatedBy: owner.ID}
+
+	for _, space := range []*models.Space{docs, archive} {
+		if err := store.Space().CreateSpace(ctx, space); err != nil {
+			t.Fatalf("CreateSpace() error = %v", err)
+		}
+	}
+
+	a := newTreeArticle(t, store, owner.ID, docs.ID, nil, "a")
+	b := newTreeArticle(t, store, owner.ID, docs.ID, nil, "b")
+	a1 := newTreeArticle(t, store, owner.ID, docs.ID, &a.ID, "a1")
+	a2 := newTreeArticle(t, store, owner.ID, docs.ID, &a.ID, "a2")
+
+	if b.Position != 1 || a2.Position != 1 {
+		t.Errorf("Expected pages to be appended, got b=%d a2=%d", b.Position, a2.Position)
+	}
+
+	tree := store.PageTree()
+
+	if err := store.Article().DeleteArticle(ctx, a.ID); !errors.Is(err, repo.ErrInUse) {
+		t.Errorf("Expected ErrInUse for page with children, got %v", err)
+	}
+
+	err := tree.MovePage(ctx, models.PageMove{ArticleID: a.ID, SpaceID: docs.ID, ParentID: &a1.ID})
+	if !errors.Is(err, repo.ErrCycle) {
+		t.Errorf("Expected ErrCycle, got %v", err)
+	}
+
+	err = tree.MovePage(ctx, models.PageMove{ArticleID: a1.ID, SpaceID: archive.ID, ParentID: &b.ID})
+	if !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for parent in other space, got %v", err)
+	}
+
+	// A2 на початок, потім A під B
+	if err = tree.MovePage(ctx, models.PageMove{ArticleID: a2.ID, SpaceID: docs.ID, ParentID: &a.ID}); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+
+	if err = tree.MovePage(ctx, models.PageMove{ArticleID: a.ID, SpaceID: docs.ID, ParentID: &b.ID, Position: 10}); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+
+	pages, err := tree.ListSpacePages(ctx, docs.ID)
+	if err != nil {
+		t.Fatalf("ListSpacePages() error = %v", err)
+	}
+
+	want := []struct {
+		id       uint
+		position int
+	}{{b.ID, 0}, {a.ID, 0}, {a2.ID, 0}, {a1.ID, 1}}
+
+	if len(pages) != len(want) {
+		t.Fatalf("Expected %d pages, got %d", len(want), len(pages))
+	}
+
+	for i, w := range want {
+		if pages[i].ID != w.id || pages[i].Position != w.position {
+			t.Errorf("pages[%d] = %d@%d, want %d@%d", i, pages[i].ID, pages[i].Position, w.id, w.position)
+		}
+	}
+
+	ancestors, err := tree.GetPageAncestors(ctx, a1.ID)
+	if err != nil || len(ancestors) != 2 || ancestors[0].ID != b.ID || ancestors[1].ID != a.ID {
+		t.Errorf("GetPageAncestors() = %v, %v", ancestors, err)
+	}
+
+	// Піддерево переїжджає в інший простір разом з коренем
+	if err = tree.MovePage(ctx, models.PageMove{ArticleID: b.ID, SpaceID: archive.ID}); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+
+	pages, err = tree.ListSpacePages(ctx, archive.ID)
+	if err != nil || len(pages) != 4 {
+		t.Errorf("Expected whole subtree in archive, got %v, %v", pages, err)
+	}
+}
+
+func newTreeArticle(t *testing.T, store *Repository, authorID, spaceID uint, parentID *uint, slug string) *models.Article {
+	t.Helper()
+
+	article := &models.Article{
+		Title:     slug,
+		Slug:      slug,
+		AuthorID:  authorID,
+		UpdatedBy: authorID,
+		Status:    models.ArticleStatusDraft,
+		Language:  models.ArticleLanguageDefault,
+		SpaceID:   &spaceID,
+		ParentID:  parentID,
+	}
+
+	if err := store.Article().CreateArticle(context.Background(), article); err != nil {
+		t.Fatalf("CreateArticle() error = %v", err)
+	}
+
+	return article
+}
+
+func TestSpaceMemberRepo(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	owner := &models.User{Username: "owner", Email: "owner@example.com", PasswordHash: "hash"}
+	member := &models.User{Username: "member", Email: "member@example.com", PasswordHash: "hash"}
+
+	for _, user := range []*models.User{owner, member} {
+		if err := store.User().CreateUser(ctx, user); err != nil {
+			t.Fatalf("CreateUser() error = %v", err)
+		}
+	}
+
+	if owner.Role != models.RoleDefault {
+		t.Errorf("Expected default role %q, got %q", models.RoleDefault, owner.Role)
+	}
+
+	if err := store.User().SetUserRole(ctx, member.ID, models.RoleViewer); err != nil {
+		t.Fatalf("SetUserRole() error = %v", err)
+	}
+
+	got, err := store.User().GetUserByID(ctx, member.ID)
+	if err != nil || got.Role != models.RoleViewer {
+		t.Errorf("Expected role viewer, got %v, %v", got, err)
+	}
+
+	space := &models.Space{Key: "docs", Name: "Docs", CreatedBy: owner.ID}
+	if err = store.Space().CreateSpace(ctx, space); err != nil {
+		t.Fatalf("CreateSpace() error = %v", err)
+	}
+
+	members := store.SpaceMember()
+
+	creator, err := members.GetSpaceMember(ctx, space.ID, owner.ID)
+	if err != nil || creator == nil || creator.Role != models.RoleAdmin {
+		t.Fatalf("Expected creator to be space admin, got %v, %v", creator, err)
+	}
+
+	for _, role := range []models.Role{models.RoleViewer, models.RoleEditor} {
+		if err = members.SetSpaceMember(ctx, &models.SpaceMember{SpaceID: space.ID, UserID: member.ID, Role: role}); err != nil {
+			t.Fatalf("SetSpaceMember(%s) error = %v", role, err)
+		}
+	}
+
+	list, err := members.ListSpaceMembers(ctx, space.ID, pagination.Params{})
+	if err != nil || len(list.Items) != 2 || list.Items[1].Role != models.RoleEditor {
+		t.Errorf("ListSpaceMembers() = %v, %v", list, err)
+	}
+
+	err = members.SetSpaceMember(ctx, &models.SpaceMember{SpaceID: space.ID, UserID: member.ID + 100, Role: models.RoleEditor})
+	if !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown user, got %v", err)
+	}
+
+	if err = members.RemoveSpaceMember(ctx, space.ID, member.ID); err != nil {
+		t.Fatalf("RemoveSpaceMember() error = %v", err)
+	}
+
+	if err = members.RemoveSpaceMember(ctx, space.ID, member.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -6,8 +6,22 @@ import (
 )
 
 type Repository struct {
-	db             *postgres.Postgres
-	userRepository *UserRepo
+	db                     *postgres.Postgres
+	userRepository         *UserRepo
+	refreshTokenRepository *RefreshTokenRepo
+	revokedTokenRepository *RevokedTokenRepo
+	loginLockoutRepository *LoginLockoutRepo
+	userTokenRepository    *UserTokenRepo
+	articleRepository      *ArticleRepo
+	revisionRepository     *ArticleRevisionRepo
+	searchRepository       *SearchRepo
+	spaceRepository        *SpaceRepo
+	pageTreeRepository     *PageTreeRepo
+	spaceMemberRepository  *SpaceMemberRepo
+	tagRepository          *TagRepo
+	commentRepository      *CommentRepo
+	attachmentRepository   *AttachmentRepo
+	linkRepository         *LinkRepo
 }
 
 func NewRepository(db *postgres.Postgres) *Repository {
@@ -26,4 +40,172 @@ func (r *Repository) User() repo.UserRepository {
 	return r.userRepository
 }
 
+func (r *Repository) RefreshToken() repo.RefreshTokenRepository {
+	if r.refreshTokenRepository != nil {
+		return r.refreshTokenRepository
+	}
+
+	r.refreshTokenRepository = &RefreshTokenRepo{
+		store: r,
+	}
+
+	return r.refreshTokenRepository
+}
+
+func (r *Repository) RevokedToken() repo.RevokedTokenRepository {
+	if r.revokedTokenRepository != nil {
+		return r.revokedTokenRepository
+	}
+
+	r.revokedTokenRepository = &RevokedTokenRepo{
+		store: r,
+	}
+
+	return r.revokedTokenRepository
+}
+
+func (r *Repository) LoginLockout() repo.LoginLockoutRepository {
+	if r.loginLockoutRepository != nil {
+		return r.loginLockoutRepository
+	}
+
+	r.loginLockoutRepository = &LoginLockoutRepo{
+		store: r,
+	}
+
+	return r.loginLockoutRepository
+}
+
+func (r *Repository) UserToken() repo.UserTokenRepository {
+	if r.userTokenRepository != nil {
+		return r.userTokenRepository
+	}
+
+	r.userTokenRepository = &UserTokenRepo{
+		store: r,
+	}
+
+	return r.userTokenRepository
+}
+
+func (r *Repository) Article() repo.ArticleRepository {
+	if r.articleRepository != nil {
+		return r.articleRepository
+	}
+
+	r.articleRepository = &ArticleRepo{
+		store: r,
+	}
+
+	return r.articleRepository
+}
+
+func (r *Repository) ArticleRevision() repo.ArticleRevisionRepository {
+	if r.revisionRepository != nil {
+		return r.revisionRepository
+	}
+
+	r.revisionRepository = &ArticleRevisionRepo{
+		store: r,
+	}
+
+	return r.revisionRepository
+}
+
+func (r *Repository) Search() repo.SearchRepository {
+	if r.searchRepository != nil {
+		return r.searchRepository
+	}
+
+	r.searchRepository = &SearchRepo{
+		store: r,
+	}
+
+	return r.searchRepository
+}
+
+func (r *Repository) Space() repo.SpaceRepository {
+	if r.spaceRepository != nil {
+		return r.spaceRepository
+	}
+
+	r.spaceRepository = &SpaceRepo{
+		store: r,
+	}
+
+	return r.spaceRepository
+}
+
+func (r *Repository) PageTree() repo.PageTreeRepository {
+	if r.pageTreeRepository != nil {
+		return r.pageTreeRepository
+	}
+
+	r.pageTreeRepository = &PageTreeRepo{
+		store: r,
+	}
+
+	return r.pageTreeRepository
+}
+
+func (r *Repository) SpaceMember() repo.SpaceMemberRepository {
+	if r.spaceMemberRepository != nil {
+		return r.spaceMemberRepository
+	}
+
+	r.spaceMemberRepository = &SpaceMemberRepo{
+		store: r,
+	}
+
+	return r.spaceMemberRepository
+}
+
+func (r *Repository) Tag() repo.TagRepository {
+	if r.tagRepository != nil {
+		return r.tagRepository
+	}
+
+	r.tagRepository = &TagRepo{
+		store: r,
+	}
+
+	return r.tagRepository
+}
+
+func (r *Repository) Comment() repo.CommentRepository {
+	if r.commentRepository != nil {
+		return r.commentRepository
+	}
+
+	r.commentRepository = &CommentRepo{
+		store: r,
+	}
+
+	return r.commentRepository
+}
+
+func (r *Repository) Attachment() repo.AttachmentRepository {
+	if r.attachmentRepository != nil {
+		return r.attachmentRepository
+	}
+
+	r.attachmentRepository = &AttachmentRepo{
+		store: r,
+	}
+
+	return r.attachmentRepository
+}
+
+func (r *Repository) Link() repo.LinkRepository {
+	if r.linkRepository != nil {
+		return r.linkRepository
+	}
+
+	r.linkRepository = &LinkRepo{
+		store: r,
+	}
+
+	return r.linkRepository
+}
+
 //... other
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"KnowledgeHub/migrations"
+	"KnowledgeHub/pkg/postgres"
+)
+
+// newTestRepository підключається до бази з TEST_PG_URL, накочує міграції
+// та очищає таблиці. Якщо змінна не задана - тест пропускається.
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+
+	url := os.Getenv("TEST_PG_URL")
+	if url == "" {
+		t.Skip("TEST_PG_URL is not set, skipping integration test")
+	}
+
+	pg, err := postgres.New(url, postgres.ConnAttempts(1))
+	if err != nil {
+		t.Fatalf("Failed to connect to postgres: %v", err)
+	}
+	t.Cleanup(pg.Close)
+
+	ctx := context.Background()
+
+	if err = pg.Migrate(ctx, migrations.FS); err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+
+	if _, err = pg.Pool.Exec(ctx, "TRUNCATE users, revoked_tokens, tags, blobs RESTART IDENTITY CASCADE"); err != nil {
+		t.Fatalf("Failed to truncate tables: %v", err)
+	}
+
+	return NewRepository(pg)
+}
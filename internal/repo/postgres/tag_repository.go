@@ -0,0 +1,270 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	_tagsTable        = "tags"
+	_articleTagsTable = "article_tags"
+)
+
+// _tagArticleCount - кількість статей з тегом для запитів, що вибирають один тег
+const _tagArticleCount = "(SELECT COUNT(*) FROM article_tags WHERE tag_id = tags.id) AS article_count"
+
+var _tagColumns = []string{"id", "name", "created_at", _tagArticleCount}
+
+type TagRepo struct {
+	store *Repository
+}
+
+func (t TagRepo) AddArticleTags(ctx context.Context, articleID uint, names []string) error {
+	err := pgx.BeginFunc(ctx, t.store.db.Pool, func(tx pgx.Tx) error {
+		_, execErr := tx.Exec(ctx,
+			"INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING", names,
+		)
+		if execErr != nil {
+			return execErr
+		}
+
+		_, execErr = tx.Exec(ctx,
+			"INSERT INTO article_tags (article_id, tag_id) SELECT $1, id FROM tags WHERE name = ANY($2) "+
+				"ON CONFLICT DO NOTHING",
+			articleID, names,
+		)
+
+		return execErr
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("TagRepo - AddArticleTags: %w", repo.ErrNotFound)
+		}
+
+		return fmt.Errorf("TagRepo - AddArticleTags - Tx: %w", err)
+	}
+
+	return nil
+}
+
+func (t TagRepo) RemoveArticleTag(ctx context.Context, articleID uint, name string) error {
+	sql, args, err := t.store.db.Builder.
+		Delete(_articleTagsTable).
+		Where(squirrel.Eq{"article_id": articleID}).
+		Where("tag_id = (SELECT id FROM tags WHERE name = ?)", name).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("TagRepo - RemoveArticleTag - Builder: %w", err)
+	}
+
+	tag, err := t.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("TagRepo - RemoveArticleTag - Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("TagRepo - RemoveArticleTag: %w", repo.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (t TagRepo) ListArticleTags(
+	ctx context.Context,
+	articleID uint,
+	page pagination.Params,
+) (*pagination.Page[*models.Tag], error) {
+	query := t.store.db.Builder.
+		Select("t.id", "t.name", "t.created_at", "0").
+		From(_tagsTable + " t").
+		Join(_articleTagsTable + " tg ON tg.tag_id = t.id").
+		Where(squirrel.Eq{"tg.article_id": articleID})
+
+	sql, args, err := repo.ArticleTagPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("TagRepo - ListArticleTags - Builder: %w", err)
+	}
+
+	tags, err := t.queryTags(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("TagRepo - ListArticleTags - Query: %w", err)
+	}
+
+	return repo.ArticleTagPages.Page(tags, page), nil
+}
+
+func (t TagRepo) ListTags(ctx context.Context, page pagination.Params) (*pagination.Page[*models.Tag], error) {
+	// Кількість статей - агрегат, тому фільтри й курсор TagPages застосовуються над згрупованою вибіркою
+	counted := squirrel.
+		Select("t.id", "t.name", "t.created_at", "COUNT(*) AS article_count").
+		From(_tagsTable + " t").
+		Join(_articleTagsTable + " tg ON tg.tag_id = t.id").
+		GroupBy("t.id")
+
+	query := t.store.db.Builder.
+		Select("id", "name", "created_at", "article_count").
+		FromSelect(counted, "t")
+
+	sql, args, err := repo.TagPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("TagRepo - ListTags - Builder: %w", err)
+	}
+
+	tags, err := t.queryTags(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("TagRepo - ListTags - Query: %w", err)
+	}
+
+	return repo.TagPages.Page(tags, page), nil
+}
+
+func (t TagRepo) GetTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	sql, args, err := t.store.db.Builder.
+		Select(_tagColumns...).
+		From(_tagsTable).
+		Where(squirrel.Eq{"name": name}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("TagRepo - GetTagByName - Builder: %w", err)
+	}
+
+	tag, err := scanTag(t.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("TagRepo - GetTagByName - QueryRow: %w", err)
+	}
+
+	return tag, nil
+}
+
+func (t TagRepo) RenameTag(ctx context.Context, name, newName string) (*models.Tag, error) {
+	sql, args, err := t.store.db.Builder.
+		Update(_tagsTable).
+		Set("name", newName).
+		Where(squirrel.Eq{"name": name}).
+		Suffix("RETURNING " + strings.Join(_tagColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("TagRepo - RenameTag - Builder: %w", err)
+	}
+
+	tag, err := scanTag(t.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("TagRepo - RenameTag: %w", repo.ErrNotFound)
+		}
+
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("TagRepo - RenameTag: %w", repo.ErrAlreadyExists)
+		}
+
+		return nil, fmt.Errorf("TagRepo - RenameTag - QueryRow: %w", err)
+	}
+
+	return tag, nil
+}
+
+func (t TagRepo) MergeTags(ctx context.Context, sources []string, target string) (*models.Tag, error) {
+	// Ціль не може бути одночасно джерелом - інакше її буде видалено
+	names := slices.DeleteFunc(slices.Clone(sources), func(name string) bool { return name == target })
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	merged := &models.Tag{}
+
+	err := pgx.BeginFunc(ctx, t.store.db.Pool, func(tx pgx.Tx) error {
+		// Блокування джерел у порядку id не дає паралельному злиттю їх змінити
+		tag, execErr := tx.Exec(ctx,
+			"SELECT id FROM tags WHERE name = ANY($1) ORDER BY id FOR UPDATE", names,
+		)
+		if execErr != nil {
+			return execErr
+		}
+
+		if tag.RowsAffected() != int64(len(names)) {
+			return repo.ErrNotFound
+		}
+
+		scanErr := tx.QueryRow(ctx,
+			"INSERT INTO tags (name) VALUES ($1) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name "+
+				"RETURNING id, name, created_at",
+			target,
+		).Scan(&merged.ID, &merged.Name, &merged.CreatedAt)
+		if scanErr != nil {
+			return scanErr
+		}
+
+		_, execErr = tx.Exec(ctx,
+			"INSERT INTO article_tags (article_id, tag_id) "+
+				"SELECT tg.article_id, $1 FROM article_tags tg JOIN tags t ON t.id = tg.tag_id WHERE t.name = ANY($2) "+
+				"ON CONFLICT DO NOTHING",
+			merged.ID, names,
+		)
+		if execErr != nil {
+			return execErr
+		}
+
+		// Зв'язки джерел видаляються каскадно
+		if _, execErr = tx.Exec(ctx, "DELETE FROM tags WHERE name = ANY($1)", names); execErr != nil {
+			return execErr
+		}
+
+		return tx.QueryRow(ctx,
+			"SELECT COUNT(*) FROM article_tags WHERE tag_id = $1", merged.ID,
+		).Scan(&merged.ArticleCount)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, fmt.Errorf("TagRepo - MergeTags: %w", err)
+		}
+
+		return nil, fmt.Errorf("TagRepo - MergeTags - Tx: %w", err)
+	}
+
+	return merged, nil
+}
+
+func (t TagRepo) queryTags(ctx context.Context, sql string, args ...any) ([]*models.Tag, error) {
+	rows, err := t.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]*models.Tag, 0)
+
+	for rows.Next() {
+		tag, scanErr := scanTag(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func scanTag(row pgx.Row) (*models.Tag, error) {
+	tag := &models.Tag{}
+
+	err := row.Scan(&tag.ID, &tag.Name, &tag.CreatedAt, &tag.ArticleCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
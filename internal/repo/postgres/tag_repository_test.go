@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestTagRepo(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	author := &models.User{Username: "author", Email: "author@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, author); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	first := newTaggedArticle(t, store, author.ID, "first")
+	second := newTaggedArticle(t, store, author.ID, "second")
+
+	tags := store.Tag()
+
+	if err := tags.AddArticleTags(ctx, first.ID, []string{"go", "k8s"}); err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	// Повторне додавання не дублює зв'язки
+	if err := tags.AddArticleTags(ctx, second.ID, []string{"go", "go_lang", "go"}); err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	if err := tags.AddArticleTags(ctx, first.ID+100, []string{"go"}); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown article, got %v", err)
+	}
+
+	popular, err := tags.ListTags(ctx, pagination.Params{})
+	if err != nil || len(popular.Items) != 3 || popular.Items[0].Name != "go" || popular.Items[0].ArticleCount != 2 {
+		t.Fatalf("ListTags() = %v, %v", popular, err)
+	}
+
+	// Курсор сторінки популярних тегів містить агрегат article_count
+	params, err := repo.TagPages.Params(pagination.Query{Limit: 1})
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	page, err := tags.ListTags(ctx, params)
+	if err != nil || len(page.Items) != 1 || page.NextCursor == "" {
+		t.Fatalf("ListTags(limit) = %+v, %v", page, err)
+	}
+
+	if params, err = repo.TagPages.Params(pagination.Query{Limit: 1, Cursor: page.NextCursor}); err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	if page, err = tags.ListTags(ctx, params); err != nil || len(page.Items) != 1 || page.Items[0].Name != "go_lang" {
+		t.Errorf("ListTags(cursor) = %+v, %v", page, err)
+	}
+
+	// '_' у префіксі порівнюється буквально, а не як будь-який символ
+	suggested, err := tags.ListTags(ctx, pagination.Params{Filters: []pagination.Filter{
+		{Field: "name", Op: pagination.Prefix, Values: []any{"go_"}},
+	}})
+	if err != nil || len(suggested.Items) != 1 || suggested.Items[0].Name != "go_lang" {
+		t.Errorf("ListTags(prefix) = %v, %v", suggested, err)
+	}
+
+	list, err := store.Article().ListArticles(ctx, models.ArticleFilter{Tag: "k8s"}, pagination.Params{})
+	if err != nil || len(list.Items) != 1 || list.Items[0].ID != first.ID {
+		t.Errorf("ListArticles(tag) = %+v, %v", list, err)
+	}
+
+	if _, err = tags.RenameTag(ctx, "k8s", "go"); !errors.Is(err, repo.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists, got %v", err)
+	}
+
+	renamed, err := tags.RenameTag(ctx, "k8s", "kubernetes")
+	if err != nil || renamed.Name != "kubernetes" || renamed.ArticleCount != 1 {
+		t.Fatalf("RenameTag() = %v, %v", renamed, err)
+	}
+
+	if _, err = tags.MergeTags(ctx, []string{"go_lang", "missing"}, "go"); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown source, got %v", err)
+	}
+
+	merged, err := tags.MergeTags(ctx, []string{"go_lang", "kubernetes"}, "golang")
+	if err != nil || merged.ArticleCount != 2 {
+		t.Fatalf("MergeTags() = %v, %v", merged, err)
+	}
+
+	got, err := tags.ListArticleTags(ctx, first.ID, pagination.Params{})
+	if err != nil || len(got.Items) != 2 || got.Items[0].Name != "go" || got.Items[1].Name != "golang" {
+		t.Errorf("ListArticleTags() = %v, %v", got, err)
+	}
+
+	if source, _ := tags.GetTagByName(ctx, "go_lang"); source != nil {
+		t.Errorf("Expected merged source to be deleted, got %v", source)
+	}
+
+	if err = tags.RemoveArticleTag(ctx, first.ID, "golang"); err != nil {
+		t.Fatalf("RemoveArticleTag() error = %v", err)
+	}
+
+	if err = tags.RemoveArticleTag(ctx, first.ID, "golang"); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func newTaggedArticle(t *testing.T, store *Repository, authorID uint, slug string) *models.Article {
+	t.Helper()
+
+	article := &models.Article{
+		Title:     slug,
+		Slug:      slug,
+		AuthorID:  authorID,
+		UpdatedBy: authorID,
+		Status:    models.ArticleStatusDraft,
+		Language:  models.ArticleLanguageDefault,
+	}
+
+	if err := store.Article().CreateArticle(context.Background(), article); err != nil {
+		t.Fatalf("CreateArticle() error = %v", err)
+	}
+
+	return article
+}
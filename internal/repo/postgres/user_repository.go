@@ -1,27 +1,243 @@
 package postgres
 
-import "KnowledgeHub/internal/models"
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _usersTable = "users"
+
+var _userColumns = []string{
+	"id", "username", "email", "role", "password_hash", "email_verified_at", "created_at", "updated_at", "deleted_at",
+}
 
 type UserRepo struct {
 	store *Repository
 }
 
-func (u UserRepo) CreateUser() error {
-	//TODO implement me
-	panic("implement me")
+func (u UserRepo) CreateUser(ctx context.Context, user *models.User) error {
+	if user.Role == "" {
+		user.Role = models.RoleDefault
+	}
+
+	sql, args, err := u.store.db.Builder.
+		Insert(_usersTable).
+		Columns("username", "email", "role", "password_hash").
+		Values(user.Username, user.Email, user.Role, user.PasswordHash).
+		Suffix("RETURNING id, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("UserRepo - CreateUser - Builder: %w", err)
+	}
+
+	err = u.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("UserRepo - CreateUser: %w", repo.ErrAlreadyExists)
+		}
+
+		return fmt.Errorf("UserRepo - CreateUser - QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+func (u UserRepo) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	return u.getUser(ctx, squirrel.Eq{"id": id})
+}
+
+func (u UserRepo) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	return u.getUser(ctx, squirrel.Expr("LOWER(username) = LOWER(?)", username))
+}
+
+func (u UserRepo) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return u.getUser(ctx, squirrel.Expr("LOWER(email) = LOWER(?)", email))
+}
+
+func (u UserRepo) UpdateUser(ctx context.Context, user *models.User) error {
+	sql, args, err := u.store.db.Builder.
+		Update(_usersTable).
+		Set("username", user.Username).
+		Set("email", user.Email).
+		Set("password_hash", user.PasswordHash).
+		Set("email_verified_at", squirrel.Expr("CASE WHEN LOWER(email) = LOWER(?) THEN email_verified_at END", user.Email)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": user.ID, "deleted_at": nil}).
+		Suffix("RETURNING email_verified_at, updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("UserRepo - UpdateUser - Builder: %w", err)
+	}
+
+	err = u.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&user.EmailVerifiedAt, &user.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return fmt.Errorf("UserRepo - UpdateUser: %w", repo.ErrNotFound)
+		case isUniqueViolation(err):
+			return fmt.Errorf("UserRepo - UpdateUser: %w", repo.ErrAlreadyExists)
+		default:
+			return fmt.Errorf("UserRepo - UpdateUser - QueryRow: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u UserRepo) MarkEmailVerified(ctx context.Context, id uint, email string) error {
+	sql, args, err := u.store.db.Builder.
+		Update(_usersTable).
+		Set("email_verified_at", squirrel.Expr("COALESCE(email_verified_at, NOW())")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
+		Where(squirrel.Expr("LOWER(email) = LOWER(?)", email)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("UserRepo - MarkEmailVerified - Builder: %w", err)
+	}
+
+	tag, err := u.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("UserRepo - MarkEmailVerified - Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("UserRepo - MarkEmailVerified: %w", repo.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (u UserRepo) SetUserRole(ctx context.Context, id uint, role models.Role) error {
+	sql, args, err := u.store.db.Builder.
+		Update(_usersTable).
+		Set("role", role).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("UserRepo - SetUserRole - Builder: %w", err)
+	}
+
+	tag, err := u.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("UserRepo - SetUserRole - Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("UserRepo - SetUserRole: %w", repo.ErrNotFound)
+	}
+
+	return nil
 }
 
-func (u UserRepo) GetUserByID(_ uint) (*models.User, error) {
-	//TODO implement me
-	panic("implement me")
+// DeleteUser виконує soft delete: рядок лишається в таблиці з заповненим deleted_at.
+func (u UserRepo) DeleteUser(ctx context.Context, id uint) error {
+	sql, args, err := u.store.db.Builder.
+		Update(_usersTable).
+		Set("deleted_at", squirrel.Expr("NOW()")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("UserRepo - DeleteUser - Builder: %w", err)
+	}
+
+	tag, err := u.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("UserRepo - DeleteUser - Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("UserRepo - DeleteUser: %w", repo.ErrNotFound)
+	}
+
+	return nil
 }
 
-func (u UserRepo) UpdateUser() error {
-	//TODO implement me
-	panic("implement me")
+func (u UserRepo) ListUsers(ctx context.Context, page pagination.Params) (*pagination.Page[*models.User], error) {
+	query := u.store.db.Builder.
+		Select(_userColumns...).
+		From(_usersTable).
+		Where(squirrel.Eq{"deleted_at": nil})
+
+	sql, args, err := repo.UserPages.Apply(query, page).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - ListUsers - Builder: %w", err)
+	}
+
+	rows, err := u.store.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - ListUsers - Query: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*models.User, 0)
+
+	for rows.Next() {
+		user, scanErr := scanUser(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("UserRepo - ListUsers - Scan: %w", scanErr)
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("UserRepo - ListUsers - rows.Err: %w", err)
+	}
+
+	return repo.UserPages.Page(users, page), nil
 }
 
-func (u UserRepo) DeleteUser(_ int) error {
-	//TODO implement me
-	panic("implement me")
+func (u UserRepo) getUser(ctx context.Context, pred squirrel.Sqlizer) (*models.User, error) {
+	sql, args, err := u.store.db.Builder.
+		Select(_userColumns...).
+		From(_usersTable).
+		Where(pred).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - getUser - Builder: %w", err)
+	}
+
+	user, err := scanUser(u.store.db.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("UserRepo - getUser - QueryRow: %w", err)
+	}
+
+	return user, nil
+}
+
+func scanUser(row pgx.Row) (*models.User, error) {
+	user := &models.User{}
+
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.Role,
+		&user.PasswordHash,
+		&user.EmailVerifiedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
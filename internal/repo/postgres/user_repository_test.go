@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestUserRepo_CRUD(t *testing.T) {
+	store := newTestRepository(t)
+	users := store.User()
+	ctx := context.Background()
+
+	user := &models.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "hash",
+	}
+
+	if err := users.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	if user.ID == 0 {
+		t.Fatal("Expected user ID to be set after create")
+	}
+
+	got, err := users.GetUserByUsername(ctx, "TestUser")
+	if err != nil || got == nil {
+		t.Fatalf("GetUserByUsername() = %v, %v", got, err)
+	}
+
+	if got.ID != user.ID {
+		t.Errorf("Expected user ID %d, got %d", user.ID, got.ID)
+	}
+
+	got, err = users.GetUserByEmail(ctx, "TEST@example.com")
+	if err != nil || got == nil || got.ID != user.ID {
+		t.Fatalf("GetUserByEmail() = %v, %v", got, err)
+	}
+
+	user.Email = "updated@example.com"
+	if err = users.UpdateUser(ctx, user); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	got, err = users.GetUserByID(ctx, user.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetUserByID() = %v, %v", got, err)
+	}
+
+	if got.Email != "updated@example.com" {
+		t.Errorf("Expected email 'updated@example.com', got '%s'", got.Email)
+	}
+
+	params, err := repo.UserPages.Params(pagination.Query{Filter: []string{"username:prefix:test"}})
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	list, err := users.ListUsers(ctx, params)
+	if err != nil || len(list.Items) != 1 || list.Items[0].ID != user.ID {
+		t.Errorf("ListUsers(prefix) = %+v, %v", list, err)
+	}
+
+	if err = users.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+
+	got, err = users.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID() after delete error = %v", err)
+	}
+
+	if got != nil {
+		t.Error("Expected soft-deleted user to be hidden")
+	}
+
+	list, err = users.ListUsers(ctx, pagination.Params{})
+	if err != nil || len(list.Items) != 0 {
+		t.Errorf("ListUsers() after delete = %+v, %v", list, err)
+	}
+
+	if err = users.DeleteUser(ctx, user.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestUserRepo_CreateUser_Duplicate(t *testing.T) {
+	store := newTestRepository(t)
+	users := store.User()
+	ctx := context.Background()
+
+	first := &models.User{Username: "dup", Email: "dup@example.com", PasswordHash: "hash"}
+	if err := users.CreateUser(ctx, first); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	second := &models.User{Username: "DUP", Email: "other@example.com", PasswordHash: "hash"}
+	if err := users.CreateUser(ctx, second); !errors.Is(err, repo.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists, got %v", err)
+	}
+
+	// Після soft delete ім'я знову доступне
+	if err := users.DeleteUser(ctx, first.ID); err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+
+	if err := users.CreateUser(ctx, second); err != nil {
+		t.Errorf("Expected username to be reusable after delete, got %v", err)
+	}
+}
+
+func TestUserRepo_MarkEmailVerified(t *testing.T) {
+	store := newTestRepository(t)
+	users := store.User()
+	ctx := context.Background()
+
+	user := &models.User{Username: "testuser", Email: "test@example.com", PasswordHash: "hash"}
+	if err := users.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	// Токен виданий на іншу адресу не підтверджує поточну
+	if err := users.MarkEmailVerified(ctx, user.ID, "old@example.com"); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for another email, got %v", err)
+	}
+
+	if err := users.MarkEmailVerified(ctx, user.ID, "TEST@example.com"); err != nil {
+		t.Fatalf("MarkEmailVerified() error = %v", err)
+	}
+
+	got, err := users.GetUserByID(ctx, user.ID)
+	if err != nil || got == nil || got.EmailVerifiedAt == nil {
+		t.Fatalf("Expected email to be verified, got %+v, %v", got, err)
+	}
+
+	// Зміна пароля зберігає підтвердження, зміна email - знімає
+	got.PasswordHash = "new-hash"
+	if err = users.UpdateUser(ctx, got); err != nil || got.EmailVerifiedAt == nil {
+		t.Fatalf("Expected verification to survive password change, got %v, %v", got.EmailVerifiedAt, err)
+	}
+
+	got.Email = "new@example.com"
+	if err = users.UpdateUser(ctx, got); err != nil || got.EmailVerifiedAt != nil {
+		t.Errorf("Expected email change to reset verification, got %v, %v", got.EmailVerifiedAt, err)
+	}
+}
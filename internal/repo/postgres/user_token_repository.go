@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const _userTokensTable = "user_tokens"
+
+type UserTokenRepo struct {
+	store *Repository
+}
+
+func (r UserTokenRepo) CreateUserToken(ctx context.Context, token *models.UserToken) error {
+	sql, args, err := r.store.db.Builder.
+		Insert(_userTokensTable).
+		Columns("user_id", "purpose", "token_hash", "expires_at").
+		Values(token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("UserTokenRepo - CreateUserToken - Builder: %w", err)
+	}
+
+	err = r.store.db.Pool.QueryRow(ctx, sql, args...).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("UserTokenRepo - CreateUserToken - QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+func (r UserTokenRepo) ConsumeUserToken(
+	ctx context.Context,
+	purpose models.TokenPurpose,
+	hash string,
+) (*models.UserToken, error) {
+	sql, args, err := r.store.db.Builder.
+		Update(_userTokensTable).
+		Set("used_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"token_hash": hash, "purpose": purpose, "used_at": nil}).
+		Where(squirrel.Expr("expires_at > NOW()")).
+		Suffix("RETURNING id, user_id, purpose, token_hash, expires_at, used_at, created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("UserTokenRepo - ConsumeUserToken - Builder: %w", err)
+	}
+
+	token := &models.UserToken{}
+
+	err = r.store.db.Pool.QueryRow(ctx, sql, args...).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("UserTokenRepo - ConsumeUserToken - QueryRow: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r UserTokenRepo) InvalidateUserTokens(ctx context.Context, userID uint, purpose models.TokenPurpose) error {
+	sql, args, err := r.store.db.Builder.
+		Update(_userTokensTable).
+		Set("used_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"user_id": userID, "purpose": purpose, "used_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("UserTokenRepo - InvalidateUserTokens - Builder: %w", err)
+	}
+
+	_, err = r.store.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("UserTokenRepo - InvalidateUserTokens - Exec: %w", err)
+	}
+
+	return nil
+}
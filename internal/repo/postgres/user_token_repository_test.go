@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"KnowledgeHub/internal/models"
+)
+
+func TestUserTokenRepo_Consume(t *testing.T) {
+	store := newTestRepository(t)
+	ctx := context.Background()
+
+	user := &models.User{Username: "testuser", Email: "test@example.com", PasswordHash: "hash"}
+	if err := store.User().CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	tokens := store.UserToken()
+
+	create := func(hash string, expiresAt time.Time) {
+		t.Helper()
+
+		token := &models.UserToken{
+			UserID:    user.ID,
+			Purpose:   models.TokenPurposePasswordReset,
+			TokenHash: hash,
+			ExpiresAt: expiresAt,
+		}
+
+		if err := tokens.CreateUserToken(ctx, token); err != nil {
+			t.Fatalf("CreateUserToken() error = %v", err)
+		}
+	}
+
+	const (
+		valid   = "0000000000000000000000000000000000000000000000000000000000000001"
+		expired = "0000000000000000000000000000000000000000000000000000000000000002"
+		other   = "0000000000000000000000000000000000000000000000000000000000000003"
+	)
+
+	create(valid, time.Now().Add(time.Hour))
+	create(expired, time.Now().Add(-time.Minute))
+	create(other, time.Now().Add(time.Hour))
+
+	got, err := tokens.ConsumeUserToken(ctx, models.TokenPurposeEmailVerification, valid)
+	if err != nil || got != nil {
+		t.Fatalf("Expected token of another purpose to be rejected, got %+v, %v", got, err)
+	}
+
+	got, err = tokens.ConsumeUserToken(ctx, models.TokenPurposePasswordReset, valid)
+	if err != nil || got == nil || got.UserID != user.ID || got.UsedAt == nil {
+		t.Fatalf("ConsumeUserToken() = %+v, %v", got, err)
+	}
+
+	if got, err = tokens.ConsumeUserToken(ctx, models.TokenPurposePasswordReset, valid); err != nil || got != nil {
+		t.Errorf("Expected token to be single-use, got %+v, %v", got, err)
+	}
+
+	if got, err = tokens.ConsumeUserToken(ctx, models.TokenPurposePasswordReset, expired); err != nil || got != nil {
+		t.Errorf("Expected expired token to be rejected, got %+v, %v", got, err)
+	}
+
+	if err = tokens.InvalidateUserTokens(ctx, user.ID, models.TokenPurposePasswordReset); err != nil {
+		t.Fatalf("InvalidateUserTokens() error = %v", err)
+	}
+
+	if got, err = tokens.ConsumeUserToken(ctx, models.TokenPurposePasswordReset, other); err != nil || got != nil {
+		t.Errorf("Expected invalidated token to be rejected, got %+v, %v", got, err)
+	}
+}
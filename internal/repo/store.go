@@ -1,16 +1,272 @@
 package repo
 
-import "KnowledgeHub/internal/models"
+import (
+	"context"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/pkg/pagination"
+)
 
 // Repository implement from interface Store
 type Store interface {
 	User() UserRepository
+	RefreshToken() RefreshTokenRepository
+	RevokedToken() RevokedTokenRepository
+	LoginLockout() LoginLockoutRepository
+	UserToken() UserTokenRepository
+	Article() ArticleRepository
+	ArticleRevision() ArticleRevisionRepository
+	Search() SearchRepository
+	Space() SpaceRepository
+	PageTree() PageTreeRepository
+	SpaceMember() SpaceMemberRepository
+	Tag() TagRepository
+	Comment() CommentRepository
+	Attachment() AttachmentRepository
+	Link() LinkRepository
 	//... other entity
 }
 
+// UserRepository - сховище користувачів.
+// Методи Get* повертають (nil, nil), якщо активного користувача не знайдено.
 type UserRepository interface {
-	CreateUser() error
-	GetUserByID(id uint) (*models.User, error)
-	UpdateUser() error
-	DeleteUser(id int) error
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	// UpdateUser зберігає username, email та хеш пароля; зміна email знімає його підтвердження.
+	UpdateUser(ctx context.Context, user *models.User) error
+	// MarkEmailVerified підтверджує email користувача, якщо він досі дорівнює email.
+	// Повертає ErrNotFound, якщо користувача немає або email вже змінився.
+	MarkEmailVerified(ctx context.Context, id uint, email string) error
+	// SetUserRole змінює глобальну роль; повертає ErrNotFound для невідомого користувача.
+	SetUserRole(ctx context.Context, id uint, role models.Role) error
+	DeleteUser(ctx context.Context, id uint) error
+	// ListUsers повертає сторінку активних користувачів за UserPages.
+	ListUsers(ctx context.Context, page pagination.Params) (*pagination.Page[*models.User], error)
+}
+
+// RefreshTokenRepository - сховище хешів виданих refresh токенів.
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	// GetRefreshTokenByHash повертає (nil, nil), якщо токен не знайдено.
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error)
+	// MarkRefreshTokenUsed атомарно позначає токен використаним.
+	// Повертає false, якщо токен вже був використаний або відкликаний.
+	MarkRefreshTokenUsed(ctx context.Context, id uint) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uint) error
+}
+
+// RevokedTokenRepository - denylist відкликаних access токенів.
+type RevokedTokenRepository interface {
+	// RevokeToken додає jti до denylist до моменту expiresAt.
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeUserTokens відкликає всі токени користувача, видані не пізніше за before.
+	// before зберігається з повною точністю, тож токен, виданий у ту ж секунду до
+	// відкликання, теж стає недійсним.
+	RevokeUserTokens(ctx context.Context, userID uint, before time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string, userID uint, issuedAt time.Time) (bool, error)
+}
+
+// LoginLockoutRepository - лічильники невдалих спроб входу та блокування за username.
+// Ключ - нормалізований username, незалежно від того, чи існує такий користувач.
+type LoginLockoutRepository interface {
+	// GetLoginLockout повертає (nil, nil), якщо невдалих спроб не було.
+	GetLoginLockout(ctx context.Context, username string) (*models.LoginLockout, error)
+	// RecordLoginFailure атомарно збільшує лічильник і повертає нове значення.
+	RecordLoginFailure(ctx context.Context, username string) (int, error)
+	LockLogin(ctx context.Context, username string, until time.Time) error
+	// ResetLoginFailures видаляє лічильник і блокування після успішного входу.
+	ResetLoginFailures(ctx context.Context, username string) error
+}
+
+// UserTokenRepository - хеші одноразових токенів дій (підтвердження email, скидання пароля).
+type UserTokenRepository interface {
+	CreateUserToken(ctx context.Context, token *models.UserToken) error
+	// ConsumeUserToken атомарно позначає дійсний невикористаний токен використаним і повертає його.
+	// Повертає (nil, nil), якщо токен не знайдено, вже використано або строк дії минув.
+	ConsumeUserToken(ctx context.Context, purpose models.TokenPurpose, hash string) (*models.UserToken, error)
+	// InvalidateUserTokens позначає використаними всі невикористані токени користувача для purpose.
+	InvalidateUserTokens(ctx context.Context, userID uint, purpose models.TokenPurpose) error
+}
+
+// ArticleRepository - сховище статей бази знань.
+// Методи Get* повертають (nil, nil), якщо статтю не знайдено.
+// CreateArticle та UpdateArticle в тій самій транзакції записують ревізію
+// від імені article.UpdatedBy і замінюють wiki-посилання статті на article.Links;
+// UpdateArticle збільшує article.Version.
+// CreateArticle ставить статтю останньою серед сусідів; UpdateArticle не змінює
+// її місце в дереві - для цього є PageTreeRepository.MovePage.
+// DeleteArticle повертає ErrInUse, якщо у статті є дочірні сторінки.
+type ArticleRepository interface {
+	CreateArticle(ctx context.Context, article *models.Article) error
+	GetArticleByID(ctx context.Context, id uint) (*models.Article, error)
+	GetArticleBySlug(ctx context.Context, slug string) (*models.Article, error)
+	// ListArticles повертає сторінку статей за ArticlePages; нульові Params - всі статті.
+	ListArticles(
+		ctx context.Context,
+		filter models.ArticleFilter,
+		page pagination.Params,
+	) (*pagination.Page[*models.Article], error)
+	UpdateArticle(ctx context.Context, article *models.Article) error
+	DeleteArticle(ctx context.Context, id uint) error
+}
+
+// ArticleRevisionRepository - історія ревізій статей; записи лише додаються.
+type ArticleRevisionRepository interface {
+	// ListRevisions повертає сторінку ревізій статті за RevisionPages, за замовчуванням від найновішої.
+	ListRevisions(
+		ctx context.Context,
+		articleID uint,
+		page pagination.Params,
+	) (*pagination.Page[*models.ArticleRevision], error)
+	// GetRevision повертає (nil, nil), якщо ревізію не знайдено.
+	GetRevision(ctx context.Context, articleID uint, version int) (*models.ArticleRevision, error)
+}
+
+// SearchRepository - повнотекстовий пошук статей.
+type SearchRepository interface {
+	// SearchArticles повертає сторінку результатів за SearchPages (за замовчуванням
+	// від найрелевантніших) та загальну кількість збігів (0, якщо сторінка порожня).
+	SearchArticles(
+		ctx context.Context,
+		query models.SearchQuery,
+		page pagination.Params,
+	) (*pagination.Page[*models.SearchHit], int, error)
+}
+
+// SpaceRepository - сховище просторів.
+// Методи Get* повертають (nil, nil), якщо простір не знайдено.
+// CreateSpace в тій самій транзакції додає space.CreatedBy учасником з роллю admin.
+type SpaceRepository interface {
+	CreateSpace(ctx context.Context, space *models.Space) error
+	GetSpaceByID(ctx context.Context, id uint) (*models.Space, error)
+	GetSpaceByKey(ctx context.Context, key string) (*models.Space, error)
+	// ListSpaces повертає сторінку просторів за SpacePages; нульові Params - всі простори.
+	ListSpaces(ctx context.Context, page pagination.Params) (*pagination.Page[*models.Space], error)
+	UpdateSpace(ctx context.Context, space *models.Space) error
+	// DeleteSpace повертає ErrInUse, якщо у просторі є статті.
+	DeleteSpace(ctx context.Context, id uint) error
+}
+
+// PageTreeRepository - структура сторінок у просторах.
+type PageTreeRepository interface {
+	// ListSpacePages повертає всі сторінки простору плоским списком,
+	// впорядкованим за position серед сусідів.
+	ListSpacePages(ctx context.Context, spaceID uint) ([]*models.PageNode, error)
+	// GetPageAncestors повертає предків сторінки від кореня простору до батька.
+	GetPageAncestors(ctx context.Context, articleID uint) ([]*models.PageNode, error)
+	// MovePage переносить сторінку разом з піддеревом і перенумеровує сусідів.
+	// Повертає ErrNotFound, якщо немає статті, простору чи батька в цьому просторі,
+	// та ErrCycle, якщо новий батько є нащадком сторінки.
+	MovePage(ctx context.Context, move models.PageMove) error
+}
+
+// SpaceMemberRepository - ролі користувачів у просторах.
+type SpaceMemberRepository interface {
+	// SetSpaceMember додає учасника або змінює його роль.
+	// Повертає ErrNotFound, якщо простору або користувача не існує.
+	SetSpaceMember(ctx context.Context, member *models.SpaceMember) error
+	// GetSpaceMember повертає (nil, nil), якщо користувач не є учасником простору.
+	GetSpaceMember(ctx context.Context, spaceID, userID uint) (*models.SpaceMember, error)
+	// ListSpaceMembers повертає сторінку учасників простору за SpaceMemberPages.
+	ListSpaceMembers(
+		ctx context.Context,
+		spaceID uint,
+		page pagination.Params,
+	) (*pagination.Page[*models.SpaceMember], error)
+	RemoveSpaceMember(ctx context.Context, spaceID, userID uint) error
+}
+
+// TagRepository - теги статей. Тег створюється при першому використанні;
+// теги без статей не потрапляють до ListTags.
+type TagRepository interface {
+	// AddArticleTags прив'язує теги до статті, вже прив'язані пропускаються.
+	// Повертає ErrNotFound, якщо статті не існує.
+	AddArticleTags(ctx context.Context, articleID uint, names []string) error
+	// RemoveArticleTag повертає ErrNotFound, якщо тег не прив'язаний до статті.
+	RemoveArticleTag(ctx context.Context, articleID uint, name string) error
+	// ListArticleTags повертає сторінку тегів статті за ArticleTagPages.
+	ListArticleTags(ctx context.Context, articleID uint, page pagination.Params) (*pagination.Page[*models.Tag], error)
+	// ListTags повертає сторінку тегів з кількістю статей за TagPages.
+	ListTags(ctx context.Context, page pagination.Params) (*pagination.Page[*models.Tag], error)
+	// GetTagByName повертає (nil, nil), якщо тег не знайдено.
+	GetTagByName(ctx context.Context, name string) (*models.Tag, error)
+	// RenameTag повертає ErrNotFound, якщо тегу немає, та ErrAlreadyExists,
+	// якщо нова назва зайнята - у цьому випадку теги слід об'єднати.
+	RenameTag(ctx context.Context, name, newName string) (*models.Tag, error)
+	// MergeTags в одній транзакції переносить статті з тегів sources на target
+	// (створюючи його за потреби) та видаляє sources.
+	// Повертає ErrNotFound, якщо будь-якого з sources не існує.
+	MergeTags(ctx context.Context, sources []string, target string) (*models.Tag, error)
+}
+
+// CommentRepository - коментарі до статей.
+// Методи Get* повертають (nil, nil), якщо коментар не знайдено; видалені коментарі повертаються.
+// Коментарі повертаються разом зі згадками; Create та Update зберігають
+// comment.Mentions за UserID, замінюючи попередній список.
+type CommentRepository interface {
+	// CreateComment повертає ErrNotFound, якщо статті або батьківського коментаря не існує.
+	CreateComment(ctx context.Context, comment *models.Comment) error
+	GetCommentByID(ctx context.Context, id uint) (*models.Comment, error)
+	// ListThreads повертає сторінку кореневих коментарів статті за CommentThreadPages,
+	// за замовчуванням від найстаріших.
+	ListThreads(
+		ctx context.Context,
+		articleID uint,
+		page pagination.Params,
+	) (*pagination.Page[*models.Comment], error)
+	// ListReplies повертає всі відповіді в гілках threadIDs від найстаріших.
+	ListReplies(ctx context.Context, threadIDs []uint) ([]*models.Comment, error)
+	// UpdateComment зберігає текст, згадки та стан вирішення.
+	// Повертає ErrNotFound для відсутнього або видаленого коментаря.
+	UpdateComment(ctx context.Context, comment *models.Comment) error
+	// DeleteComment м'яко видаляє коментар: очищує текст і згадки.
+	// Повертає ErrNotFound для відсутнього або вже видаленого коментаря.
+	DeleteComment(ctx context.Context, id uint) error
+}
+
+// BlobFunc виконує операцію з BlobStore над вмістом sha256, поки запис про нього заблоковано.
+type BlobFunc func(ctx context.Context, sha256 string) error
+
+// AttachmentRepository - вкладення статей та облік їхнього вмісту.
+// Вміст з однаковим SHA256 зберігається один раз; операції зі сховищем виконуються
+// через BlobFunc у транзакції під блокуванням запису вмісту, тому паралельні
+// завантаження й видалення однакових файлів не лишають вкладень без вмісту.
+type AttachmentRepository interface {
+	// CreateAttachment зберігає вкладення. Якщо вмісту attachment.SHA256 ще немає,
+	// реєструє його і викликає upload; помилка upload скасовує створення.
+	// Повертає ErrNotFound, якщо статті не існує.
+	CreateAttachment(ctx context.Context, attachment *models.Attachment, upload BlobFunc) error
+	// GetAttachmentByID повертає (nil, nil), якщо вкладення не знайдено.
+	GetAttachmentByID(ctx context.Context, id uint) (*models.Attachment, error)
+	// ListAttachments повертає сторінку вкладень статті за AttachmentPages.
+	ListAttachments(
+		ctx context.Context,
+		articleID uint,
+		page pagination.Params,
+	) (*pagination.Page[*models.Attachment], error)
+	// DeleteAttachment видаляє вкладення; якщо на його вміст більше ніхто не посилається,
+	// видаляє і вміст, викликаючи remove. Повертає ErrNotFound для відсутнього вкладення.
+	DeleteAttachment(ctx context.Context, id uint, remove BlobFunc) error
+	// DeleteOrphanBlobs видаляє до limit записів вмісту без вкладень (наприклад, після
+	// видалення статей), викликаючи remove для кожного, і повертає їх кількість.
+	DeleteOrphanBlobs(ctx context.Context, limit uint64, remove BlobFunc) (int, error)
+}
+
+// LinkRepository - граф wiki-посилань між статтями. Посилання записує ArticleRepository;
+// ціль зіставляється зі статтею за slug під час читання.
+type LinkRepository interface {
+	// ListArticleLinks повертає сторінку вихідних посилань статті за ArticleLinkPages.
+	ListArticleLinks(
+		ctx context.Context,
+		articleID uint,
+		page pagination.Params,
+	) (*pagination.Page[*models.ArticleLink], error)
+	// ListBacklinks повертає сторінку інших статей, що посилаються на articleID, за BacklinkPages.
+	ListBacklinks(ctx context.Context, articleID uint, page pagination.Params) (*pagination.Page[*models.Article], error)
+	// ListBrokenLinks повертає сторінку посилань на неіснуючі статті за BrokenLinkPages.
+	ListBrokenLinks(ctx context.Context, page pagination.Params) (*pagination.Page[*models.ArticleLink], error)
 }
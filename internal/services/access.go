@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+)
+
+var ErrForbidden = apperror.Forbidden("forbidden", "insufficient permissions")
+
+// AccessService визначає ефективну роль користувача: глобальну роль з токена,
+// підвищену роллю учасника простору, якщо дія стосується простору.
+type AccessService struct {
+	memberRepo  repo.SpaceMemberRepository
+	articleRepo repo.ArticleRepository
+}
+
+func NewAccessService(memberRepo repo.SpaceMemberRepository, articleRepo repo.ArticleRepository) *AccessService {
+	return &AccessService{
+		memberRepo:  memberRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// SpaceRole повертає вищу з глобальної ролі та ролі учасника простору
+func (s *AccessService) SpaceRole(
+	ctx context.Context,
+	userID uint,
+	role models.Role,
+	spaceID uint,
+) (models.Role, error) {
+	member, err := s.memberRepo.GetSpaceMember(ctx, spaceID, userID)
+	if err != nil {
+		return "", fmt.Errorf("AccessService - SpaceRole - GetSpaceMember: %w", err)
+	}
+
+	if member != nil {
+		role = role.Max(member.Role)
+	}
+
+	return role, nil
+}
+
+// Authorize повертає ErrForbidden, якщо роль не дозволяє permission.
+// Для spaceID == nil враховується лише глобальна роль.
+func (s *AccessService) Authorize(
+	ctx context.Context,
+	userID uint,
+	role models.Role,
+	permission models.Permission,
+	spaceID *uint,
+) error {
+	if spaceID != nil && !role.Can(permission) {
+		var err error
+		if role, err = s.SpaceRole(ctx, userID, role, *spaceID); err != nil {
+			return err
+		}
+	}
+
+	if !role.Can(permission) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// ArticleSpaceID повертає простір статті; nil - стаття поза простором або не існує
+func (s *AccessService) ArticleSpaceID(ctx context.Context, articleID uint) (*uint, error) {
+	article, err := s.articleRepo.GetArticleByID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("AccessService - ArticleSpaceID - GetArticleByID: %w", err)
+	}
+
+	if article == nil {
+		return nil, nil
+	}
+
+	return article.SpaceID, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+)
+
+func TestAccessService_Authorize(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "owner", Email: "owner@example.com"})
+	mockRepo.AddUser(&models.User{ID: 2, Username: "member", Email: "member@example.com"})
+
+	ctx := context.Background()
+
+	space := &models.Space{Key: "docs", Name: "Docs", CreatedBy: 1}
+	if err := mockRepo.Space().CreateSpace(ctx, space); err != nil {
+		t.Fatalf("CreateSpace() error = %v", err)
+	}
+
+	err := mockRepo.SpaceMember().SetSpaceMember(ctx, &models.SpaceMember{SpaceID: space.ID, UserID: 2, Role: models.RoleViewer})
+	if err != nil {
+		t.Fatalf("SetSpaceMember() error = %v", err)
+	}
+
+	service := NewAccessService(mockRepo.SpaceMember(), mockRepo.Article())
+	otherSpace := space.ID + 1
+
+	tests := []struct {
+		name       string
+		userID     uint
+		role       models.Role
+		permission models.Permission
+		spaceID    *uint
+		want       error
+	}{
+		{"global viewer reads", 2, models.RoleViewer, models.PermissionReadArticles, nil, nil},
+		{"global viewer cannot write", 2, models.RoleViewer, models.PermissionWriteArticles, nil, ErrForbidden},
+		{"space admin manages own space", 1, models.RoleViewer, models.PermissionManageSpaces, &space.ID, nil},
+		{"space admin only in own space", 1, models.RoleViewer, models.PermissionManageSpaces, &otherSpace, ErrForbidden},
+		{"membership never lowers global role", 2, models.RoleEditor, models.PermissionWriteArticles, &space.ID, nil},
+		{"global admin everywhere", 2, models.RoleAdmin, models.PermissionManageSpaces, &otherSpace, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.Authorize(ctx, tt.userID, tt.role, tt.permission, tt.spaceID)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Authorize() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
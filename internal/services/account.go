@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/mailer"
+)
+
+// Шляхи клієнта, що відкриваються посиланнями з листів; токен передається в ?token=
+const (
+	_verifyEmailPath   = "/verify-email"
+	_resetPasswordPath = "/reset-password"
+)
+
+var (
+	ErrEmailAlreadyVerified = apperror.Conflict("email_already_verified", "email is already verified")
+	ErrInvalidActionToken   = apperror.Validation("invalid_action_token",
+		"token is invalid, expired or has already been used")
+)
+
+// AccountConfig - параметри листів підтвердження email та скидання пароля
+type AccountConfig struct {
+	// LinkBaseURL - адреса клієнта, до якої додаються шляхи посилань з листів
+	LinkBaseURL      string
+	VerificationTTL  time.Duration
+	PasswordResetTTL time.Duration
+}
+
+// AccountService підтверджує email та відновлює доступ через одноразові токени,
+// надіслані листом. Токен підписаний JWTService, а в базі зберігається його хеш,
+// тому кожен токен можна використати лише раз.
+type AccountService struct {
+	jwtService     *JWTService
+	sessionService *SessionService
+	userRepo       repo.UserRepository
+	tokenRepo      repo.UserTokenRepository
+	lockoutRepo    repo.LoginLockoutRepository
+	mailer         mailer.Mailer
+	cfg            AccountConfig
+}
+
+func NewAccountService(
+	jwtService *JWTService,
+	sessionService *SessionService,
+	userRepo repo.UserRepository,
+	tokenRepo repo.UserTokenRepository,
+	lockoutRepo repo.LoginLockoutRepository,
+	mail mailer.Mailer,
+	cfg AccountConfig,
+) *AccountService {
+	return &AccountService{
+		jwtService:     jwtService,
+		sessionService: sessionService,
+		userRepo:       userRepo,
+		tokenRepo:      tokenRepo,
+		lockoutRepo:    lockoutRepo,
+		mailer:         mail,
+		cfg:            cfg,
+	}
+}
+
+// SendEmailVerification надсилає користувачу лист з посиланням підтвердження email
+func (s *AccountService) SendEmailVerification(ctx context.Context, user *models.User) error {
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	err := s.send(ctx, user, models.TokenPurposeEmailVerification, s.cfg.VerificationTTL,
+		_verifyEmailPath, _verifyEmailTemplate)
+	if err != nil {
+		return fmt.Errorf("AccountService - SendEmailVerification: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail підтверджує email за токеном з листа. Токен, виданий до зміни email,
+// недійсний.
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	claims, err := s.consume(ctx, models.TokenPurposeEmailVerification, token)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userRepo.MarkEmailVerified(ctx, claims.UserID, claims.Email)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrInvalidActionToken
+		}
+
+		return nil, fmt.Errorf("AccountService - VerifyEmail - MarkEmailVerified: %w", err)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("AccountService - VerifyEmail - GetUserByID: %w", err)
+	}
+
+	if user == nil {
+		return nil, ErrInvalidActionToken
+	}
+
+	return user, nil
+}
+
+// RequestPasswordReset надсилає лист зі скиданням пароля, якщо email належить користувачу.
+// Для невідомого email нічого не робить і не повертає помилки, щоб відповідь не
+// видавала, які адреси зареєстровані. Попередні посилання скидання стають недійсними.
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("AccountService - RequestPasswordReset - GetUserByEmail: %w", err)
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	err = s.tokenRepo.InvalidateUserTokens(ctx, user.ID, models.TokenPurposePasswordReset)
+	if err != nil {
+		return fmt.Errorf("AccountService - RequestPasswordReset - InvalidateUserTokens: %w", err)
+	}
+
+	err = s.send(ctx, user, models.TokenPurposePasswordReset, s.cfg.PasswordResetTTL,
+		_resetPasswordPath, _passwordResetTemplate)
+	if err != nil {
+		return fmt.Errorf("AccountService - RequestPasswordReset: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword встановлює новий пароль за токеном з листа і завершує всі сесії
+// користувача, зокрема відкриті тим, хто знав старий пароль.
+func (s *AccountService) ResetPassword(ctx context.Context, token, password string) error {
+	// Пароль перевіряється до використання токена, щоб помилка не спалила посилання
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+
+	claims, err := s.consume(ctx, models.TokenPurposePasswordReset, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return fmt.Errorf("AccountService - ResetPassword - GetUserByID: %w", err)
+	}
+
+	if user == nil {
+		return ErrInvalidActionToken
+	}
+
+	user.PasswordHash, err = HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("AccountService - ResetPassword - HashPassword: %w", err)
+	}
+
+	if err = s.userRepo.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("AccountService - ResetPassword - UpdateUser: %w", err)
+	}
+
+	err = s.tokenRepo.InvalidateUserTokens(ctx, user.ID, models.TokenPurposePasswordReset)
+	if err != nil {
+		return fmt.Errorf("AccountService - ResetPassword - InvalidateUserTokens: %w", err)
+	}
+
+	// Власник довів контроль над email, тож блокування входу після невдалих спроб знімається
+	if err = s.lockoutRepo.ResetLoginFailures(ctx, lockoutKey(user.Username)); err != nil {
+		return fmt.Errorf("AccountService - ResetPassword - ResetLoginFailures: %w", err)
+	}
+
+	if err = s.sessionService.EndAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("AccountService - ResetPassword - EndAllSessions: %w", err)
+	}
+
+	return nil
+}
+
+// send видає токен purpose, зберігає його хеш і надсилає лист template з посиланням на path
+func (s *AccountService) send(
+	ctx context.Context,
+	user *models.User,
+	purpose models.TokenPurpose,
+	ttl time.Duration,
+	path, template string,
+) error {
+	token, expiresAt, err := s.jwtService.GenerateActionToken(purpose, user.ID, user.Email, ttl)
+	if err != nil {
+		return fmt.Errorf("GenerateActionToken: %w", err)
+	}
+
+	err = s.tokenRepo.CreateUserToken(ctx, &models.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("CreateUserToken: %w", err)
+	}
+
+	msg, err := renderEmail(template, user.Email, emailData{
+		Username:  user.Username,
+		Link:      strings.TrimSuffix(s.cfg.LinkBaseURL, "/") + path + "?token=" + url.QueryEscape(token),
+		ExpiresIn: humanDuration(ttl),
+	})
+	if err != nil {
+		return fmt.Errorf("renderEmail: %w", err)
+	}
+
+	if err = s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("mailer.Send: %w", err)
+	}
+
+	return nil
+}
+
+// consume перевіряє підпис токена та атомарно позначає його використаним
+func (s *AccountService) consume(
+	ctx context.Context,
+	purpose models.TokenPurpose,
+	token string,
+) (*ActionClaims, error) {
+	claims, err := s.jwtService.ValidateActionToken(token, purpose)
+	if err != nil {
+		return nil, ErrInvalidActionToken
+	}
+
+	stored, err := s.tokenRepo.ConsumeUserToken(ctx, purpose, hashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("AccountService - consume - ConsumeUserToken: %w", err)
+	}
+
+	if stored == nil || stored.UserID != claims.UserID {
+		return nil, ErrInvalidActionToken
+	}
+
+	return claims, nil
+}
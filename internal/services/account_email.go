@@ -0,0 +1,118 @@
+package services
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"KnowledgeHub/pkg/mailer"
+)
+
+// Імена листів; кожен має шаблони <name>.subject, <name>.text та <name>.html
+const (
+	_verifyEmailTemplate   = "verify_email"
+	_passwordResetTemplate = "password_reset"
+)
+
+// emailData - дані для шаблонів листів
+type emailData struct {
+	Username string
+	Link     string
+	// ExpiresIn - строк дії посилання, наприклад "1 hour"
+	ExpiresIn string
+}
+
+// _emailTextTemplates - теми та текстові версії листів
+var _emailTextTemplates = template.Must(template.New("email").Parse(`
+{{define "verify_email.subject"}}Confirm your KnowledgeHub email address{{end}}
+
+{{define "verify_email.text"}}Hi {{.Username}},
+
+please confirm your email address by opening the link below:
+
+{{.Link}}
+
+The link is valid for {{.ExpiresIn}} and can be used once. If you did not create
+a KnowledgeHub account, you can ignore this email.
+{{end}}
+
+{{define "password_reset.subject"}}Reset your KnowledgeHub password{{end}}
+
+{{define "password_reset.text"}}Hi {{.Username}},
+
+we received a request to reset your password. Open the link below to choose a new one:
+
+{{.Link}}
+
+The link is valid for {{.ExpiresIn}} and can be used once. If you did not request
+a password reset, you can ignore this email; your password will not change.
+{{end}}
+`))
+
+// _emailHTMLTemplates - HTML версії листів
+var _emailHTMLTemplates = htmltemplate.Must(htmltemplate.New("email").Parse(`
+{{define "verify_email.html"}}<!DOCTYPE html>
+<html><head><meta charset="utf-8"></head>
+<body style="font:16px/1.5 system-ui,sans-serif;color:#1f2328">
+<p>Hi {{.Username}},</p>
+<p>please confirm your email address:</p>
+<p><a href="{{.Link}}">Confirm email</a></p>
+<p style="color:#59636e;font-size:14px">The link is valid for {{.ExpiresIn}} and can be used once.
+If you did not create a KnowledgeHub account, you can ignore this email.</p>
+</body></html>{{end}}
+
+{{define "password_reset.html"}}<!DOCTYPE html>
+<html><head><meta charset="utf-8"></head>
+<body style="font:16px/1.5 system-ui,sans-serif;color:#1f2328">
+<p>Hi {{.Username}},</p>
+<p>we received a request to reset your password:</p>
+<p><a href="{{.Link}}">Choose a new password</a></p>
+<p style="color:#59636e;font-size:14px">The link is valid for {{.ExpiresIn}} and can be used once.
+If you did not request a password reset, you can ignore this email; your password will not change.</p>
+</body></html>{{end}}
+`))
+
+// renderEmail збирає лист name з шаблонів для отримувача to
+func renderEmail(name, to string, data emailData) (mailer.Message, error) {
+	var subject, text, html bytes.Buffer
+
+	if err := _emailTextTemplates.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return mailer.Message{}, err
+	}
+
+	if err := _emailTextTemplates.ExecuteTemplate(&text, name+".text", data); err != nil {
+		return mailer.Message{}, err
+	}
+
+	if err := _emailHTMLTemplates.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return mailer.Message{}, err
+	}
+
+	return mailer.Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// humanDuration описує строк дії посилання для листа: "30 minutes", "1 hour", "2 days"
+func humanDuration(d time.Duration) string {
+	unit, n := "minute", int(d.Round(time.Minute)/time.Minute)
+
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		unit, n = "day", int(d/(24*time.Hour))
+	case d >= time.Hour && d%time.Hour == 0:
+		unit, n = "hour", int(d/time.Hour)
+	}
+
+	if n == 1 {
+		return "1 " + unit
+	}
+
+	return strconv.Itoa(n) + " " + unit + "s"
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/mailer"
+)
+
+// recordingMailer запам'ятовує надіслані листи замість надсилання
+type recordingMailer struct {
+	sent []mailer.Message
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+var _linkToken = regexp.MustCompile(`https://kb\.example\.com(/[a-z-]+)\?token=(\S+)`)
+
+// lastLink повертає шлях і токен з посилання в останньому листі
+func (m *recordingMailer) lastLink(t *testing.T) (string, string) {
+	t.Helper()
+
+	if len(m.sent) == 0 {
+		t.Fatal("Expected an email to be sent")
+	}
+
+	match := _linkToken.FindStringSubmatch(m.sent[len(m.sent)-1].Text)
+	if match == nil {
+		t.Fatalf("No link in email:\n%s", m.sent[len(m.sent)-1].Text)
+	}
+
+	token, err := url.QueryUnescape(match[2])
+	if err != nil {
+		t.Fatalf("Invalid token in link: %v", err)
+	}
+
+	return match[1], token
+}
+
+func getTestAccountService(t *testing.T) (*AccountService, *mocks.Mocks, *recordingMailer) {
+	t.Helper()
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: testUsername, Email: testEmail})
+
+	jwtService, err := NewJWTService(getTestConfig(), WithRevocationStore(mockRepo.RevokedToken()))
+	if err != nil {
+		t.Fatalf("Failed to create JWT service: %v", err)
+	}
+
+	sessionService := NewSessionService(jwtService, mockRepo.User(), mockRepo.RefreshToken())
+	mail := &recordingMailer{}
+
+	service := NewAccountService(jwtService, sessionService, mockRepo.User(), mockRepo.UserToken(), mockRepo.LoginLockout(), mail,
+		AccountConfig{
+			LinkBaseURL:      "https://kb.example.com/",
+			VerificationTTL:  48 * time.Hour,
+			PasswordResetTTL: time.Hour,
+		})
+
+	return service, mockRepo, mail
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	service, mockRepo, mail := getTestAccountService(t)
+	ctx := context.Background()
+
+	user, _ := mockRepo.User().GetUserByID(ctx, 1)
+	if err := service.SendEmailVerification(ctx, user); err != nil {
+		t.Fatalf("SendEmailVerification() error = %v", err)
+	}
+
+	msg := mail.sent[0]
+	if msg.To != testEmail || !strings.Contains(msg.Subject, "email") || !strings.Contains(msg.Text, "2 days") {
+		t.Errorf("Unexpected email %+v", msg)
+	}
+
+	path, token := mail.lastLink(t)
+	if path != "/verify-email" {
+		t.Errorf("Expected /verify-email link, got %s", path)
+	}
+
+	// Довільний рядок не є токеном
+	if _, err := service.VerifyEmail(ctx, "not-a-token"); !errors.Is(err, ErrInvalidActionToken) {
+		t.Errorf("Expected ErrInvalidActionToken for garbage, got %v", err)
+	}
+
+	verified, err := service.VerifyEmail(ctx, token)
+	if err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+
+	if verified.EmailVerifiedAt == nil {
+		t.Error("Expected email to be verified")
+	}
+
+	if _, err = service.VerifyEmail(ctx, token); !errors.Is(err, ErrInvalidActionToken) {
+		t.Errorf("Expected token to be single-use, got %v", err)
+	}
+
+	if err = service.SendEmailVerification(ctx, verified); !errors.Is(err, ErrEmailAlreadyVerified) {
+		t.Errorf("Expected ErrEmailAlreadyVerified, got %v", err)
+	}
+}
+
+func TestAccountService_VerifyEmail_EmailChanged(t *testing.T) {
+	service, mockRepo, mail := getTestAccountService(t)
+	ctx := context.Background()
+
+	user, _ := mockRepo.User().GetUserByID(ctx, 1)
+	if err := service.SendEmailVerification(ctx, user); err != nil {
+		t.Fatalf("SendEmailVerification() error = %v", err)
+	}
+
+	changed := *user
+	changed.Email = "new@example.com"
+
+	if err := mockRepo.User().UpdateUser(ctx, &changed); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	_, token := mail.lastLink(t)
+	if _, err := service.VerifyEmail(ctx, token); !errors.Is(err, ErrInvalidActionToken) {
+		t.Errorf("Expected token for the old email to be rejected, got %v", err)
+	}
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	service, mockRepo, mail := getTestAccountService(t)
+	ctx := context.Background()
+
+	// Невідомий email не дає помилки і листа
+	if err := service.RequestPasswordReset(ctx, "nobody@example.com"); err != nil || len(mail.sent) != 0 {
+		t.Fatalf("Expected silent success for unknown email, got %v and %d emails", err, len(mail.sent))
+	}
+
+	if err := service.RequestPasswordReset(ctx, strings.ToUpper(testEmail)); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+
+	_, first := mail.lastLink(t)
+
+	if err := service.RequestPasswordReset(ctx, testEmail); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+
+	path, token := mail.lastLink(t)
+	if path != "/reset-password" || !strings.Contains(mail.sent[1].Text, "1 hour") {
+		t.Errorf("Unexpected reset email:\n%s", mail.sent[1].Text)
+	}
+
+	// Задовгий пароль відхиляється, а посилання лишається дійсним
+	if err := service.ResetPassword(ctx, token, strings.Repeat("a", MaxPasswordBytes+1)); !errors.Is(err, ErrPasswordTooLong) {
+		t.Fatalf("Expected ErrPasswordTooLong, got %v", err)
+	}
+
+	// Новий запит скасовує попереднє посилання
+	if err := service.ResetPassword(ctx, first, "new-password"); !errors.Is(err, ErrInvalidActionToken) {
+		t.Errorf("Expected superseded token to be rejected, got %v", err)
+	}
+
+	sessions := service.sessionService
+	tokenPair, err := sessions.StartSession(ctx, &models.User{ID: 1, Username: testUsername, Email: testEmail})
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+
+	if err = service.ResetPassword(ctx, token, "new-password"); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	user, _ := mockRepo.User().GetUserByID(ctx, 1)
+	if ok, _ := CheckPassword(user.PasswordHash, "new-password"); !ok {
+		t.Error("Expected password to be changed")
+	}
+
+	if err = service.ResetPassword(ctx, token, "other-password"); !errors.Is(err, ErrInvalidActionToken) {
+		t.Errorf("Expected token to be single-use, got %v", err)
+	}
+
+	// Сесії, відкриті до скидання, завершено
+	if _, _, err = sessions.RefreshSession(ctx, tokenPair.RefreshToken); err == nil {
+		t.Error("Expected existing sessions to be ended after password reset")
+	}
+}
+
+func TestAccountService_ResetPasswordClearsLockout(t *testing.T) {
+	service, mockRepo, mail := getTestAccountService(t)
+	ctx := context.Background()
+
+	policy := LockoutPolicy{MaxFailures: 2, Duration: time.Hour}
+	users := NewUserService(mockRepo.User(), WithLockout(mockRepo.LoginLockout(), policy))
+
+	user, _ := mockRepo.User().GetUserByID(ctx, 1)
+	user.PasswordHash, _ = HashPassword("old-password")
+	if err := mockRepo.User().UpdateUser(ctx, user); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	for range policy.MaxFailures {
+		_, _ = users.Authenticate(ctx, testUsername, "wrong")
+	}
+
+	if _, err := users.Authenticate(ctx, testUsername, "wrong"); !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("Expected ErrAccountLocked before reset, got %v", err)
+	}
+
+	if err := service.RequestPasswordReset(ctx, testEmail); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+
+	_, token := mail.lastLink(t)
+	if err := service.ResetPassword(ctx, token, "new-password"); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	// Власник довів контроль над email і одразу входить з новим паролем
+	if _, err := users.Authenticate(ctx, testUsername, "new-password"); err != nil {
+		t.Errorf("Expected login after password reset, got %v", err)
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Minute:      "1 minute",
+		30 * time.Minute: "30 minutes",
+		time.Hour:        "1 hour",
+		90 * time.Minute: "90 minutes",
+		48 * time.Hour:   "2 days",
+	}
+
+	for d, want := range tests {
+		if got := humanDuration(d); got != want {
+			t.Errorf("humanDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
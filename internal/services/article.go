@@ -0,0 +1,412 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/pagination"
+)
+
+const (
+	_maxSlugLength   = 200
+	_maxSlugAttempts = 50
+)
+
+var (
+	ErrArticleNotFound      = apperror.NotFound("article_not_found", "article not found")
+	ErrSlugAlreadyExists    = apperror.Conflict("slug_exists", "article with this slug already exists")
+	ErrInvalidArticleStatus = apperror.Validation("invalid_article_status", "invalid article status")
+	ErrInvalidSlug          = apperror.Validation("invalid_slug", "invalid article slug")
+	ErrRevisionNotFound     = apperror.NotFound("revision_not_found", "article revision not found")
+	ErrUnsupportedLanguage  = apperror.Validation("unsupported_language", "unsupported article language")
+)
+
+// ArticleInput - дані для створення або оновлення статті.
+// Порожній Slug генерується з Title, порожні Status та Language означають draft
+// і simple при створенні та "без змін" при оновленні.
+// SpaceID та ParentID враховуються лише при створенні; якщо заданий лише ParentID,
+// стаття потрапляє в простір батька. Переміщення - через SpaceService.MovePage.
+type ArticleInput struct {
+	Title    string
+	Slug     string
+	Body     string
+	Status   models.ArticleStatus
+	Language string
+	SpaceID  *uint
+	ParentID *uint
+}
+
+type ArticleService struct {
+	articleRepo  repo.ArticleRepository
+	revisionRepo repo.ArticleRevisionRepository
+	spaceRepo    repo.SpaceRepository
+	access       *AccessService
+}
+
+func NewArticleService(
+	articleRepo repo.ArticleRepository,
+	revisionRepo repo.ArticleRevisionRepository,
+	spaceRepo repo.SpaceRepository,
+	access *AccessService,
+) *ArticleService {
+	return &ArticleService{
+		articleRepo:  articleRepo,
+		revisionRepo: revisionRepo,
+		spaceRepo:    spaceRepo,
+		access:       access,
+	}
+}
+
+// Create створює статтю від імені actor. Право писати статті перевіряється для простору,
+// куди потрапляє стаття (space_id або простір батьківської сторінки), тож редактор
+// простору може створювати в ньому сторінки й з глобальною роллю viewer.
+func (s *ArticleService) Create(ctx context.Context, actor Actor, input ArticleInput) (*models.Article, error) {
+	article, err := s.newArticle(ctx, actor.UserID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.access.Authorize(ctx, actor.UserID, actor.Role, models.PermissionWriteArticles, article.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.insert(ctx, article, input.Slug); err != nil {
+		return nil, err
+	}
+
+	return article, nil
+}
+
+// create створює статтю без перевірки прав; для імпорту, який вже доступний лише адміністратору
+func (s *ArticleService) create(ctx context.Context, authorID uint, input ArticleInput) (*models.Article, error) {
+	article, err := s.newArticle(ctx, authorID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.insert(ctx, article, input.Slug); err != nil {
+		return nil, err
+	}
+
+	return article, nil
+}
+
+// newArticle перевіряє input і будує статтю authorID з визначеним місцем у просторі
+func (s *ArticleService) newArticle(ctx context.Context, authorID uint, input ArticleInput) (*models.Article, error) {
+	status := input.Status
+	if status == "" {
+		status = models.ArticleStatusDraft
+	}
+
+	if !status.IsValid() {
+		return nil, ErrInvalidArticleStatus
+	}
+
+	language := input.Language
+	if language == "" {
+		language = models.ArticleLanguageDefault
+	}
+
+	if !models.IsArticleLanguage(language) {
+		return nil, ErrUnsupportedLanguage
+	}
+
+	article := &models.Article{
+		Title:     input.Title,
+		Body:      input.Body,
+		AuthorID:  authorID,
+		UpdatedBy: authorID,
+		Status:    status,
+		Language:  language,
+		Links:     ParseWikiLinks(input.Body),
+	}
+	setPublishedAt(article)
+
+	if err := s.place(ctx, article, input.SpaceID, input.ParentID); err != nil {
+		return nil, err
+	}
+
+	return article, nil
+}
+
+// insert зберігає статтю зі slug або зі згенерованим із заголовка
+func (s *ArticleService) insert(ctx context.Context, article *models.Article, slug string) error {
+	var err error
+
+	if slug != "" {
+		if article.Slug, err = normalizeSlug(slug); err != nil {
+			return err
+		}
+
+		err = s.articleRepo.CreateArticle(ctx, article)
+	} else {
+		err = s.createWithGeneratedSlug(ctx, article)
+	}
+
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return ErrSlugAlreadyExists
+		}
+
+		return fmt.Errorf("ArticleService - Create - CreateArticle: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ArticleService) Get(ctx context.Context, id uint) (*models.Article, error) {
+	article, err := s.articleRepo.GetArticleByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("ArticleService - Get - GetArticleByID: %w", err)
+	}
+
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	return article, nil
+}
+
+func (s *ArticleService) GetBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	article, err := s.articleRepo.GetArticleBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("ArticleService - GetBySlug - GetArticleBySlug: %w", err)
+	}
+
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	return article, nil
+}
+
+// List повертає сторінку статей; сортування та вирази фільтрів query перевіряються за repo.ArticlePages
+func (s *ArticleService) List(
+	ctx context.Context,
+	filter models.ArticleFilter,
+	query pagination.Query,
+) (*pagination.Page[*models.Article], error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, ErrInvalidArticleStatus
+	}
+
+	if filter.Tag != "" {
+		tag, err := NormalizeTag(filter.Tag)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Tag = tag
+	}
+
+	params, err := repo.ArticlePages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := s.articleRepo.ListArticles(ctx, filter, params)
+	if err != nil {
+		return nil, fmt.Errorf("ArticleService - List - ListArticles: %w", err)
+	}
+
+	return articles, nil
+}
+
+// Update замінює заголовок і текст статті; slug та статус змінюються лише якщо передані.
+// Кожне оновлення створює нову ревізію від імені editorID.
+func (s *ArticleService) Update(ctx context.Context, id, editorID uint, input ArticleInput) (*models.Article, error) {
+	article, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Status != "" {
+		if !input.Status.IsValid() {
+			return nil, ErrInvalidArticleStatus
+		}
+
+		article.Status = input.Status
+	}
+
+	if input.Language != "" {
+		if !models.IsArticleLanguage(input.Language) {
+			return nil, ErrUnsupportedLanguage
+		}
+
+		article.Language = input.Language
+	}
+
+	if input.Slug != "" {
+		if article.Slug, err = normalizeSlug(input.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	article.Title = input.Title
+	article.Body = input.Body
+	article.UpdatedBy = editorID
+	setPublishedAt(article)
+
+	return s.save(ctx, article)
+}
+
+func (s *ArticleService) Delete(ctx context.Context, id uint) error {
+	err := s.articleRepo.DeleteArticle(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			return ErrArticleNotFound
+		case errors.Is(err, repo.ErrInUse):
+			return ErrArticleHasChildren
+		default:
+			return fmt.Errorf("ArticleService - Delete - DeleteArticle: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ArticleService) save(ctx context.Context, article *models.Article) (*models.Article, error) {
+	article.Links = ParseWikiLinks(article.Body)
+
+	err := s.articleRepo.UpdateArticle(ctx, article)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			return nil, ErrArticleNotFound
+		case errors.Is(err, repo.ErrAlreadyExists):
+			return nil, ErrSlugAlreadyExists
+		default:
+			return nil, fmt.Errorf("ArticleService - save - UpdateArticle: %w", err)
+		}
+	}
+
+	return article, nil
+}
+
+// place перевіряє простір і батька нової статті та заповнює SpaceID і ParentID
+func (s *ArticleService) place(ctx context.Context, article *models.Article, spaceID, parentID *uint) error {
+	if parentID != nil {
+		parent, err := s.articleRepo.GetArticleByID(ctx, *parentID)
+		if err != nil {
+			return fmt.Errorf("ArticleService - place - GetArticleByID: %w", err)
+		}
+
+		if parent == nil || parent.SpaceID == nil || (spaceID != nil && *spaceID != *parent.SpaceID) {
+			return ErrParentNotFound
+		}
+
+		article.SpaceID = parent.SpaceID
+		article.ParentID = parentID
+
+		return nil
+	}
+
+	if spaceID != nil {
+		space, err := s.spaceRepo.GetSpaceByID(ctx, *spaceID)
+		if err != nil {
+			return fmt.Errorf("ArticleService - place - GetSpaceByID: %w", err)
+		}
+
+		if space == nil {
+			return ErrSpaceNotFound
+		}
+
+		article.SpaceID = spaceID
+	}
+
+	return nil
+}
+
+// createWithGeneratedSlug підбирає вільний slug з заголовка, додаючи суфікс -2, -3, ...
+// Конфлікт при вставці (паралельне створення) обробляється так само, як зайнятий slug.
+func (s *ArticleService) createWithGeneratedSlug(ctx context.Context, article *models.Article) error {
+	base := Slugify(article.Title)
+	if base == "" {
+		base = "article"
+	}
+
+	for attempt := 1; attempt <= _maxSlugAttempts; attempt++ {
+		article.Slug = base
+		if attempt > 1 {
+			article.Slug = fmt.Sprintf("%s-%d", base, attempt)
+		}
+
+		existing, err := s.articleRepo.GetArticleBySlug(ctx, article.Slug)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			continue
+		}
+
+		err = s.articleRepo.CreateArticle(ctx, article)
+		if !errors.Is(err, repo.ErrAlreadyExists) {
+			return err
+		}
+	}
+
+	return repo.ErrAlreadyExists
+}
+
+// setPublishedAt фіксує час першої публікації; повернення в draft його скидає
+func setPublishedAt(article *models.Article) {
+	switch article.Status {
+	case models.ArticleStatusPublished:
+		if article.PublishedAt == nil {
+			now := time.Now()
+			article.PublishedAt = &now
+		}
+	case models.ArticleStatusDraft:
+		article.PublishedAt = nil
+	}
+}
+
+// Slugify перетворює довільний текст на slug: літери та цифри в нижньому регістрі,
+// розділені одинарними дефісами. Нелатинські літери зберігаються.
+func Slugify(s string) string {
+	var b strings.Builder
+
+	pendingDash := false
+
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingDash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			pendingDash = false
+
+			b.WriteRune(r)
+
+			continue
+		}
+
+		pendingDash = true
+	}
+
+	slug := b.String()
+	if len(slug) > _maxSlugLength {
+		slug = strings.TrimRight(strings.ToValidUTF8(slug[:_maxSlugLength], ""), "-")
+	}
+
+	return slug
+}
+
+func normalizeSlug(slug string) (string, error) {
+	normalized := Slugify(slug)
+	if normalized == "" {
+		return "", ErrInvalidSlug
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestArticleService_Revisions(t *testing.T) {
+	service := newTestArticleService()
+	ctx := context.Background()
+
+	article, err := service.Create(ctx, asEditor(1), ArticleInput{Title: "Guide", Body: "one\ntwo\nthree\n"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if article.Version != 1 {
+		t.Errorf("Expected version 1 after create, got %d", article.Version)
+	}
+
+	article, err = service.Update(ctx, article.ID, 2, ArticleInput{Title: "Guide v2", Body: "one\n2\nthree\nfour\n"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if article.Version != 2 || article.UpdatedBy != 2 {
+		t.Errorf("Expected version 2 updated by user 2, got version %d by %d", article.Version, article.UpdatedBy)
+	}
+
+	page, err := service.ListRevisions(ctx, article.ID, pagination.Query{})
+	if err != nil {
+		t.Fatalf("ListRevisions() error = %v", err)
+	}
+
+	revisions := page.Items
+
+	if len(revisions) != 2 || revisions[0].Version != 2 || revisions[1].Version != 1 {
+		t.Fatalf("Expected revisions [2 1], got %+v", revisions)
+	}
+
+	if revisions[0].EditorID != 2 || revisions[1].EditorID != 1 {
+		t.Errorf("Unexpected revision editors: %d, %d", revisions[0].EditorID, revisions[1].EditorID)
+	}
+
+	revisionDiff, err := service.DiffRevisions(ctx, article.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("DiffRevisions() error = %v", err)
+	}
+
+	if revisionDiff.Added != 2 || revisionDiff.Removed != 1 || !revisionDiff.TitleChanged {
+		t.Errorf("Unexpected diff: %+v", revisionDiff)
+	}
+
+	if revisionDiff.Unified == "" || len(revisionDiff.Hunks) != 1 {
+		t.Errorf("Expected one hunk with unified output, got %+v", revisionDiff)
+	}
+
+	restored, err := service.RestoreRevision(ctx, article.ID, 1, 3)
+	if err != nil {
+		t.Fatalf("RestoreRevision() error = %v", err)
+	}
+
+	if restored.Version != 3 || restored.Title != "Guide" || restored.Body != "one\ntwo\nthree\n" {
+		t.Errorf("Unexpected restored article: %+v", restored)
+	}
+
+	// Відновлення додає ревізію, а не переписує історію
+	revision, err := service.GetRevision(ctx, article.ID, 2)
+	if err != nil || revision.Title != "Guide v2" {
+		t.Errorf("Expected revision 2 to be kept, got %+v, %v", revision, err)
+	}
+
+	if _, err = service.GetRevision(ctx, article.ID, 42); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("Expected ErrRevisionNotFound, got %v", err)
+	}
+
+	if _, err = service.DiffRevisions(ctx, article.ID, 1, 42); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("Expected ErrRevisionNotFound, got %v", err)
+	}
+
+	if _, err = service.ListRevisions(ctx, 999, pagination.Query{}); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+}
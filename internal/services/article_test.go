@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// asEditor - автор статей у тестах з глобальним правом їх писати
+func asEditor(userID uint) Actor {
+	return Actor{UserID: userID, Role: models.RoleEditor}
+}
+
+func newTestArticleService() *ArticleService {
+	mockRepo := mocks.NewRepository()
+
+	return NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Getting Started", "getting-started"},
+		{"  Hello,   World!  ", "hello-world"},
+		{"Go 1.22 release notes", "go-1-22-release-notes"},
+		{"Як почати роботу", "як-почати-роботу"},
+		{"---", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.in); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestArticleService_Create(t *testing.T) {
+	service := newTestArticleService()
+	ctx := context.Background()
+
+	article, err := service.Create(ctx, asEditor(1), ArticleInput{Title: "Getting Started", Body: "# Hi"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if article.Slug != "getting-started" {
+		t.Errorf("Expected slug 'getting-started', got '%s'", article.Slug)
+	}
+
+	if article.Status != models.ArticleStatusDraft {
+		t.Errorf("Expected status draft, got '%s'", article.Status)
+	}
+
+	if article.PublishedAt != nil {
+		t.Error("Expected draft article to have no published_at")
+	}
+
+	// Однаковий заголовок отримує slug з суфіксом
+	second, err := service.Create(ctx, asEditor(1), ArticleInput{Title: "Getting started", Status: models.ArticleStatusPublished})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if second.Slug != "getting-started-2" {
+		t.Errorf("Expected slug 'getting-started-2', got '%s'", second.Slug)
+	}
+
+	if second.PublishedAt == nil {
+		t.Error("Expected published article to have published_at")
+	}
+
+	// Явно заданий зайнятий slug - конфлікт
+	_, err = service.Create(ctx, asEditor(1), ArticleInput{Title: "Other", Slug: "Getting-Started"})
+	if !errors.Is(err, ErrSlugAlreadyExists) {
+		t.Errorf("Expected ErrSlugAlreadyExists, got %v", err)
+	}
+
+	_, err = service.Create(ctx, asEditor(1), ArticleInput{Title: "Other", Status: "deleted"})
+	if !errors.Is(err, ErrInvalidArticleStatus) {
+		t.Errorf("Expected ErrInvalidArticleStatus, got %v", err)
+	}
+}
+
+func TestArticleService_UpdateAndDelete(t *testing.T) {
+	service := newTestArticleService()
+	ctx := context.Background()
+
+	article, err := service.Create(ctx, asEditor(1), ArticleInput{Title: "Draft"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	updated, err := service.Update(ctx, article.ID, 2, ArticleInput{
+		Title:  "Published",
+		Body:   "text",
+		Status: models.ArticleStatusPublished,
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if updated.Slug != "draft" {
+		t.Errorf("Expected slug to stay 'draft', got '%s'", updated.Slug)
+	}
+
+	if updated.Status != models.ArticleStatusPublished || updated.PublishedAt == nil {
+		t.Errorf("Expected published article with published_at, got %+v", updated)
+	}
+
+	publishedAt := *updated.PublishedAt
+
+	// Архівування не змінює дату першої публікації
+	updated, err = service.Update(ctx, article.ID, 2, ArticleInput{Title: "Published", Status: models.ArticleStatusArchived})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if updated.PublishedAt == nil || !updated.PublishedAt.Equal(publishedAt) {
+		t.Errorf("Expected published_at %v to be kept, got %v", publishedAt, updated.PublishedAt)
+	}
+
+	if _, err = service.Update(ctx, 999, 2, ArticleInput{Title: "x"}); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+
+	if err = service.Delete(ctx, article.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err = service.Get(ctx, article.ID); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound after delete, got %v", err)
+	}
+
+	if err = service.Delete(ctx, article.ID); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound on second delete, got %v", err)
+	}
+}
+
+func TestArticleService_List(t *testing.T) {
+	service := newTestArticleService()
+	ctx := context.Background()
+
+	for i, author := range []uint{1, 1, 2} {
+		status := models.ArticleStatusDraft
+		if i == 0 {
+			status = models.ArticleStatusPublished
+		}
+
+		if _, err := service.Create(ctx, asEditor(author), ArticleInput{Title: "Article", Status: status}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	articles, err := service.List(ctx, models.ArticleFilter{AuthorID: 1}, pagination.Query{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(articles.Items) != 2 {
+		t.Errorf("Expected 2 articles of author 1, got %d", len(articles.Items))
+	}
+
+	articles, err = service.List(ctx, models.ArticleFilter{Status: models.ArticleStatusPublished}, pagination.Query{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(articles.Items) != 1 {
+		t.Errorf("Expected 1 published article, got %d", len(articles.Items))
+	}
+
+	articles, err = service.List(ctx, models.ArticleFilter{}, pagination.Query{Limit: 2, Sort: "id"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(articles.Items) != 2 || articles.NextCursor == "" {
+		t.Fatalf("Expected full first page with next cursor, got %d items, cursor %q",
+			len(articles.Items), articles.NextCursor)
+	}
+
+	articles, err = service.List(ctx, models.ArticleFilter{}, pagination.Query{
+		Limit: 2, Sort: "id", Cursor: articles.NextCursor,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(articles.Items) != 1 || articles.Items[0].ID != 3 || articles.NextCursor != "" {
+		t.Errorf("Expected last article on second page without cursor, got %+v, %q",
+			articles.Items, articles.NextCursor)
+	}
+
+	articles, err = service.List(ctx, models.ArticleFilter{}, pagination.Query{Filter: []string{"author_id:eq:2"}})
+	if err != nil || len(articles.Items) != 1 {
+		t.Errorf("List() by filter expression = %v, %v", articles, err)
+	}
+
+	_, err = service.List(ctx, models.ArticleFilter{Status: "unknown"}, pagination.Query{})
+	if !errors.Is(err, ErrInvalidArticleStatus) {
+		t.Errorf("Expected ErrInvalidArticleStatus, got %v", err)
+	}
+
+	_, err = service.List(ctx, models.ArticleFilter{}, pagination.Query{Sort: "body"})
+	if !errors.Is(err, pagination.ErrInvalidQuery) {
+		t.Errorf("Expected ErrInvalidQuery, got %v", err)
+	}
+}
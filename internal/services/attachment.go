@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"unicode"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/pagination"
+	"KnowledgeHub/pkg/storage"
+	"KnowledgeHub/pkg/tracing"
+)
+
+const (
+	// DefaultMaxAttachmentSize - максимальний розмір вкладення, якщо його не задано
+	DefaultMaxAttachmentSize = 25 << 20
+
+	// _sniffLength - скільки байтів початку файлу аналізує http.DetectContentType
+	_sniffLength = 512
+	// _orphanBlobBatch - кількість записів вмісту, що видаляються за один запит до БД
+	_orphanBlobBatch       = 100
+	_defaultAttachmentName = "attachment"
+)
+
+// DefaultAttachmentTypes - MIME типи, дозволені за замовчуванням. SVG та HTML не
+// дозволяються: відкриті браузером, вони можуть виконати скрипт.
+var DefaultAttachmentTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf"}
+
+var (
+	ErrAttachmentNotFound        = apperror.NotFound("attachment_not_found", "attachment not found")
+	ErrEmptyAttachment           = apperror.Validation("empty_attachment", "attachment is empty")
+	ErrAttachmentTooLarge        = apperror.New(apperror.KindTooLarge, "attachment_too_large", "attachment is too large")
+	ErrUnsupportedAttachmentType = apperror.New(apperror.KindUnsupported,
+		"unsupported_attachment_type", "attachment type is not allowed")
+)
+
+// AttachmentService керує файлами статей. Тип файлу визначається за вмістом, а не
+// за іменем чи заголовком клієнта; однаковий вміст зберігається в BlobStore один раз.
+type AttachmentService struct {
+	attachmentRepo repo.AttachmentRepository
+	articleRepo    repo.ArticleRepository
+	blobs          storage.BlobStore
+	maxSize        int64
+	allowedTypes   map[string]bool
+}
+
+// AttachmentOption - опціональні обмеження AttachmentService
+type AttachmentOption func(*AttachmentService)
+
+// WithMaxAttachmentSize задає максимальний розмір файлу в байтах
+func WithMaxAttachmentSize(size int64) AttachmentOption {
+	return func(s *AttachmentService) {
+		if size > 0 {
+			s.maxSize = size
+		}
+	}
+}
+
+// WithAllowedAttachmentTypes замінює список дозволених MIME типів; порожній список ігнорується
+func WithAllowedAttachmentTypes(types []string) AttachmentOption {
+	return func(s *AttachmentService) {
+		allowed := make(map[string]bool, len(types))
+
+		for _, contentType := range types {
+			if contentType = strings.ToLower(strings.TrimSpace(contentType)); contentType != "" {
+				allowed[contentType] = true
+			}
+		}
+
+		if len(allowed) > 0 {
+			s.allowedTypes = allowed
+		}
+	}
+}
+
+func NewAttachmentService(
+	attachmentRepo repo.AttachmentRepository,
+	articleRepo repo.ArticleRepository,
+	blobs storage.BlobStore,
+	opts ...AttachmentOption,
+) *AttachmentService {
+	s := &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		articleRepo:    articleRepo,
+		blobs:          blobs,
+		maxSize:        DefaultMaxAttachmentSize,
+	}
+
+	WithAllowedAttachmentTypes(DefaultAttachmentTypes)(s)
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// MaxSize повертає максимальний розмір файлу в байтах
+func (s *AttachmentService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Upload зберігає файл з r як вкладення статті. Файл буферизується на диску,
+// щоб перевірити розмір і тип та обчислити SHA-256 до запису в сховище.
+func (s *AttachmentService) Upload(
+	ctx context.Context,
+	userID, articleID uint,
+	filename string,
+	r io.Reader,
+) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Upload", tracing.ID("article.id", articleID))
+	defer span.End()
+
+	if err := s.ensureArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentService - Upload - os.CreateTemp: %w", err)
+	}
+
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentService - Upload - io.Copy: %w", err)
+	}
+
+	switch {
+	case size == 0:
+		return nil, ErrEmptyAttachment
+	case size > s.maxSize:
+		return nil, ErrAttachmentTooLarge
+	}
+
+	contentType, err := s.detectType(tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &models.Attachment{
+		ArticleID:   articleID,
+		Filename:    NormalizeFilename(filename),
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		UploadedBy:  userID,
+	}
+
+	upload := func(ctx context.Context, digest string) error {
+		if _, seekErr := tmp.Seek(0, io.SeekStart); seekErr != nil {
+			return seekErr
+		}
+
+		return s.blobs.Put(ctx, BlobKey(digest), tmp, size, contentType)
+	}
+
+	if err = s.attachmentRepo.CreateAttachment(ctx, attachment, upload); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrArticleNotFound
+		}
+
+		return nil, fmt.Errorf("AttachmentService - Upload - CreateAttachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// List повертає сторінку вкладень статті, за замовчуванням від найстаріших
+func (s *AttachmentService) List(
+	ctx context.Context,
+	articleID uint,
+	query pagination.Query,
+) (*pagination.Page[*models.Attachment], error) {
+	if err := s.ensureArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	params, err := repo.AttachmentPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.ListAttachments(ctx, articleID, params)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentService - List - ListAttachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Get повертає вкладення статті articleID; вкладення іншої статті вважається відсутнім
+func (s *AttachmentService) Get(ctx context.Context, articleID, id uint) (*models.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentService - Get - GetAttachmentByID: %w", err)
+	}
+
+	if attachment == nil || attachment.ArticleID != articleID {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+// Content відкриває вміст вкладення; закрити ReadCloser має той, хто викликає
+func (s *AttachmentService) Content(ctx context.Context, attachment *models.Attachment) (io.ReadCloser, error) {
+	content, err := s.blobs.Get(ctx, BlobKey(attachment.SHA256))
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentService - Content - Get: %w", err)
+	}
+
+	return content, nil
+}
+
+// Delete видаляє вкладення; вміст видаляється зі сховища, якщо більше ніде не використовується
+func (s *AttachmentService) Delete(ctx context.Context, articleID, id uint) error {
+	if _, err := s.Get(ctx, articleID, id); err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.DeleteAttachment(ctx, id, s.removeBlob); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrAttachmentNotFound
+		}
+
+		return fmt.Errorf("AttachmentService - Delete - DeleteAttachment: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeOrphans видаляє зі сховища вміст, на який не посилається жодне вкладення
+// (лишається після видалення статей), і повертає кількість видалених об'єктів
+func (s *AttachmentService) PurgeOrphans(ctx context.Context) (int, error) {
+	total := 0
+
+	for {
+		deleted, err := s.attachmentRepo.DeleteOrphanBlobs(ctx, _orphanBlobBatch, s.removeBlob)
+		total += deleted
+
+		if err != nil {
+			return total, fmt.Errorf("AttachmentService - PurgeOrphans - DeleteOrphanBlobs: %w", err)
+		}
+
+		if deleted < _orphanBlobBatch {
+			return total, nil
+		}
+	}
+}
+
+func (s *AttachmentService) removeBlob(ctx context.Context, digest string) error {
+	return s.blobs.Delete(ctx, BlobKey(digest))
+}
+
+// detectType визначає MIME тип за початком файлу і перевіряє його за списком дозволених
+func (s *AttachmentService) detectType(file *os.File) (string, error) {
+	head := make([]byte, _sniffLength)
+
+	n, err := file.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("AttachmentService - detectType - ReadAt: %w", err)
+	}
+
+	contentType := http.DetectContentType(head[:n])
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !s.allowedTypes[mediaType] {
+		return "", ErrUnsupportedAttachmentType
+	}
+
+	return contentType, nil
+}
+
+func (s *AttachmentService) ensureArticle(ctx context.Context, articleID uint) error {
+	article, err := s.articleRepo.GetArticleByID(ctx, articleID)
+	if err != nil {
+		return fmt.Errorf("AttachmentService - ensureArticle - GetArticleByID: %w", err)
+	}
+
+	if article == nil {
+		return ErrArticleNotFound
+	}
+
+	return nil
+}
+
+// BlobKey повертає ключ вмісту в BlobStore; префікс з двох символів хешу
+// розподіляє файли локального сховища по підкаталогах
+func BlobKey(digest string) string {
+	return "sha256/" + digest[:2] + "/" + digest
+}
+
+// NormalizeFilename лишає від імені файлу клієнта лише останній сегмент шляху
+// без керівних символів, обрізаний до models.AttachmentFilenameMaxLength символів
+func NormalizeFilename(filename string) string {
+	if i := strings.LastIndexAny(filename, `/\`); i >= 0 {
+		filename = filename[i+1:]
+	}
+
+	filename = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return -1
+		}
+
+		return r
+	}, filename))
+
+	if runes := []rune(filename); len(runes) > models.AttachmentFilenameMaxLength {
+		filename = string(runes[:models.AttachmentFilenameMaxLength])
+	}
+
+	if filename == "" || filename == "." || filename == ".." {
+		return _defaultAttachmentName
+	}
+
+	return filename
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+	"KnowledgeHub/pkg/storage"
+)
+
+// _testPNG - мінімальний початок PNG файлу, достатній для визначення типу
+var _testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newTestAttachmentServices(
+	t *testing.T,
+	opts ...AttachmentOption,
+) (*AttachmentService, *ArticleService, storage.BlobStore) {
+	t.Helper()
+
+	blobs, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	mockRepo := mocks.NewRepository()
+
+	return NewAttachmentService(mockRepo.Attachment(), mockRepo.Article(), blobs, opts...),
+		NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+			NewAccessService(mockRepo.SpaceMember(), mockRepo.Article())),
+		blobs
+}
+
+func TestAttachmentService_UploadDeduplicates(t *testing.T) {
+	attachments, articles, blobs := newTestAttachmentServices(t)
+	ctx := context.Background()
+
+	first, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "First"})
+	second, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Second"})
+
+	original, err := attachments.Upload(ctx, 1, first.ID, "../../diagram.png", bytes.NewReader(_testPNG))
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	if original.Filename != "diagram.png" || original.ContentType != "image/png" ||
+		original.Size != int64(len(_testPNG)) || len(original.SHA256) != 64 {
+		t.Errorf("Unexpected attachment: %+v", original)
+	}
+
+	copied, err := attachments.Upload(ctx, 2, second.ID, "copy.png", bytes.NewReader(_testPNG))
+	if err != nil {
+		t.Fatalf("Upload(copy) error = %v", err)
+	}
+
+	if copied.SHA256 != original.SHA256 || copied.ID == original.ID {
+		t.Errorf("Expected a new attachment sharing content, got %+v", copied)
+	}
+
+	// Вміст лишається, поки ним користується інше вкладення
+	if err = attachments.Delete(ctx, first.ID, original.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if exists, _ := blobs.Exists(ctx, BlobKey(original.SHA256)); !exists {
+		t.Fatal("Shared content was removed from storage")
+	}
+
+	got, err := attachments.Get(ctx, second.ID, copied.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	content, err := attachments.Content(ctx, got)
+	if err != nil {
+		t.Fatalf("Content() error = %v", err)
+	}
+
+	data, _ := io.ReadAll(content)
+	content.Close()
+
+	if got.Filename != "copy.png" || !bytes.Equal(data, _testPNG) {
+		t.Errorf("Get() = %+v, content %q", got, data)
+	}
+
+	if err = attachments.Delete(ctx, second.ID, copied.ID); err != nil {
+		t.Fatalf("Delete(copy) error = %v", err)
+	}
+
+	if exists, _ := blobs.Exists(ctx, BlobKey(original.SHA256)); exists {
+		t.Error("Unused content was left in storage")
+	}
+}
+
+func TestAttachmentService_UploadValidation(t *testing.T) {
+	attachments, articles, _ := newTestAttachmentServices(t, WithMaxAttachmentSize(64))
+	ctx := context.Background()
+
+	article, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Runbook"})
+
+	tests := []struct {
+		name      string
+		articleID uint
+		content   []byte
+		want      error
+	}{
+		{"Empty file", article.ID, nil, ErrEmptyAttachment},
+		{"Too large", article.ID, slices.Concat(_testPNG, make([]byte, 64)), ErrAttachmentTooLarge},
+		{"HTML", article.ID, []byte("<html><script>alert(1)</script>"), ErrUnsupportedAttachmentType},
+		{"SVG", article.ID, []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), ErrUnsupportedAttachmentType},
+		{"Unknown article", article.ID + 100, _testPNG, ErrArticleNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := attachments.Upload(ctx, 1, tt.articleID, "file.png", bytes.NewReader(tt.content))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Upload() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Тип визначається за вмістом, тому PDF з розширенням .png лишається PDF
+	pdf, err := attachments.Upload(ctx, 1, article.ID, "scan.png", strings.NewReader("%PDF-1.7"))
+	if err != nil || pdf.ContentType != "application/pdf" {
+		t.Errorf("Upload(pdf) = %+v, %v", pdf, err)
+	}
+}
+
+func TestAttachmentService_AllowedTypes(t *testing.T) {
+	attachments, articles, _ := newTestAttachmentServices(t, WithAllowedAttachmentTypes([]string{" Text/Plain "}))
+	ctx := context.Background()
+
+	article, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Notes"})
+
+	notes, err := attachments.Upload(ctx, 1, article.ID, "notes.txt", strings.NewReader("plain notes"))
+	if err != nil || notes.ContentType != "text/plain; charset=utf-8" {
+		t.Errorf("Upload(text) = %+v, %v", notes, err)
+	}
+
+	if _, err = attachments.Upload(ctx, 1, article.ID, "a.png", bytes.NewReader(_testPNG)); !errors.Is(err,
+		ErrUnsupportedAttachmentType) {
+		t.Errorf("Upload(png) error = %v, want ErrUnsupportedAttachmentType", err)
+	}
+}
+
+func TestAttachmentService_NotFound(t *testing.T) {
+	attachments, articles, _ := newTestAttachmentServices(t)
+	ctx := context.Background()
+
+	first, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "First"})
+	second, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Second"})
+
+	attachment, err := attachments.Upload(ctx, 1, first.ID, "a.png", bytes.NewReader(_testPNG))
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	// Вкладення іншої статті недоступне через чужий шлях
+	if _, err = attachments.Get(ctx, second.ID, attachment.ID); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Get() error = %v, want ErrAttachmentNotFound", err)
+	}
+
+	if err = attachments.Delete(ctx, second.ID, attachment.ID); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Delete() error = %v, want ErrAttachmentNotFound", err)
+	}
+
+	if _, err = attachments.List(ctx, second.ID+100, pagination.Query{}); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("List() error = %v, want ErrArticleNotFound", err)
+	}
+}
+
+func TestAttachmentService_PurgeOrphans(t *testing.T) {
+	attachments, articles, blobs := newTestAttachmentServices(t)
+	ctx := context.Background()
+
+	article, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Temporary"})
+
+	attachment, err := attachments.Upload(ctx, 1, article.ID, "a.png", bytes.NewReader(_testPNG))
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	if deleted, purgeErr := attachments.PurgeOrphans(ctx); purgeErr != nil || deleted != 0 {
+		t.Fatalf("PurgeOrphans() with used content = %d, %v", deleted, purgeErr)
+	}
+
+	if err = articles.Delete(ctx, article.ID); err != nil {
+		t.Fatalf("Delete article error = %v", err)
+	}
+
+	deleted, err := attachments.PurgeOrphans(ctx)
+	if err != nil || deleted != 1 {
+		t.Fatalf("PurgeOrphans() = %d, %v", deleted, err)
+	}
+
+	if exists, _ := blobs.Exists(ctx, BlobKey(attachment.SHA256)); exists {
+		t.Error("Orphaned content was left in storage")
+	}
+}
+
+func TestNormalizeFilename(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{`C:\Users\me\scan.png`, "scan.png"},
+		{"../../etc/passwd", "passwd"},
+		{"  bad\x00name\r\n.png ", "badname.png"},
+		{"dir/", "attachment"},
+		{"..", "attachment"},
+		{"звіт 2025.pdf", "звіт 2025.pdf"},
+		{strings.Repeat("я", 300), strings.Repeat("я", 255)},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeFilename(tt.in); got != tt.want {
+			t.Errorf("NormalizeFilename(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/pagination"
+)
+
+const (
+	// MaxCommentLength - максимальна довжина коментаря в символах
+	MaxCommentLength = 10000
+	// MaxCommentMentions обмежує кількість згадок, що розпізнаються в одному коментарі
+	MaxCommentMentions = 20
+)
+
+var (
+	ErrCommentNotFound = apperror.NotFound("comment_not_found", "comment not found")
+	ErrEmptyComment    = apperror.Validation("empty_comment", "comment body is empty")
+	ErrCommentTooLong  = apperror.Validation("comment_too_long", "comment body is too long")
+	ErrNotThreadRoot   = apperror.Validation("not_thread_root", "only thread root comments can be resolved")
+)
+
+// _mentionPattern знаходить @username на початку тексту або після символу, що не
+// може бути частиною імені, тому адреси на зразок john@example.com не є згадками
+var _mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@-])@([\p{L}\p{N}_.-]+)`)
+
+// Actor - автентифікований користувач, від імені якого виконується дія
+type Actor struct {
+	UserID uint
+	Role   models.Role
+}
+
+// CommentService керує обговоренням статей: гілками коментарів, згадками та вирішенням гілок
+type CommentService struct {
+	commentRepo repo.CommentRepository
+	articleRepo repo.ArticleRepository
+	userRepo    repo.UserRepository
+	access      *AccessService
+}
+
+func NewCommentService(
+	commentRepo repo.CommentRepository,
+	articleRepo repo.ArticleRepository,
+	userRepo repo.UserRepository,
+	access *AccessService,
+) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		articleRepo: articleRepo,
+		userRepo:    userRepo,
+		access:      access,
+	}
+}
+
+// List повертає сторінку гілок статті, за замовчуванням від найстаріших;
+// кожна гілка містить усі відповіді
+func (s *CommentService) List(
+	ctx context.Context,
+	articleID uint,
+	query pagination.Query,
+) (*pagination.Page[*models.Comment], error) {
+	if _, err := s.getArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	params, err := repo.CommentThreadPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	threads, err := s.commentRepo.ListThreads(ctx, articleID, params)
+	if err != nil {
+		return nil, fmt.Errorf("CommentService - List - ListThreads: %w", err)
+	}
+
+	threadIDs := make([]uint, 0, len(threads.Items))
+	for _, thread := range threads.Items {
+		threadIDs = append(threadIDs, thread.ID)
+	}
+
+	replies, err := s.commentRepo.ListReplies(ctx, threadIDs)
+	if err != nil {
+		return nil, fmt.Errorf("CommentService - List - ListReplies: %w", err)
+	}
+
+	threads.Items = buildThreads(threads.Items, replies)
+
+	return threads, nil
+}
+
+// Create додає коментар до статті або відповідь на коментар parentID
+func (s *CommentService) Create(
+	ctx context.Context,
+	actor Actor,
+	articleID uint,
+	parentID *uint,
+	body string,
+) (*models.Comment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.getArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		ArticleID: articleID,
+		AuthorID:  actor.UserID,
+		Body:      body,
+	}
+
+	if parentID != nil {
+		parent, getErr := s.getComment(ctx, articleID, *parentID)
+		if getErr != nil {
+			return nil, getErr
+		}
+
+		if parent.DeletedAt != nil {
+			return nil, ErrCommentNotFound
+		}
+
+		comment.ParentID = &parent.ID
+		comment.ThreadID = &parent.ID
+
+		if parent.ThreadID != nil {
+			comment.ThreadID = parent.ThreadID
+		}
+	}
+
+	if comment.Mentions, err = s.resolveMentions(ctx, body); err != nil {
+		return nil, err
+	}
+
+	if err = s.commentRepo.CreateComment(ctx, comment); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrCommentNotFound
+		}
+
+		return nil, fmt.Errorf("CommentService - Create - CreateComment: %w", err)
+	}
+
+	comment.Replies = make([]*models.Comment, 0)
+
+	return comment, nil
+}
+
+// Update замінює текст коментаря; редагувати можна лише власні коментарі
+func (s *CommentService) Update(
+	ctx context.Context,
+	actor Actor,
+	articleID, id uint,
+	body string,
+) (*models.Comment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.getComment(ctx, articleID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.DeletedAt != nil {
+		return nil, ErrCommentNotFound
+	}
+
+	if comment.AuthorID != actor.UserID {
+		return nil, ErrForbidden
+	}
+
+	comment.Body = body
+
+	if comment.Mentions, err = s.resolveMentions(ctx, body); err != nil {
+		return nil, err
+	}
+
+	return s.save(ctx, comment)
+}
+
+// Delete м'яко видаляє коментар. Чужі коментарі можуть видаляти модератори
+// глобально або в просторі статті.
+func (s *CommentService) Delete(ctx context.Context, actor Actor, articleID, id uint) error {
+	article, err := s.getArticle(ctx, articleID)
+	if err != nil {
+		return err
+	}
+
+	comment, err := s.getComment(ctx, articleID, id)
+	if err != nil {
+		return err
+	}
+
+	if comment.AuthorID != actor.UserID {
+		err = s.access.Authorize(ctx, actor.UserID, actor.Role, models.PermissionModerateComments, article.SpaceID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = s.commentRepo.DeleteComment(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrCommentNotFound
+		}
+
+		return fmt.Errorf("CommentService - Delete - DeleteComment: %w", err)
+	}
+
+	return nil
+}
+
+// Resolve позначає гілку вирішеною (resolved = false - знову відкриває).
+// Це може зробити автор гілки або редактор статті.
+func (s *CommentService) Resolve(
+	ctx context.Context,
+	actor Actor,
+	articleID, id uint,
+	resolved bool,
+) (*models.Comment, error) {
+	article, err := s.getArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.getComment(ctx, articleID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.DeletedAt != nil {
+		return nil, ErrCommentNotFound
+	}
+
+	if comment.ParentID != nil {
+		return nil, ErrNotThreadRoot
+	}
+
+	if comment.AuthorID != actor.UserID {
+		err = s.access.Authorize(ctx, actor.UserID, actor.Role, models.PermissionWriteArticles, article.SpaceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if (comment.ResolvedAt != nil) == resolved {
+		comment.Replies = make([]*models.Comment, 0)
+		return comment, nil
+	}
+
+	comment.ResolvedAt = nil
+	comment.ResolvedBy = nil
+
+	if resolved {
+		now := time.Now()
+		comment.ResolvedAt = &now
+		comment.ResolvedBy = &actor.UserID
+	}
+
+	return s.save(ctx, comment)
+}
+
+func (s *CommentService) save(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	if err := s.commentRepo.UpdateComment(ctx, comment); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrCommentNotFound
+		}
+
+		return nil, fmt.Errorf("CommentService - save - UpdateComment: %w", err)
+	}
+
+	comment.Replies = make([]*models.Comment, 0)
+
+	return comment, nil
+}
+
+// resolveMentions знаходить користувачів, згаданих у тексті; невідомі імена пропускаються
+func (s *CommentService) resolveMentions(ctx context.Context, body string) ([]*models.CommentMention, error) {
+	mentions := make([]*models.CommentMention, 0)
+
+	for _, username := range ParseMentions(body) {
+		user, err := s.userRepo.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("CommentService - resolveMentions - GetUserByUsername: %w", err)
+		}
+
+		if user != nil {
+			mentions = append(mentions, &models.CommentMention{UserID: user.ID, Username: user.Username})
+		}
+	}
+
+	return mentions, nil
+}
+
+func (s *CommentService) getArticle(ctx context.Context, articleID uint) (*models.Article, error) {
+	article, err := s.articleRepo.GetArticleByID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("CommentService - getArticle - GetArticleByID: %w", err)
+	}
+
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	return article, nil
+}
+
+// getComment повертає коментар статті articleID; коментар іншої статті вважається відсутнім
+func (s *CommentService) getComment(ctx context.Context, articleID, id uint) (*models.Comment, error) {
+	comment, err := s.commentRepo.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("CommentService - getComment - GetCommentByID: %w", err)
+	}
+
+	if comment == nil || comment.ArticleID != articleID {
+		return nil, ErrCommentNotFound
+	}
+
+	return comment, nil
+}
+
+// ParseMentions повертає унікальні імена, згадані в тексті як @username, у порядку появи.
+// Крапки та дефіси в кінці імені вважаються розділовими знаками речення.
+func ParseMentions(body string) []string {
+	usernames := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range _mentionPattern.FindAllStringSubmatch(body, -1) {
+		username := strings.TrimRight(match[1], ".-")
+		if username == "" || seen[username] {
+			continue
+		}
+
+		seen[username] = true
+		usernames = append(usernames, username)
+
+		if len(usernames) == MaxCommentMentions {
+			break
+		}
+	}
+
+	return usernames
+}
+
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+
+	if body == "" {
+		return "", ErrEmptyComment
+	}
+
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return "", ErrCommentTooLong
+	}
+
+	return body, nil
+}
+
+// buildThreads вкладає відповіді у батьківські коментарі, зберігаючи порядок створення.
+// Відповідь, чий батько відсутній у списку, потрапляє в корінь своєї гілки.
+func buildThreads(threads, replies []*models.Comment) []*models.Comment {
+	byID := make(map[uint]*models.Comment, len(threads)+len(replies))
+
+	for _, comment := range slices.Concat(threads, replies) {
+		comment.Replies = make([]*models.Comment, 0)
+		byID[comment.ID] = comment
+	}
+
+	for _, reply := range replies {
+		parent, ok := byID[*reply.ParentID]
+		if !ok {
+			parent, ok = byID[*reply.ThreadID]
+		}
+
+		if ok {
+			parent.Replies = append(parent.Replies, reply)
+		}
+	}
+
+	return threads
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func newTestCommentServices() (*CommentService, *SpaceService, *ArticleService) {
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "owner", Email: "owner@example.com"})
+	mockRepo.AddUser(&models.User{ID: 2, Username: "reviewer", Email: "reviewer@example.com"})
+	mockRepo.AddUser(&models.User{ID: 3, Username: "jane.doe", Email: "jane@example.com"})
+
+	access := NewAccessService(mockRepo.SpaceMember(), mockRepo.Article())
+
+	return NewCommentService(mockRepo.Comment(), mockRepo.Article(), mockRepo.User(), access),
+		NewSpaceService(mockRepo.Space(), mockRepo.Article(), mockRepo.PageTree(), mockRepo.SpaceMember(), access),
+		NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(), access)
+}
+
+func mentionNames(comment *models.Comment) []string {
+	names := make([]string, 0, len(comment.Mentions))
+	for _, mention := range comment.Mentions {
+		names = append(names, mention.Username)
+	}
+
+	return names
+}
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"@jane.doe please review", "[jane.doe]"},
+		{"cc @bob, @alice and @bob.", "[bob alice]"},
+		{"(@olena) і @олег-", "[olena олег]"},
+		{"mail john@example.com or @@nobody", "[]"},
+		{"no mentions", "[]"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprint(ParseMentions(tt.in)); got != tt.want {
+			t.Errorf("ParseMentions(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCommentService_Threads(t *testing.T) {
+	comments, _, articles := newTestCommentServices()
+	ctx := context.Background()
+
+	owner := Actor{UserID: 1, Role: models.RoleEditor}
+	reviewer := Actor{UserID: 2, Role: models.RoleViewer}
+
+	article, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Runbook"})
+	if err != nil {
+		t.Fatalf("Create article error = %v", err)
+	}
+
+	root, err := comments.Create(ctx, reviewer, article.ID, nil, "  @owner and @jane.doe, @ghost: step 3 is outdated ")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if root.Body != "@owner and @jane.doe, @ghost: step 3 is outdated" {
+		t.Errorf("Expected trimmed body, got %q", root.Body)
+	}
+
+	if names := fmt.Sprint(mentionNames(root)); names != "[owner jane.doe]" {
+		t.Errorf("Expected known users to be mentioned, got %s", names)
+	}
+
+	reply, err := comments.Create(ctx, owner, article.ID, &root.ID, "Fixed")
+	if err != nil {
+		t.Fatalf("Create reply error = %v", err)
+	}
+
+	nested, err := comments.Create(ctx, reviewer, article.ID, &reply.ID, "Thanks")
+	if err != nil {
+		t.Fatalf("Create nested reply error = %v", err)
+	}
+
+	if nested.ThreadID == nil || *nested.ThreadID != root.ID {
+		t.Errorf("Expected nested reply in thread %d, got %v", root.ID, nested.ThreadID)
+	}
+
+	second, err := comments.Create(ctx, owner, article.ID, nil, "Second thread")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	_, err = comments.Create(ctx, owner, article.ID, uintPtr(99), "Orphan")
+	if !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("Expected ErrCommentNotFound for unknown parent, got %v", err)
+	}
+
+	if _, err = comments.Create(ctx, owner, article.ID, nil, "   "); !errors.Is(err, ErrEmptyComment) {
+		t.Errorf("Expected ErrEmptyComment, got %v", err)
+	}
+
+	if _, err = comments.Create(ctx, owner, 99, nil, "Hi"); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+
+	list, err := comments.List(ctx, article.ID, pagination.Query{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	threads := list.Items
+
+	if len(threads) != 2 || threads[0].ID != root.ID || threads[1].ID != second.ID {
+		t.Fatalf("Expected two threads in creation order, got %v", threads)
+	}
+
+	if len(threads[0].Replies) != 1 || len(threads[0].Replies[0].Replies) != 1 ||
+		threads[0].Replies[0].Replies[0].ID != nested.ID {
+		t.Errorf("Expected reply chain root -> reply -> nested, got %+v", threads[0].Replies)
+	}
+
+	page, err := comments.List(ctx, article.ID, pagination.Query{Limit: 1})
+	if err != nil || len(page.Items) != 1 || page.Items[0].ID != root.ID || page.NextCursor == "" {
+		t.Fatalf("List(limit=1) = %+v, %v", page, err)
+	}
+
+	page, err = comments.List(ctx, article.ID, pagination.Query{Limit: 1, Cursor: page.NextCursor})
+	if err != nil || len(page.Items) != 1 || page.Items[0].ID != second.ID || page.NextCursor != "" {
+		t.Errorf("List(limit=1, cursor) = %+v, %v", page, err)
+	}
+}
+
+func TestCommentService_EditDeleteResolve(t *testing.T) {
+	comments, spaces, articles := newTestCommentServices()
+	ctx := context.Background()
+
+	owner := Actor{UserID: 1, Role: models.RoleViewer}
+	reviewer := Actor{UserID: 2, Role: models.RoleViewer}
+	outsider := Actor{UserID: 3, Role: models.RoleViewer}
+
+	space, err := spaces.Create(ctx, 1, SpaceInput{Name: "Ops"})
+	if err != nil {
+		t.Fatalf("Create space error = %v", err)
+	}
+
+	article, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Runbook", SpaceID: &space.ID})
+	if err != nil {
+		t.Fatalf("Create article error = %v", err)
+	}
+
+	root, _ := comments.Create(ctx, reviewer, article.ID, nil, "Typo in step 2")
+	reply, _ := comments.Create(ctx, outsider, article.ID, &root.ID, "+1")
+
+	if _, err = comments.Update(ctx, outsider, article.ID, root.ID, "Hijack"); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden when editing someone else's comment, got %v", err)
+	}
+
+	updated, err := comments.Update(ctx, reviewer, article.ID, root.ID, "Typo in step 2, cc @owner")
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if names := fmt.Sprint(mentionNames(updated)); names != "[owner]" {
+		t.Errorf("Expected mentions to be parsed again, got %s", names)
+	}
+
+	if _, err = comments.Resolve(ctx, owner, article.ID, reply.ID, true); !errors.Is(err, ErrNotThreadRoot) {
+		t.Errorf("Expected ErrNotThreadRoot, got %v", err)
+	}
+
+	// Автор гілки, а не редактор статті
+	if _, err = comments.Resolve(ctx, outsider, article.ID, root.ID, true); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for outsider, got %v", err)
+	}
+
+	// Творець простору є його адміністратором, тож може вирішувати гілки з глобальною роллю viewer
+	resolved, err := comments.Resolve(ctx, owner, article.ID, root.ID, true)
+	if err != nil || resolved.ResolvedAt == nil || resolved.ResolvedBy == nil || *resolved.ResolvedBy != owner.UserID {
+		t.Fatalf("Resolve() = %+v, %v", resolved, err)
+	}
+
+	reopened, err := comments.Resolve(ctx, reviewer, article.ID, root.ID, false)
+	if err != nil || reopened.ResolvedAt != nil || reopened.ResolvedBy != nil {
+		t.Fatalf("Reopen = %+v, %v", reopened, err)
+	}
+
+	if err = comments.Delete(ctx, outsider, article.ID, root.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden when deleting someone else's comment, got %v", err)
+	}
+
+	if err = comments.Delete(ctx, owner, article.ID, root.ID); err != nil {
+		t.Fatalf("Delete() by space admin error = %v", err)
+	}
+
+	if err = comments.Delete(ctx, reviewer, article.ID, root.ID); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("Expected ErrCommentNotFound for deleted comment, got %v", err)
+	}
+
+	if _, err = comments.Update(ctx, reviewer, article.ID, root.ID, "Back"); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("Expected ErrCommentNotFound when editing deleted comment, got %v", err)
+	}
+
+	list, err := comments.List(ctx, article.ID, pagination.Query{})
+	if err != nil || len(list.Items) != 1 {
+		t.Fatalf("List() = %v, %v", list, err)
+	}
+
+	threads := list.Items
+
+	if threads[0].DeletedAt == nil || threads[0].Body != "" || len(threads[0].Mentions) != 0 {
+		t.Errorf("Expected deleted placeholder, got %+v", threads[0])
+	}
+
+	if len(threads[0].Replies) != 1 || threads[0].Replies[0].ID != reply.ID {
+		t.Errorf("Expected replies to survive deletion of the root, got %+v", threads[0].Replies)
+	}
+
+	if _, err = comments.Update(ctx, outsider, article.ID+1, reply.ID, "Moved"); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("Expected ErrCommentNotFound for comment of another article, got %v", err)
+	}
+}
@@ -0,0 +1,626 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/markdown"
+	"KnowledgeHub/pkg/pagination"
+	"KnowledgeHub/pkg/storage"
+	"KnowledgeHub/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ExportFormat - формат архіву експорту
+type ExportFormat string
+
+const (
+	// ExportFormatMarkdown - markdown файли з front matter у структурі, яку читає імпорт
+	ExportFormatMarkdown ExportFormat = "markdown"
+	// ExportFormatHTML - статичний HTML сайт з навігацією, що відкривається без сервера
+	ExportFormatHTML ExportFormat = "html"
+	// ExportFormatJSON - версійований дамп ExportDumpFile з вмістом вкладень
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// IsValid перевіряє, що формат належить до відомих значень
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportFormatMarkdown, ExportFormatHTML, ExportFormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	// ExportDumpVersion - версія формату ExportDump; імпорт відхиляє інші версії
+	ExportDumpVersion = 1
+	// ExportDumpFile - ім'я дампу в корені JSON архіву; вміст вкладень лежить поруч
+	// у attachments/<sha256>
+	ExportDumpFile = "knowledgehub.json"
+
+	_dumpAttachmentsDir = "attachments"
+)
+
+var (
+	ErrInvalidExportFormat    = apperror.Validation("invalid_export_format", "invalid export format")
+	ErrUnsupportedDumpVersion = apperror.Validation("unsupported_dump_version", "unsupported export dump version")
+)
+
+// _attachmentURLPattern - адреса вкладення в тексті статті, див. _attachmentURLFormat
+var _attachmentURLPattern = regexp.MustCompile(`^/v1/articles/(\d+)/attachments/(\d+)$`)
+
+// ExportOptions - що і в якому форматі експортувати; SpaceID 0 - вся база знань
+type ExportOptions struct {
+	SpaceID uint
+	Format  ExportFormat
+}
+
+// ExportDump - повна копія просторів і статей у форматі ExportFormatJSON.
+// Статті йдуть у порядку дерева: батьківська сторінка раніше за дочірні,
+// сусіди - за позицією. Поля ID потрібні, щоб імпорт переписав адреси вкладень у Body.
+type ExportDump struct {
+	Version    int            `json:"version" example:"1"`
+	ExportedAt time.Time      `json:"exported_at" example:"2025-01-01T00:00:00Z"`
+	Spaces     []*DumpSpace   `json:"spaces"`
+	Articles   []*DumpArticle `json:"articles"`
+}
+
+// DumpSpace - простір у дампі
+type DumpSpace struct {
+	Key         string `json:"key" example:"engineering"`
+	Name        string `json:"name" example:"Engineering"`
+	Description string `json:"description" example:"Runbooks and architecture notes"`
+}
+
+// DumpArticle - стаття в дампі. Space - ключ простору, Parent - slug батьківської сторінки,
+// Author - ім'я користувача (порожнє, якщо автора видалено).
+type DumpArticle struct {
+	ID          uint                 `json:"id" example:"1"`
+	Slug        string               `json:"slug" example:"deploy-guide"`
+	Title       string               `json:"title" example:"Deploy guide"`
+	Body        string               `json:"body" example:"# Deploy"`
+	Status      models.ArticleStatus `json:"status" example:"published"`
+	Language    string               `json:"language" example:"english"`
+	Author      string               `json:"author,omitempty" example:"johndoe"`
+	Space       string               `json:"space,omitempty" example:"engineering"`
+	Parent      string               `json:"parent,omitempty" example:"engineering"`
+	Tags        []string             `json:"tags"`
+	Attachments []*DumpAttachment    `json:"attachments"`
+	PublishedAt *time.Time           `json:"published_at,omitempty" example:"2025-01-01T00:00:00Z"`
+	CreatedAt   time.Time            `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt   time.Time            `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// DumpAttachment - вкладення статті; вміст лежить в архіві за шляхом attachments/<SHA256>
+type DumpAttachment struct {
+	ID          uint   `json:"id" example:"1"`
+	Filename    string `json:"filename" example:"architecture.png"`
+	ContentType string `json:"content_type" example:"image/png"`
+	Size        int64  `json:"size" example:"48213"`
+	SHA256      string `json:"sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+// ExportService пакує простір або всю базу знань у zip архів для резервних
+// та офлайн копій. Експортуються всі статті незалежно від статусу.
+type ExportService struct {
+	articleRepo    repo.ArticleRepository
+	spaceRepo      repo.SpaceRepository
+	pageTreeRepo   repo.PageTreeRepository
+	tagRepo        repo.TagRepository
+	attachmentRepo repo.AttachmentRepository
+	userRepo       repo.UserRepository
+	blobs          storage.BlobStore
+	renderer       *markdown.Renderer
+}
+
+func NewExportService(
+	articleRepo repo.ArticleRepository,
+	spaceRepo repo.SpaceRepository,
+	pageTreeRepo repo.PageTreeRepository,
+	tagRepo repo.TagRepository,
+	attachmentRepo repo.AttachmentRepository,
+	userRepo repo.UserRepository,
+	blobs storage.BlobStore,
+) *ExportService {
+	return &ExportService{
+		articleRepo:    articleRepo,
+		spaceRepo:      spaceRepo,
+		pageTreeRepo:   pageTreeRepo,
+		tagRepo:        tagRepo,
+		attachmentRepo: attachmentRepo,
+		userRepo:       userRepo,
+		blobs:          blobs,
+		renderer:       markdown.New(),
+	}
+}
+
+// exportArticle - стаття зі зв'язаними даними; children впорядковані за позицією
+type exportArticle struct {
+	article     *models.Article
+	space       *models.Space
+	parent      *exportArticle
+	children    []*exportArticle
+	author      string
+	tags        []string
+	attachments []*models.Attachment
+}
+
+// exportSnapshot - вміст експорту. articles - у порядку дерева: простори за ключем,
+// у кожному сторінки в глибину; статті поза просторами (лише при експорті всієї бази) - в кінці за slug.
+type exportSnapshot struct {
+	spaces   []*models.Space
+	roots    map[uint][]*exportArticle
+	loose    []*exportArticle
+	articles []*exportArticle
+	bySlug   map[string]*exportArticle
+	// attachments - вкладення всіх статей експорту за ID
+	attachments map[uint]*models.Attachment
+}
+
+// Export записує архів у w. Помилки вибірки (зокрема ErrSpaceNotFound та
+// ErrInvalidExportFormat) повертаються до першого запису в w.
+func (s *ExportService) Export(ctx context.Context, w io.Writer, opts ExportOptions) error {
+	ctx, span := tracing.Start(ctx, "ExportService.Export", attribute.String("export.format", string(opts.Format)))
+	defer span.End()
+
+	if !opts.Format.IsValid() {
+		return ErrInvalidExportFormat
+	}
+
+	snapshot, err := s.snapshot(ctx, opts.SpaceID)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+
+	switch opts.Format {
+	case ExportFormatMarkdown:
+		err = s.writeMarkdown(ctx, archive, snapshot)
+	case ExportFormatHTML:
+		err = s.writeSite(ctx, archive, snapshot)
+	case ExportFormatJSON:
+		err = s.writeDump(ctx, archive, snapshot)
+	}
+
+	if err != nil {
+		return fmt.Errorf("ExportService - Export - %s: %w", opts.Format, err)
+	}
+
+	if err = archive.Close(); err != nil {
+		return fmt.Errorf("ExportService - Export - Close: %w", err)
+	}
+
+	return nil
+}
+
+// snapshot вибирає простори, статті, теги та вкладення експорту
+func (s *ExportService) snapshot(ctx context.Context, spaceID uint) (*exportSnapshot, error) {
+	snapshot := &exportSnapshot{
+		roots:       make(map[uint][]*exportArticle),
+		bySlug:      make(map[string]*exportArticle),
+		attachments: make(map[uint]*models.Attachment),
+	}
+
+	var err error
+
+	if snapshot.spaces, err = s.listSpaces(ctx, spaceID); err != nil {
+		return nil, err
+	}
+
+	page, err := s.articleRepo.ListArticles(ctx, models.ArticleFilter{SpaceID: spaceID}, pagination.Params{})
+	if err != nil {
+		return nil, fmt.Errorf("ExportService - snapshot - ListArticles: %w", err)
+	}
+
+	articles := page.Items
+
+	byID := make(map[uint]*models.Article, len(articles))
+	for _, article := range articles {
+		byID[article.ID] = article
+	}
+
+	for _, space := range snapshot.spaces {
+		pages, pagesErr := s.pageTreeRepo.ListSpacePages(ctx, space.ID)
+		if pagesErr != nil {
+			return nil, fmt.Errorf("ExportService - snapshot - ListSpacePages: %w", pagesErr)
+		}
+
+		snapshot.roots[space.ID] = snapshot.addPages(buildTree(pages), space, nil, byID)
+	}
+
+	if spaceID == 0 {
+		for _, article := range articles {
+			if article.SpaceID == nil {
+				snapshot.loose = append(snapshot.loose, &exportArticle{article: article})
+			}
+		}
+
+		sort.Slice(snapshot.loose, func(i, j int) bool {
+			return snapshot.loose[i].article.Slug < snapshot.loose[j].article.Slug
+		})
+
+		snapshot.articles = append(snapshot.articles, snapshot.loose...)
+	}
+
+	authors := make(map[uint]string)
+
+	for _, item := range snapshot.articles {
+		snapshot.bySlug[item.article.Slug] = item
+
+		if err = s.loadDetails(ctx, item, authors); err != nil {
+			return nil, err
+		}
+
+		for _, attachment := range item.attachments {
+			snapshot.attachments[attachment.ID] = attachment
+		}
+	}
+
+	return snapshot, nil
+}
+
+// listSpaces повертає простір spaceID або всі простори, впорядковані за ключем
+func (s *ExportService) listSpaces(ctx context.Context, spaceID uint) ([]*models.Space, error) {
+	if spaceID != 0 {
+		space, err := s.spaceRepo.GetSpaceByID(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("ExportService - listSpaces - GetSpaceByID: %w", err)
+		}
+
+		if space == nil {
+			return nil, ErrSpaceNotFound
+		}
+
+		return []*models.Space{space}, nil
+	}
+
+	page, err := s.spaceRepo.ListSpaces(ctx, pagination.Params{})
+	if err != nil {
+		return nil, fmt.Errorf("ExportService - listSpaces - ListSpaces: %w", err)
+	}
+
+	spaces := page.Items
+
+	sort.Slice(spaces, func(i, j int) bool {
+		return spaces[i].Key < spaces[j].Key
+	})
+
+	return spaces, nil
+}
+
+// addPages додає сторінки дерева в глибину; сторінки, створені після вибірки статей, пропускаються
+func (e *exportSnapshot) addPages(
+	pages []*models.PageNode,
+	space *models.Space,
+	parent *exportArticle,
+	byID map[uint]*models.Article,
+) []*exportArticle {
+	items := make([]*exportArticle, 0, len(pages))
+
+	for _, page := range pages {
+		article, ok := byID[page.ID]
+		if !ok {
+			continue
+		}
+
+		item := &exportArticle{article: article, space: space, parent: parent}
+		e.articles = append(e.articles, item)
+		item.children = e.addPages(page.Children, space, item, byID)
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func (s *ExportService) loadDetails(ctx context.Context, item *exportArticle, authors map[uint]string) error {
+	author, ok := authors[item.article.AuthorID]
+	if !ok {
+		user, err := s.userRepo.GetUserByID(ctx, item.article.AuthorID)
+		if err != nil {
+			return fmt.Errorf("ExportService - loadDetails - GetUserByID: %w", err)
+		}
+
+		if user != nil {
+			author = user.Username
+		}
+
+		authors[item.article.AuthorID] = author
+	}
+
+	item.author = author
+
+	tags, err := s.tagRepo.ListArticleTags(ctx, item.article.ID, pagination.Params{})
+	if err != nil {
+		return fmt.Errorf("ExportService - loadDetails - ListArticleTags: %w", err)
+	}
+
+	item.tags = make([]string, 0, len(tags.Items))
+	for _, tag := range tags.Items {
+		item.tags = append(item.tags, tag.Name)
+	}
+
+	attachments, err := s.attachmentRepo.ListAttachments(ctx, item.article.ID, pagination.Params{})
+	if err != nil {
+		return fmt.Errorf("ExportService - loadDetails - ListAttachments: %w", err)
+	}
+
+	item.attachments = attachments.Items
+
+	return nil
+}
+
+// exportFrontMatter - front matter markdown експорту; поля збігаються з тими, що читає імпорт
+type exportFrontMatter struct {
+	Title    string               `yaml:"title"`
+	Slug     string               `yaml:"slug"`
+	Author   string               `yaml:"author,omitempty"`
+	Status   models.ArticleStatus `yaml:"status"`
+	Language string               `yaml:"language"`
+	Tags     []string             `yaml:"tags,omitempty"`
+}
+
+// writeMarkdown пише простори директоріями за ключем. Сторінка з дочірніми стає
+// директорією з README.md, решта - файлами <slug>.md; вкладення лежать поруч у
+// <документ>.files, а посилання на них у тексті стають відносними.
+func (s *ExportService) writeMarkdown(ctx context.Context, archive *zip.Writer, snapshot *exportSnapshot) error {
+	documents := make(map[*exportArticle]string, len(snapshot.articles))
+	files := make(map[uint]string, len(snapshot.attachments))
+
+	for _, item := range snapshot.articles {
+		dir := "."
+
+		switch {
+		case item.parent != nil:
+			dir = path.Dir(documents[item.parent])
+		case item.space != nil:
+			dir = item.space.Key
+		}
+
+		document := path.Join(dir, item.article.Slug+".md")
+		if len(item.children) > 0 || _indexNames[item.article.Slug] {
+			document = path.Join(dir, item.article.Slug, "README.md")
+		}
+
+		documents[item] = document
+
+		assets := strings.TrimSuffix(document, ".md") + ".files"
+		for name, attachment := range attachmentFileNames(item.attachments) {
+			files[attachment.ID] = path.Join(assets, name)
+		}
+	}
+
+	for _, item := range snapshot.articles {
+		document := documents[item]
+
+		body := rewriteAttachmentLinks(item.article.Body, func(attachmentID uint) (string, bool) {
+			file, ok := files[attachmentID]
+			if !ok {
+				return "", false
+			}
+
+			return relativePath(path.Dir(document), file), true
+		})
+
+		source, err := markdown.WithFrontMatter(exportFrontMatter{
+			Title:    item.article.Title,
+			Slug:     item.article.Slug,
+			Author:   item.author,
+			Status:   item.article.Status,
+			Language: item.article.Language,
+			Tags:     item.tags,
+		}, []byte(body))
+		if err != nil {
+			return err
+		}
+
+		if err = writeZipFile(archive, document, item.article.UpdatedAt, source); err != nil {
+			return err
+		}
+
+		for _, attachment := range item.attachments {
+			if err = s.writeAttachment(ctx, archive, files[attachment.ID], attachment); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeDump пише ExportDumpFile і вміст вкладень, кожен вміст - один раз
+func (s *ExportService) writeDump(ctx context.Context, archive *zip.Writer, snapshot *exportSnapshot) error {
+	dump := &ExportDump{
+		Version:    ExportDumpVersion,
+		ExportedAt: time.Now().UTC(),
+		Spaces:     make([]*DumpSpace, 0, len(snapshot.spaces)),
+		Articles:   make([]*DumpArticle, 0, len(snapshot.articles)),
+	}
+
+	for _, space := range snapshot.spaces {
+		dump.Spaces = append(dump.Spaces, &DumpSpace{Key: space.Key, Name: space.Name, Description: space.Description})
+	}
+
+	written := make(map[string]bool)
+
+	for _, item := range snapshot.articles {
+		article := &DumpArticle{
+			ID:          item.article.ID,
+			Slug:        item.article.Slug,
+			Title:       item.article.Title,
+			Body:        item.article.Body,
+			Status:      item.article.Status,
+			Language:    item.article.Language,
+			Author:      item.author,
+			Tags:        item.tags,
+			Attachments: make([]*DumpAttachment, 0, len(item.attachments)),
+			PublishedAt: item.article.PublishedAt,
+			CreatedAt:   item.article.CreatedAt,
+			UpdatedAt:   item.article.UpdatedAt,
+		}
+
+		if item.space != nil {
+			article.Space = item.space.Key
+		}
+
+		if item.parent != nil {
+			article.Parent = item.parent.article.Slug
+		}
+
+		for _, attachment := range item.attachments {
+			article.Attachments = append(article.Attachments, &DumpAttachment{
+				ID:          attachment.ID,
+				Filename:    attachment.Filename,
+				ContentType: attachment.ContentType,
+				Size:        attachment.Size,
+				SHA256:      attachment.SHA256,
+			})
+
+			if written[attachment.SHA256] {
+				continue
+			}
+
+			written[attachment.SHA256] = true
+
+			if err := s.writeAttachment(ctx, archive, dumpAttachmentPath(attachment.SHA256), attachment); err != nil {
+				return err
+			}
+		}
+
+		dump.Articles = append(dump.Articles, article)
+	}
+
+	content, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeZipFile(archive, ExportDumpFile, dump.ExportedAt, content)
+}
+
+func (s *ExportService) writeAttachment(
+	ctx context.Context,
+	archive *zip.Writer,
+	name string,
+	attachment *models.Attachment,
+) error {
+	content, err := s.blobs.Get(ctx, BlobKey(attachment.SHA256))
+	if err != nil {
+		return fmt.Errorf("attachment %d: %w", attachment.ID, err)
+	}
+
+	defer func() {
+		_ = content.Close()
+	}()
+
+	// Вміст вкладень зазвичай вже стиснений (зображення, PDF)
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: attachment.CreatedAt})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, content)
+
+	return err
+}
+
+func writeZipFile(archive *zip.Writer, name string, modified time.Time, content []byte) error {
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(content)
+
+	return err
+}
+
+// attachmentFileNames дає вкладенням статті імена файлів; однакові або приховані
+// імена отримують префікс з ID вкладення
+func attachmentFileNames(attachments []*models.Attachment) map[string]*models.Attachment {
+	counts := make(map[string]int, len(attachments))
+	for _, attachment := range attachments {
+		counts[attachment.Filename]++
+	}
+
+	names := make(map[string]*models.Attachment, len(attachments))
+
+	for _, attachment := range attachments {
+		name := attachment.Filename
+		if counts[name] > 1 || name == "" || strings.HasPrefix(name, ".") {
+			name = strconv.FormatUint(uint64(attachment.ID), 10) + "-" + name
+		}
+
+		names[name] = attachment
+	}
+
+	return names
+}
+
+// rewriteAttachmentLinks замінює адреси вкладень у посиланнях та зображеннях тексту
+// на результат target; вкладення, для яких target повертає false, лишаються як є
+func rewriteAttachmentLinks(body string, target func(attachmentID uint) (string, bool)) string {
+	rewritten := markdown.RewriteLinks([]byte(body), func(link markdown.Link) (string, bool) {
+		match := _attachmentURLPattern.FindStringSubmatch(link.Destination)
+		if match == nil {
+			return "", false
+		}
+
+		id, err := strconv.ParseUint(match[2], 10, 32)
+		if err != nil {
+			return "", false
+		}
+
+		destination, ok := target(uint(id))
+		if !ok {
+			return "", false
+		}
+
+		link.Destination = destination
+
+		return link.String(), true
+	})
+
+	return string(rewritten)
+}
+
+// relativePath повертає шлях до target відносно директорії dir; обидва шляхи - від кореня архіву
+func relativePath(dir, target string) string {
+	from := strings.Split(dir, "/")
+	if dir == "." {
+		from = nil
+	}
+
+	to := strings.Split(target, "/")
+
+	common := 0
+	for common < len(from) && common < len(to)-1 && from[common] == to[common] {
+		common++
+	}
+
+	parts := make([]string, 0, len(from)-common+len(to)-common)
+	for range from[common:] {
+		parts = append(parts, "..")
+	}
+
+	return strings.Join(append(parts, to[common:]...), "/")
+}
+
+func dumpAttachmentPath(digest string) string {
+	return _dumpAttachmentsDir + "/" + digest
+}
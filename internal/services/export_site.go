@@ -0,0 +1,242 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"html/template"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	_sitePagesDir = "pages"
+	_siteFilesDir = "files"
+)
+
+// siteNavItem - пункт навігації; Href відносний до сторінки, на якій виводиться
+type siteNavItem struct {
+	Title    string
+	Href     string
+	Current  bool
+	Children []*siteNavItem
+}
+
+// siteSection - простір у навігації; Name порожній для статей поза просторами
+type siteSection struct {
+	Name        string
+	Description string
+	Pages       []*siteNavItem
+}
+
+type siteHeading struct {
+	Level  int
+	Text   string
+	Anchor string
+}
+
+type sitePage struct {
+	Title       string
+	Section     string
+	Breadcrumbs []*siteNavItem
+	Nav         []*siteNavItem
+	TOC         []siteHeading
+	Body        template.HTML
+	Tags        []string
+	Author      string
+	UpdatedAt   time.Time
+}
+
+type siteIndex struct {
+	Title      string
+	Sections   []*siteSection
+	ExportedAt time.Time
+}
+
+// _siteTemplates - сторінки статичного сайту. Стилі вбудовані, щоб кожна сторінка
+// відкривалася з диска без сервера та зовнішніх ресурсів.
+var _siteTemplates = template.Must(template.New("site").Parse(`
+{{define "style"}}<style>
+body{margin:0;font:16px/1.6 system-ui,sans-serif;color:#1f2328;display:flex;min-height:100vh}
+nav{width:18rem;flex:none;padding:1rem 1.25rem;background:#f6f8fa;border-right:1px solid #d0d7de}
+nav ul{list-style:none;padding-left:1rem;margin:0}nav>ul{padding-left:0}
+nav a{color:#1f2328;text-decoration:none}nav a:hover{text-decoration:underline}
+nav .current{font-weight:600}
+main{flex:1;max-width:52rem;padding:1rem 2rem}
+.breadcrumbs,.meta{color:#59636e;font-size:.875rem}
+.toc{border-left:3px solid #d0d7de;padding-left:1rem;font-size:.9rem}
+.tag{display:inline-block;background:#ddf4ff;border-radius:1rem;padding:0 .6rem;margin-right:.25rem}
+pre{background:#f6f8fa;padding:1rem;overflow:auto}code{font-size:.9em}
+table{border-collapse:collapse}td,th{border:1px solid #d0d7de;padding:.25rem .5rem}
+img{max-width:100%}.wiki-link-broken{color:#cf222e;text-decoration:line-through}
+</style>{{end}}
+
+{{define "tree"}}<ul>{{range .}}<li><a href="{{.Href}}"{{if .Current}} class="current"{{end}}>{{.Title}}</a>
+{{- if .Children}}{{template "tree" .Children}}{{end}}</li>{{end}}</ul>{{end}}
+
+{{define "index"}}<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>{{template "style"}}</head>
+<body><main><h1>{{.Title}}</h1>
+{{range .Sections}}<section>{{if .Name}}<h2>{{.Name}}</h2>{{else}}<h2>Other articles</h2>{{end}}
+{{if .Description}}<p>{{.Description}}</p>{{end}}{{template "tree" .Pages}}</section>
+{{end}}<p class="meta">Exported {{.ExportedAt.Format "2006-01-02 15:04 MST"}}</p></main></body></html>
+{{end}}
+
+{{define "page"}}<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>{{template "style"}}</head>
+<body><nav><p><a href="../index.html">&larr; All spaces</a></p>{{if .Section}}<h3>{{.Section}}</h3>{{end}}
+{{template "tree" .Nav}}</nav>
+<main>{{if .Breadcrumbs}}<p class="breadcrumbs">{{range $i, $crumb := .Breadcrumbs}}{{if $i}} / {{end}}
+<a href="{{$crumb.Href}}">{{$crumb.Title}}</a>{{end}}</p>{{end}}
+<h1>{{.Title}}</h1>
+<p class="meta">{{if .Author}}{{.Author}}, {{end}}updated {{.UpdatedAt.Format "2006-01-02"}}
+{{range .Tags}} <span class="tag">{{.}}</span>{{end}}</p>
+{{if .TOC}}<div class="toc">{{range .TOC}}<div style="margin-left:{{.Level}}em">
+<a href="#{{.Anchor}}">{{.Text}}</a></div>{{end}}</div>{{end}}
+{{.Body}}</main></body></html>
+{{end}}
+`))
+
+// writeSite пише index.html зі змістом усіх просторів, сторінки pages/<slug>.html
+// з деревом простору в навігації та вкладення files/<id>-<ім'я>. Wiki-посилання на
+// статті поза експортом виводяться як биті.
+func (s *ExportService) writeSite(ctx context.Context, archive *zip.Writer, snapshot *exportSnapshot) error {
+	files := make(map[uint]string, len(snapshot.attachments))
+	for id, attachment := range snapshot.attachments {
+		files[id] = _siteFilesDir + "/" + strconv.FormatUint(uint64(id), 10) + "-" + attachment.Filename
+	}
+
+	exportedAt := time.Now().UTC()
+	index := &siteIndex{Title: "Knowledge Hub", ExportedAt: exportedAt}
+
+	for _, space := range snapshot.spaces {
+		index.Sections = append(index.Sections, &siteSection{
+			Name:        space.Name,
+			Description: space.Description,
+			Pages:       siteNav(snapshot.roots[space.ID], _sitePagesDir+"/", nil),
+		})
+	}
+
+	if len(snapshot.loose) > 0 {
+		index.Sections = append(index.Sections, &siteSection{Pages: siteNav(snapshot.loose, _sitePagesDir+"/", nil)})
+	}
+
+	if len(snapshot.spaces) == 1 && len(snapshot.loose) == 0 {
+		index.Title = snapshot.spaces[0].Name
+	}
+
+	if err := writeSiteTemplate(archive, "index.html", "index", exportedAt, index); err != nil {
+		return err
+	}
+
+	for _, item := range snapshot.articles {
+		if err := s.writeSitePage(archive, snapshot, item, files); err != nil {
+			return err
+		}
+	}
+
+	for id, attachment := range snapshot.attachments {
+		if err := s.writeAttachment(ctx, archive, files[id], attachment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ExportService) writeSitePage(
+	archive *zip.Writer,
+	snapshot *exportSnapshot,
+	item *exportArticle,
+	files map[uint]string,
+) error {
+	body := rewriteAttachmentLinks(item.article.Body, func(attachmentID uint) (string, bool) {
+		file, ok := files[attachmentID]
+		if !ok {
+			return "", false
+		}
+
+		return "../" + escapePath(file), true
+	})
+
+	doc, err := s.renderer.Render([]byte(body), func(target string) (string, bool) {
+		linked, ok := snapshot.bySlug[Slugify(target)]
+		if !ok {
+			return "", false
+		}
+
+		return sitePageHref(linked), true
+	})
+	if err != nil {
+		return err
+	}
+
+	page := &sitePage{
+		Title:     item.article.Title,
+		Body:      template.HTML(doc.HTML), //nolint:gosec // HTML санітизовано markdown.Renderer
+		Tags:      item.tags,
+		Author:    item.author,
+		UpdatedAt: item.article.UpdatedAt,
+	}
+
+	for _, heading := range doc.Headings {
+		page.TOC = append(page.TOC, siteHeading{Level: heading.Level, Text: heading.Text, Anchor: heading.Anchor})
+	}
+
+	if item.space != nil {
+		page.Section = item.space.Name
+		page.Nav = siteNav(snapshot.roots[item.space.ID], "", item)
+	} else {
+		page.Nav = siteNav(snapshot.loose, "", item)
+	}
+
+	for parent := item.parent; parent != nil; parent = parent.parent {
+		crumb := &siteNavItem{Title: parent.article.Title, Href: sitePageHref(parent)}
+		page.Breadcrumbs = append([]*siteNavItem{crumb}, page.Breadcrumbs...)
+	}
+
+	return writeSiteTemplate(archive, _sitePagesDir+"/"+sitePageFile(item), "page", item.article.UpdatedAt, page)
+}
+
+// siteNav будує дерево навігації з адресами з префіксом prefix; current позначається
+func siteNav(items []*exportArticle, prefix string, current *exportArticle) []*siteNavItem {
+	nav := make([]*siteNavItem, 0, len(items))
+
+	for _, item := range items {
+		nav = append(nav, &siteNavItem{
+			Title:    item.article.Title,
+			Href:     prefix + sitePageHref(item),
+			Current:  item == current,
+			Children: siteNav(item.children, prefix, current),
+		})
+	}
+
+	return nav
+}
+
+func writeSiteTemplate(archive *zip.Writer, name, tmpl string, modified time.Time, data any) error {
+	var buf bytes.Buffer
+
+	if err := _siteTemplates.ExecuteTemplate(&buf, tmpl, data); err != nil {
+		return err
+	}
+
+	return writeZipFile(archive, name, modified, buf.Bytes())
+}
+
+func sitePageFile(item *exportArticle) string {
+	return item.article.Slug + ".html"
+}
+
+// sitePageHref - адреса сторінки статті відносно директорії pages
+func sitePageHref(item *exportArticle) string {
+	return url.PathEscape(sitePageFile(item))
+}
+
+// escapePath екранує кожен сегмент шляху для використання в адресі
+func escapePath(p string) string {
+	return (&url.URL{Path: p}).EscapedPath()
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+	"KnowledgeHub/pkg/storage"
+)
+
+// testHub - сервіси над одним сховищем для експорту та повторного імпорту
+type testHub struct {
+	mocks       *mocks.Mocks
+	articles    *ArticleService
+	spaces      *SpaceService
+	tags        *TagService
+	attachments *AttachmentService
+	exporter    *ExportService
+	importer    *ImportService
+}
+
+func newTestHub(t *testing.T) *testHub {
+	t.Helper()
+
+	blobs, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "admin"})
+	mockRepo.AddUser(&models.User{ID: 2, Username: "johndoe"})
+
+	access := NewAccessService(mockRepo.SpaceMember(), mockRepo.Article())
+
+	hub := &testHub{
+		mocks:       mockRepo,
+		articles:    NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(), access),
+		spaces:      NewSpaceService(mockRepo.Space(), mockRepo.Article(), mockRepo.PageTree(), mockRepo.SpaceMember(), access),
+		tags:        NewTagService(mockRepo.Tag(), mockRepo.Article()),
+		attachments: NewAttachmentService(mockRepo.Attachment(), mockRepo.Article(), blobs),
+	}
+
+	hub.exporter = NewExportService(mockRepo.Article(), mockRepo.Space(), mockRepo.PageTree(), mockRepo.Tag(),
+		mockRepo.Attachment(), mockRepo.User(), blobs)
+	hub.importer = NewImportService(hub.articles, hub.spaces, hub.tags, hub.attachments, mockRepo.User(), mockRepo.Space())
+
+	return hub
+}
+
+// seed створює простір ops з деревом guide -> deploy, вкладенням і статтю notes поза просторами
+func (h *testHub) seed(t *testing.T) (*models.Space, *models.Article) {
+	t.Helper()
+	ctx := context.Background()
+
+	space, err := h.spaces.Create(ctx, 1, SpaceInput{Key: "ops", Name: "Operations", Description: "Runbooks"})
+	if err != nil {
+		t.Fatalf("Create space: %v", err)
+	}
+
+	guide, err := h.articles.Create(ctx, asEditor(2), ArticleInput{
+		Title: "Guide", Body: "Start with [[Deploy]].", Status: models.ArticleStatusPublished, SpaceID: &space.ID,
+	})
+	if err != nil {
+		t.Fatalf("Create guide: %v", err)
+	}
+
+	deploy, err := h.articles.Create(ctx, asEditor(1), ArticleInput{Title: "Deploy", Language: "english", ParentID: &guide.ID})
+	if err != nil {
+		t.Fatalf("Create deploy: %v", err)
+	}
+
+	attachment, err := h.attachments.Upload(ctx, 1, deploy.ID, "flow chart.png", strings.NewReader(_pngHeader))
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+
+	body := fmt.Sprintf("# Steps\n\n![flow](/v1/articles/%d/attachments/%d)\n\nBack to [[Guide]], see [[Notes]].",
+		deploy.ID, attachment.ID)
+	if deploy, err = h.articles.Update(ctx, deploy.ID, 1, ArticleInput{Title: "Deploy", Body: body}); err != nil {
+		t.Fatalf("Update deploy: %v", err)
+	}
+
+	if _, err = h.tags.AddArticleTags(ctx, deploy.ID, []string{"ops", "k8s"}); err != nil {
+		t.Fatalf("AddArticleTags: %v", err)
+	}
+
+	if _, err = h.articles.Create(ctx, asEditor(1), ArticleInput{Title: "Notes", Body: "Loose page"}); err != nil {
+		t.Fatalf("Create notes: %v", err)
+	}
+
+	return space, deploy
+}
+
+func (h *testHub) export(t *testing.T, opts ExportOptions) *zip.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := h.exporter.Export(context.Background(), &buf, opts); err != nil {
+		t.Fatalf("Export(%+v) error = %v", opts, err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+
+	return archive
+}
+
+func zipFiles(t *testing.T, archive *zip.Reader) map[string]string {
+	t.Helper()
+
+	files := make(map[string]string, len(archive.File))
+
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatalf("Open %s: %v", file.Name, err)
+		}
+
+		content, _ := io.ReadAll(r)
+		_ = r.Close()
+		files[file.Name] = string(content)
+	}
+
+	return files
+}
+
+func TestExportService_Markdown(t *testing.T) {
+	hub := newTestHub(t)
+	space, _ := hub.seed(t)
+
+	files := zipFiles(t, hub.export(t, ExportOptions{SpaceID: space.ID, Format: ExportFormatMarkdown}))
+
+	if len(files) != 3 {
+		t.Fatalf("Unexpected files: %v", files)
+	}
+
+	guide := files["ops/guide/README.md"]
+	if !strings.Contains(guide, "title: Guide\n") || !strings.Contains(guide, "author: johndoe\n") ||
+		!strings.Contains(guide, "status: published\n") || !strings.HasSuffix(guide, "---\nStart with [[Deploy]].") {
+		t.Errorf("Unexpected guide:\n%s", guide)
+	}
+
+	deploy := files["ops/guide/deploy.md"]
+	if !strings.Contains(deploy, "![flow](<deploy.files/flow chart.png>)") || !strings.Contains(deploy, "- k8s\n") {
+		t.Errorf("Unexpected deploy:\n%s", deploy)
+	}
+
+	if files["ops/guide/deploy.files/flow chart.png"] != _pngHeader {
+		t.Errorf("Attachment content = %q", files["ops/guide/deploy.files/flow chart.png"])
+	}
+
+	// Markdown архів імпортується в порожню базу з тим самим деревом
+	target := newTestHub(t)
+
+	report, err := target.importer.Import(context.Background(), hub.export(t, ExportOptions{
+		SpaceID: space.ID, Format: ExportFormatMarkdown,
+	}), ImportOptions{UserID: 1, Commit: true})
+	if err != nil || report.Summary.Warnings != 0 {
+		t.Fatalf("Import() = %+v, %v", report, err)
+	}
+
+	imported, err := target.articles.GetBySlug(context.Background(), "deploy")
+	if err != nil || imported.ParentID == nil || imported.Language != "english" ||
+		!strings.Contains(imported.Body, fmt.Sprintf("/v1/articles/%d/attachments/", imported.ID)) {
+		t.Errorf("Imported deploy = %+v, %v", imported, err)
+	}
+}
+
+func TestExportService_HTML(t *testing.T) {
+	hub := newTestHub(t)
+	_, deploy := hub.seed(t)
+
+	files := zipFiles(t, hub.export(t, ExportOptions{Format: ExportFormatHTML}))
+
+	list, _ := hub.mocks.Attachment().ListAttachments(context.Background(), deploy.ID, pagination.Params{})
+	if len(list.Items) != 1 {
+		t.Fatalf("ListAttachments() = %v", list)
+	}
+
+	attachments := list.Items
+
+	index := files["index.html"]
+	if !strings.Contains(index, "<h2>Operations</h2>") || !strings.Contains(index, `href="pages/deploy.html"`) ||
+		!strings.Contains(index, `href="pages/notes.html"`) {
+		t.Errorf("Unexpected index:\n%s", index)
+	}
+
+	page := files["pages/deploy.html"]
+	for _, want := range []string{
+		`<a href="guide.html">Guide</a>`,
+		`<a href="deploy.html" class="current">Deploy</a>`,
+		fmt.Sprintf(`src="../files/%d-flow%%20chart.png"`, attachments[0].ID),
+		`<a href="guide.html" class="wiki-link" rel="nofollow">Guide</a>`,
+		// Notes поза простором, але в експорті всієї бази
+		`<a href="notes.html" class="wiki-link" rel="nofollow">Notes</a>`,
+		`<span class="tag">k8s</span>`,
+		`<a href="#steps">Steps</a>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Page does not contain %s:\n%s", want, page)
+		}
+	}
+
+	if _, ok := files[fmt.Sprintf("files/%d-flow chart.png", attachments[0].ID)]; !ok {
+		t.Errorf("Attachment is not exported: %v", files)
+	}
+}
+
+func TestExportService_JSON(t *testing.T) {
+	hub := newTestHub(t)
+	hub.seed(t)
+
+	files := zipFiles(t, hub.export(t, ExportOptions{Format: ExportFormatJSON}))
+
+	dump := files[ExportDumpFile]
+	if !strings.Contains(dump, `"version": 1`) || !strings.Contains(dump, `"parent": "guide"`) ||
+		!strings.Contains(dump, `"description": "Runbooks"`) || len(files) != 2 {
+		t.Errorf("Unexpected dump (%d files):\n%s", len(files), dump)
+	}
+
+	// Дамп відновлюється в порожню базу разом з описом простору, мовою та вкладеннями
+	target := newTestHub(t)
+	ctx := context.Background()
+
+	dryRun, err := target.importer.Import(ctx, hub.export(t, ExportOptions{Format: ExportFormatJSON}), ImportOptions{UserID: 1})
+	if err != nil || dryRun.Summary.Articles != 3 || dryRun.Summary.Spaces != 1 || dryRun.Summary.Attachments != 1 {
+		t.Fatalf("Import() dry run = %+v, %v", dryRun, err)
+	}
+
+	report, err := target.importer.Import(ctx, hub.export(t, ExportOptions{Format: ExportFormatJSON}),
+		ImportOptions{UserID: 1, Commit: true})
+	if err != nil || !report.Committed || report.Summary.Warnings != 0 {
+		t.Fatalf("Import() = %+v, %v", report, err)
+	}
+
+	space, _ := target.mocks.Space().GetSpaceByKey(ctx, "ops")
+	if space == nil || space.Description != "Runbooks" || space.Name != "Operations" {
+		t.Errorf("Imported space = %+v", space)
+	}
+
+	guide, _ := target.articles.GetBySlug(ctx, "guide")
+
+	deploy, err := target.articles.GetBySlug(ctx, "deploy")
+	if err != nil || deploy.ParentID == nil || guide == nil || *deploy.ParentID != guide.ID ||
+		guide.AuthorID != 2 || guide.Status != models.ArticleStatusPublished || deploy.Language != "english" {
+		t.Fatalf("Imported deploy = %+v, guide = %+v, %v", deploy, guide, err)
+	}
+
+	list, _ := target.mocks.Attachment().ListAttachments(ctx, deploy.ID, pagination.Params{})
+	attachments := list.Items
+
+	if len(attachments) != 1 || attachments[0].Filename != "flow chart.png" ||
+		!strings.Contains(deploy.Body, fmt.Sprintf("(/v1/articles/%d/attachments/%d)", deploy.ID, attachments[0].ID)) {
+		t.Errorf("Imported attachments = %v, body %q", attachments, deploy.Body)
+	}
+
+	if tags, _ := target.mocks.Tag().ListArticleTags(ctx, deploy.ID, pagination.Params{}); len(tags.Items) != 2 {
+		t.Errorf("Imported tags = %v", tags)
+	}
+}
+
+func TestExportService_Errors(t *testing.T) {
+	hub := newTestHub(t)
+
+	err := hub.exporter.Export(context.Background(), io.Discard, ExportOptions{Format: "pdf"})
+	if !errors.Is(err, ErrInvalidExportFormat) {
+		t.Errorf("Export() error = %v, want %v", err, ErrInvalidExportFormat)
+	}
+
+	err = hub.exporter.Export(context.Background(), io.Discard, ExportOptions{SpaceID: 42, Format: ExportFormatJSON})
+	if !errors.Is(err, ErrSpaceNotFound) {
+		t.Errorf("Export() error = %v, want %v", err, ErrSpaceNotFound)
+	}
+
+	tree := map[string]string{ExportDumpFile: `{"version": 99, "articles": []}`}
+	if _, err = hub.importer.Import(context.Background(), mapFS(tree), ImportOptions{UserID: 1}); !errors.Is(
+		err, ErrUnsupportedDumpVersion) {
+		t.Errorf("Import() error = %v, want %v", err, ErrUnsupportedDumpVersion)
+	}
+
+	tree[ExportDumpFile] = "{"
+	if _, err = hub.importer.Import(context.Background(), mapFS(tree), ImportOptions{UserID: 1}); !errors.Is(
+		err, ErrInvalidImportDump) {
+		t.Errorf("Import() error = %v, want %v", err, ErrInvalidImportDump)
+	}
+}
+
+func mapFS(files map[string]string) fstest.MapFS {
+	fsys := make(fstest.MapFS, len(files))
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+
+	return fsys
+}
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		dir, target, want string
+	}{
+		{"ops", "ops/a.files/x.png", "a.files/x.png"},
+		{"ops/guide", "ops/a.files/x.png", "../a.files/x.png"},
+		{".", "ops/a.files/x.png", "ops/a.files/x.png"},
+		{"ops/guide", "dev/x.png", "../../dev/x.png"},
+	}
+
+	for _, tt := range tests {
+		if got := relativePath(tt.dir, tt.target); got != tt.want {
+			t.Errorf("relativePath(%q, %q) = %q, want %q", tt.dir, tt.target, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,912 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/markdown"
+	"KnowledgeHub/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// MaxImportFiles обмежує кількість файлів у дереві, що імпортується
+	MaxImportFiles = 10000
+	// MaxImportDocumentSize - максимальний розмір одного markdown документа в байтах
+	MaxImportDocumentSize = 1 << 20
+
+	_maxImportTitleLength = 255
+	// _attachmentURLFormat - адреса вмісту вкладення статті
+	_attachmentURLFormat = "/v1/articles/%d/attachments/%d"
+)
+
+var (
+	ErrNothingToImport    = apperror.Validation("nothing_to_import", "no markdown documents to import")
+	ErrTooManyImportFiles = apperror.Validation("too_many_import_files", "too many files to import")
+	ErrInvalidImportDump  = apperror.Validation("invalid_import_dump", "invalid export dump")
+)
+
+// _indexNames - документи, що описують свою директорію і стають батьками інших сторінок у ній
+var _indexNames = map[string]bool{"readme": true, "index": true, "_index": true}
+
+// ImportOptions - параметри імпорту
+type ImportOptions struct {
+	// UserID - користувач, від імені якого йде імпорт: він створює простори,
+	// завантажує вкладення і стає автором документів без відомого author
+	UserID uint
+	// Commit створює статті; без нього Import лише повертає звіт dry-run
+	Commit bool
+}
+
+// ImportReport - план імпорту (dry-run) або результат виконаного імпорту
+type ImportReport struct {
+	Committed bool               `json:"committed" example:"false"`
+	Spaces    []*ImportedSpace   `json:"spaces"`
+	Articles  []*ImportedArticle `json:"articles"`
+	Skipped   []*SkippedFile     `json:"skipped"`
+	Summary   ImportSummary      `json:"summary"`
+}
+
+// ImportedSpace - простір, у який потрапляють документи директорії верхнього рівня
+type ImportedSpace struct {
+	ID     uint   `json:"id,omitempty" example:"1"`
+	Key    string `json:"key" example:"engineering"`
+	Name   string `json:"name" example:"engineering"`
+	Exists bool   `json:"exists" example:"false"`
+
+	description string
+}
+
+// ImportedArticle - стаття, створена з документа Path (для дампу - knowledgehub.json#<slug>).
+// Parent - шлях документа батьківської сторінки, Author - ім'я з front matter.
+// Links - кількість відносних посилань, перетворених на wiki-посилання,
+// Attachments - файли дерева, що завантажуються як вкладення статті.
+type ImportedArticle struct {
+	ID          uint                 `json:"id,omitempty" example:"12"`
+	Path        string               `json:"path" example:"engineering/deploy.md"`
+	Title       string               `json:"title" example:"Deploy guide"`
+	Slug        string               `json:"slug" example:"deploy-guide"`
+	Status      models.ArticleStatus `json:"status" example:"draft"`
+	Space       string               `json:"space,omitempty" example:"engineering"`
+	Parent      string               `json:"parent,omitempty" example:"engineering/README.md"`
+	Author      string               `json:"author,omitempty" example:"johndoe"`
+	Tags        []string             `json:"tags"`
+	Links       int                  `json:"links" example:"3"`
+	Attachments []string             `json:"attachments"`
+	Warnings    []string             `json:"warnings"`
+}
+
+// SkippedFile - документ, який не буде імпортовано, з причиною
+type SkippedFile struct {
+	Path   string `json:"path" example:"notes/broken.md"`
+	Reason string `json:"reason" example:"invalid front matter"`
+}
+
+// ImportSummary - підсумкові лічильники звіту; Spaces рахує лише нові простори
+type ImportSummary struct {
+	Articles    int `json:"articles" example:"42"`
+	Spaces      int `json:"spaces" example:"3"`
+	Attachments int `json:"attachments" example:"17"`
+	Links       int `json:"links" example:"120"`
+	Warnings    int `json:"warnings" example:"2"`
+	Skipped     int `json:"skipped" example:"1"`
+}
+
+// ImportService переносить базу знань з дерева markdown файлів (директорії або zip архіву).
+// Директорії верхнього рівня стають просторами, README.md або index.md директорії -
+// батьківською сторінкою інших документів у ній. Відносні посилання на документи
+// перетворюються на wiki-посилання, а на інші файли дерева - на вкладення статті.
+// Дерево з ExportDumpFile у корені відновлюється з дампу ExportService.
+type ImportService struct {
+	articleService    *ArticleService
+	spaceService      *SpaceService
+	tagService        *TagService
+	attachmentService *AttachmentService
+	userRepo          repo.UserRepository
+	spaceRepo         repo.SpaceRepository
+}
+
+func NewImportService(
+	articleService *ArticleService,
+	spaceService *SpaceService,
+	tagService *TagService,
+	attachmentService *AttachmentService,
+	userRepo repo.UserRepository,
+	spaceRepo repo.SpaceRepository,
+) *ImportService {
+	return &ImportService{
+		articleService:    articleService,
+		spaceService:      spaceService,
+		tagService:        tagService,
+		attachmentService: attachmentService,
+		userRepo:          userRepo,
+		spaceRepo:         spaceRepo,
+	}
+}
+
+// importFrontMatter - поля front matter, які розуміє імпорт
+type importFrontMatter struct {
+	Title    string     `yaml:"title"`
+	Slug     string     `yaml:"slug"`
+	Author   string     `yaml:"author"`
+	Status   string     `yaml:"status"`
+	Language string     `yaml:"language"`
+	Tags     importTags `yaml:"tags"`
+}
+
+// importTags приймає теги як YAML список або рядок через кому
+type importTags []string
+
+func (t *importTags) UnmarshalYAML(unmarshal func(any) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*t = list
+		return nil
+	}
+
+	var line string
+	if err := unmarshal(&line); err != nil {
+		return err
+	}
+
+	*t = strings.Split(line, ",")
+
+	return nil
+}
+
+// importDocument - документ у плані імпорту. Для дампу dump встановлено, а dir порожній.
+type importDocument struct {
+	report   *ImportedArticle
+	dir      string
+	index    bool
+	dump     bool
+	body     string
+	language string
+	authorID uint
+	space    *ImportedSpace
+	parent   *importDocument
+	assets   []*importAsset
+}
+
+// importAsset - файл дерева path, що завантажується як вкладення filename.
+// ref - адреса файлу в тексті документа: шлях у дереві або адреса вкладення в дампі.
+type importAsset struct {
+	path     string
+	filename string
+	ref      string
+}
+
+// importPlan - розібране дерево: документи в порядку створення та решта файлів.
+// spaces - простори за ключем.
+type importPlan struct {
+	report    *ImportReport
+	documents []*importDocument
+	byPath    map[string]*importDocument
+	indexes   map[string]*importDocument
+	assets    map[string]bool
+	spaces    map[string]*ImportedSpace
+	slugs     map[string]bool
+	authors   map[string]uint
+}
+
+// Import будує план імпорту дерева fsys і, якщо задано opts.Commit, виконує його.
+// Імпорт не атомарний: при помилці повертається і звіт, у якому статті, що вже
+// створені, мають ID.
+func (s *ImportService) Import(ctx context.Context, fsys fs.FS, opts ImportOptions) (*ImportReport, error) {
+	ctx, span := tracing.Start(ctx, "ImportService.Import", attribute.Bool("import.commit", opts.Commit))
+	defer span.End()
+
+	plan := newImportPlan()
+
+	var err error
+
+	if _, statErr := fs.Stat(fsys, ExportDumpFile); statErr == nil {
+		err = s.planDump(ctx, fsys, plan, opts)
+	} else {
+		err = s.plan(ctx, fsys, plan, opts)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Commit {
+		err = s.commit(ctx, fsys, plan, opts)
+		plan.report.Committed = err == nil
+	}
+
+	plan.summarize()
+
+	return plan.report, err
+}
+
+func newImportPlan() *importPlan {
+	return &importPlan{
+		report: &ImportReport{
+			Spaces:   make([]*ImportedSpace, 0),
+			Articles: make([]*ImportedArticle, 0),
+			Skipped:  make([]*SkippedFile, 0),
+		},
+		byPath:  make(map[string]*importDocument),
+		indexes: make(map[string]*importDocument),
+		assets:  make(map[string]bool),
+		spaces:  make(map[string]*ImportedSpace),
+		slugs:   make(map[string]bool),
+		authors: make(map[string]uint),
+	}
+}
+
+// plan розбирає дерево markdown файлів
+func (s *ImportService) plan(ctx context.Context, fsys fs.FS, plan *importPlan, opts ImportOptions) error {
+	paths, err := listImportFiles(fsys)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range paths {
+		if !isMarkdownFile(p) {
+			plan.assets[p] = true
+			continue
+		}
+
+		if err = s.planDocument(ctx, fsys, plan, p, opts); err != nil {
+			return err
+		}
+	}
+
+	if len(plan.documents) == 0 {
+		return ErrNothingToImport
+	}
+
+	// Батьківська сторінка завжди в тій самій або вищій директорії, тому
+	// після сортування батьки створюються раніше за дочірні сторінки
+	sort.SliceStable(plan.documents, func(i, j int) bool {
+		a, b := plan.documents[i], plan.documents[j]
+
+		if depthA, depthB := pathDepth(a.dir), pathDepth(b.dir); depthA != depthB {
+			return depthA < depthB
+		}
+
+		if a.index != b.index {
+			return a.index
+		}
+
+		return a.report.Path < b.report.Path
+	})
+
+	for _, doc := range plan.documents {
+		plan.assignParent(doc)
+		plan.rewriteLinks(doc)
+		plan.report.Articles = append(plan.report.Articles, doc.report)
+	}
+
+	return nil
+}
+
+// planDocument читає документ і визначає його заголовок, slug, автора, теги та простір
+func (s *ImportService) planDocument(
+	ctx context.Context,
+	fsys fs.FS,
+	plan *importPlan,
+	p string,
+	opts ImportOptions,
+) error {
+	source, reason := readImportDocument(fsys, p)
+	if reason != "" {
+		plan.skip(p, reason)
+		return nil
+	}
+
+	var meta importFrontMatter
+
+	body, err := markdown.ParseFrontMatter(source, &meta)
+	if err != nil {
+		plan.skip(p, "invalid front matter: "+err.Error())
+		return nil
+	}
+
+	doc := newImportDocument(p, meta.Title, meta.Author, opts.UserID)
+	doc.dir = path.Dir(p)
+	doc.index = _indexNames[strings.ToLower(strings.TrimSuffix(path.Base(p), path.Ext(p)))]
+	doc.body = string(body)
+
+	if doc.report.Title == "" {
+		doc.report.Title = titleFromPath(p, doc.index)
+	}
+
+	if err = s.planFields(ctx, plan, doc, meta.Slug, meta.Status, meta.Language); err != nil {
+		return err
+	}
+
+	if err = s.planSpace(ctx, plan, doc); err != nil {
+		return err
+	}
+
+	planTags(doc, meta.Tags)
+
+	plan.documents = append(plan.documents, doc)
+	plan.byPath[p] = doc
+
+	if doc.index && plan.indexes[doc.dir] == nil {
+		plan.indexes[doc.dir] = doc
+	}
+
+	return nil
+}
+
+func newImportDocument(p, title, author string, userID uint) *importDocument {
+	return &importDocument{
+		report: &ImportedArticle{
+			Path:        p,
+			Title:       strings.TrimSpace(title),
+			Status:      models.ArticleStatusDraft,
+			Author:      strings.TrimSpace(author),
+			Tags:        make([]string, 0),
+			Attachments: make([]string, 0),
+			Warnings:    make([]string, 0),
+		},
+		authorID: userID,
+	}
+}
+
+// planFields перевіряє заголовок, статус і мову документа та підбирає автора і slug
+func (s *ImportService) planFields(
+	ctx context.Context,
+	plan *importPlan,
+	doc *importDocument,
+	slug, status, language string,
+) error {
+	if utf8.RuneCountInString(doc.report.Title) > _maxImportTitleLength {
+		doc.report.Title = string([]rune(doc.report.Title)[:_maxImportTitleLength])
+		doc.warn("title truncated to %d characters", _maxImportTitleLength)
+	}
+
+	if status != "" {
+		if parsed := models.ArticleStatus(strings.ToLower(status)); parsed.IsValid() {
+			doc.report.Status = parsed
+		} else {
+			doc.warn("unknown status %q, importing as draft", status)
+		}
+	}
+
+	if language != "" {
+		if models.IsArticleLanguage(strings.ToLower(language)) {
+			doc.language = strings.ToLower(language)
+		} else {
+			doc.warn("unsupported language %q, importing as %s", language, models.ArticleLanguageDefault)
+		}
+	}
+
+	if err := s.planAuthor(ctx, plan, doc); err != nil {
+		return err
+	}
+
+	return s.planSlug(ctx, plan, doc, slug)
+}
+
+func (s *ImportService) planAuthor(ctx context.Context, plan *importPlan, doc *importDocument) error {
+	username := doc.report.Author
+	if username == "" {
+		return nil
+	}
+
+	if id, ok := plan.authors[username]; ok {
+		if id == 0 {
+			doc.warn("unknown author %q, importing as the current user", username)
+		} else {
+			doc.authorID = id
+		}
+
+		return nil
+	}
+
+	user, err := s.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("ImportService - planAuthor - GetUserByUsername: %w", err)
+	}
+
+	if user == nil {
+		plan.authors[username] = 0
+		doc.warn("unknown author %q, importing as the current user", username)
+
+		return nil
+	}
+
+	plan.authors[username] = user.ID
+	doc.authorID = user.ID
+
+	return nil
+}
+
+// planSlug підбирає slug, вільний і серед наявних статей, і серед документів імпорту
+func (s *ImportService) planSlug(ctx context.Context, plan *importPlan, doc *importDocument, requested string) error {
+	base := Slugify(requested)
+	if base == "" {
+		base = Slugify(doc.report.Title)
+	}
+
+	if base == "" {
+		base = "article"
+	}
+
+	for attempt := 1; attempt <= _maxSlugAttempts; attempt++ {
+		slug := base
+		if attempt > 1 {
+			slug = fmt.Sprintf("%s-%d", base, attempt)
+		}
+
+		if plan.slugs[slug] {
+			continue
+		}
+
+		_, err := s.articleService.GetBySlug(ctx, slug)
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, ErrArticleNotFound) {
+			return fmt.Errorf("ImportService - planSlug - GetBySlug: %w", err)
+		}
+
+		if requested != "" && slug != base {
+			doc.warn("slug %q is taken, using %q", base, slug)
+		}
+
+		plan.slugs[slug] = true
+		doc.report.Slug = slug
+
+		return nil
+	}
+
+	return fmt.Errorf("ImportService - planSlug: %w", ErrSlugAlreadyExists)
+}
+
+// planSpace зіставляє директорію верхнього рівня з простором за ключем
+func (s *ImportService) planSpace(ctx context.Context, plan *importPlan, doc *importDocument) error {
+	if doc.dir == "." {
+		return nil
+	}
+
+	name, _, _ := strings.Cut(doc.dir, "/")
+
+	space, err := s.space(ctx, plan, SpaceInput{Name: name})
+	if err != nil {
+		return err
+	}
+
+	if space == nil {
+		doc.warn("directory %q has no valid space key, importing outside spaces", name)
+		return nil
+	}
+
+	doc.space = space
+	doc.report.Space = space.Key
+
+	return nil
+}
+
+// space повертає простір плану з ключем input; наявний простір з таким ключем
+// використовується замість створення нового. Повертає nil для некоректного ключа.
+func (s *ImportService) space(ctx context.Context, plan *importPlan, input SpaceInput) (*ImportedSpace, error) {
+	key, err := spaceKey(input)
+	if err != nil {
+		return nil, nil
+	}
+
+	if space, ok := plan.spaces[key]; ok {
+		return space, nil
+	}
+
+	space := &ImportedSpace{Key: key, Name: input.Name, description: input.Description}
+
+	existing, err := s.spaceRepo.GetSpaceByKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("ImportService - space - GetSpaceByKey: %w", err)
+	}
+
+	if existing != nil {
+		space.ID = existing.ID
+		space.Exists = true
+	}
+
+	plan.spaces[key] = space
+	plan.report.Spaces = append(plan.report.Spaces, space)
+
+	return space, nil
+}
+
+func planTags(doc *importDocument, names []string) {
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			doc.warn("invalid tag %q skipped", name)
+			continue
+		}
+
+		if seen[tag] {
+			continue
+		}
+
+		if len(doc.report.Tags) == MaxArticleTags {
+			doc.warn("only the first %d tags are imported", MaxArticleTags)
+			return
+		}
+
+		seen[tag] = true
+		doc.report.Tags = append(doc.report.Tags, tag)
+	}
+}
+
+// assignParent робить батьком індексну сторінку найближчої директорії в межах простору
+func (p *importPlan) assignParent(doc *importDocument) {
+	if doc.space == nil {
+		return
+	}
+
+	dir := doc.dir
+	if doc.index {
+		dir = path.Dir(dir)
+	}
+
+	for ; dir != "."; dir = path.Dir(dir) {
+		parent := p.indexes[dir]
+		if parent != nil && parent != doc && parent.space == doc.space {
+			doc.parent = parent
+			doc.report.Parent = parent.report.Path
+
+			return
+		}
+	}
+}
+
+// rewriteLinks замінює відносні посилання на документи wiki-посиланнями
+// і збирає файли, на які посилається документ, для завантаження як вкладень
+func (p *importPlan) rewriteLinks(doc *importDocument) {
+	attached := make(map[string]bool)
+
+	body := markdown.RewriteLinks([]byte(doc.body), func(link markdown.Link) (string, bool) {
+		target, ok := resolveImportLink(doc.dir, link.Destination)
+		if !ok {
+			return "", false
+		}
+
+		if target == ".." || strings.HasPrefix(target, "../") {
+			doc.warn("link %q points outside the imported tree", link.Destination)
+			return "", false
+		}
+
+		if p.assets[target] {
+			if !attached[target] {
+				attached[target] = true
+				doc.assets = append(doc.assets, &importAsset{path: target, filename: path.Base(target), ref: target})
+				doc.report.Attachments = append(doc.report.Attachments, target)
+			}
+
+			return "", false
+		}
+
+		linked := p.byPath[target]
+		if linked == nil {
+			linked = p.indexes[target]
+		}
+
+		if linked == nil || link.Image {
+			doc.warn("broken link %q", link.Destination)
+			return "", false
+		}
+
+		doc.report.Links++
+
+		return wikiLink(linked.report.Slug, link.Text, linked.report.Title), true
+	})
+
+	doc.body = string(body)
+}
+
+// commit створює нові простори, потім статті в порядку плану
+func (s *ImportService) commit(ctx context.Context, fsys fs.FS, plan *importPlan, opts ImportOptions) error {
+	for _, planned := range plan.report.Spaces {
+		if planned.Exists {
+			continue
+		}
+
+		space, err := s.spaceService.Create(ctx, opts.UserID, SpaceInput{
+			Key:         planned.Key,
+			Name:        planned.Name,
+			Description: planned.description,
+		})
+		if err != nil {
+			return fmt.Errorf("ImportService - commit - CreateSpace %q: %w", planned.Key, err)
+		}
+
+		planned.ID = space.ID
+	}
+
+	for _, doc := range plan.documents {
+		if err := s.commitDocument(ctx, fsys, doc, opts); err != nil {
+			return fmt.Errorf("ImportService - commit - %s: %w", doc.report.Path, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ImportService) commitDocument(ctx context.Context, fsys fs.FS, doc *importDocument, opts ImportOptions) error {
+	input := ArticleInput{
+		Title:    doc.report.Title,
+		Slug:     doc.report.Slug,
+		Body:     doc.body,
+		Status:   doc.report.Status,
+		Language: doc.language,
+	}
+
+	if doc.space != nil {
+		input.SpaceID = &doc.space.ID
+	}
+
+	if doc.parent != nil {
+		input.ParentID = &doc.parent.report.ID
+	}
+
+	article, err := s.articleService.create(ctx, doc.authorID, input)
+	if err != nil {
+		return err
+	}
+
+	doc.report.ID = article.ID
+
+	if len(doc.report.Tags) > 0 {
+		if _, err = s.tagService.AddArticleTags(ctx, article.ID, doc.report.Tags); err != nil {
+			return err
+		}
+	}
+
+	urls := make(map[string]string, len(doc.assets))
+
+	for _, asset := range doc.assets {
+		attachment, uploadErr := s.uploadAsset(ctx, fsys, asset, article.ID, opts.UserID)
+		if uploadErr != nil {
+			if !isAttachmentRejected(uploadErr) {
+				return uploadErr
+			}
+
+			doc.warn("%s not attached: %v", asset.filename, uploadErr)
+
+			continue
+		}
+
+		urls[asset.ref] = fmt.Sprintf(_attachmentURLFormat, article.ID, attachment.ID)
+	}
+
+	if len(urls) == 0 {
+		return nil
+	}
+
+	// Адреси вкладень відомі лише після створення статті, тому вони записуються другою ревізією
+	body := markdown.RewriteLinks([]byte(doc.body), func(link markdown.Link) (string, bool) {
+		ref := link.Destination
+		if !doc.dump {
+			ref, _ = resolveImportLink(doc.dir, link.Destination)
+		}
+
+		if urls[ref] == "" {
+			return "", false
+		}
+
+		link.Destination = urls[ref]
+
+		return link.String(), true
+	})
+
+	_, err = s.articleService.Update(ctx, article.ID, doc.authorID, ArticleInput{Title: article.Title, Body: string(body)})
+
+	return err
+}
+
+func (s *ImportService) uploadAsset(
+	ctx context.Context,
+	fsys fs.FS,
+	asset *importAsset,
+	articleID, userID uint,
+) (*models.Attachment, error) {
+	file, err := fsys.Open(asset.path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return s.attachmentService.Upload(ctx, userID, articleID, asset.filename, file)
+}
+
+func (p *importPlan) skip(path, reason string) {
+	p.report.Skipped = append(p.report.Skipped, &SkippedFile{Path: path, Reason: reason})
+}
+
+func (p *importPlan) summarize() {
+	summary := ImportSummary{Articles: len(p.report.Articles), Skipped: len(p.report.Skipped)}
+
+	for _, space := range p.report.Spaces {
+		if !space.Exists {
+			summary.Spaces++
+		}
+	}
+
+	for _, article := range p.report.Articles {
+		summary.Attachments += len(article.Attachments)
+		summary.Links += article.Links
+		summary.Warnings += len(article.Warnings)
+	}
+
+	p.report.Summary = summary
+}
+
+func (d *importDocument) warn(format string, args ...any) {
+	d.report.Warnings = append(d.report.Warnings, fmt.Sprintf(format, args...))
+}
+
+// listImportFiles повертає звичайні файли дерева; приховані файли та директорії
+// (.git, .github, ...) і символьні посилання пропускаються
+func listImportFiles(fsys fs.FS) ([]string, error) {
+	paths := make([]string, 0)
+
+	err := fs.WalkDir(fsys, ".", func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if p != "." && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		if len(paths) == MaxImportFiles {
+			return ErrTooManyImportFiles
+		}
+
+		paths = append(paths, p)
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrTooManyImportFiles) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("ImportService - listImportFiles - WalkDir: %w", err)
+	}
+
+	return paths, nil
+}
+
+// readImportDocument читає документ; непорожня причина означає, що документ пропускається
+func readImportDocument(fsys fs.FS, p string) ([]byte, string) {
+	file, err := fsys.Open(p)
+	if err != nil {
+		return nil, "cannot open file: " + err.Error()
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	// Розмір із заголовка zip може не відповідати вмісту, тому читання теж обмежене
+	source, err := io.ReadAll(io.LimitReader(file, MaxImportDocumentSize+1))
+	if err != nil {
+		return nil, "cannot read file: " + err.Error()
+	}
+
+	switch {
+	case len(source) > MaxImportDocumentSize:
+		return nil, fmt.Sprintf("document is larger than %d bytes", MaxImportDocumentSize)
+	case !utf8.Valid(source):
+		return nil, "document is not valid UTF-8"
+	}
+
+	return source, ""
+}
+
+// resolveImportLink перетворює відносне посилання документа з директорії dir на шлях
+// у дереві; абсолютні адреси, якорі та посилання з URL схемою не є відносними
+func resolveImportLink(dir, destination string) (string, bool) {
+	if destination == "" || strings.HasPrefix(destination, "/") || strings.HasPrefix(destination, "#") {
+		return "", false
+	}
+
+	if parsed, err := url.Parse(destination); err != nil || parsed.Scheme != "" || parsed.Host != "" {
+		return "", false
+	}
+
+	destination, _, _ = strings.Cut(destination, "#")
+	destination, _, _ = strings.Cut(destination, "?")
+
+	if unescaped, err := url.PathUnescape(destination); err == nil {
+		destination = unescaped
+	}
+
+	if destination == "" {
+		return "", false
+	}
+
+	return path.Join(dir, destination), true
+}
+
+// wikiLink будує [[slug|label]]; текст посилання, що не може бути підписом, замінюється заголовком
+func wikiLink(slug, text, title string) string {
+	label := strings.Join(strings.Fields(text), " ")
+	if label == "" || strings.ContainsAny(label, "[]|") {
+		label = title
+	}
+
+	if strings.ContainsAny(label, "[]|") {
+		return "[[" + slug + "]]"
+	}
+
+	return "[[" + slug + "|" + label + "]]"
+}
+
+// titleFromPath будує заголовок з імені файлу, а для індексної сторінки - з імені директорії
+func titleFromPath(p string, index bool) string {
+	name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+	if index && path.Dir(p) != "." {
+		name = path.Base(path.Dir(p))
+	}
+
+	title := strings.Join(strings.Fields(strings.NewReplacer("-", " ", "_", " ").Replace(name)), " ")
+	if title == "" {
+		return name
+	}
+
+	return title
+}
+
+func isMarkdownFile(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".md", ".markdown":
+		return true
+	default:
+		return false
+	}
+}
+
+// isAttachmentRejected - файл не пройшов перевірки вкладень, імпорт продовжується без нього
+func isAttachmentRejected(err error) bool {
+	return errors.Is(err, ErrAttachmentTooLarge) ||
+		errors.Is(err, ErrUnsupportedAttachmentType) ||
+		errors.Is(err, ErrEmptyAttachment)
+}
+
+func pathDepth(dir string) int {
+	if dir == "." {
+		return 0
+	}
+
+	return strings.Count(dir, "/") + 1
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// _maxImportDumpSize обмежує розмір ExportDumpFile; вміст вкладень лежить окремо
+const _maxImportDumpSize = 256 << 20
+
+// planDump будує план з дампу ExportFormatJSON. Статті створюються в порядку дампу,
+// тому батьківські сторінки передують дочірнім, а сусіди зберігають порядок.
+// Адреси вкладень статті в її тексті переписуються на нові після завантаження.
+func (s *ImportService) planDump(ctx context.Context, fsys fs.FS, plan *importPlan, opts ImportOptions) error {
+	dump, err := readImportDump(fsys)
+	if err != nil {
+		return err
+	}
+
+	for _, dumpSpace := range dump.Spaces {
+		space, spaceErr := s.space(ctx, plan, SpaceInput{
+			Key:         dumpSpace.Key,
+			Name:        dumpSpace.Name,
+			Description: dumpSpace.Description,
+		})
+		if spaceErr != nil {
+			return spaceErr
+		}
+
+		if space == nil {
+			plan.skip(ExportDumpFile+"#"+dumpSpace.Key, "invalid space key")
+		}
+	}
+
+	// bySlug - документи за slug з дампу: slug у плані може змінитися через конфлікт
+	bySlug := make(map[string]*importDocument, len(dump.Articles))
+
+	for _, article := range dump.Articles {
+		doc := newImportDocument(ExportDumpFile+"#"+article.Slug, article.Title, article.Author, opts.UserID)
+		doc.dump = true
+		doc.body = article.Body
+
+		if doc.report.Title == "" {
+			doc.report.Title = article.Slug
+		}
+
+		if err = s.planFields(ctx, plan, doc, article.Slug, string(article.Status), article.Language); err != nil {
+			return err
+		}
+
+		planDumpPlacement(plan, doc, article, bySlug)
+		planTags(doc, article.Tags)
+
+		for _, attachment := range article.Attachments {
+			asset := &importAsset{
+				path:     dumpAttachmentPath(attachment.SHA256),
+				filename: attachment.Filename,
+				ref:      fmt.Sprintf(_attachmentURLFormat, article.ID, attachment.ID),
+			}
+
+			if _, statErr := fs.Stat(fsys, asset.path); statErr != nil {
+				doc.warn("content of attachment %q is missing", attachment.Filename)
+				continue
+			}
+
+			doc.assets = append(doc.assets, asset)
+			doc.report.Attachments = append(doc.report.Attachments, attachment.Filename)
+		}
+
+		bySlug[article.Slug] = doc
+		plan.documents = append(plan.documents, doc)
+		plan.report.Articles = append(plan.report.Articles, doc.report)
+	}
+
+	if len(plan.documents) == 0 {
+		return ErrNothingToImport
+	}
+
+	return nil
+}
+
+// planDumpPlacement знаходить простір і батьківську сторінку статті дампу
+func planDumpPlacement(plan *importPlan, doc *importDocument, article *DumpArticle, bySlug map[string]*importDocument) {
+	if article.Space == "" {
+		return
+	}
+
+	for _, space := range plan.spaces {
+		if space.Key == article.Space {
+			doc.space = space
+			doc.report.Space = space.Key
+		}
+	}
+
+	if doc.space == nil {
+		doc.warn("space %q is not in the dump, importing outside spaces", article.Space)
+		return
+	}
+
+	if article.Parent == "" {
+		return
+	}
+
+	parent := bySlug[article.Parent]
+	if parent == nil || parent.space != doc.space {
+		doc.warn("parent page %q is not imported before this page, importing at the space root", article.Parent)
+		return
+	}
+
+	doc.parent = parent
+	doc.report.Parent = parent.report.Path
+}
+
+func readImportDump(fsys fs.FS) (*ExportDump, error) {
+	file, err := fsys.Open(ExportDumpFile)
+	if err != nil {
+		return nil, fmt.Errorf("ImportService - readImportDump - Open: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var dump ExportDump
+
+	decoder := json.NewDecoder(io.LimitReader(file, _maxImportDumpSize))
+	if err = decoder.Decode(&dump); err != nil {
+		return nil, ErrInvalidImportDump.Withf("%v", err)
+	}
+
+	if dump.Version != ExportDumpVersion {
+		return nil, ErrUnsupportedDumpVersion.Withf("%d", dump.Version)
+	}
+
+	return &dump, nil
+}
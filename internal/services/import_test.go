@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+	"KnowledgeHub/pkg/storage"
+)
+
+// _pngHeader - сигнатура PNG, за якою AttachmentService визначає тип файлу
+const _pngHeader = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+func newTestImportServices(t *testing.T) (*ImportService, *ArticleService, *mocks.Mocks) {
+	t.Helper()
+
+	blobs, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "admin"})
+	mockRepo.AddUser(&models.User{ID: 2, Username: "johndoe"})
+
+	articles := NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	spaces := NewSpaceService(mockRepo.Space(), mockRepo.Article(), mockRepo.PageTree(), mockRepo.SpaceMember(),
+		NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+
+	return NewImportService(
+		articles,
+		spaces,
+		NewTagService(mockRepo.Tag(), mockRepo.Article()),
+		NewAttachmentService(mockRepo.Attachment(), mockRepo.Article(), blobs),
+		mockRepo.User(),
+		mockRepo.Space(),
+	), articles, mockRepo
+}
+
+func testImportTree() fstest.MapFS {
+	return fstest.MapFS{
+		"Engineering/README.md": {Data: []byte("---\ntitle: Engineering\nauthor: johndoe\nstatus: published\n" +
+			"tags: [docs, Onboarding]\n---\nStart with the [deploy guide](deploy-guide.md).\n")},
+		"Engineering/deploy-guide.md": {Data: []byte("---\ntags: ops, deploy\n---\n" +
+			"# Deploy\n\nSee [home](README.md#top), ![diagram](img/flow.png) and [gone](missing.md).\n" +
+			"`[code](README.md)` and [site](https://example.com).\n")},
+		"Engineering/runbooks/db.md": {Data: []byte("Back to [index](../README.md) or [outside](../../x.md).\n")},
+		"Engineering/img/flow.png":   {Data: []byte(_pngHeader + "data")},
+		"welcome.md":                 {Data: []byte("Go to [engineering](Engineering/)\n")},
+		"bad.md":                     {Data: []byte("---\ntitle: [unclosed\n---\n")},
+		".git/config":                {Data: []byte("[core]")},
+	}
+}
+
+func findImported(t *testing.T, report *ImportReport, p string) *ImportedArticle {
+	t.Helper()
+
+	for _, article := range report.Articles {
+		if article.Path == p {
+			return article
+		}
+	}
+
+	t.Fatalf("%s is not in the report: %+v", p, report.Articles)
+
+	return nil
+}
+
+func TestImportService_DryRun(t *testing.T) {
+	importer, articles, _ := newTestImportServices(t)
+	ctx := context.Background()
+
+	if _, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Welcome"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	report, err := importer.Import(ctx, testImportTree(), ImportOptions{UserID: 1})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if report.Committed || len(report.Articles) != 4 || len(report.Skipped) != 1 || report.Skipped[0].Path != "bad.md" {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+
+	// Батьківські сторінки створюються першими
+	if report.Articles[0].Path != "welcome.md" || report.Articles[1].Path != "Engineering/README.md" {
+		t.Errorf("Unexpected order: %s, %s", report.Articles[0].Path, report.Articles[1].Path)
+	}
+
+	if len(report.Spaces) != 1 || report.Spaces[0].Key != "engineering" || report.Spaces[0].Exists {
+		t.Errorf("Unexpected spaces: %+v", report.Spaces)
+	}
+
+	index := findImported(t, report, "Engineering/README.md")
+	if index.Title != "Engineering" || index.Author != "johndoe" || index.Status != models.ArticleStatusPublished ||
+		index.Parent != "" || strings.Join(index.Tags, ",") != "docs,onboarding" || index.Links != 1 {
+		t.Errorf("Unexpected index: %+v", index)
+	}
+
+	guide := findImported(t, report, "Engineering/deploy-guide.md")
+	if guide.Title != "deploy guide" || guide.Slug != "deploy-guide" || guide.Parent != "Engineering/README.md" ||
+		guide.Links != 1 || strings.Join(guide.Attachments, ",") != "Engineering/img/flow.png" ||
+		len(guide.Warnings) != 1 || !strings.Contains(guide.Warnings[0], "missing.md") {
+		t.Errorf("Unexpected guide: %+v", guide)
+	}
+
+	db := findImported(t, report, "Engineering/runbooks/db.md")
+	if db.Parent != "Engineering/README.md" || db.Links != 1 || len(db.Warnings) != 1 {
+		t.Errorf("Unexpected runbook: %+v", db)
+	}
+
+	// Slug зайнятий наявною статтею
+	welcome := findImported(t, report, "welcome.md")
+	if welcome.Slug != "welcome-2" || welcome.Space != "" || welcome.Links != 1 {
+		t.Errorf("Unexpected welcome: %+v", welcome)
+	}
+
+	if report.Summary.Articles != 4 || report.Summary.Spaces != 1 || report.Summary.Attachments != 1 ||
+		report.Summary.Links != 4 || report.Summary.Warnings != 2 || report.Summary.Skipped != 1 {
+		t.Errorf("Unexpected summary: %+v", report.Summary)
+	}
+
+	// Dry-run нічого не створює
+	if _, err = articles.GetBySlug(ctx, "engineering"); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("GetBySlug() error = %v, want %v", err, ErrArticleNotFound)
+	}
+}
+
+func TestImportService_Commit(t *testing.T) {
+	importer, articles, mockRepo := newTestImportServices(t)
+	ctx := context.Background()
+
+	report, err := importer.Import(ctx, testImportTree(), ImportOptions{UserID: 1, Commit: true})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if !report.Committed {
+		t.Fatal("Import() report is not committed")
+	}
+
+	index, err := articles.GetBySlug(ctx, "engineering")
+	if err != nil {
+		t.Fatalf("GetBySlug() error = %v", err)
+	}
+
+	if index.AuthorID != 2 || index.SpaceID == nil || *index.SpaceID != report.Spaces[0].ID ||
+		index.Body != "Start with the [[deploy-guide|deploy guide]].\n" {
+		t.Errorf("Unexpected index: %+v", index)
+	}
+
+	guide, err := articles.GetBySlug(ctx, "deploy-guide")
+	if err != nil {
+		t.Fatalf("GetBySlug() error = %v", err)
+	}
+
+	list, err := mockRepo.Attachment().ListAttachments(ctx, guide.ID, pagination.Params{})
+	if err != nil || len(list.Items) != 1 {
+		t.Fatalf("ListAttachments() = %v, %v", list, err)
+	}
+
+	attachments := list.Items
+
+	wantBody := fmt.Sprintf("# Deploy\n\nSee [[engineering|home]], ![diagram](/v1/articles/%d/attachments/%d) "+
+		"and [gone](missing.md).\n`[code](README.md)` and [site](https://example.com).\n", guide.ID, attachments[0].ID)
+	if guide.ParentID == nil || *guide.ParentID != index.ID || guide.AuthorID != 1 || guide.Body != wantBody {
+		t.Errorf("Unexpected guide: %+v\nbody: %q", guide, guide.Body)
+	}
+
+	if tags, _ := mockRepo.Tag().ListArticleTags(ctx, guide.ID, pagination.Params{}); len(tags.Items) != 2 {
+		t.Errorf("ListArticleTags() = %v", tags)
+	}
+
+	// Повторний імпорт використовує наявний простір і підбирає нові slug
+	again, err := importer.Import(ctx, testImportTree(), ImportOptions{UserID: 1})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if !again.Spaces[0].Exists || again.Spaces[0].ID != report.Spaces[0].ID ||
+		findImported(t, again, "Engineering/README.md").Slug != "engineering-2" {
+		t.Errorf("Unexpected second import: %+v", again.Spaces[0])
+	}
+}
+
+func TestImportService_Errors(t *testing.T) {
+	importer, _, _ := newTestImportServices(t)
+
+	tree := fstest.MapFS{"img/logo.png": {Data: []byte(_pngHeader)}, "notes.md": {Data: []byte("\xff\xfe")}}
+
+	if _, err := importer.Import(context.Background(), tree, ImportOptions{UserID: 1}); !errors.Is(err, ErrNothingToImport) {
+		t.Errorf("Import() error = %v, want %v", err, ErrNothingToImport)
+	}
+}
+
+func TestImportService_FrontMatterWarnings(t *testing.T) {
+	importer, _, _ := newTestImportServices(t)
+
+	tree := fstest.MapFS{"notes.md": {Data: []byte("---\nslug: Notes\nauthor: ghost\nstatus: wip\n" +
+		"tags: [ok, '!!!']\n---\nbody\n")}}
+
+	report, err := importer.Import(context.Background(), tree, ImportOptions{UserID: 1})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	notes := report.Articles[0]
+	if notes.Slug != "notes" || notes.Status != models.ArticleStatusDraft || len(notes.Tags) != 1 ||
+		len(notes.Warnings) != 3 {
+		t.Errorf("Unexpected article: %+v", notes)
+	}
+}
+
+func TestWikiLink(t *testing.T) {
+	tests := []struct {
+		text, title, want string
+	}{
+		{"deploy  guide", "Deploy", "[[deploy|deploy guide]]"},
+		{"", "Deploy", "[[deploy|Deploy]]"},
+		{"a|b", "Deploy", "[[deploy|Deploy]]"},
+		{"a|b", "[x]", "[[deploy]]"},
+	}
+
+	for _, tt := range tests {
+		if got := wikiLink("deploy", tt.text, tt.title); got != tt.want {
+			t.Errorf("wikiLink(%q, %q) = %q, want %q", tt.text, tt.title, got, tt.want)
+		}
+	}
+}
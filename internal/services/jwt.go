@@ -1,11 +1,15 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"KnowledgeHub/config"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -15,57 +19,131 @@ type JWTClaims struct {
 	UserID   uint   `json:"user_id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
+	// Role - глобальна роль на момент видачі токена
+	Role models.Role `json:"role,omitempty"`
+	// SessionID - сім'я refresh токенів, разом з якою видано access токен
+	SessionID string `json:"sid,omitempty"`
+	// IssuedAtNano - час видачі в наносекундах Unix. iat має точність до секунди,
+	// а відкликання всіх токенів користувача порівнюється з точним часом.
+	IssuedAtNano int64 `json:"iat_ns,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// ActionClaims - claims одноразового токена дії з листа. Subject дорівнює призначенню
+// токена, тому його не можна пред'явити як access чи refresh токен і навпаки.
+type ActionClaims struct {
+	UserID uint `json:"user_id"`
+	// Email - адреса, на яку надіслано лист; після її зміни токен підтвердження недійсний
+	Email string `json:"email,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type TokenPair struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	ExpiresAt    int64  `json:"expires_at"`
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresAt        int64  `json:"expires_at"`
+	RefreshExpiresAt int64  `json:"refresh_expires_at"`
 }
 
 type JWTService struct {
-	config *config.Config
+	config      *config.Config
+	keys        *keySet
+	revocations repo.RevokedTokenRepository
 }
 
-func NewJWTService(cfg *config.Config) *JWTService {
-	return &JWTService{
+// JWTOption - опціональні залежності JWTService
+type JWTOption func(*JWTService)
+
+// WithRevocationStore підключає denylist відкликаних access токенів
+func WithRevocationStore(store repo.RevokedTokenRepository) JWTOption {
+	return func(j *JWTService) {
+		j.revocations = store
+	}
+}
+
+// NewJWTService завантажує ключі згідно з cfg.JWT і повертає помилку,
+// якщо алгоритм не підтримується або ключі відсутні чи не відповідають алгоритму.
+func NewJWTService(cfg *config.Config, opts ...JWTOption) (*JWTService, error) {
+	keys, err := loadKeySet(cfg.JWT)
+	if err != nil {
+		return nil, fmt.Errorf("JWTService - loadKeySet: %w", err)
+	}
+
+	j := &JWTService{
 		config: cfg,
+		keys:   keys,
+	}
+
+	for _, opt := range opts {
+		opt(j)
 	}
+
+	return j, nil
 }
 
 var (
-	ErrInvalidToken  = errors.New("invalid token")
-	ErrExpiredToken  = errors.New("token has expired")
-	ErrInvalidClaims = errors.New("invalid token claims")
+	ErrInvalidToken  = apperror.Unauthorized("invalid_token", "invalid token")
+	ErrExpiredToken  = apperror.Unauthorized("expired_token", "token has expired")
+	ErrInvalidClaims = apperror.Unauthorized("invalid_token_claims", "invalid token claims")
+	ErrRevokedToken  = apperror.Unauthorized("revoked_token", "token has been revoked")
+
+	ErrRevocationUnavailable = errors.New("token revocation store is not configured")
 )
 
+// UserRole повертає роль з токена; токени без ролі (видані до її появи) мають найменші права
+func (c *JWTClaims) UserRole() models.Role {
+	if c.Role.IsValid() {
+		return c.Role
+	}
+
+	return models.RoleViewer
+}
+
 func (j *JWTService) GenerateTokenPair(userID uint, username, email string) (*TokenPair, error) {
-	accessToken, expiresAt, err := j.generateAccessToken(userID, username, email)
+	return j.GenerateSessionTokenPair("", userID, username, email, "")
+}
+
+// GenerateSessionTokenPair видає пару токенів, прив'язавши access токен до сесії sessionID
+func (j *JWTService) GenerateSessionTokenPair(
+	sessionID string,
+	userID uint,
+	username, email string,
+	role models.Role,
+) (*TokenPair, error) {
+	accessToken, expiresAt, err := j.generateAccessToken(sessionID, userID, username, email, role)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := j.generateRefreshToken(userID)
+	refreshToken, refreshExpiresAt, err := j.generateRefreshToken(userID)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TokenPair{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    expiresAt,
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresAt:        expiresAt,
+		RefreshExpiresAt: refreshExpiresAt,
 	}, nil
 }
 
-func (j *JWTService) generateAccessToken(userID uint, username, email string) (string, int64, error) {
+func (j *JWTService) generateAccessToken(
+	sessionID string,
+	userID uint,
+	username, email string,
+	role models.Role,
+) (string, int64, error) {
 	now := time.Now()
 	expiresAt := now.Add(time.Duration(j.config.JWT.AccessTokenTTL) * time.Second)
 
 	claims := &JWTClaims{
-		UserID:   userID,
-		Username: username,
-		Email:    email,
+		UserID:       userID,
+		Username:     username,
+		Email:        email,
+		Role:         role,
+		SessionID:    sessionID,
+		IssuedAtNano: now.UnixNano(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -76,8 +154,7 @@ func (j *JWTService) generateAccessToken(userID uint, username, email string) (s
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.GetSigningMethod(j.config.JWT.SigningAlgorithm), claims)
-	tokenString, err := token.SignedString([]byte(j.config.JWT.Secret))
+	tokenString, err := j.keys.sign(claims)
 	if err != nil {
 		return "", 0, err
 	}
@@ -85,7 +162,7 @@ func (j *JWTService) generateAccessToken(userID uint, username, email string) (s
 	return tokenString, expiresAt.Unix(), nil
 }
 
-func (j *JWTService) generateRefreshToken(userID uint) (string, error) {
+func (j *JWTService) generateRefreshToken(userID uint) (string, int64, error) {
 	now := time.Now()
 	expiresAt := now.Add(time.Duration(j.config.JWT.RefreshTokenTTL) * time.Second)
 
@@ -98,22 +175,16 @@ func (j *JWTService) generateRefreshToken(userID uint) (string, error) {
 		ID:        fmt.Sprintf("%d_%d", userID, now.UnixNano()),
 	}
 
-	token := jwt.NewWithClaims(jwt.GetSigningMethod(j.config.JWT.SigningAlgorithm), claims)
-	tokenString, err := token.SignedString([]byte(j.config.JWT.Secret))
+	tokenString, err := j.keys.sign(claims)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 
-	return tokenString, nil
+	return tokenString, expiresAt.Unix(), nil
 }
 
 func (j *JWTService) ValidateAccessToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if token.Method.Alg() != j.config.JWT.SigningAlgorithm {
-			return nil, ErrInvalidToken
-		}
-		return []byte(j.config.JWT.Secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, j.keys.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -136,13 +207,7 @@ func (j *JWTService) ValidateAccessToken(tokenString string) (*JWTClaims, error)
 }
 
 func (j *JWTService) ValidateRefreshToken(tokenString string) (*jwt.RegisteredClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-
-		if token.Method.Alg() != j.config.JWT.SigningAlgorithm {
-			return nil, ErrInvalidToken
-		}
-		return []byte(j.config.JWT.Secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, j.keys.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -173,6 +238,121 @@ func (j *JWTService) RefreshTokens(refreshToken string, userID uint, username, e
 	return j.GenerateTokenPair(userID, username, email)
 }
 
+// GenerateActionToken підписує токен дії purpose для користувача зі строком дії ttl
+func (j *JWTService) GenerateActionToken(
+	purpose models.TokenPurpose,
+	userID uint,
+	email string,
+	ttl time.Duration,
+) (string, time.Time, error) {
+	id, err := newFamilyID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims := &ActionClaims{
+		UserID: userID,
+		Email:  email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "KnowledgeHub",
+			Subject:   string(purpose),
+			ID:        id,
+		},
+	}
+
+	tokenString, err := j.keys.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+// ValidateActionToken перевіряє підпис, строк дії та призначення токена дії
+func (j *JWTService) ValidateActionToken(tokenString string, purpose models.TokenPurpose) (*ActionClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &ActionClaims{}, j.keys.keyFunc)
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*ActionClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidClaims
+	}
+
+	if claims.Subject != string(purpose) {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// CheckRevoked повертає ErrRevokedToken, якщо access токен відкликано.
+// Без підключеного сховища відкликань перевірка не виконується.
+func (j *JWTService) CheckRevoked(ctx context.Context, claims *JWTClaims) error {
+	if j.revocations == nil {
+		return nil
+	}
+
+	var issuedAt time.Time
+
+	switch {
+	case claims.IssuedAtNano != 0:
+		issuedAt = time.Unix(0, claims.IssuedAtNano)
+	case claims.IssuedAt != nil:
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	revoked, err := j.revocations.IsTokenRevoked(ctx, claims.ID, claims.UserID, issuedAt)
+	if err != nil {
+		return fmt.Errorf("JWTService - CheckRevoked - IsTokenRevoked: %w", err)
+	}
+
+	if revoked {
+		return ErrRevokedToken
+	}
+
+	return nil
+}
+
+// RevokeAccessToken додає токен до denylist на залишок його строку дії
+func (j *JWTService) RevokeAccessToken(ctx context.Context, claims *JWTClaims) error {
+	if j.revocations == nil {
+		return ErrRevocationUnavailable
+	}
+
+	expiresAt := time.Now().Add(time.Duration(j.config.JWT.AccessTokenTTL) * time.Second)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	return j.revocations.RevokeToken(ctx, claims.ID, expiresAt)
+}
+
+// RevokeUserAccessTokens відкликає всі access токени користувача, видані до цього моменту
+func (j *JWTService) RevokeUserAccessTokens(ctx context.Context, userID uint) error {
+	if j.revocations == nil {
+		return ErrRevocationUnavailable
+	}
+
+	return j.revocations.RevokeUserTokens(ctx, userID, time.Now())
+}
+
+// JWKS повертає публічні ключі перевірки токенів для інших сервісів
+func (j *JWTService) JWKS() JWKS {
+	return j.keys.jwks()
+}
+
 func (j *JWTService) ExtractTokenFromHeader(authHeader string) (string, error) {
 	if authHeader == "" {
 		return "", ErrInvalidToken
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"KnowledgeHub/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported JWT signing algorithm")
+	ErrUnsupportedKey       = errors.New("unsupported JWT key type")
+)
+
+// JWK - публічний ключ у форматі RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS - набір публічних ключів для /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// jwtKey - ключ для перевірки підпису; key - []byte для HMAC або публічний ключ
+type jwtKey struct {
+	id     string
+	method jwt.SigningMethod
+	key    any
+}
+
+// keySet містить поточний ключ підпису та всі ключі, якими ще можна перевіряти токени.
+// Для ротації новий ключ стає ключем підпису, а попередній лишається у verify до
+// закінчення строку дії виданих ним токенів.
+type keySet struct {
+	signing    jwtKey
+	signingKey any
+	verify     map[string]jwtKey
+}
+
+// loadKeySet будує набір ключів з конфігурації:
+// HS* - спільний секрет JWT_SECRET; RS*/ES*/EdDSA - PEM з JWT_PRIVATE_KEY_PATH
+// та додаткові ключі перевірки з JWT_PUBLIC_KEY_PATHS.
+func loadKeySet(cfg config.JWT) (*keySet, error) {
+	method := jwt.GetSigningMethod(cfg.SigningAlgorithm)
+	if method == nil || method == jwt.SigningMethodNone {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, cfg.SigningAlgorithm)
+	}
+
+	if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("JWT_SECRET is required for %s", cfg.SigningAlgorithm)
+		}
+
+		secret := []byte(cfg.Secret)
+		signing := jwtKey{id: cfg.KeyID, method: method, key: secret}
+
+		return &keySet{
+			signing:    signing,
+			signingKey: secret,
+			verify:     map[string]jwtKey{signing.id: signing},
+		}, nil
+	}
+
+	if cfg.PrivateKeyPath == "" {
+		return nil, fmt.Errorf("JWT_PRIVATE_KEY_PATH is required for %s", cfg.SigningAlgorithm)
+	}
+
+	private, public, err := readPEMKey(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if private == nil {
+		return nil, fmt.Errorf("%s does not contain a private key", cfg.PrivateKeyPath)
+	}
+
+	if keyMethod, _ := methodForKey(public, cfg.SigningAlgorithm); keyMethod != method {
+		return nil, fmt.Errorf("%w: key in %s cannot be used with %s",
+			ErrUnsupportedKey, cfg.PrivateKeyPath, cfg.SigningAlgorithm)
+	}
+
+	kid := cfg.KeyID
+	if kid == "" {
+		if kid, err = thumbprint(public); err != nil {
+			return nil, err
+		}
+	}
+
+	set := &keySet{
+		signing:    jwtKey{id: kid, method: method, key: public},
+		signingKey: private,
+		verify:     make(map[string]jwtKey),
+	}
+	set.verify[kid] = set.signing
+
+	for _, entry := range cfg.PublicKeyPaths {
+		if err = set.addVerificationKey(entry, cfg.SigningAlgorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
+
+// addVerificationKey додає ключ перевірки із запису "path" або "kid=path".
+// Явний kid потрібен для ключа, який підписував токени з власним JWT_KEY_ID,
+// інакше kid за замовчуванням - його thumbprint.
+func (s *keySet) addVerificationKey(entry, preferredAlg string) error {
+	kid, path, ok := strings.Cut(entry, "=")
+	if !ok {
+		kid, path = "", entry
+	}
+
+	_, public, err := readPEMKey(path)
+	if err != nil {
+		return err
+	}
+
+	method, err := methodForKey(public, preferredAlg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if kid == "" {
+		if kid, err = thumbprint(public); err != nil {
+			return err
+		}
+	}
+
+	s.verify[kid] = jwtKey{id: kid, method: method, key: public}
+
+	return nil
+}
+
+// keyFunc обирає ключ перевірки за заголовком kid і не дозволяє підмінити алгоритм
+func (s *keySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := s.verify[kid]
+	if !ok || token.Method.Alg() != key.method.Alg() {
+		return nil, ErrInvalidToken
+	}
+
+	return key.key, nil
+}
+
+func (s *keySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.method, claims)
+	if s.signing.id != "" {
+		token.Header["kid"] = s.signing.id
+	}
+
+	return token.SignedString(s.signingKey)
+}
+
+// jwks повертає публічні ключі; HMAC-секрет ніколи не публікується
+func (s *keySet) jwks() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(s.verify))}
+
+	for _, key := range s.verify {
+		jwk, err := publicJWK(key.key)
+		if err != nil {
+			continue
+		}
+
+		jwk.Kid = key.id
+		jwk.Use = "sig"
+		jwk.Alg = key.method.Alg()
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+// readPEMKey читає приватний або публічний ключ з PEM-файлу.
+// Для приватного ключа повертає також відповідний публічний.
+func readPEMKey(path string) (crypto.PrivateKey, crypto.PublicKey, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from trusted configuration
+	if err != nil {
+		return nil, nil, fmt.Errorf("read JWT key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+
+	var public crypto.PublicKey
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		public, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if public != nil {
+		return nil, public, nil
+	}
+
+	var private crypto.PrivateKey
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		private, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		err = fmt.Errorf("%w: PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: %w", path, ErrUnsupportedKey)
+	}
+
+	return private, signer.Public(), nil
+}
+
+// methodForKey визначає алгоритм для публічного ключа. Якщо тип ключа сумісний
+// з preferredAlg (наприклад RS384 для RSA), використовується саме він.
+func methodForKey(public crypto.PublicKey, preferredAlg string) (jwt.SigningMethod, error) {
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(preferredAlg, "RS") || strings.HasPrefix(preferredAlg, "PS") {
+			return jwt.GetSigningMethod(preferredAlg), nil
+		}
+
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+
+	return nil, ErrUnsupportedKey
+}
+
+func publicJWK(public any) (JWK, error) {
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64URL(key.N.Bytes()),
+			E:   base64URL(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+
+		return JWK{
+			Kty: "EC",
+			Crv: key.Curve.Params().Name,
+			X:   base64URL(key.X.FillBytes(make([]byte, size))),
+			Y:   base64URL(key.Y.FillBytes(make([]byte, size))),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64URL(key),
+		}, nil
+	}
+
+	return JWK{}, ErrUnsupportedKey
+}
+
+// thumbprint обчислює JWK thumbprint (RFC 7638), який використовується як kid за замовчуванням
+func thumbprint(public crypto.PublicKey) (string, error) {
+	jwk, err := publicJWK(public)
+	if err != nil {
+		return "", err
+	}
+
+	// Обов'язкові поля в лексикографічному порядку, як вимагає RFC 7638
+	var members any
+
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+
+	return base64URL(sum[:]), nil
+}
+
+func base64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"KnowledgeHub/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeTestKey зберігає приватний ключ у PKCS#8 PEM і повертає шлях до файлу
+func writeTestKey(t *testing.T, name string, key crypto.PrivateKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	if err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	return path
+}
+
+// writeTestPublicKey зберігає публічну частину ключа у PKIX PEM
+func writeTestPublicKey(t *testing.T, name string, key crypto.Signer) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	if err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+
+	return path
+}
+
+func getAsymmetricTestConfig(alg, privateKeyPath string, publicKeyPaths ...string) *config.Config {
+	cfg := getTestConfig()
+	cfg.JWT.Secret = ""
+	cfg.JWT.SigningAlgorithm = alg
+	cfg.JWT.PrivateKeyPath = privateKeyPath
+	cfg.JWT.PublicKeyPaths = publicKeyPaths
+
+	return cfg
+}
+
+func TestJWTService_AsymmetricAlgorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate EC key: %v", err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+
+	tests := []struct {
+		alg     string
+		key     crypto.PrivateKey
+		wantKty string
+	}{
+		{"RS256", rsaKey, "RSA"},
+		{"ES256", ecKey, "EC"},
+		{"EdDSA", edKey, "OKP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			jwtService := newTestJWTService(t, getAsymmetricTestConfig(tt.alg, writeTestKey(t, "key.pem", tt.key)))
+
+			tokenPair, err := jwtService.GenerateTokenPair(1, testUsername, testEmail)
+			if err != nil {
+				t.Fatalf("GenerateTokenPair() error = %v", err)
+			}
+
+			token, _, err := jwt.NewParser().ParseUnverified(tokenPair.AccessToken, &JWTClaims{})
+			if err != nil {
+				t.Fatalf("Failed to parse token: %v", err)
+			}
+
+			if token.Method.Alg() != tt.alg {
+				t.Errorf("Expected alg %s, got %s", tt.alg, token.Method.Alg())
+			}
+
+			kid, _ := token.Header["kid"].(string)
+			if kid == "" {
+				t.Error("Expected kid header to be set")
+			}
+
+			if _, err = jwtService.ValidateAccessToken(tokenPair.AccessToken); err != nil {
+				t.Errorf("ValidateAccessToken() error = %v", err)
+			}
+
+			if _, err = jwtService.ValidateRefreshToken(tokenPair.RefreshToken); err != nil {
+				t.Errorf("ValidateRefreshToken() error = %v", err)
+			}
+
+			jwks := jwtService.JWKS()
+			if len(jwks.Keys) != 1 {
+				t.Fatalf("Expected 1 key in JWKS, got %d", len(jwks.Keys))
+			}
+
+			if jwks.Keys[0].Kid != kid || jwks.Keys[0].Kty != tt.wantKty || jwks.Keys[0].Alg != tt.alg {
+				t.Errorf("Unexpected JWK %+v", jwks.Keys[0])
+			}
+		})
+	}
+}
+
+func TestJWTService_KeyRotation(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	oldService := newTestJWTService(t, getAsymmetricTestConfig("ES256", writeTestKey(t, "old.pem", oldKey)))
+
+	tokenPair, err := oldService.GenerateTokenPair(1, testUsername, testEmail)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	// Новий ключ підпису, старий лишається лише для перевірки
+	rotated := newTestJWTService(t, getAsymmetricTestConfig("ES256",
+		writeTestKey(t, "new.pem", newKey),
+		writeTestPublicKey(t, "old.pub.pem", oldKey),
+	))
+
+	if _, err = rotated.ValidateAccessToken(tokenPair.AccessToken); err != nil {
+		t.Errorf("Expected token signed with previous key to be valid, got %v", err)
+	}
+
+	if len(rotated.JWKS().Keys) != 2 {
+		t.Errorf("Expected 2 keys in JWKS, got %d", len(rotated.JWKS().Keys))
+	}
+
+	// Після виведення старого ключа з обігу токен більше не приймається
+	retired := newTestJWTService(t, getAsymmetricTestConfig("ES256", writeTestKey(t, "new.pem", newKey)))
+
+	if _, err = retired.ValidateAccessToken(tokenPair.AccessToken); err != ErrInvalidToken {
+		t.Errorf("Expected ErrInvalidToken for retired key, got %v", err)
+	}
+}
+
+func TestJWTService_KeyRotationCustomKeyID(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	oldCfg := getAsymmetricTestConfig("ES256", writeTestKey(t, "old.pem", oldKey))
+	oldCfg.JWT.KeyID = "2024-01"
+	oldService := newTestJWTService(t, oldCfg)
+
+	tokenPair, err := oldService.GenerateTokenPair(1, testUsername, testEmail)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	oldPublic := writeTestPublicKey(t, "old.pub.pem", oldKey)
+
+	// Без kid старий ключ реєструється під thumbprint і токен з kid "2024-01" не знаходить ключа
+	byThumbprint := newTestJWTService(t, getAsymmetricTestConfig("ES256", writeTestKey(t, "new.pem", newKey), oldPublic))
+
+	if _, err = byThumbprint.ValidateAccessToken(tokenPair.AccessToken); err != ErrInvalidToken {
+		t.Errorf("Expected ErrInvalidToken for unknown kid, got %v", err)
+	}
+
+	newCfg := getAsymmetricTestConfig("ES256", writeTestKey(t, "new.pem", newKey), "2024-01="+oldPublic)
+	newCfg.JWT.KeyID = "2024-02"
+	rotated := newTestJWTService(t, newCfg)
+
+	if _, err = rotated.ValidateAccessToken(tokenPair.AccessToken); err != nil {
+		t.Errorf("Expected token signed with previous key to be valid, got %v", err)
+	}
+
+	kids := make(map[string]bool)
+	for _, key := range rotated.JWKS().Keys {
+		kids[key.Kid] = true
+	}
+
+	if len(kids) != 2 || !kids["2024-01"] || !kids["2024-02"] {
+		t.Errorf("Expected kids 2024-01 and 2024-02 in JWKS, got %v", kids)
+	}
+}
+
+func TestNewJWTService_InvalidKeyConfig(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+	}{
+		{"Missing private key", getAsymmetricTestConfig("RS256", "")},
+		{"Key does not match algorithm", getAsymmetricTestConfig("RS256", writeTestKey(t, "ec.pem", ecKey))},
+		{"Public key as signing key", getAsymmetricTestConfig("ES256", writeTestPublicKey(t, "ec.pub.pem", ecKey))},
+		{"Unknown algorithm", getAsymmetricTestConfig("XX256", "")},
+		{"None algorithm", getAsymmetricTestConfig("none", "")},
+		{"HMAC without secret", getAsymmetricTestConfig("HS256", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewJWTService(tt.cfg); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestJWTService_HMACNotPublishedInJWKS(t *testing.T) {
+	jwtService := newTestJWTService(t, getTestConfig())
+
+	if keys := jwtService.JWKS().Keys; len(keys) != 0 {
+		t.Errorf("Expected no keys in JWKS for HMAC, got %d", len(keys))
+	}
+}
@@ -1,10 +1,14 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"KnowledgeHub/config"
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
 )
 
 const (
@@ -23,9 +27,20 @@ func getTestConfig() *config.Config {
 	}
 }
 
+func newTestJWTService(t *testing.T, cfg *config.Config) *JWTService {
+	t.Helper()
+
+	jwtService, err := NewJWTService(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create JWT service: %v", err)
+	}
+
+	return jwtService
+}
+
 func TestJWTService_GenerateTokenPair(t *testing.T) {
 	cfg := getTestConfig()
-	jwtService := NewJWTService(cfg)
+	jwtService := newTestJWTService(t, cfg)
 
 	userID := uint(1)
 	username := testUsername
@@ -60,7 +75,7 @@ func TestJWTService_GenerateTokenPair(t *testing.T) {
 
 func TestJWTService_ValidateAccessToken(t *testing.T) {
 	cfg := getTestConfig()
-	jwtService := NewJWTService(cfg)
+	jwtService := newTestJWTService(t, cfg)
 
 	userID := uint(1)
 	username := testUsername
@@ -95,9 +110,52 @@ func TestJWTService_ValidateAccessToken(t *testing.T) {
 	}
 }
 
+func TestJWTService_RevokeUserAccessTokens(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	ctx := context.Background()
+
+	jwtService, err := NewJWTService(getTestConfig(), WithRevocationStore(mockRepo.RevokedToken()))
+	if err != nil {
+		t.Fatalf("Failed to create JWT service: %v", err)
+	}
+
+	before, err := jwtService.GenerateTokenPair(1, testUsername, testEmail)
+	if err != nil {
+		t.Fatalf("Failed to generate token pair: %v", err)
+	}
+
+	if err = jwtService.RevokeUserAccessTokens(ctx, 1); err != nil {
+		t.Fatalf("RevokeUserAccessTokens() error = %v", err)
+	}
+
+	after, err := jwtService.GenerateTokenPair(1, testUsername, testEmail)
+	if err != nil {
+		t.Fatalf("Failed to generate token pair: %v", err)
+	}
+
+	// Обидва токени видані в межах однієї секунди; межа відкликання їх розрізняє
+	claims, err := jwtService.ValidateAccessToken(before.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+
+	if err = jwtService.CheckRevoked(ctx, claims); !errors.Is(err, ErrRevokedToken) {
+		t.Errorf("Expected ErrRevokedToken for token issued before revocation, got %v", err)
+	}
+
+	claims, err = jwtService.ValidateAccessToken(after.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+
+	if err = jwtService.CheckRevoked(ctx, claims); err != nil {
+		t.Errorf("Expected token issued after revocation to be valid, got %v", err)
+	}
+}
+
 func TestJWTService_ValidateAccessToken_InvalidToken(t *testing.T) {
 	cfg := getTestConfig()
-	jwtService := NewJWTService(cfg)
+	jwtService := newTestJWTService(t, cfg)
 
 	// Тестуємо з невалідним токеном
 	_, err := jwtService.ValidateAccessToken("invalid_token")
@@ -112,7 +170,7 @@ func TestJWTService_ValidateAccessToken_InvalidToken(t *testing.T) {
 
 func TestJWTService_ValidateRefreshToken(t *testing.T) {
 	cfg := getTestConfig()
-	jwtService := NewJWTService(cfg)
+	jwtService := newTestJWTService(t, cfg)
 
 	userID := uint(1)
 	username := testUsername
@@ -139,9 +197,50 @@ func TestJWTService_ValidateRefreshToken(t *testing.T) {
 	}
 }
 
+func TestJWTService_ActionToken(t *testing.T) {
+	jwtService := newTestJWTService(t, getTestConfig())
+
+	token, expiresAt, err := jwtService.GenerateActionToken(models.TokenPurposePasswordReset, 1, testEmail, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to generate action token: %v", err)
+	}
+
+	if d := time.Until(expiresAt); d <= 0 || d > time.Hour {
+		t.Errorf("Expected expiry within an hour, got %v", expiresAt)
+	}
+
+	claims, err := jwtService.ValidateActionToken(token, models.TokenPurposePasswordReset)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if claims.UserID != 1 || claims.Email != testEmail || claims.ID == "" {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+
+	// Призначення токенів не взаємозамінні
+	if _, err = jwtService.ValidateActionToken(token, models.TokenPurposeEmailVerification); err != ErrInvalidToken {
+		t.Errorf("Expected ErrInvalidToken for another purpose, got %v", err)
+	}
+
+	if _, err = jwtService.ValidateAccessToken(token); err != ErrInvalidToken {
+		t.Errorf("Expected action token to be rejected as access token, got %v", err)
+	}
+
+	tokenPair, _ := jwtService.GenerateTokenPair(1, testUsername, testEmail)
+	if _, err = jwtService.ValidateActionToken(tokenPair.AccessToken, models.TokenPurposePasswordReset); err != ErrInvalidToken {
+		t.Errorf("Expected access token to be rejected as action token, got %v", err)
+	}
+
+	expired, _, _ := jwtService.GenerateActionToken(models.TokenPurposePasswordReset, 1, testEmail, -time.Minute)
+	if _, err = jwtService.ValidateActionToken(expired, models.TokenPurposePasswordReset); err != ErrExpiredToken {
+		t.Errorf("Expected ErrExpiredToken, got %v", err)
+	}
+}
+
 func TestJWTService_RefreshTokens(t *testing.T) {
 	cfg := getTestConfig()
-	jwtService := NewJWTService(cfg)
+	jwtService := newTestJWTService(t, cfg)
 
 	userID := uint(1)
 	username := testUsername
@@ -183,7 +282,7 @@ func TestJWTService_RefreshTokens(t *testing.T) {
 
 func TestJWTService_ExtractTokenFromHeader(t *testing.T) {
 	cfg := getTestConfig()
-	jwtService := NewJWTService(cfg)
+	jwtService := newTestJWTService(t, cfg)
 
 	tests := []struct {
 		name        string
@@ -253,7 +352,7 @@ func TestJWTService_ExpiredToken(t *testing.T) {
 		},
 	}
 
-	jwtService := NewJWTService(cfg)
+	jwtService := newTestJWTService(t, cfg)
 
 	userID := uint(1)
 	username := testUsername
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/markdown"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// LinkService показує граф wiki-посилань між статтями: куди веде стаття,
+// які сторінки залежать від неї та які посилання нікуди не ведуть
+type LinkService struct {
+	linkRepo    repo.LinkRepository
+	articleRepo repo.ArticleRepository
+}
+
+func NewLinkService(linkRepo repo.LinkRepository, articleRepo repo.ArticleRepository) *LinkService {
+	return &LinkService{
+		linkRepo:    linkRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// ArticleLinks повертає сторінку вихідних посилань статті разом з їхніми цілями
+func (s *LinkService) ArticleLinks(
+	ctx context.Context,
+	articleID uint,
+	query pagination.Query,
+) (*pagination.Page[*models.ArticleLink], error) {
+	if err := s.ensureArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	params, err := repo.ArticleLinkPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkRepo.ListArticleLinks(ctx, articleID, params)
+	if err != nil {
+		return nil, fmt.Errorf("LinkService - ArticleLinks - ListArticleLinks: %w", err)
+	}
+
+	return links, nil
+}
+
+// Backlinks повертає сторінку статей, що посилаються на articleID: їх зачепить
+// перейменування slug або видалення статті
+func (s *LinkService) Backlinks(
+	ctx context.Context,
+	articleID uint,
+	query pagination.Query,
+) (*pagination.Page[*models.Article], error) {
+	if err := s.ensureArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	params, err := repo.BacklinkPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := s.linkRepo.ListBacklinks(ctx, articleID, params)
+	if err != nil {
+		return nil, fmt.Errorf("LinkService - Backlinks - ListBacklinks: %w", err)
+	}
+
+	return articles, nil
+}
+
+// BrokenLinks повертає сторінку посилань на неіснуючі статті
+func (s *LinkService) BrokenLinks(
+	ctx context.Context,
+	query pagination.Query,
+) (*pagination.Page[*models.ArticleLink], error) {
+	params, err := repo.BrokenLinkPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkRepo.ListBrokenLinks(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("LinkService - BrokenLinks - ListBrokenLinks: %w", err)
+	}
+
+	return links, nil
+}
+
+func (s *LinkService) ensureArticle(ctx context.Context, articleID uint) error {
+	article, err := s.articleRepo.GetArticleByID(ctx, articleID)
+	if err != nil {
+		return fmt.Errorf("LinkService - ensureArticle - GetArticleByID: %w", err)
+	}
+
+	if article == nil {
+		return ErrArticleNotFound
+	}
+
+	return nil
+}
+
+// ParseWikiLinks знаходить у markdown тексті посилання [[Title]] і [[Title|label]].
+// Ціль посилання - стаття зі slug, отриманим із Title так само, як slug нової статті,
+// тому [[Getting Started]] веде на getting-started. Посилання з однаковою ціллю
+// об'єднуються, посилання всередині коду та без літер чи цифр пропускаються.
+func ParseWikiLinks(body string) []*models.ArticleLink {
+	links := make([]*models.ArticleLink, 0)
+	seen := make(map[string]bool)
+
+	for _, target := range markdown.WikiLinks([]byte(body)) {
+		slug := Slugify(target)
+		if slug == "" || seen[slug] {
+			continue
+		}
+
+		seen[slug] = true
+		links = append(links, &models.ArticleLink{Target: target, TargetSlug: slug})
+	}
+
+	return links
+}
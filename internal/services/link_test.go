@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func newTestLinkServices() (*LinkService, *ArticleService) {
+	mockRepo := mocks.NewRepository()
+
+	return NewLinkService(mockRepo.Link(), mockRepo.Article()),
+		NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+			NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+}
+
+func TestParseWikiLinks(t *testing.T) {
+	links := ParseWikiLinks("[[Getting Started]], [[getting  started|again]], [[!!!]], `[[Code]]`, [[Деплой]]")
+
+	if len(links) != 2 {
+		t.Fatalf("ParseWikiLinks() = %+v", links)
+	}
+
+	if links[0].Target != "Getting Started" || links[0].TargetSlug != "getting-started" || links[1].TargetSlug != "деплой" {
+		t.Errorf("Unexpected links: %+v, %+v", links[0], links[1])
+	}
+}
+
+func TestLinkService(t *testing.T) {
+	links, articles := newTestLinkServices()
+	ctx := context.Background()
+
+	index, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Index", Body: "[[Guide]] and [[Missing page]]"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	guide, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Guide", Body: "Back to [[Index]], see [[Guide]]"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	outgoing, err := links.ArticleLinks(ctx, index.ID, pagination.Query{})
+	if err != nil || len(outgoing.Items) != 2 {
+		t.Fatalf("ArticleLinks() = %v, %v", outgoing, err)
+	}
+
+	first, second := outgoing.Items[0], outgoing.Items[1]
+	if first.TargetSlug != "guide" || first.TargetID == nil || *first.TargetID != guide.ID || !second.Broken {
+		t.Errorf("Unexpected links: %+v, %+v", first, second)
+	}
+
+	page, err := links.ArticleLinks(ctx, index.ID, pagination.Query{Limit: 1})
+	if err != nil || len(page.Items) != 1 || page.NextCursor == "" {
+		t.Fatalf("ArticleLinks(limit) = %+v, %v", page, err)
+	}
+
+	page, err = links.ArticleLinks(ctx, index.ID, pagination.Query{Limit: 1, Cursor: page.NextCursor})
+	if err != nil || len(page.Items) != 1 || page.Items[0].TargetSlug != second.TargetSlug || page.NextCursor != "" {
+		t.Errorf("ArticleLinks(cursor) = %+v, %v", page, err)
+	}
+
+	// Посилання статті на себе не є зворотним
+	backlinks, err := links.Backlinks(ctx, guide.ID, pagination.Query{})
+	if err != nil || len(backlinks.Items) != 1 || backlinks.Items[0].ID != index.ID {
+		t.Errorf("Backlinks() = %v, %v", backlinks, err)
+	}
+
+	broken, err := links.BrokenLinks(ctx, pagination.Query{})
+	if err != nil || len(broken.Items) != 1 || broken.Items[0].Target != "Missing page" {
+		t.Errorf("BrokenLinks() = %v, %v", broken, err)
+	}
+
+	// Оновлення тексту замінює посилання, зміна slug цілі робить посилання битими
+	if _, err = articles.Update(ctx, index.ID, 1, ArticleInput{Title: "Index", Body: "[[Guide]]"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if _, err = articles.Update(ctx, guide.ID, 1, ArticleInput{Title: "Guide", Slug: "handbook"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	broken, _ = links.BrokenLinks(ctx, pagination.Query{})
+	if len(broken.Items) != 1 || broken.Items[0].SourceID != index.ID || broken.Items[0].TargetSlug != "guide" {
+		t.Errorf("BrokenLinks() after rename = %+v", broken)
+	}
+
+	if _, err = links.Backlinks(ctx, 42, pagination.Query{}); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+
+	if _, err = links.ArticleLinks(ctx, 42, pagination.Query{}); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+}
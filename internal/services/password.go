@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+
+	"KnowledgeHub/pkg/apperror"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MaxPasswordBytes - bcrypt враховує лише перші 72 байти пароля і довший відхиляє
+const MaxPasswordBytes = 72
+
+var ErrPasswordTooLong = apperror.Validation("password_too_long", "password is too long").
+	WithFields(apperror.FieldError{Field: "password", Message: "must be at most 72 bytes"})
+
+// _dummyPasswordHash використовується, коли користувача не знайдено, щоб час
+// відповіді логіну не видавав, чи існує такий username.
+var _dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// validatePassword перевіряє обмеження bcrypt на довжину пароля в байтах
+func validatePassword(password string) error {
+	if len(password) > MaxPasswordBytes {
+		return ErrPasswordTooLong
+	}
+
+	return nil
+}
+
+// HashPassword повертає bcrypt-хеш пароля
+func HashPassword(password string) (string, error) {
+	if err := validatePassword(password); err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// CheckPassword перевіряє пароль проти збереженого хешу
+func CheckPassword(hash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+
+	return false, err
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/markdown"
+	"KnowledgeHub/pkg/pagination"
+	"KnowledgeHub/pkg/tracing"
+)
+
+// DefaultRenderCacheSize - кількість відрендерених ревізій у кеші за замовчуванням
+const DefaultRenderCacheSize = 1000
+
+// _articleURLFormat - адреса статті, на яку ведуть wiki-посилання
+const _articleURLFormat = "/v1/articles/%d"
+
+// RenderService перетворює markdown тіло статті в санітизований HTML зі змістом.
+// Ревізія статті незмінна, тому результат кешується за парою (ID, Version) разом
+// з поточними цілями її wiki-посилань: створення чи перейменування сторінки-цілі
+// змінює ключ, і посилання рендеряться заново.
+type RenderService struct {
+	linkRepo repo.LinkRepository
+	renderer *markdown.Renderer
+	cache    *renderCache
+}
+
+// NewRenderService створює сервіс з LRU кешем на cacheSize ревізій;
+// cacheSize <= 0 означає DefaultRenderCacheSize
+func NewRenderService(linkRepo repo.LinkRepository, cacheSize int) *RenderService {
+	if cacheSize <= 0 {
+		cacheSize = DefaultRenderCacheSize
+	}
+
+	return &RenderService{
+		linkRepo: linkRepo,
+		renderer: markdown.New(),
+		cache:    newRenderCache(cacheSize),
+	}
+}
+
+// Render повертає статтю разом з HTML та змістом поточної ревізії.
+// Wiki-посилання ведуть на статті-цілі, посилання на неіснуючі сторінки позначаються битими.
+func (s *RenderService) Render(ctx context.Context, article *models.Article) (*models.RenderedArticle, error) {
+	ctx, span := tracing.Start(ctx, "RenderService.Render", tracing.ID("article.id", article.ID))
+	defer span.End()
+
+	links, err := s.linkRepo.ListArticleLinks(ctx, article.ID, pagination.Params{})
+	if err != nil {
+		return nil, fmt.Errorf("RenderService - Render - ListArticleLinks: %w", err)
+	}
+
+	targets := make(map[string]uint, len(links.Items))
+
+	var resolved strings.Builder
+
+	for _, link := range links.Items {
+		if link.TargetID != nil {
+			targets[link.TargetSlug] = *link.TargetID
+			resolved.WriteString(link.TargetSlug + "=" + strconv.FormatUint(uint64(*link.TargetID), 10) + ";")
+		}
+	}
+
+	key := renderKey{articleID: article.ID, version: article.Version, links: resolved.String()}
+
+	rendered, ok := s.cache.get(key)
+	if !ok {
+		doc, renderErr := s.renderer.Render([]byte(article.Body), func(target string) (string, bool) {
+			id, found := targets[Slugify(target)]
+			if !found {
+				return "", false
+			}
+
+			return fmt.Sprintf(_articleURLFormat, id), true
+		})
+		if renderErr != nil {
+			return nil, fmt.Errorf("RenderService - Render - markdown.Render: %w", renderErr)
+		}
+
+		rendered = renderedBody{html: doc.HTML, toc: make([]models.TOCEntry, 0, len(doc.Headings))}
+
+		for _, heading := range doc.Headings {
+			rendered.toc = append(rendered.toc, models.TOCEntry{
+				Level:  heading.Level,
+				Text:   heading.Text,
+				Anchor: heading.Anchor,
+			})
+		}
+
+		s.cache.put(key, rendered)
+	}
+
+	return &models.RenderedArticle{
+		Article: *article,
+		HTML:    rendered.html,
+		TOC:     append(make([]models.TOCEntry, 0, len(rendered.toc)), rendered.toc...),
+	}, nil
+}
+
+// renderKey - ревізія статті та розв'язані цілі її посилань у вигляді "slug=id;..."
+type renderKey struct {
+	articleID uint
+	version   int
+	links     string
+}
+
+type renderedBody struct {
+	html string
+	toc  []models.TOCEntry
+}
+
+type renderCacheEntry struct {
+	key  renderKey
+	body renderedBody
+}
+
+// renderCache - потокобезпечний LRU кеш відрендерених ревізій
+type renderCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[renderKey]*list.Element
+}
+
+func newRenderCache(capacity int) *renderCache {
+	return &renderCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[renderKey]*list.Element, capacity),
+	}
+}
+
+func (c *renderCache) get(key renderKey) (renderedBody, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return renderedBody{}, false
+	}
+
+	c.order.MoveToFront(element)
+
+	return element.Value.(*renderCacheEntry).body, true //nolint:errcheck // list holds only cache entries
+}
+
+func (c *renderCache) put(key renderKey, body renderedBody) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&renderCacheEntry{key: key, body: body})
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*renderCacheEntry).key) //nolint:errcheck // list holds only cache entries
+	}
+}
+
+func (c *renderCache) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+)
+
+func TestRenderService_Render(t *testing.T) {
+	renders := NewRenderService(mocks.NewRepository().Link(), 0)
+
+	article := &models.Article{ID: 1, Version: 1, Body: "# Intro\n\n<script>alert(1)</script>\n\n## Setup"}
+
+	rendered, err := renders.Render(context.Background(), article)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if rendered.ID != 1 || rendered.Body != article.Body {
+		t.Errorf("Rendered article lost fields: %+v", rendered.Article)
+	}
+
+	if strings.Contains(rendered.HTML, "<script") || !strings.Contains(rendered.HTML, `<h2 id="setup">Setup</h2>`) {
+		t.Errorf("Unexpected HTML: %s", rendered.HTML)
+	}
+
+	if len(rendered.TOC) != 2 || rendered.TOC[0].Anchor != "intro" || rendered.TOC[1].Level != 2 {
+		t.Errorf("Unexpected TOC: %+v", rendered.TOC)
+	}
+}
+
+func TestRenderService_CacheByRevision(t *testing.T) {
+	renders := NewRenderService(mocks.NewRepository().Link(), 2)
+	ctx := context.Background()
+
+	first, _ := renders.Render(ctx, &models.Article{ID: 1, Version: 1, Body: "# One"})
+
+	// Та сама ревізія віддається з кешу, навіть якщо тіло змінилось без нової версії
+	cached, _ := renders.Render(ctx, &models.Article{ID: 1, Version: 1, Body: "# Changed"})
+	if cached.HTML != first.HTML {
+		t.Errorf("Expected cached HTML %q, got %q", first.HTML, cached.HTML)
+	}
+
+	cached.TOC[0].Text = "mutated"
+
+	if again, _ := renders.Render(ctx, &models.Article{ID: 1, Version: 1}); again.TOC[0].Text != "One" {
+		t.Errorf("Caller mutated cached TOC: %+v", again.TOC)
+	}
+
+	updated, _ := renders.Render(ctx, &models.Article{ID: 1, Version: 2, Body: "# Two"})
+	if !strings.Contains(updated.HTML, "Two") {
+		t.Errorf("New revision was not rendered: %s", updated.HTML)
+	}
+
+	// Третя ревізія витісняє найдавніше використану
+	_, _ = renders.Render(ctx, &models.Article{ID: 2, Version: 1, Body: "# Other"})
+
+	if size := renders.cache.size(); size != 2 {
+		t.Errorf("Cache size = %d, want 2", size)
+	}
+
+	if _, ok := renders.cache.get(renderKey{articleID: 1, version: 1}); ok {
+		t.Error("Least recently used revision was not evicted")
+	}
+}
+
+func TestRenderService_WikiLinks(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	articles := NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	renders := NewRenderService(mockRepo.Link(), 0)
+	ctx := context.Background()
+
+	source, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Index", Body: "See [[Deploy Guide|deploy]]"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	rendered, err := renders.Render(ctx, source)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if !strings.Contains(rendered.HTML, `<span class="wiki-link wiki-link-broken">deploy</span>`) {
+		t.Errorf("Expected broken link, got %s", rendered.HTML)
+	}
+
+	// Поява цілі змінює HTML тієї самої ревізії, незважаючи на кеш
+	target, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Deploy guide"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	rendered, err = renders.Render(ctx, source)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<a href="/v1/articles/2" class="wiki-link" rel="nofollow">deploy</a>`
+	if target.ID != 2 || !strings.Contains(rendered.HTML, want) {
+		t.Errorf("Expected %s, got %s", want, rendered.HTML)
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/pagination"
+	"KnowledgeHub/pkg/tracing"
+)
+
+const _maxSearchQueryLength = 256
+
+var (
+	ErrEmptySearchQuery   = apperror.Validation("empty_search_query", "search query is empty")
+	ErrSearchQueryTooLong = apperror.Validation("search_query_too_long", "search query is too long")
+	ErrInvalidDateRange   = apperror.Validation("invalid_date_range", "invalid date range")
+)
+
+// SearchResult - сторінка результатів пошуку
+type SearchResult struct {
+	Hits []*models.SearchHit
+	// NextCursor - курсор наступної сторінки; порожній на останній сторінці
+	NextCursor string
+	// Total - кількість усіх збігів, а не лише решти після курсора
+	Total int
+}
+
+// SearchService - повнотекстовий пошук по базі знань
+type SearchService struct {
+	searchRepo repo.SearchRepository
+}
+
+func NewSearchService(searchRepo repo.SearchRepository) *SearchService {
+	return &SearchService{
+		searchRepo: searchRepo,
+	}
+}
+
+// Search перевіряє параметри запиту і повертає сторінку статей, за замовчуванням від найрелевантніших
+func (s *SearchService) Search(
+	ctx context.Context,
+	query models.SearchQuery,
+	page pagination.Query,
+) (*SearchResult, error) {
+	ctx, span := tracing.Start(ctx, "SearchService.Search")
+	defer span.End()
+
+	query.Text = strings.TrimSpace(query.Text)
+
+	if query.Text == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	if utf8.RuneCountInString(query.Text) > _maxSearchQueryLength {
+		return nil, ErrSearchQueryTooLong
+	}
+
+	if query.Language != "" && !models.IsArticleLanguage(query.Language) {
+		return nil, ErrUnsupportedLanguage
+	}
+
+	if query.Status != "" && !query.Status.IsValid() {
+		return nil, ErrInvalidArticleStatus
+	}
+
+	if len(query.Tags) > 0 {
+		tags, err := normalizeTags(query.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Tags = tags
+	}
+
+	if query.UpdatedFrom != nil && query.UpdatedTo != nil && !query.UpdatedFrom.Before(*query.UpdatedTo) {
+		return nil, ErrInvalidDateRange
+	}
+
+	params, err := repo.SearchPages.Params(page)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, total, err := s.searchRepo.SearchArticles(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("SearchService - Search - SearchArticles: %w", err)
+	}
+
+	return &SearchResult{
+		Hits:       hits.Items,
+		NextCursor: hits.NextCursor,
+		Total:      total,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	articleService := NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	service := NewSearchService(mockRepo.Search())
+	ctx := context.Background()
+
+	inputs := []struct {
+		author uint
+		input  ArticleInput
+	}{
+		{1, ArticleInput{Title: "Deploy guide", Body: "How to ship the service", Status: models.ArticleStatusPublished}},
+		{2, ArticleInput{Title: "Onboarding", Body: "First deploy happens on day one"}},
+		{1, ArticleInput{Title: "Vacation policy", Body: "Nothing about shipping"}},
+	}
+
+	for _, in := range inputs {
+		if _, err := articleService.Create(ctx, asEditor(in.author), in.input); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	result, err := service.Search(ctx, models.SearchQuery{Text: "  deploy "}, pagination.Query{})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if result.Total != 2 || len(result.Hits) != 2 {
+		t.Fatalf("Expected 2 hits, got %d (total %d)", len(result.Hits), result.Total)
+	}
+
+	// Збіг у заголовку має вищу релевантність
+	if result.Hits[0].Title != "Deploy guide" {
+		t.Errorf("Expected title match first, got %q", result.Hits[0].Title)
+	}
+
+	// Наступна сторінка за курсором; total - кількість усіх збігів
+	page, err := service.Search(ctx, models.SearchQuery{Text: "deploy"}, pagination.Query{Limit: 1})
+	if err != nil || len(page.Hits) != 1 || page.NextCursor == "" {
+		t.Fatalf("Search(limit) = %+v, %v", page, err)
+	}
+
+	page, err = service.Search(ctx, models.SearchQuery{Text: "deploy"}, pagination.Query{Limit: 1, Cursor: page.NextCursor})
+	if err != nil || page.Total != 2 || len(page.Hits) != 1 || page.Hits[0].ArticleID != result.Hits[1].ArticleID ||
+		page.NextCursor != "" {
+		t.Errorf("Search(cursor) = %+v, %v", page, err)
+	}
+
+	if _, err = service.Search(ctx, models.SearchQuery{Text: "deploy"}, pagination.Query{Sort: "title"}); err == nil {
+		t.Error("Expected error for unsupported sort field")
+	}
+
+	result, err = service.Search(ctx, models.SearchQuery{Text: "deploy", AuthorID: 2}, pagination.Query{})
+	if err != nil || result.Total != 1 || result.Hits[0].AuthorID != 2 {
+		t.Errorf("Expected 1 hit of author 2, got %+v, %v", result, err)
+	}
+
+	result, err = service.Search(ctx, models.SearchQuery{Text: "deploy", Status: models.ArticleStatusPublished}, pagination.Query{})
+	if err != nil || result.Total != 1 {
+		t.Errorf("Expected 1 published hit, got %+v, %v", result, err)
+	}
+
+	future := time.Now().Add(time.Hour)
+	result, err = service.Search(ctx, models.SearchQuery{Text: "deploy", UpdatedFrom: &future}, pagination.Query{})
+	if err != nil || result.Total != 0 {
+		t.Errorf("Expected no hits updated in the future, got %+v, %v", result, err)
+	}
+
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		query   models.SearchQuery
+		wantErr error
+	}{
+		{"Empty query", models.SearchQuery{Text: "   "}, ErrEmptySearchQuery},
+		{"Too long query", models.SearchQuery{Text: strings.Repeat("a", 300)}, ErrSearchQueryTooLong},
+		{"Unknown language", models.SearchQuery{Text: "deploy", Language: "klingon"}, ErrUnsupportedLanguage},
+		{"Unknown status", models.SearchQuery{Text: "deploy", Status: "deleted"}, ErrInvalidArticleStatus},
+		{"Inverted range", models.SearchQuery{Text: "deploy", UpdatedFrom: &future, UpdatedTo: &past}, ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Search(ctx, tt.query, pagination.Query{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Search() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchService_Tags(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	articleService := NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+		NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+	tagService := NewTagService(mockRepo.Tag(), mockRepo.Article())
+	service := NewSearchService(mockRepo.Search())
+	ctx := context.Background()
+
+	tagged, _ := articleService.Create(ctx, asEditor(1), ArticleInput{Title: "Runbook", Body: "Restart the service"})
+	titled, _ := articleService.Create(ctx, asEditor(1), ArticleInput{Title: "Kubernetes basics", Body: "Pods and nodes"})
+
+	if _, err := tagService.AddArticleTags(ctx, tagged.ID, []string{"Kubernetes", "ops"}); err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	// Назва тегу знаходить статтю, але важить менше за збіг у заголовку
+	result, err := service.Search(ctx, models.SearchQuery{Text: "kubernetes"}, pagination.Query{})
+	if err != nil || result.Total != 2 {
+		t.Fatalf("Expected 2 hits, got %+v, %v", result, err)
+	}
+
+	if result.Hits[0].ArticleID != titled.ID || result.Hits[1].ArticleID != tagged.ID {
+		t.Errorf("Expected title match before tag match, got %d, %d", result.Hits[0].ArticleID, result.Hits[1].ArticleID)
+	}
+
+	result, err = service.Search(ctx, models.SearchQuery{Text: "kubernetes", Tags: []string{"OPS", "kubernetes"}}, pagination.Query{})
+	if err != nil || result.Total != 1 || result.Hits[0].ArticleID != tagged.ID {
+		t.Errorf("Expected only the article with both tags, got %+v, %v", result, err)
+	}
+
+	result, err = service.Search(ctx, models.SearchQuery{Text: "kubernetes", Tags: []string{"ops", "release"}}, pagination.Query{})
+	if err != nil || result.Total != 0 {
+		t.Errorf("Expected no hits for a missing tag, got %+v, %v", result, err)
+	}
+
+	if _, err = service.Search(ctx, models.SearchQuery{Text: "kubernetes", Tags: []string{"a/b"}}, pagination.Query{}); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("Expected ErrInvalidTag, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+)
+
+// ErrRefreshTokenReused - повторне пред'явлення вже використаного refresh токена.
+// Вся сім'я токенів при цьому відкликається.
+var ErrRefreshTokenReused = apperror.Unauthorized("refresh_token_reused", "refresh token reuse detected")
+
+// SessionService видає пари токенів і керує серверним станом refresh токенів.
+// Кожен логін відкриває нову сім'ю токенів; refresh токен одноразовий і при
+// використанні замінюється новим з тієї ж сім'ї.
+type SessionService struct {
+	jwtService       *JWTService
+	userRepo         repo.UserRepository
+	refreshTokenRepo repo.RefreshTokenRepository
+}
+
+func NewSessionService(
+	jwtService *JWTService,
+	userRepo repo.UserRepository,
+	refreshTokenRepo repo.RefreshTokenRepository,
+) *SessionService {
+	return &SessionService{
+		jwtService:       jwtService,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// StartSession видає пару токенів для користувача та відкриває нову сім'ю refresh токенів
+func (s *SessionService) StartSession(ctx context.Context, user *models.User) (*TokenPair, error) {
+	familyID, err := newFamilyID()
+	if err != nil {
+		return nil, fmt.Errorf("SessionService - StartSession - newFamilyID: %w", err)
+	}
+
+	return s.issueTokens(ctx, user, familyID)
+}
+
+// RefreshSession обмінює refresh токен на нову пару токенів.
+// Якщо токен вже використовувався, відкликає всю його сім'ю і повертає ErrRefreshTokenReused.
+func (s *SessionService) RefreshSession(ctx context.Context, refreshToken string) (*TokenPair, *models.User, error) {
+	if _, err := s.jwtService.ValidateRefreshToken(refreshToken); err != nil {
+		return nil, nil, err
+	}
+
+	stored, err := s.refreshTokenRepo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, nil, fmt.Errorf("SessionService - RefreshSession - GetRefreshTokenByHash: %w", err)
+	}
+
+	if stored == nil || stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return nil, nil, ErrInvalidToken
+	}
+
+	if stored.UsedAt != nil {
+		return nil, nil, s.revokeReusedFamily(ctx, stored.FamilyID)
+	}
+
+	// Атомарна позначка захищає від двох паралельних обмінів одного токена
+	marked, err := s.refreshTokenRepo.MarkRefreshTokenUsed(ctx, stored.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SessionService - RefreshSession - MarkRefreshTokenUsed: %w", err)
+	}
+
+	if !marked {
+		return nil, nil, s.revokeReusedFamily(ctx, stored.FamilyID)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SessionService - RefreshSession - GetUserByID: %w", err)
+	}
+
+	if user == nil {
+		if err = s.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+			return nil, nil, fmt.Errorf("SessionService - RefreshSession - RevokeRefreshTokenFamily: %w", err)
+		}
+
+		return nil, nil, ErrInvalidToken
+	}
+
+	tokenPair, err := s.issueTokens(ctx, user, stored.FamilyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokenPair, user, nil
+}
+
+// EndSession відкликає access токен і всю сім'ю refresh токенів його сесії
+func (s *SessionService) EndSession(ctx context.Context, claims *JWTClaims) error {
+	if err := s.jwtService.RevokeAccessToken(ctx, claims); err != nil {
+		return fmt.Errorf("SessionService - EndSession - RevokeAccessToken: %w", err)
+	}
+
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	if err := s.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, claims.SessionID); err != nil {
+		return fmt.Errorf("SessionService - EndSession - RevokeRefreshTokenFamily: %w", err)
+	}
+
+	return nil
+}
+
+// EndAllSessions відкликає всі access та refresh токени користувача
+func (s *SessionService) EndAllSessions(ctx context.Context, userID uint) error {
+	if err := s.jwtService.RevokeUserAccessTokens(ctx, userID); err != nil {
+		return fmt.Errorf("SessionService - EndAllSessions - RevokeUserAccessTokens: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return fmt.Errorf("SessionService - EndAllSessions - RevokeUserRefreshTokens: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) issueTokens(ctx context.Context, user *models.User, familyID string) (*TokenPair, error) {
+	tokenPair, err := s.jwtService.GenerateSessionTokenPair(familyID, user.ID, user.Username, user.Email, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("SessionService - issueTokens - GenerateSessionTokenPair: %w", err)
+	}
+
+	err = s.refreshTokenRepo.CreateRefreshToken(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(tokenPair.RefreshToken),
+		ExpiresAt: time.Unix(tokenPair.RefreshExpiresAt, 0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SessionService - issueTokens - CreateRefreshToken: %w", err)
+	}
+
+	return tokenPair, nil
+}
+
+func (s *SessionService) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := s.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("SessionService - revokeReusedFamily - RevokeRefreshTokenFamily: %w", err)
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// hashToken повертає SHA-256 токена у hex; у сховищі зберігається лише хеш
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func newFamilyID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
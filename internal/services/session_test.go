@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+)
+
+func getTestSessionService(t *testing.T) (*SessionService, *models.User) {
+	t.Helper()
+
+	mockRepo := mocks.NewRepository()
+	user := &models.User{ID: 1, Username: testUsername, Email: testEmail}
+	mockRepo.AddUser(user)
+
+	service := NewSessionService(newTestJWTService(t, getTestConfig()), mockRepo.User(), mockRepo.RefreshToken())
+
+	return service, user
+}
+
+func TestSessionService_RefreshSession_Rotates(t *testing.T) {
+	service, user := getTestSessionService(t)
+	ctx := context.Background()
+
+	tokenPair, err := service.StartSession(ctx, user)
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+
+	newPair, gotUser, err := service.RefreshSession(ctx, tokenPair.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshSession() error = %v", err)
+	}
+
+	if gotUser.ID != user.ID {
+		t.Errorf("Expected user ID %d, got %d", user.ID, gotUser.ID)
+	}
+
+	if newPair.RefreshToken == tokenPair.RefreshToken {
+		t.Error("Expected refresh token to be rotated")
+	}
+
+	// Новий токен також можна обміняти
+	if _, _, err = service.RefreshSession(ctx, newPair.RefreshToken); err != nil {
+		t.Errorf("Expected rotated token to be valid, got %v", err)
+	}
+}
+
+func TestSessionService_RefreshSession_ReuseDetection(t *testing.T) {
+	service, user := getTestSessionService(t)
+	ctx := context.Background()
+
+	tokenPair, err := service.StartSession(ctx, user)
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	// Паралельна сесія іншого пристрою не повинна постраждати
+	otherPair, err := service.StartSession(ctx, user)
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	rotated, _, err := service.RefreshSession(ctx, tokenPair.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshSession() error = %v", err)
+	}
+
+	_, _, err = service.RefreshSession(ctx, tokenPair.RefreshToken)
+	if !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("Expected ErrRefreshTokenReused, got %v", err)
+	}
+
+	_, _, err = service.RefreshSession(ctx, rotated.RefreshToken)
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for revoked family, got %v", err)
+	}
+
+	if _, _, err = service.RefreshSession(ctx, otherPair.RefreshToken); err != nil {
+		t.Errorf("Expected other session to stay valid, got %v", err)
+	}
+}
+
+func TestSessionService_RefreshSession_UnknownToken(t *testing.T) {
+	service, _ := getTestSessionService(t)
+
+	tokenPair, err := service.jwtService.GenerateTokenPair(1, testUsername, testEmail)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	_, _, err = service.RefreshSession(context.Background(), tokenPair.RefreshToken)
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestSessionService_EmbedsRole(t *testing.T) {
+	service, user := getTestSessionService(t)
+	ctx := context.Background()
+
+	user.Role = models.RoleAdmin
+
+	tokenPair, err := service.StartSession(ctx, user)
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	claims, err := service.jwtService.ValidateAccessToken(tokenPair.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+
+	if claims.UserRole() != models.RoleAdmin {
+		t.Errorf("Expected role admin, got %q", claims.Role)
+	}
+
+	// Роль перечитується з користувача при оновленні сесії
+	user.Role = models.RoleViewer
+
+	newPair, _, err := service.RefreshSession(ctx, tokenPair.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshSession() error = %v", err)
+	}
+
+	claims, err = service.jwtService.ValidateAccessToken(newPair.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+
+	if claims.UserRole() != models.RoleViewer {
+		t.Errorf("Expected role viewer after refresh, got %q", claims.Role)
+	}
+}
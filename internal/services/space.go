@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/pagination"
+)
+
+const _maxSpaceKeyLength = 100
+
+var (
+	ErrSpaceNotFound      = apperror.NotFound("space_not_found", "space not found")
+	ErrSpaceKeyExists     = apperror.Conflict("space_key_exists", "space with this key already exists")
+	ErrInvalidSpaceKey    = apperror.Validation("invalid_space_key", "invalid space key")
+	ErrSpaceNotEmpty      = apperror.Conflict("space_not_empty", "space contains articles")
+	ErrParentNotFound     = apperror.Validation("parent_not_found", "parent page not found in space")
+	ErrInvalidMove        = apperror.Conflict("invalid_move", "page cannot be moved under itself or its descendant")
+	ErrArticleHasChildren = apperror.Conflict("article_has_children", "article has child pages")
+	ErrMemberNotFound     = apperror.NotFound("member_not_found", "space member not found")
+)
+
+// SpaceInput - дані для створення або оновлення простору; порожній Key генерується з Name
+type SpaceInput struct {
+	Key         string
+	Name        string
+	Description string
+}
+
+// SpaceTree - простір разом з деревом його сторінок
+type SpaceTree struct {
+	Space *models.Space      `json:"space"`
+	Pages []*models.PageNode `json:"pages"`
+}
+
+// Breadcrumbs - шлях до сторінки: простір, предки від кореня і сама сторінка.
+// Space дорівнює nil для статей поза простором.
+type Breadcrumbs struct {
+	Space *models.Space      `json:"space"`
+	Pages []*models.PageNode `json:"pages"`
+}
+
+// SpaceService керує просторами та структурою сторінок у них
+type SpaceService struct {
+	spaceRepo    repo.SpaceRepository
+	articleRepo  repo.ArticleRepository
+	pageTreeRepo repo.PageTreeRepository
+	memberRepo   repo.SpaceMemberRepository
+	access       *AccessService
+}
+
+func NewSpaceService(
+	spaceRepo repo.SpaceRepository,
+	articleRepo repo.ArticleRepository,
+	pageTreeRepo repo.PageTreeRepository,
+	memberRepo repo.SpaceMemberRepository,
+	access *AccessService,
+) *SpaceService {
+	return &SpaceService{
+		spaceRepo:    spaceRepo,
+		articleRepo:  articleRepo,
+		pageTreeRepo: pageTreeRepo,
+		memberRepo:   memberRepo,
+		access:       access,
+	}
+}
+
+// Create створює простір від імені userID; автор стає його адміністратором
+func (s *SpaceService) Create(ctx context.Context, userID uint, input SpaceInput) (*models.Space, error) {
+	key, err := spaceKey(input)
+	if err != nil {
+		return nil, err
+	}
+
+	space := &models.Space{
+		Key:         key,
+		Name:        input.Name,
+		Description: input.Description,
+		CreatedBy:   userID,
+	}
+
+	if err = s.spaceRepo.CreateSpace(ctx, space); err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, ErrSpaceKeyExists
+		}
+
+		return nil, fmt.Errorf("SpaceService - Create - CreateSpace: %w", err)
+	}
+
+	return space, nil
+}
+
+func (s *SpaceService) Get(ctx context.Context, id uint) (*models.Space, error) {
+	space, err := s.spaceRepo.GetSpaceByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("SpaceService - Get - GetSpaceByID: %w", err)
+	}
+
+	if space == nil {
+		return nil, ErrSpaceNotFound
+	}
+
+	return space, nil
+}
+
+// List повертає сторінку просторів, за замовчуванням за назвою
+func (s *SpaceService) List(ctx context.Context, query pagination.Query) (*pagination.Page[*models.Space], error) {
+	params, err := repo.SpacePages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	spaces, err := s.spaceRepo.ListSpaces(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("SpaceService - List - ListSpaces: %w", err)
+	}
+
+	return spaces, nil
+}
+
+// Update замінює назву та опис; ключ змінюється лише якщо переданий
+func (s *SpaceService) Update(ctx context.Context, id uint, input SpaceInput) (*models.Space, error) {
+	space, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Key != "" {
+		if space.Key, err = spaceKey(input); err != nil {
+			return nil, err
+		}
+	}
+
+	space.Name = input.Name
+	space.Description = input.Description
+
+	if err = s.spaceRepo.UpdateSpace(ctx, space); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			return nil, ErrSpaceNotFound
+		case errors.Is(err, repo.ErrAlreadyExists):
+			return nil, ErrSpaceKeyExists
+		default:
+			return nil, fmt.Errorf("SpaceService - Update - UpdateSpace: %w", err)
+		}
+	}
+
+	return space, nil
+}
+
+// Delete видаляє порожній простір
+func (s *SpaceService) Delete(ctx context.Context, id uint) error {
+	err := s.spaceRepo.DeleteSpace(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			return ErrSpaceNotFound
+		case errors.Is(err, repo.ErrInUse):
+			return ErrSpaceNotEmpty
+		default:
+			return fmt.Errorf("SpaceService - Delete - DeleteSpace: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListMembers повертає сторінку учасників простору, за замовчуванням у порядку додавання
+func (s *SpaceService) ListMembers(
+	ctx context.Context,
+	spaceID uint,
+	query pagination.Query,
+) (*pagination.Page[*models.SpaceMember], error) {
+	if _, err := s.Get(ctx, spaceID); err != nil {
+		return nil, err
+	}
+
+	params, err := repo.SpaceMemberPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.ListSpaceMembers(ctx, spaceID, params)
+	if err != nil {
+		return nil, fmt.Errorf("SpaceService - ListMembers - ListSpaceMembers: %w", err)
+	}
+
+	return members, nil
+}
+
+// SetMember додає користувача до простору або змінює його роль
+func (s *SpaceService) SetMember(
+	ctx context.Context,
+	spaceID, userID uint,
+	role models.Role,
+) (*models.SpaceMember, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	if _, err := s.Get(ctx, spaceID); err != nil {
+		return nil, err
+	}
+
+	member := &models.SpaceMember{
+		SpaceID: spaceID,
+		UserID:  userID,
+		Role:    role,
+	}
+
+	if err := s.memberRepo.SetSpaceMember(ctx, member); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			// Простір щойно перевірено, тож бракує користувача
+			return nil, ErrUserNotFound
+		}
+
+		return nil, fmt.Errorf("SpaceService - SetMember - SetSpaceMember: %w", err)
+	}
+
+	return member, nil
+}
+
+func (s *SpaceService) RemoveMember(ctx context.Context, spaceID, userID uint) error {
+	err := s.memberRepo.RemoveSpaceMember(ctx, spaceID, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrMemberNotFound
+		}
+
+		return fmt.Errorf("SpaceService - RemoveMember - RemoveSpaceMember: %w", err)
+	}
+
+	return nil
+}
+
+// Tree повертає простір і всі його сторінки одним деревом
+func (s *SpaceService) Tree(ctx context.Context, id uint) (*SpaceTree, error) {
+	space, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := s.pageTreeRepo.ListSpacePages(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("SpaceService - Tree - ListSpacePages: %w", err)
+	}
+
+	return &SpaceTree{
+		Space: space,
+		Pages: buildTree(pages),
+	}, nil
+}
+
+// MovePage переносить статтю разом з піддеревом під parentID (nil - у корінь простору)
+// на позицію position серед нових сусідів. Позиція за межами списку ставить статтю в кінець.
+// Права на поточний простір статті перевіряє маршрут, а на цільовий - MovePage:
+// переносити сторінки можна лише туди, де actor може їх редагувати.
+func (s *SpaceService) MovePage(ctx context.Context, actor Actor, move models.PageMove) (*models.Article, error) {
+	if move.ParentID != nil && *move.ParentID == move.ArticleID {
+		return nil, ErrInvalidMove
+	}
+
+	if _, err := s.getArticle(ctx, move.ArticleID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.Get(ctx, move.SpaceID); err != nil {
+		return nil, err
+	}
+
+	err := s.access.Authorize(ctx, actor.UserID, actor.Role, models.PermissionWriteArticles, &move.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pageTreeRepo.MovePage(ctx, move)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCycle):
+			return nil, ErrInvalidMove
+		case errors.Is(err, repo.ErrNotFound):
+			// Стаття і простір щойно перевірені, тож бракує батька
+			return nil, ErrParentNotFound
+		default:
+			return nil, fmt.Errorf("SpaceService - MovePage - MovePage: %w", err)
+		}
+	}
+
+	return s.getArticle(ctx, move.ArticleID)
+}
+
+// Breadcrumbs повертає шлях від простору до статті
+func (s *SpaceService) Breadcrumbs(ctx context.Context, articleID uint) (*Breadcrumbs, error) {
+	article, err := s.getArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	self := &models.PageNode{
+		ID:       article.ID,
+		Title:    article.Title,
+		Slug:     article.Slug,
+		Status:   article.Status,
+		ParentID: article.ParentID,
+		Position: article.Position,
+		Children: make([]*models.PageNode, 0),
+	}
+
+	if article.SpaceID == nil {
+		return &Breadcrumbs{Pages: []*models.PageNode{self}}, nil
+	}
+
+	space, err := s.Get(ctx, *article.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.pageTreeRepo.GetPageAncestors(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("SpaceService - Breadcrumbs - GetPageAncestors: %w", err)
+	}
+
+	return &Breadcrumbs{
+		Space: space,
+		Pages: append(ancestors, self),
+	}, nil
+}
+
+func (s *SpaceService) getArticle(ctx context.Context, id uint) (*models.Article, error) {
+	article, err := s.articleRepo.GetArticleByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("SpaceService - getArticle - GetArticleByID: %w", err)
+	}
+
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	return article, nil
+}
+
+// buildTree збирає плаский список сторінок у дерево, зберігаючи порядок сусідів.
+// Сторінки, чий батько відсутній у списку, стають кореневими.
+func buildTree(pages []*models.PageNode) []*models.PageNode {
+	byID := make(map[uint]*models.PageNode, len(pages))
+	for _, page := range pages {
+		if page.Children == nil {
+			page.Children = make([]*models.PageNode, 0)
+		}
+
+		byID[page.ID] = page
+	}
+
+	roots := make([]*models.PageNode, 0)
+
+	for _, page := range pages {
+		if page.ParentID != nil {
+			if parent, ok := byID[*page.ParentID]; ok {
+				parent.Children = append(parent.Children, page)
+				continue
+			}
+		}
+
+		roots = append(roots, page)
+	}
+
+	return roots
+}
+
+// spaceKey нормалізує ключ простору так само, як slug статті, але коротший
+func spaceKey(input SpaceInput) (string, error) {
+	source := input.Key
+	if source == "" {
+		source = input.Name
+	}
+
+	key := Slugify(source)
+	if len(key) > _maxSpaceKeyLength {
+		key = strings.TrimRight(strings.ToValidUTF8(key[:_maxSpaceKeyLength], ""), "-")
+	}
+
+	if key == "" {
+		return "", ErrInvalidSpaceKey
+	}
+
+	return key, nil
+}
@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func newTestSpaceServices() (*SpaceService, *ArticleService) {
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "owner", Email: "owner@example.com"})
+	mockRepo.AddUser(&models.User{ID: 2, Username: "member", Email: "member@example.com"})
+
+	access := NewAccessService(mockRepo.SpaceMember(), mockRepo.Article())
+
+	return NewSpaceService(mockRepo.Space(), mockRepo.Article(), mockRepo.PageTree(), mockRepo.SpaceMember(), access),
+		NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(), access)
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestSpaceService_CRUD(t *testing.T) {
+	spaces, articles := newTestSpaceServices()
+	ctx := context.Background()
+
+	space, err := spaces.Create(ctx, 1, SpaceInput{Name: "Engineering Handbook"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if space.Key != "engineering-handbook" || space.CreatedBy != 1 {
+		t.Errorf("Unexpected space: %+v", space)
+	}
+
+	_, err = spaces.Create(ctx, 1, SpaceInput{Key: "Engineering Handbook", Name: "Other"})
+	if !errors.Is(err, ErrSpaceKeyExists) {
+		t.Errorf("Expected ErrSpaceKeyExists, got %v", err)
+	}
+
+	_, err = spaces.Create(ctx, 1, SpaceInput{Name: "!!!"})
+	if !errors.Is(err, ErrInvalidSpaceKey) {
+		t.Errorf("Expected ErrInvalidSpaceKey, got %v", err)
+	}
+
+	updated, err := spaces.Update(ctx, space.ID, SpaceInput{Name: "Engineering", Description: "Docs"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if updated.Key != "engineering-handbook" || updated.Name != "Engineering" {
+		t.Errorf("Expected key to stay and name to change, got %+v", updated)
+	}
+
+	if _, err = articles.Create(ctx, asEditor(1), ArticleInput{Title: "Intro", SpaceID: &space.ID}); err != nil {
+		t.Fatalf("Create article error = %v", err)
+	}
+
+	if err = spaces.Delete(ctx, space.ID); !errors.Is(err, ErrSpaceNotEmpty) {
+		t.Errorf("Expected ErrSpaceNotEmpty, got %v", err)
+	}
+
+	if err = spaces.Delete(ctx, 99); !errors.Is(err, ErrSpaceNotFound) {
+		t.Errorf("Expected ErrSpaceNotFound, got %v", err)
+	}
+}
+
+func TestArticleService_CreateInSpace(t *testing.T) {
+	spaces, articles := newTestSpaceServices()
+	ctx := context.Background()
+
+	space, err := spaces.Create(ctx, 1, SpaceInput{Name: "Docs"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	root, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Root", SpaceID: &space.ID})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Простір береться з батька
+	child, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Child", ParentID: &root.ID})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if child.SpaceID == nil || *child.SpaceID != space.ID || child.Position != 0 {
+		t.Errorf("Unexpected child placement: %+v", child)
+	}
+
+	second, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Second", ParentID: &root.ID})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if second.Position != 1 {
+		t.Errorf("Expected new page to be appended, got position %d", second.Position)
+	}
+
+	tests := []struct {
+		name  string
+		input ArticleInput
+		want  error
+	}{
+		{"unknown space", ArticleInput{Title: "X", SpaceID: uintPtr(99)}, ErrSpaceNotFound},
+		{"unknown parent", ArticleInput{Title: "X", ParentID: uintPtr(99)}, ErrParentNotFound},
+		{"parent in other space", ArticleInput{Title: "X", SpaceID: uintPtr(99), ParentID: &root.ID}, ErrParentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := articles.Create(ctx, asEditor(1), tt.input); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if err = articles.Delete(ctx, root.ID); !errors.Is(err, ErrArticleHasChildren) {
+		t.Errorf("Expected ErrArticleHasChildren, got %v", err)
+	}
+}
+
+func TestSpaceService_TreeAndMove(t *testing.T) {
+	spaces, articles := newTestSpaceServices()
+	ctx := context.Background()
+
+	space, _ := spaces.Create(ctx, 1, SpaceInput{Name: "Docs"})
+	other, _ := spaces.Create(ctx, 1, SpaceInput{Name: "Archive"})
+	// Автор просторів - їх адміністратор, тож глобальної ролі переглядача достатньо
+	owner := Actor{UserID: 1, Role: models.RoleViewer}
+
+	create := func(title string, parentID *uint) *models.Article {
+		article, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: title, SpaceID: &space.ID, ParentID: parentID})
+		if err != nil {
+			t.Fatalf("Create(%q) error = %v", title, err)
+		}
+		return article
+	}
+
+	a := create("A", nil)
+	b := create("B", nil)
+	a1 := create("A1", &a.ID)
+	a2 := create("A2", &a.ID)
+
+	tree, err := spaces.Tree(ctx, space.ID)
+	if err != nil {
+		t.Fatalf("Tree() error = %v", err)
+	}
+
+	if titles(tree.Pages) != "A,B" || titles(tree.Pages[0].Children) != "A1,A2" {
+		t.Fatalf("Unexpected tree: %s / %s", titles(tree.Pages), titles(tree.Pages[0].Children))
+	}
+
+	// Переставляємо A2 перед A1
+	if _, err = spaces.MovePage(ctx, owner, models.PageMove{ArticleID: a2.ID, SpaceID: space.ID, ParentID: &a.ID}); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+
+	tree, _ = spaces.Tree(ctx, space.ID)
+	if titles(tree.Pages[0].Children) != "A2,A1" {
+		t.Errorf("Expected reordered children A2,A1, got %s", titles(tree.Pages[0].Children))
+	}
+
+	// A під B: піддерево переїжджає разом з A
+	moved, err := spaces.MovePage(ctx, owner, models.PageMove{ArticleID: a.ID, SpaceID: space.ID, ParentID: &b.ID, Position: 5})
+	if err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+
+	if moved.ParentID == nil || *moved.ParentID != b.ID || moved.Position != 0 {
+		t.Errorf("Unexpected moved article: %+v", moved)
+	}
+
+	breadcrumbs, err := spaces.Breadcrumbs(ctx, a1.ID)
+	if err != nil {
+		t.Fatalf("Breadcrumbs() error = %v", err)
+	}
+
+	if breadcrumbs.Space == nil || breadcrumbs.Space.ID != space.ID || titles(breadcrumbs.Pages) != "B,A,A1" {
+		t.Errorf("Unexpected breadcrumbs: %+v %s", breadcrumbs.Space, titles(breadcrumbs.Pages))
+	}
+
+	tests := []struct {
+		name string
+		move models.PageMove
+		want error
+	}{
+		{"under itself", models.PageMove{ArticleID: b.ID, SpaceID: space.ID, ParentID: &b.ID}, ErrInvalidMove},
+		{"under descendant", models.PageMove{ArticleID: b.ID, SpaceID: space.ID, ParentID: &a1.ID}, ErrInvalidMove},
+		{"parent in other space", models.PageMove{ArticleID: a1.ID, SpaceID: other.ID, ParentID: &b.ID}, ErrParentNotFound},
+		{"unknown space", models.PageMove{ArticleID: a1.ID, SpaceID: 99}, ErrSpaceNotFound},
+		{"unknown article", models.PageMove{ArticleID: 99, SpaceID: space.ID}, ErrArticleNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := spaces.MovePage(ctx, owner, tt.move); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Перенесення в інший простір забирає все піддерево
+	if _, err = spaces.MovePage(ctx, owner, models.PageMove{ArticleID: b.ID, SpaceID: other.ID}); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+
+	child, _ := articles.Get(ctx, a1.ID)
+	if child.SpaceID == nil || *child.SpaceID != other.ID {
+		t.Errorf("Expected descendant to move to space %d, got %v", other.ID, child.SpaceID)
+	}
+
+	tree, _ = spaces.Tree(ctx, space.ID)
+	if len(tree.Pages) != 0 {
+		t.Errorf("Expected source space to be empty, got %s", titles(tree.Pages))
+	}
+}
+
+func TestSpaceService_MovePage_TargetSpacePermission(t *testing.T) {
+	spaces, articles := newTestSpaceServices()
+	ctx := context.Background()
+
+	docs, _ := spaces.Create(ctx, 1, SpaceInput{Name: "Docs"})
+	private, _ := spaces.Create(ctx, 1, SpaceInput{Name: "Private"})
+
+	article, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Page", SpaceID: &docs.ID})
+	if err != nil {
+		t.Fatalf("Create article error = %v", err)
+	}
+
+	if _, err = spaces.SetMember(ctx, docs.ID, 2, models.RoleEditor); err != nil {
+		t.Fatalf("SetMember() error = %v", err)
+	}
+
+	member := Actor{UserID: 2, Role: models.RoleViewer}
+	move := models.PageMove{ArticleID: article.ID, SpaceID: private.ID}
+
+	// Редактор лише вихідного простору не може перенести сторінку в чужий простір
+	if _, err = spaces.MovePage(ctx, member, move); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("Expected ErrForbidden without access to the target space, got %v", err)
+	}
+
+	if _, err = spaces.SetMember(ctx, private.ID, 2, models.RoleViewer); err != nil {
+		t.Fatalf("SetMember() error = %v", err)
+	}
+
+	if _, err = spaces.MovePage(ctx, member, move); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("Expected ErrForbidden with read-only access to the target space, got %v", err)
+	}
+
+	moved, _ := articles.Get(ctx, article.ID)
+	if moved.SpaceID == nil || *moved.SpaceID != docs.ID {
+		t.Fatalf("Expected article to stay in space %d, got %v", docs.ID, moved.SpaceID)
+	}
+
+	if _, err = spaces.SetMember(ctx, private.ID, 2, models.RoleEditor); err != nil {
+		t.Fatalf("SetMember() error = %v", err)
+	}
+
+	if _, err = spaces.MovePage(ctx, member, move); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+}
+
+func TestArticleService_Create_TargetSpacePermission(t *testing.T) {
+	spaces, articles := newTestSpaceServices()
+	ctx := context.Background()
+
+	docs, err := spaces.Create(ctx, 1, SpaceInput{Name: "Docs"})
+	if err != nil {
+		t.Fatalf("Create space error = %v", err)
+	}
+
+	root, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Root", SpaceID: &docs.ID})
+	if err != nil {
+		t.Fatalf("Create article error = %v", err)
+	}
+
+	viewer := Actor{UserID: 2, Role: models.RoleViewer}
+
+	// Глобальний viewer без членства не створює сторінок ні поза простором, ні в ньому
+	inputs := []ArticleInput{
+		{Title: "Loose"},
+		{Title: "Page", SpaceID: &docs.ID},
+		{Title: "Child", ParentID: &root.ID},
+	}
+
+	for _, input := range inputs {
+		if _, err = articles.Create(ctx, viewer, input); !errors.Is(err, ErrForbidden) {
+			t.Fatalf("Expected ErrForbidden for %q, got %v", input.Title, err)
+		}
+	}
+
+	// Редактор простору створює сторінки в ньому, зокрема через батьківську сторінку
+	if _, err = spaces.SetMember(ctx, docs.ID, 2, models.RoleEditor); err != nil {
+		t.Fatalf("SetMember() error = %v", err)
+	}
+
+	for _, input := range inputs[1:] {
+		article, err := articles.Create(ctx, viewer, input)
+		if err != nil {
+			t.Fatalf("Create(%q) error = %v", input.Title, err)
+		}
+
+		if article.AuthorID != 2 || article.SpaceID == nil || *article.SpaceID != docs.ID {
+			t.Errorf("Expected %q by user 2 in space %d, got %+v", input.Title, docs.ID, article)
+		}
+	}
+
+	// Членство в одному просторі не дає права писати поза ним
+	if _, err = articles.Create(ctx, viewer, inputs[0]); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden outside the space, got %v", err)
+	}
+}
+
+func TestSpaceService_Members(t *testing.T) {
+	spaces, _ := newTestSpaceServices()
+	ctx := context.Background()
+
+	space, err := spaces.Create(ctx, 1, SpaceInput{Name: "Docs"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	members, err := spaces.ListMembers(ctx, space.ID, pagination.Query{})
+	if err != nil || len(members.Items) != 1 || members.Items[0].UserID != 1 || members.Items[0].Role != models.RoleAdmin {
+		t.Fatalf("Expected creator to be space admin, got %+v, %v", members, err)
+	}
+
+	member, err := spaces.SetMember(ctx, space.ID, 2, models.RoleEditor)
+	if err != nil || member.Role != models.RoleEditor {
+		t.Fatalf("SetMember() = %+v, %v", member, err)
+	}
+
+	if _, err = spaces.SetMember(ctx, space.ID, 2, models.RoleViewer); err != nil {
+		t.Fatalf("SetMember() error = %v", err)
+	}
+
+	members, _ = spaces.ListMembers(ctx, space.ID, pagination.Query{})
+	if len(members.Items) != 2 || members.Items[1].Role != models.RoleViewer {
+		t.Errorf("Expected role to be replaced, got %+v", members)
+	}
+
+	tests := []struct {
+		name    string
+		spaceID uint
+		userID  uint
+		role    models.Role
+		want    error
+	}{
+		{"invalid role", space.ID, 2, "owner", ErrInvalidRole},
+		{"unknown space", 99, 2, models.RoleEditor, ErrSpaceNotFound},
+		{"unknown user", space.ID, 99, models.RoleEditor, ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := spaces.SetMember(ctx, tt.spaceID, tt.userID, tt.role); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if err = spaces.RemoveMember(ctx, space.ID, 2); err != nil {
+		t.Fatalf("RemoveMember() error = %v", err)
+	}
+
+	if err = spaces.RemoveMember(ctx, space.ID, 2); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound, got %v", err)
+	}
+}
+
+func titles(pages []*models.PageNode) string {
+	result := ""
+	for i, page := range pages {
+		if i > 0 {
+			result += ","
+		}
+		result += page.Title
+	}
+	return result
+}
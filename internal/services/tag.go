@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/pagination"
+)
+
+// MaxArticleTags - максимальна кількість тегів однієї статті
+const MaxArticleTags = 20
+
+var (
+	ErrTagNotFound     = apperror.NotFound("tag_not_found", "tag not found")
+	ErrInvalidTag      = apperror.Validation("invalid_tag", "invalid tag name")
+	ErrTooManyTags     = apperror.Validation("too_many_tags", "too many tags on article")
+	ErrTagExists       = apperror.Conflict("tag_exists", "tag with this name already exists, merge tags instead")
+	ErrNothingToMerge  = apperror.Validation("nothing_to_merge", "no source tags to merge")
+	ErrEmptyTagRequest = apperror.Validation("empty_tag_request", "no tags given")
+)
+
+// TagService керує тегами статей і навігацією за ними
+type TagService struct {
+	tagRepo     repo.TagRepository
+	articleRepo repo.ArticleRepository
+}
+
+func NewTagService(tagRepo repo.TagRepository, articleRepo repo.ArticleRepository) *TagService {
+	return &TagService{
+		tagRepo:     tagRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// ArticleTags повертає сторінку тегів статті, за замовчуванням за алфавітом
+func (s *TagService) ArticleTags(
+	ctx context.Context,
+	articleID uint,
+	query pagination.Query,
+) (*pagination.Page[*models.Tag], error) {
+	if err := s.ensureArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	params, err := repo.ArticleTagPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.tagRepo.ListArticleTags(ctx, articleID, params)
+	if err != nil {
+		return nil, fmt.Errorf("TagService - ArticleTags - ListArticleTags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// AddArticleTags нормалізує назви і прив'язує теги до статті, створюючи нові.
+// Повертає всі теги статті після зміни: їх не більше MaxArticleTags.
+func (s *TagService) AddArticleTags(ctx context.Context, articleID uint, names []string) ([]*models.Tag, error) {
+	normalized, err := normalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.ensureArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.tagRepo.ListArticleTags(ctx, articleID, pagination.Params{})
+	if err != nil {
+		return nil, fmt.Errorf("TagService - AddArticleTags - ListArticleTags: %w", err)
+	}
+
+	count := len(current.Items)
+
+	for _, name := range normalized {
+		if !hasTagName(current.Items, name) {
+			count++
+		}
+	}
+
+	if count > MaxArticleTags {
+		return nil, ErrTooManyTags
+	}
+
+	if err = s.tagRepo.AddArticleTags(ctx, articleID, normalized); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrArticleNotFound
+		}
+
+		return nil, fmt.Errorf("TagService - AddArticleTags - AddArticleTags: %w", err)
+	}
+
+	tags, err := s.tagRepo.ListArticleTags(ctx, articleID, pagination.Params{})
+	if err != nil {
+		return nil, fmt.Errorf("TagService - AddArticleTags - ListArticleTags: %w", err)
+	}
+
+	return tags.Items, nil
+}
+
+func (s *TagService) RemoveArticleTag(ctx context.Context, articleID uint, name string) error {
+	normalized, err := NormalizeTag(name)
+	if err != nil {
+		return ErrTagNotFound
+	}
+
+	if err = s.ensureArticle(ctx, articleID); err != nil {
+		return err
+	}
+
+	if err = s.tagRepo.RemoveArticleTag(ctx, articleID, normalized); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrTagNotFound
+		}
+
+		return fmt.Errorf("TagService - RemoveArticleTag - RemoveArticleTag: %w", err)
+	}
+
+	return nil
+}
+
+// Popular повертає сторінку тегів, за замовчуванням від тих, що мають найбільше статей
+func (s *TagService) Popular(ctx context.Context, query pagination.Query) (*pagination.Page[*models.Tag], error) {
+	params, err := repo.TagPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, params)
+}
+
+// Autocomplete повертає сторінку популярних тегів, назва яких починається з prefix
+func (s *TagService) Autocomplete(
+	ctx context.Context,
+	prefix string,
+	query pagination.Query,
+) (*pagination.Page[*models.Tag], error) {
+	params, err := repo.TagPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Префікс нормалізується так само, як назва, але незавершене слово не є помилкою
+	prefix = strings.Join(strings.Fields(strings.ToLower(prefix)), "-")
+	if prefix == "" || utf8.RuneCountInString(prefix) > models.TagNameMaxLength {
+		return &pagination.Page[*models.Tag]{Items: make([]*models.Tag, 0)}, nil
+	}
+
+	params.Filters = append(params.Filters, pagination.Filter{
+		Field:  "name",
+		Op:     pagination.Prefix,
+		Values: []any{prefix},
+	})
+
+	return s.list(ctx, params)
+}
+
+// Get повертає тег за назвою разом з кількістю статей
+func (s *TagService) Get(ctx context.Context, name string) (*models.Tag, error) {
+	normalized, err := NormalizeTag(name)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+
+	tag, err := s.tagRepo.GetTagByName(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("TagService - Get - GetTagByName: %w", err)
+	}
+
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+
+	return tag, nil
+}
+
+// Rename перейменовує тег; зв'язки зі статтями зберігаються.
+// Якщо нова назва вже зайнята, повертає ErrTagExists - такі теги слід об'єднати через Merge.
+func (s *TagService) Rename(ctx context.Context, name, newName string) (*models.Tag, error) {
+	normalized, err := NormalizeTag(name)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+
+	target, err := NormalizeTag(newName)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.tagRepo.RenameTag(ctx, normalized, target)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			return nil, ErrTagNotFound
+		case errors.Is(err, repo.ErrAlreadyExists):
+			return nil, ErrTagExists
+		default:
+			return nil, fmt.Errorf("TagService - Rename - RenameTag: %w", err)
+		}
+	}
+
+	return tag, nil
+}
+
+// Merge переносить статті з тегів sources на target і видаляє sources.
+// Target створюється, якщо його ще немає; збіг target з одним із sources ігнорується.
+func (s *TagService) Merge(ctx context.Context, sources []string, target string) (*models.Tag, error) {
+	normalizedTarget, err := NormalizeTag(target)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := normalizeTags(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := normalized[:0]
+
+	for _, name := range normalized {
+		if name != normalizedTarget {
+			filtered = append(filtered, name)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, ErrNothingToMerge
+	}
+
+	tag, err := s.tagRepo.MergeTags(ctx, filtered, normalizedTarget)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrTagNotFound
+		}
+
+		return nil, fmt.Errorf("TagService - Merge - MergeTags: %w", err)
+	}
+
+	return tag, nil
+}
+
+func (s *TagService) list(ctx context.Context, params pagination.Params) (*pagination.Page[*models.Tag], error) {
+	tags, err := s.tagRepo.ListTags(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("TagService - list - ListTags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (s *TagService) ensureArticle(ctx context.Context, articleID uint) error {
+	article, err := s.articleRepo.GetArticleByID(ctx, articleID)
+	if err != nil {
+		return fmt.Errorf("TagService - ensureArticle - GetArticleByID: %w", err)
+	}
+
+	if article == nil {
+		return ErrArticleNotFound
+	}
+
+	return nil
+}
+
+// NormalizeTag приводить назву тегу до канонічного вигляду: нижній регістр,
+// пробіли замінені на '-'. Дозволені літери, цифри та символи "-_.+#",
+// тому "C++" та "C#" лишаються різними тегами.
+func NormalizeTag(name string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(name)), "-")
+
+	if normalized == "" || utf8.RuneCountInString(normalized) > models.TagNameMaxLength {
+		return "", ErrInvalidTag
+	}
+
+	for _, r := range normalized {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_.+#", r) {
+			return "", ErrInvalidTag
+		}
+	}
+
+	return normalized, nil
+}
+
+// normalizeTags нормалізує назви і прибирає дублікати, зберігаючи порядок
+func normalizeTags(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, ErrEmptyTagRequest
+	}
+
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized, nil
+}
+
+func hasTagName(tags []*models.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
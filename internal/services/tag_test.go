@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"KnowledgeHub/internal/models"
+	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/pagination"
+)
+
+func newTestTagServices() (*TagService, *ArticleService) {
+	mockRepo := mocks.NewRepository()
+
+	return NewTagService(mockRepo.Tag(), mockRepo.Article()),
+		NewArticleService(mockRepo.Article(), mockRepo.ArticleRevision(), mockRepo.Space(),
+			NewAccessService(mockRepo.SpaceMember(), mockRepo.Article()))
+}
+
+func tagNames(tags []*models.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"Kubernetes", "kubernetes", false},
+		{"  How   To ", "how-to", false},
+		{"C++", "c++", false},
+		{"C#", "c#", false},
+		{"Розгортання", "розгортання", false},
+		{"", "", true},
+		{"a/b", "", true},
+		{"50%", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeTag(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeTag(%q) = %q, %v, want %q (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestTagService_ArticleTags(t *testing.T) {
+	tags, articles := newTestTagServices()
+	ctx := context.Background()
+
+	article, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Deploy"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := tags.AddArticleTags(ctx, article.ID, []string{"Kubernetes", "how to", "kubernetes"})
+	if err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	if names := fmt.Sprint(tagNames(got)); names != "[how-to kubernetes]" {
+		t.Errorf("Expected normalized unique tags, got %s", names)
+	}
+
+	if _, err = tags.AddArticleTags(ctx, 99, []string{"go"}); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+
+	if _, err = tags.AddArticleTags(ctx, article.ID, []string{"a/b"}); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("Expected ErrInvalidTag, got %v", err)
+	}
+
+	tooMany := make([]string, 0, MaxArticleTags)
+	for i := range MaxArticleTags {
+		tooMany = append(tooMany, fmt.Sprintf("tag-%d", i))
+	}
+
+	if _, err = tags.AddArticleTags(ctx, article.ID, tooMany); !errors.Is(err, ErrTooManyTags) {
+		t.Errorf("Expected ErrTooManyTags, got %v", err)
+	}
+
+	if err = tags.RemoveArticleTag(ctx, article.ID, "How To"); err != nil {
+		t.Fatalf("RemoveArticleTag() error = %v", err)
+	}
+
+	if err = tags.RemoveArticleTag(ctx, article.ID, "how-to"); !errors.Is(err, ErrTagNotFound) {
+		t.Errorf("Expected ErrTagNotFound, got %v", err)
+	}
+
+	list, err := articles.List(ctx, models.ArticleFilter{Tag: "Kubernetes"}, pagination.Query{})
+	if err != nil || len(list.Items) != 1 || list.Items[0].ID != article.ID {
+		t.Errorf("List() by tag = %v, %v", list, err)
+	}
+
+	list, err = articles.List(ctx, models.ArticleFilter{Tag: "how-to"}, pagination.Query{})
+	if err != nil || len(list.Items) != 0 {
+		t.Errorf("Expected no articles for removed tag, got %v, %v", list, err)
+	}
+}
+
+func TestTagService_PopularAndAutocomplete(t *testing.T) {
+	tags, articles := newTestTagServices()
+	ctx := context.Background()
+
+	articleTags := [][]string{
+		{"kubernetes", "kafka"},
+		{"kubernetes", "go"},
+		{"kubernetes", "kafka", "go"},
+	}
+
+	for i, names := range articleTags {
+		article, err := articles.Create(ctx, asEditor(1), ArticleInput{Title: fmt.Sprintf("Article %d", i)})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		if _, err = tags.AddArticleTags(ctx, article.ID, names); err != nil {
+			t.Fatalf("AddArticleTags() error = %v", err)
+		}
+	}
+
+	popular, err := tags.Popular(ctx, pagination.Query{})
+	if err != nil {
+		t.Fatalf("Popular() error = %v", err)
+	}
+
+	if names := fmt.Sprint(tagNames(popular.Items)); names != "[kubernetes go kafka]" {
+		t.Errorf("Expected tags by count then name, got %s", names)
+	}
+
+	if popular.Items[0].ArticleCount != 3 {
+		t.Errorf("Expected kubernetes count 3, got %d", popular.Items[0].ArticleCount)
+	}
+
+	page, err := tags.Popular(ctx, pagination.Query{Limit: 2})
+	if err != nil || page.NextCursor == "" {
+		t.Fatalf("Popular(limit) = %+v, %v", page, err)
+	}
+
+	page, err = tags.Popular(ctx, pagination.Query{Limit: 2, Cursor: page.NextCursor})
+	if names := fmt.Sprint(tagNames(page.Items)); err != nil || names != "[kafka]" || page.NextCursor != "" {
+		t.Errorf("Popular(cursor) = %s, %q, %v", names, page.NextCursor, err)
+	}
+
+	suggested, err := tags.Autocomplete(ctx, " K", pagination.Query{Limit: 1})
+	if err != nil {
+		t.Fatalf("Autocomplete() error = %v", err)
+	}
+
+	if names := fmt.Sprint(tagNames(suggested.Items)); names != "[kubernetes]" || suggested.NextCursor == "" {
+		t.Errorf("Expected most popular tag with prefix and a next page, got %s", names)
+	}
+
+	suggested, err = tags.Autocomplete(ctx, " K", pagination.Query{Limit: 1, Cursor: suggested.NextCursor})
+	if names := fmt.Sprint(tagNames(suggested.Items)); err != nil || names != "[kafka]" {
+		t.Errorf("Autocomplete(cursor) = %s, %v", names, err)
+	}
+
+	suggested, err = tags.Autocomplete(ctx, "  ", pagination.Query{})
+	if err != nil || len(suggested.Items) != 0 {
+		t.Errorf("Expected empty list for empty prefix, got %v, %v", suggested, err)
+	}
+}
+
+func TestTagService_RenameAndMerge(t *testing.T) {
+	tags, articles := newTestTagServices()
+	ctx := context.Background()
+
+	first, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "First"})
+	second, _ := articles.Create(ctx, asEditor(1), ArticleInput{Title: "Second"})
+
+	if _, err := tags.AddArticleTags(ctx, first.ID, []string{"k8s", "kubernetes"}); err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	if _, err := tags.AddArticleTags(ctx, second.ID, []string{"kube"}); err != nil {
+		t.Fatalf("AddArticleTags() error = %v", err)
+	}
+
+	if _, err := tags.Rename(ctx, "kube", "Kubernetes"); !errors.Is(err, ErrTagExists) {
+		t.Errorf("Expected ErrTagExists, got %v", err)
+	}
+
+	if _, err := tags.Rename(ctx, "missing", "other"); !errors.Is(err, ErrTagNotFound) {
+		t.Errorf("Expected ErrTagNotFound, got %v", err)
+	}
+
+	renamed, err := tags.Rename(ctx, "kube", "K8S Cluster")
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+
+	if renamed.Name != "k8s-cluster" || renamed.ArticleCount != 1 {
+		t.Errorf("Unexpected renamed tag: %+v", renamed)
+	}
+
+	if _, err = tags.Merge(ctx, []string{"k8s", "missing"}, "kubernetes"); !errors.Is(err, ErrTagNotFound) {
+		t.Errorf("Expected ErrTagNotFound, got %v", err)
+	}
+
+	if _, err = tags.Merge(ctx, []string{"kubernetes"}, "Kubernetes"); !errors.Is(err, ErrNothingToMerge) {
+		t.Errorf("Expected ErrNothingToMerge, got %v", err)
+	}
+
+	merged, err := tags.Merge(ctx, []string{"k8s", "k8s-cluster", "kubernetes"}, "kubernetes")
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if merged.ArticleCount != 2 {
+		t.Errorf("Expected merged tag on 2 articles, got %d", merged.ArticleCount)
+	}
+
+	got, err := tags.ArticleTags(ctx, first.ID, pagination.Query{})
+	if err != nil {
+		t.Fatalf("ArticleTags() error = %v", err)
+	}
+
+	if names := fmt.Sprint(tagNames(got.Items)); names != "[kubernetes]" {
+		t.Errorf("Expected sources replaced by target, got %s", names)
+	}
+
+	if _, err = tags.Get(ctx, "k8s"); !errors.Is(err, ErrTagNotFound) {
+		t.Errorf("Expected merged source to be deleted, got %v", err)
+	}
+}
@@ -1,23 +1,271 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/repo"
+	"KnowledgeHub/pkg/apperror"
+	"KnowledgeHub/pkg/pagination"
+)
+
+var (
+	ErrUserAlreadyExists  = apperror.Conflict("user_exists", "user with this username or email already exists")
+	ErrInvalidCredentials = apperror.Unauthorized("invalid_credentials", "invalid username or password")
+	ErrUserNotFound       = apperror.NotFound("user_not_found", "user not found")
+	ErrInvalidRole        = apperror.Validation("invalid_role", "invalid role")
+	ErrAccountLocked      = apperror.New(apperror.KindTooManyRequests, "account_locked",
+		"account is temporarily locked")
 )
 
+// LockoutPolicy - правила блокування облікового запису після невдалих входів.
+// Кожні MaxFailures помилок поспіль блокують вхід на Duration, і кожне наступне
+// блокування вдвічі довше за попереднє, але не довше за MaxDuration.
+type LockoutPolicy struct {
+	MaxFailures int
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+// lockDuration повертає тривалість блокування після failures помилок або 0
+func (p LockoutPolicy) lockDuration(failures int) time.Duration {
+	if p.MaxFailures <= 0 || failures < p.MaxFailures || failures%p.MaxFailures != 0 {
+		return 0
+	}
+
+	d := p.Duration
+	for i := failures / p.MaxFailures; i > 1; i-- {
+		d *= 2
+		if p.MaxDuration > 0 && d >= p.MaxDuration {
+			return p.MaxDuration
+		}
+	}
+
+	if p.MaxDuration > 0 && d > p.MaxDuration {
+		return p.MaxDuration
+	}
+
+	return d
+}
+
 type UserService struct {
-	userRepo repo.UserRepository
+	userRepo    repo.UserRepository
+	lockoutRepo repo.LoginLockoutRepository
+	lockout     LockoutPolicy
+}
+
+// UserOption - опціональні залежності UserService
+type UserOption func(*UserService)
+
+// WithLockout вмикає блокування облікових записів після невдалих спроб входу
+func WithLockout(lockoutRepo repo.LoginLockoutRepository, policy LockoutPolicy) UserOption {
+	return func(uc *UserService) {
+		if policy.MaxFailures > 0 && policy.Duration > 0 {
+			uc.lockoutRepo = lockoutRepo
+			uc.lockout = policy
+		}
+	}
 }
 
-func NewUserService(userRepo repo.UserRepository) *UserService {
-	return &UserService{
+func NewUserService(userRepo repo.UserRepository, opts ...UserOption) *UserService {
+	uc := &UserService{
 		userRepo: userRepo,
 	}
+
+	for _, opt := range opts {
+		opt(uc)
+	}
+
+	return uc
+}
+
+// GetUser повертає активного користувача або ErrUserNotFound
+func (uc *UserService) GetUser(ctx context.Context, id uint) (*models.User, error) {
+	user, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("UserService - GetUser - GetUserByID: %w", err)
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// List повертає сторінку активних користувачів
+func (uc *UserService) List(ctx context.Context, query pagination.Query) (*pagination.Page[*models.User], error) {
+	params, err := repo.UserPages.Params(query)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := uc.userRepo.ListUsers(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("UserService - List - ListUsers: %w", err)
+	}
+
+	return users, nil
+}
+
+// Register створює нового користувача з хешованим паролем
+func (uc *UserService) Register(ctx context.Context, username, email, password string) (*models.User, error) {
+	existing, err := uc.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("UserService - Register - GetUserByUsername: %w", err)
+	}
+
+	if existing == nil {
+		existing, err = uc.userRepo.GetUserByEmail(ctx, email)
+		if err != nil {
+			return nil, fmt.Errorf("UserService - Register - GetUserByEmail: %w", err)
+		}
+	}
+
+	if existing != nil {
+		return nil, ErrUserAlreadyExists
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("UserService - Register - HashPassword: %w", err)
+	}
+
+	user := &models.User{
+		Username:     username,
+		Email:        email,
+		Role:         models.RoleDefault,
+		PasswordHash: hash,
+	}
+
+	// Унікальність гарантує база; перевірка вище лише дає швидку відповідь
+	err = uc.userRepo.CreateUser(ctx, user)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, ErrUserAlreadyExists
+		}
+
+		return nil, fmt.Errorf("UserService - Register - CreateUser: %w", err)
+	}
+
+	return user, nil
+}
+
+// Authenticate перевіряє облікові дані та повертає користувача.
+// Невідомий username і хибний пароль дають однакову помилку ErrInvalidCredentials.
+// Якщо блокування увімкнене, невдалі спроби рахуються за нормалізованим username
+// навіть для неіснуючих користувачів, тож ErrAccountLocked не розкриває, чи існує
+// обліковий запис; заблокований username отримує її незалежно від пароля.
+func (uc *UserService) Authenticate(ctx context.Context, username, password string) (*models.User, error) {
+	key := lockoutKey(username)
+
+	var (
+		lockout *models.LoginLockout
+		err     error
+	)
+
+	if uc.lockoutRepo != nil {
+		lockout, err = uc.lockoutRepo.GetLoginLockout(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("UserService - Authenticate - GetLoginLockout: %w", err)
+		}
+
+		if lockout != nil && lockout.LockedUntil != nil && time.Now().Before(*lockout.LockedUntil) {
+			return nil, accountLocked(*lockout.LockedUntil)
+		}
+	}
+
+	user, err := uc.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("UserService - Authenticate - GetUserByUsername: %w", err)
+	}
+
+	hash := string(_dummyPasswordHash)
+	if user != nil {
+		hash = user.PasswordHash
+	}
+
+	ok, err := CheckPassword(hash, password)
+	if err != nil {
+		return nil, fmt.Errorf("UserService - Authenticate - CheckPassword: %w", err)
+	}
+
+	if user == nil || !ok {
+		return nil, uc.loginFailed(ctx, key)
+	}
+
+	if lockout != nil {
+		if err = uc.lockoutRepo.ResetLoginFailures(ctx, key); err != nil {
+			return nil, fmt.Errorf("UserService - Authenticate - ResetLoginFailures: %w", err)
+		}
+	}
+
+	return user, nil
 }
 
-func (uc *UserService) GetUser(id uint) (*models.User, error) {
-	// Бізнес-логіка
-	return uc.userRepo.GetUserByID(id)
+// lockoutKey нормалізує username так само, як унікальний індекс users (LOWER)
+func lockoutKey(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
 }
 
-// Інші методи
+// loginFailed рахує невдалу спробу входу і блокує username за політикою
+func (uc *UserService) loginFailed(ctx context.Context, key string) error {
+	if uc.lockoutRepo == nil {
+		return ErrInvalidCredentials
+	}
+
+	failures, err := uc.lockoutRepo.RecordLoginFailure(ctx, key)
+	if err != nil {
+		return fmt.Errorf("UserService - Authenticate - RecordLoginFailure: %w", err)
+	}
+
+	d := uc.lockout.lockDuration(failures)
+	if d == 0 {
+		return ErrInvalidCredentials
+	}
+
+	until := time.Now().Add(d)
+	if err = uc.lockoutRepo.LockLogin(ctx, key, until); err != nil {
+		return fmt.Errorf("UserService - Authenticate - LockLogin: %w", err)
+	}
+
+	return accountLocked(until)
+}
+
+func accountLocked(until time.Time) error {
+	return ErrAccountLocked.
+		Withf("locked until %s", until.UTC().Format(time.RFC3339)).
+		WithRetryAfter(time.Until(until))
+}
+
+// SetRole змінює глобальну роль користувача. Роль у вже виданих токенах
+// не змінюється, тому викликач має відкликати їх.
+func (uc *UserService) SetRole(ctx context.Context, id uint, role models.Role) (*models.User, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	err := uc.userRepo.SetUserRole(ctx, id, role)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, fmt.Errorf("UserService - SetRole - SetUserRole: %w", err)
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("UserService - SetRole - GetUserByID: %w", err)
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
@@ -1,10 +1,15 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"KnowledgeHub/internal/models"
 	"KnowledgeHub/internal/repo/mocks"
+	"KnowledgeHub/pkg/apperror"
 )
 
 func TestUserService_GetUser(t *testing.T) {
@@ -40,14 +45,14 @@ func TestUserService_GetUser(t *testing.T) {
 			name:     "Non-existing user",
 			userID:   2,
 			wantUser: nil,
-			wantErr:  false, // або true, залежно від вашої логіки
+			wantErr:  true,
 		},
 	}
 
 	// Виконання тестів
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotUser, err := service.GetUser(tt.userID)
+			gotUser, err := service.GetUser(context.Background(), tt.userID)
 
 			// Перевірка помилки
 			if (err != nil) != tt.wantErr {
@@ -55,6 +60,10 @@ func TestUserService_GetUser(t *testing.T) {
 				return
 			}
 
+			if tt.wantErr && !errors.Is(err, ErrUserNotFound) {
+				t.Errorf("UserService.GetUser() error = %v, want ErrUserNotFound", err)
+			}
+
 			// Перевірка результату
 			if tt.wantUser == nil && gotUser != nil {
 				t.Errorf("UserService.GetUser() = %v, want nil", gotUser)
@@ -70,3 +79,187 @@ func TestUserService_GetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_Register(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	service := NewUserService(mockRepo.User())
+	ctx := context.Background()
+
+	user, err := service.Register(ctx, "newuser", "new@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if user.ID == 0 {
+		t.Error("Expected user ID to be assigned")
+	}
+
+	if user.PasswordHash == "" || user.PasswordHash == "password123" {
+		t.Errorf("Expected password to be hashed, got %q", user.PasswordHash)
+	}
+
+	// Права редактора надає адміністратор
+	if user.Role != models.RoleViewer {
+		t.Errorf("Expected new user to be a viewer, got %q", user.Role)
+	}
+
+	// Дублікати за username та email (без урахування регістру)
+	_, err = service.Register(ctx, "NewUser", "other@example.com", "password123")
+	if !errors.Is(err, ErrUserAlreadyExists) {
+		t.Errorf("Expected ErrUserAlreadyExists for duplicate username, got %v", err)
+	}
+
+	_, err = service.Register(ctx, "other", "NEW@example.com", "password123")
+	if !errors.Is(err, ErrUserAlreadyExists) {
+		t.Errorf("Expected ErrUserAlreadyExists for duplicate email, got %v", err)
+	}
+
+	// 36 кириличних літер - це 72 байти, 37 - вже більше за ліміт bcrypt
+	if _, err = service.Register(ctx, "cyrillic", "cyr@example.com", strings.Repeat("ж", 36)); err != nil {
+		t.Errorf("Expected 72-byte password to be accepted, got %v", err)
+	}
+
+	_, err = service.Register(ctx, "longpass", "long@example.com", strings.Repeat("ж", 37))
+	if !errors.Is(err, ErrPasswordTooLong) || apperror.KindOf(err) != apperror.KindValidation {
+		t.Errorf("Expected validation ErrPasswordTooLong, got %v", err)
+	}
+}
+
+func TestUserService_Authenticate(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	service := NewUserService(mockRepo.User())
+	ctx := context.Background()
+
+	registered, err := service.Register(ctx, "newuser", "new@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantErr  error
+	}{
+		{"Valid credentials", "newuser", "password123", nil},
+		{"Wrong password", "newuser", "wrongpassword", ErrInvalidCredentials},
+		{"Unknown user", "nobody", "password123", ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := service.Authenticate(ctx, tt.username, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr == nil && user.ID != registered.ID {
+				t.Errorf("Expected user ID %d, got %d", registered.ID, user.ID)
+			}
+		})
+	}
+}
+
+func TestUserService_AuthenticateLockout(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	policy := LockoutPolicy{MaxFailures: 2, Duration: time.Minute, MaxDuration: 90 * time.Second}
+	service := NewUserService(mockRepo.User(), WithLockout(mockRepo.LoginLockout(), policy))
+	ctx := context.Background()
+
+	registered, err := service.Register(ctx, "newuser", "new@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	if _, err = service.Authenticate(ctx, "newuser", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("Expected ErrInvalidCredentials on first failure, got %v", err)
+	}
+
+	_, err = service.Authenticate(ctx, "newuser", "wrong")
+	if !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("Expected ErrAccountLocked after %d failures, got %v", policy.MaxFailures, err)
+	}
+
+	// Поки блокування діє, навіть правильний пароль відхиляється
+	_, err = service.Authenticate(ctx, "newuser", "password123")
+	if !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("Expected ErrAccountLocked for correct password while locked, got %v", err)
+	}
+
+	if appErr, ok := apperror.As(err); !ok || appErr.RetryAfter <= 0 || appErr.RetryAfter > time.Minute {
+		t.Errorf("Expected retry after within lock duration, got %v", err)
+	}
+
+	// Блокування минуло: вхід успішний і лічильник скидається
+	if err = mockRepo.LoginLockout().LockLogin(ctx, "newuser", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("LockLogin() error = %v", err)
+	}
+
+	// Регістр username не впливає ні на вхід, ні на лічильник
+	user, err := service.Authenticate(ctx, "NewUser", "password123")
+	if err != nil || user.ID != registered.ID {
+		t.Fatalf("Expected successful login after lock expired, got %v", err)
+	}
+
+	lockout, _ := mockRepo.LoginLockout().GetLoginLockout(ctx, "newuser")
+	if lockout != nil {
+		t.Errorf("Expected failures to be reset after successful login, got %+v", lockout)
+	}
+
+	// Невідомий username блокується так само, щоб account_locked не розкривав існування облікового запису
+	if _, err = service.Authenticate(ctx, "nobody", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("Expected ErrInvalidCredentials on first failure for unknown user, got %v", err)
+	}
+
+	if _, err = service.Authenticate(ctx, "Nobody", "wrong"); !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("Expected ErrAccountLocked for unknown user after %d failures, got %v", policy.MaxFailures, err)
+	}
+}
+
+func TestLockoutPolicy_LockDuration(t *testing.T) {
+	policy := LockoutPolicy{MaxFailures: 3, Duration: time.Minute, MaxDuration: 5 * time.Minute}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, 0},
+		{3, time.Minute},
+		{4, 0},
+		{6, 2 * time.Minute},
+		{9, 4 * time.Minute},
+		{12, 5 * time.Minute},
+		{300, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := policy.lockDuration(tt.failures); got != tt.want {
+			t.Errorf("lockDuration(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestUserService_SetRole(t *testing.T) {
+	mockRepo := mocks.NewRepository()
+	mockRepo.AddUser(&models.User{ID: 1, Username: "testuser", Email: "test@example.com", Role: models.RoleEditor})
+
+	service := NewUserService(mockRepo.User())
+	ctx := context.Background()
+
+	user, err := service.SetRole(ctx, 1, models.RoleAdmin)
+	if err != nil {
+		t.Fatalf("SetRole() error = %v", err)
+	}
+
+	if user.Role != models.RoleAdmin {
+		t.Errorf("Expected role admin, got %q", user.Role)
+	}
+
+	if _, err = service.SetRole(ctx, 1, "owner"); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("Expected ErrInvalidRole, got %v", err)
+	}
+
+	if _, err = service.SetRole(ctx, 99, models.RoleViewer); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS users (
+    id         BIGSERIAL PRIMARY KEY,
+    username   VARCHAR(50)  NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    password   TEXT         NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+-- Унікальність перевіряється лише серед активних користувачів,
+-- щоб після soft delete ім'я та email можна було використати повторно.
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_active_key
+    ON users (LOWER(username)) WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_active_key
+    ON users (LOWER(email)) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+-- Паролі більше не зберігаються у відкритому вигляді, лише bcrypt-хеш.
+ALTER TABLE users RENAME COLUMN password TO password_hash;
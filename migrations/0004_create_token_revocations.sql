@@ -0,0 +1,14 @@
+-- Відкликані access токени за jti; рядок потрібен лише до закінчення строку дії токена.
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti        VARCHAR(255) PRIMARY KEY,
+    expires_at TIMESTAMPTZ  NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
+
+-- "Вийти з усіх сесій": недійсні всі access токени користувача, видані до revoked_before.
+CREATE TABLE IF NOT EXISTS user_token_revocations (
+    user_id        BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    revoked_before TIMESTAMPTZ NOT NULL
+);
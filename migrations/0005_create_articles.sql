@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS articles (
+    id           BIGSERIAL PRIMARY KEY,
+    title        VARCHAR(255) NOT NULL,
+    slug         VARCHAR(255) NOT NULL UNIQUE,
+    body         TEXT         NOT NULL DEFAULT '',
+    author_id    BIGINT       NOT NULL REFERENCES users (id),
+    status       VARCHAR(20)  NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'published', 'archived')),
+    published_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS articles_author_id_idx ON articles (author_id);
+CREATE INDEX IF NOT EXISTS articles_status_idx ON articles (status);
@@ -0,0 +1,26 @@
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS version    INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS updated_by BIGINT REFERENCES users (id);
+
+UPDATE articles SET updated_by = author_id WHERE updated_by IS NULL;
+
+ALTER TABLE articles ALTER COLUMN updated_by SET NOT NULL;
+
+-- Незмінний знімок статті після кожного створення чи оновлення
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id         BIGSERIAL PRIMARY KEY,
+    article_id BIGINT       NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    version    INTEGER      NOT NULL,
+    title      VARCHAR(255) NOT NULL,
+    slug       VARCHAR(255) NOT NULL,
+    body       TEXT         NOT NULL,
+    status     VARCHAR(20)  NOT NULL,
+    editor_id  BIGINT       NOT NULL REFERENCES users (id),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    UNIQUE (article_id, version)
+);
+
+INSERT INTO article_revisions (article_id, version, title, slug, body, status, editor_id, created_at)
+SELECT id, version, title, slug, body, status, updated_by, updated_at
+FROM articles
+ON CONFLICT (article_id, version) DO NOTHING;
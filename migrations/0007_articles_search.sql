@@ -0,0 +1,47 @@
+-- language - конфігурація текстового пошуку Postgres для стемінгу статті
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS language      VARCHAR(32) NOT NULL DEFAULT 'simple',
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR    NOT NULL DEFAULT ''::tsvector;
+
+-- Вектор містить і стемовані мовою статті лексеми, і точні слова ('simple'),
+-- тому стаття знаходиться як запитом її мовою, так і запитом без мови.
+-- Ваги: A - заголовок, B - текст.
+CREATE OR REPLACE FUNCTION articles_search_vector(
+    lang  VARCHAR,
+    title TEXT,
+    body  TEXT
+) RETURNS TSVECTOR AS $$
+DECLARE
+    cfg    REGCONFIG := lang::regconfig;
+    result TSVECTOR;
+BEGIN
+    result := setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+              setweight(to_tsvector('simple', coalesce(body, '')), 'B');
+
+    IF cfg <> 'simple'::regconfig THEN
+        result := result ||
+                  setweight(to_tsvector(cfg, coalesce(title, '')), 'A') ||
+                  setweight(to_tsvector(cfg, coalesce(body, '')), 'B');
+    END IF;
+
+    RETURN result;
+END
+$$ LANGUAGE plpgsql STABLE;
+
+CREATE OR REPLACE FUNCTION articles_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := articles_search_vector(NEW.language, NEW.title, NEW.body);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS articles_search_vector_trigger ON articles;
+
+CREATE TRIGGER articles_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, body, language ON articles
+    FOR EACH ROW EXECUTE FUNCTION articles_search_vector_update();
+
+UPDATE articles SET search_vector = articles_search_vector(language, title, body);
+
+CREATE INDEX IF NOT EXISTS articles_search_vector_idx ON articles USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS articles_updated_at_idx ON articles (updated_at);
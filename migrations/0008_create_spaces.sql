@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS spaces (
+    id          BIGSERIAL PRIMARY KEY,
+    key         VARCHAR(100) NOT NULL UNIQUE,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT         NOT NULL DEFAULT '',
+    created_by  BIGINT       NOT NULL REFERENCES users (id),
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- Сторінки простору утворюють дерево через parent_id; position - порядок серед сусідів.
+-- Статті без простору лишаються поза деревом.
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS space_id  BIGINT REFERENCES spaces (id),
+    ADD COLUMN IF NOT EXISTS parent_id BIGINT REFERENCES articles (id),
+    ADD COLUMN IF NOT EXISTS position  INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_parent_check;
+ALTER TABLE articles ADD CONSTRAINT articles_parent_check
+    CHECK (parent_id IS NULL OR (space_id IS NOT NULL AND parent_id <> id));
+
+CREATE INDEX IF NOT EXISTS articles_space_tree_idx ON articles (space_id, parent_id, position);
+CREATE INDEX IF NOT EXISTS articles_parent_id_idx ON articles (parent_id);
@@ -0,0 +1,20 @@
+-- Глобальна роль користувача. Існуючі користувачі лишаються редакторами, нові
+-- отримують роль переглядача; першого адміністратора призначають вручну:
+--   UPDATE users SET role = 'admin' WHERE username = '...';
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS role VARCHAR(16) NOT NULL DEFAULT 'editor';
+
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'viewer';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('viewer', 'editor', 'admin'));
+
+CREATE TABLE IF NOT EXISTS space_members (
+    space_id   BIGINT      NOT NULL REFERENCES spaces (id) ON DELETE CASCADE,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role       VARCHAR(16) NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (space_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS space_members_user_id_idx ON space_members (user_id);
@@ -0,0 +1,19 @@
+-- Теги зберігаються в нормалізованому вигляді (нижній регістр, пробіли замінено на '-'),
+-- тому назва однозначно ідентифікує тег.
+CREATE TABLE IF NOT EXISTS tags (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- text_pattern_ops дозволяє використовувати індекс для автодоповнення через LIKE 'prefix%'
+CREATE INDEX IF NOT EXISTS tags_name_prefix_idx ON tags (name text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id BIGINT      NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    tag_id     BIGINT      NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (article_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS article_tags_tag_id_idx ON article_tags (tag_id);
@@ -0,0 +1,28 @@
+-- Коментарі утворюють гілки: parent_id - коментар, на який відповідають,
+-- thread_id - кореневий коментар гілки (NULL для самого кореня).
+-- Видалення м'яке: deleted_at зберігає місце коментаря в гілці, текст очищується.
+CREATE TABLE IF NOT EXISTS comments (
+    id          BIGSERIAL PRIMARY KEY,
+    article_id  BIGINT      NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    parent_id   BIGINT REFERENCES comments (id) ON DELETE CASCADE,
+    thread_id   BIGINT REFERENCES comments (id) ON DELETE CASCADE,
+    author_id   BIGINT      NOT NULL REFERENCES users (id),
+    body        TEXT        NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    resolved_by BIGINT REFERENCES users (id),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at  TIMESTAMPTZ,
+    CONSTRAINT comments_thread_check CHECK ((parent_id IS NULL) = (thread_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS comments_article_threads_idx ON comments (article_id, created_at, id) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS comments_thread_id_idx ON comments (thread_id, created_at, id);
+
+CREATE TABLE IF NOT EXISTS comment_mentions (
+    comment_id BIGINT NOT NULL REFERENCES comments (id) ON DELETE CASCADE,
+    user_id    BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS comment_mentions_user_id_idx ON comment_mentions (user_id);
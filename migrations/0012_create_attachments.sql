@@ -0,0 +1,22 @@
+-- Вміст вкладень зберігається в BlobStore під ключем, похідним від SHA-256,
+-- тому однакові файли займають місце один раз. Рядок blobs існує, доки об'єкт є в сховищі;
+-- blobs без вкладень (після видалення статей) прибирає адміністративна очистка.
+CREATE TABLE IF NOT EXISTS blobs (
+    sha256     CHAR(64)    PRIMARY KEY,
+    size       BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS attachments (
+    id           BIGSERIAL PRIMARY KEY,
+    article_id   BIGINT       NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    sha256       CHAR(64)     NOT NULL REFERENCES blobs (sha256),
+    filename     VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size         BIGINT       NOT NULL,
+    uploaded_by  BIGINT       NOT NULL REFERENCES users (id),
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS attachments_article_id_idx ON attachments (article_id, created_at, id);
+CREATE INDEX IF NOT EXISTS attachments_sha256_idx ON attachments (sha256);
@@ -0,0 +1,12 @@
+-- Wiki-посилання [[Target]] між статтями. Ціль зберігається як slug, отриманий із тексту
+-- посилання, і зіставляється зі статтями під час читання: посилання на ще не створену
+-- сторінку оживає після її створення, а перейменування slug робить посилання битим.
+-- Посилання наявних статей з'являються після їх наступного збереження.
+CREATE TABLE IF NOT EXISTS article_links (
+    source_id   BIGINT NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    target_slug TEXT   NOT NULL,
+    target      TEXT   NOT NULL,
+    PRIMARY KEY (source_id, target_slug)
+);
+
+CREATE INDEX IF NOT EXISTS article_links_target_slug_idx ON article_links (target_slug);
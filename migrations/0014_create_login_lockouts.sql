@@ -0,0 +1,9 @@
+-- Невдалі спроби входу поспіль для облікового запису. Лічильник не скидається, коли
+-- блокування закінчується, тому кожне наступне блокування довше; рядок видаляється
+-- після успішного входу.
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    user_id      BIGINT      PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    failures     INTEGER     NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,88 @@
+-- Назви тегів статті входять до search_vector з вагою C - нижче за заголовок (A)
+-- і текст (B). Вектор перераховується тригерами, коли змінюються теги статті
+-- або назва тегу.
+CREATE OR REPLACE FUNCTION article_tag_names(article BIGINT) RETURNS TEXT AS $$
+    SELECT coalesce(string_agg(t.name, ' ' ORDER BY t.name), '')
+    FROM article_tags tg
+    JOIN tags t ON t.id = tg.tag_id
+    WHERE tg.article_id = article
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION articles_search_vector(
+    lang  VARCHAR,
+    title TEXT,
+    body  TEXT,
+    tags  TEXT
+) RETURNS TSVECTOR AS $$
+DECLARE
+    cfg    REGCONFIG := lang::regconfig;
+    result TSVECTOR;
+BEGIN
+    result := setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+              setweight(to_tsvector('simple', coalesce(body, '')), 'B') ||
+              setweight(to_tsvector('simple', coalesce(tags, '')), 'C');
+
+    IF cfg <> 'simple'::regconfig THEN
+        result := result ||
+                  setweight(to_tsvector(cfg, coalesce(title, '')), 'A') ||
+                  setweight(to_tsvector(cfg, coalesce(body, '')), 'B') ||
+                  setweight(to_tsvector(cfg, coalesce(tags, '')), 'C');
+    END IF;
+
+    RETURN result;
+END
+$$ LANGUAGE plpgsql STABLE;
+
+CREATE OR REPLACE FUNCTION articles_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := articles_search_vector(NEW.language, NEW.title, NEW.body, article_tag_names(NEW.id));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS articles_search_vector(VARCHAR, TEXT, TEXT);
+
+-- refresh_article_search_vector перераховує вектор однієї статті
+CREATE OR REPLACE FUNCTION refresh_article_search_vector(article BIGINT) RETURNS VOID AS $$
+    UPDATE articles
+    SET search_vector = articles_search_vector(language, title, body, article_tag_names(id))
+    WHERE id = article;
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION article_tags_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM refresh_article_search_vector(OLD.article_id);
+    ELSE
+        PERFORM refresh_article_search_vector(NEW.article_id);
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS article_tags_search_vector_trigger ON article_tags;
+
+CREATE TRIGGER article_tags_search_vector_trigger
+    AFTER INSERT OR DELETE ON article_tags
+    FOR EACH ROW EXECUTE FUNCTION article_tags_search_vector_update();
+
+CREATE OR REPLACE FUNCTION tags_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_article_search_vector(article_id)
+    FROM article_tags
+    WHERE tag_id = NEW.id;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tags_search_vector_trigger ON tags;
+
+CREATE TRIGGER tags_search_vector_trigger
+    AFTER UPDATE OF name ON tags
+    FOR EACH ROW
+    WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION tags_search_vector_update();
+
+UPDATE articles SET search_vector = articles_search_vector(language, title, body, article_tag_names(id));
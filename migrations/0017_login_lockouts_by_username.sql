@@ -0,0 +1,15 @@
+-- Лічильники невдалих входів ведуться за нормалізованим username (LOWER), а не за
+-- id користувача: невідомі імена блокуються так само, як існуючі, тому відповідь
+-- account_locked не розкриває, чи існує обліковий запис.
+ALTER TABLE login_lockouts ADD COLUMN username VARCHAR(50);
+
+UPDATE login_lockouts l
+SET username = LOWER(u.username)
+FROM users u
+WHERE u.id = l.user_id;
+
+DELETE FROM login_lockouts WHERE username IS NULL;
+
+ALTER TABLE login_lockouts DROP COLUMN user_id;
+ALTER TABLE login_lockouts ALTER COLUMN username SET NOT NULL;
+ALTER TABLE login_lockouts ADD PRIMARY KEY (username);
@@ -0,0 +1,10 @@
+// Package migrations містить SQL-міграції схеми бази даних, вбудовані у бінарник.
+package migrations
+
+import "embed"
+
+// FS - файли міграцій. Імена мають формат NNNN_description.sql і
+// застосовуються у лексикографічному порядку.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,156 @@
+// Package apperror описує типізовані помилки домену: вид помилки (не знайдено, конфлікт,
+// некоректні дані, заборонено...) та стабільний машиночитний код. Сервіси та репозиторії
+// оголошують через нього свої помилки, а транспорт перетворює вид на статус відповіді,
+// не знаючи про конкретні помилки.
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Kind - вид помилки
+type Kind uint8
+
+const (
+	// KindInternal - непередбачена помилка; її текст не показують клієнту
+	KindInternal Kind = iota
+	// KindValidation - некоректні дані запиту
+	KindValidation
+	// KindUnauthorized - клієнта не автентифіковано
+	KindUnauthorized
+	// KindForbidden - клієнту заборонено дію
+	KindForbidden
+	// KindNotFound - сутності не існує
+	KindNotFound
+	// KindConflict - дія суперечить поточному стану
+	KindConflict
+	// KindTooLarge - вміст перевищує дозволений розмір
+	KindTooLarge
+	// KindUnsupported - тип вмісту не підтримується
+	KindUnsupported
+	// KindTooManyRequests - забагато запитів; повторити можна через RetryAfter
+	KindTooManyRequests
+)
+
+// FieldError - помилка окремого поля запиту
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Message string `json:"message" example:"must be a valid email address"`
+}
+
+// Error - типізована помилка. Оголошені в пакетах помилки порівнюються через errors.Is,
+// уточнені через With* копії лишаються рівними своєму оригіналу.
+type Error struct {
+	Kind Kind
+	// Code - стабільний машиночитний код у snake_case
+	Code string
+	// Message - загальний опис помилки
+	Message string
+	// Detail - уточнення для конкретного випадку
+	Detail string
+	Fields []FieldError
+	// RetryAfter - коли запит має сенс повторити; 0 - невідомо
+	RetryAfter time.Duration
+
+	parent *Error
+}
+
+// New створює помилку виду kind
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Validation створює помилку некоректних даних
+func Validation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// Unauthorized створює помилку автентифікації
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+// Forbidden створює помилку доступу
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// NotFound створює помилку відсутньої сутності
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict створює помилку конфлікту зі станом
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func (e *Error) Error() string {
+	if e.Detail == "" {
+		return e.Message
+	}
+
+	return e.Message + ": " + e.Detail
+}
+
+// Unwrap повертає помилку, з якої уточнено цю
+func (e *Error) Unwrap() error {
+	if e.parent == nil {
+		return nil
+	}
+
+	return e.parent
+}
+
+// Withf повертає копію помилки з уточненням
+func (e *Error) Withf(format string, args ...any) *Error {
+	derived := e.derive()
+	derived.Detail = fmt.Sprintf(format, args...)
+
+	return derived
+}
+
+// WithFields повертає копію помилки з помилками полів
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	derived := e.derive()
+	derived.Fields = append(derived.Fields, fields...)
+
+	return derived
+}
+
+// WithRetryAfter повертає копію помилки з часом, після якого запит можна повторити
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	derived := e.derive()
+	derived.RetryAfter = d
+
+	return derived
+}
+
+func (e *Error) derive() *Error {
+	derived := *e
+	derived.Fields = append([]FieldError(nil), e.Fields...)
+	derived.parent = e
+
+	return &derived
+}
+
+// As знаходить типізовану помилку в ланцюжку err
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+
+	return nil, false
+}
+
+// KindOf повертає вид помилки; помилки без типу - KindInternal
+func KindOf(err error) Kind {
+	if appErr, ok := As(err); ok {
+		return appErr.Kind
+	}
+
+	return KindInternal
+}
@@ -0,0 +1,61 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError_Derived(t *testing.T) {
+	errBase := NotFound("article_not_found", "article not found")
+
+	derived := errBase.Withf("id %d", 42)
+	if derived.Error() != "article not found: id 42" {
+		t.Errorf("Error() = %q", derived.Error())
+	}
+
+	if !errors.Is(derived, errBase) {
+		t.Error("Expected derived error to match its parent")
+	}
+
+	if errBase.Detail != "" {
+		t.Errorf("Expected parent to stay unchanged, got detail %q", errBase.Detail)
+	}
+
+	withFields := derived.WithFields(FieldError{Field: "title", Message: "is required"})
+	if len(derived.Fields) != 0 || len(withFields.Fields) != 1 {
+		t.Errorf("Expected fields only on the copy, got %v and %v", derived.Fields, withFields.Fields)
+	}
+
+	if !errors.Is(withFields, errBase) || withFields.Code != "article_not_found" {
+		t.Errorf("Expected copy to keep code and identity, got %+v", withFields)
+	}
+
+	if errors.Is(errBase, NotFound("article_not_found", "article not found")) {
+		t.Error("Expected distinct errors with the same code not to match")
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	errConflict := Conflict("slug_exists", "slug already exists")
+
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{"typed", errConflict, KindConflict},
+		{"wrapped", fmt.Errorf("ArticleService - Create: %w", errConflict), KindConflict},
+		{"derived", Validation("invalid_query", "invalid query").Withf("bad sort"), KindValidation},
+		{"untyped", errors.New("connection refused"), KindInternal},
+		{"nil", nil, KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.want {
+				t.Errorf("KindOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+// Package diff обчислює порядкову різницю двох текстів алгоритмом Майерса
+// і форматує її у вигляді hunk-ів unified diff.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// _maxEditDistance обмежує пам'ять пошуку: якщо тексти відрізняються більше
+// ніж на стільки рядків, змінений фрагмент подається як повна заміна.
+const _maxEditDistance = 2000
+
+// DefaultContext - кількість незмінних рядків навколо змін у hunk-у
+const DefaultContext = 3
+
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+func (o Op) String() string {
+	switch o {
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+// MarshalText дозволяє віддавати Op у JSON рядком
+func (o Op) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText розбирає Op з рядка, отриманого від MarshalText
+func (o *Op) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "equal":
+		*o = Equal
+	case "insert":
+		*o = Insert
+	case "delete":
+		*o = Delete
+	default:
+		return fmt.Errorf("diff: unknown op %q", text)
+	}
+
+	return nil
+}
+
+func (o Op) prefix() byte {
+	switch o {
+	case Insert:
+		return '+'
+	case Delete:
+		return '-'
+	default:
+		return ' '
+	}
+}
+
+// Line - рядок результату; номери рядків починаються з 1, 0 означає відсутність
+// рядка у відповідному тексті.
+type Line struct {
+	Op      Op     `json:"op"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
+
+// Hunk - група змін разом з контекстом
+type Hunk struct {
+	OldStart int    `json:"old_start"`
+	OldLines int    `json:"old_lines"`
+	NewStart int    `json:"new_start"`
+	NewLines int    `json:"new_lines"`
+	Lines    []Line `json:"lines"`
+}
+
+// Header повертає заголовок hunk-а у форматі "@@ -1,3 +1,4 @@"
+func (h Hunk) Header() string {
+	return fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.OldStart, h.OldLines), hunkRange(h.NewStart, h.NewLines))
+}
+
+// Lines порівнює тексти a та b порядково
+func Lines(a, b string) []Line {
+	return diffLines(splitLines(a), splitLines(b))
+}
+
+// Stats рахує кількість доданих та видалених рядків
+func Stats(lines []Line) (added, removed int) {
+	for _, line := range lines {
+		switch line.Op {
+		case Insert:
+			added++
+		case Delete:
+			removed++
+		}
+	}
+
+	return added, removed
+}
+
+// Hunks групує зміни, залишаючи до context незмінних рядків навколо кожної.
+// Для однакових текстів повертає порожній список.
+func Hunks(lines []Line, context int) []Hunk {
+	hunks := make([]Hunk, 0)
+
+	for i := 0; i < len(lines); {
+		if lines[i].Op == Equal {
+			i++
+			continue
+		}
+
+		start := max(i-context, 0)
+		end := i
+
+		// Розширюємо hunk, поки наступна зміна ближче ніж 2*context рядків
+		for end < len(lines) {
+			next := end
+			for next < len(lines) && lines[next].Op == Equal {
+				next++
+			}
+
+			if next == len(lines) || next-end > 2*context {
+				break
+			}
+
+			for next < len(lines) && lines[next].Op != Equal {
+				next++
+			}
+
+			end = next
+		}
+
+		end = min(end+context, len(lines))
+		hunks = append(hunks, newHunk(lines[start:end]))
+		i = end
+	}
+
+	return hunks
+}
+
+// Unified форматує hunk-и як unified diff з заголовками oldName та newName
+func Unified(oldName, newName string, hunks []Hunk) string {
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	for _, hunk := range hunks {
+		b.WriteString(hunk.Header())
+		b.WriteByte('\n')
+
+		for _, line := range hunk.Lines {
+			b.WriteByte(line.Op.prefix())
+			b.WriteString(line.Text)
+			b.WriteByte('\n')
+		}
+	}
+
+	return b.String()
+}
+
+func newHunk(lines []Line) Hunk {
+	hunk := Hunk{Lines: lines}
+
+	for _, line := range lines {
+		if line.Op != Insert {
+			if hunk.OldStart == 0 {
+				hunk.OldStart = line.OldLine
+			}
+			hunk.OldLines++
+		}
+
+		if line.Op != Delete {
+			if hunk.NewStart == 0 {
+				hunk.NewStart = line.NewLine
+			}
+			hunk.NewLines++
+		}
+	}
+
+	// Для порожнього боку unified diff вказує рядок перед змінами
+	if hunk.OldLines == 0 {
+		hunk.OldStart = lineBefore(lines, func(l Line) int { return l.OldLine })
+	}
+
+	if hunk.NewLines == 0 {
+		hunk.NewStart = lineBefore(lines, func(l Line) int { return l.NewLine })
+	}
+
+	return hunk
+}
+
+// lineBefore повертає номер рядка, після якого вставлено (або з якого видалено) весь hunk
+func lineBefore(lines []Line, number func(Line) int) int {
+	for _, line := range lines {
+		if n := number(line); n > 0 {
+			return n - 1
+		}
+	}
+
+	return 0
+}
+
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func diffLines(a, b []string) []Line {
+	// Спільні початок і кінець не потребують пошуку
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops, ok := myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	if !ok {
+		ops = replaceAll(len(a)-prefix-suffix, len(b)-prefix-suffix)
+	}
+
+	result := make([]Line, 0, len(a)+len(b))
+	oldLine, newLine := 0, 0
+
+	emit := func(op Op) {
+		switch op {
+		case Equal:
+			result = append(result, Line{Op: Equal, Text: a[oldLine], OldLine: oldLine + 1, NewLine: newLine + 1})
+			oldLine++
+			newLine++
+		case Delete:
+			result = append(result, Line{Op: Delete, Text: a[oldLine], OldLine: oldLine + 1})
+			oldLine++
+		case Insert:
+			result = append(result, Line{Op: Insert, Text: b[newLine], NewLine: newLine + 1})
+			newLine++
+		}
+	}
+
+	for range prefix {
+		emit(Equal)
+	}
+
+	for _, op := range ops {
+		emit(op)
+	}
+
+	for range suffix {
+		emit(Equal)
+	}
+
+	return result
+}
+
+func replaceAll(n, m int) []Op {
+	ops := make([]Op, 0, n+m)
+
+	for range n {
+		ops = append(ops, Delete)
+	}
+
+	for range m {
+		ops = append(ops, Insert)
+	}
+
+	return ops
+}
+
+// myers повертає найкоротший сценарій редагування a у b.
+// Повертає false, якщо відстань редагування перевищує _maxEditDistance.
+func myers(a, b []string) ([]Op, bool) {
+	n, m := len(a), len(b)
+	limit := min(n+m, _maxEditDistance)
+	offset := limit + 1
+
+	v := make([]int, 2*offset+1)
+	trace := make([][]int, 0, limit+1)
+
+	for d := 0; d <= limit; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+				return backtrack(trace, n, m), true
+			}
+		}
+
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+
+	return nil, false
+}
+
+// backtrack відновлює операції з trace, де trace[d][k+d] - найдальший x на діагоналі k після кроку d
+func backtrack(trace [][]int, n, m int) []Op {
+	ops := make([]Op, 0, n+m)
+	x, y := n, m
+
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		at := func(k int) int { return prev[k+d-1] }
+
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, Equal)
+			x--
+			y--
+		}
+
+		if x == prevX {
+			ops = append(ops, Insert)
+		} else {
+			ops = append(ops, Delete)
+		}
+
+		x, y = prevX, prevY
+	}
+
+	for x > 0 && y > 0 {
+		ops = append(ops, Equal)
+		x--
+		y--
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	return ops
+}
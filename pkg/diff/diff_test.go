@@ -0,0 +1,115 @@
+package diff
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// reconstruct збирає старий і новий тексти з результату порівняння
+func reconstruct(lines []Line) (oldText, newText []string) {
+	for _, line := range lines {
+		if line.Op != Insert {
+			oldText = append(oldText, line.Text)
+		}
+
+		if line.Op != Delete {
+			newText = append(newText, line.Text)
+		}
+	}
+
+	return oldText, newText
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		wantAdded   int
+		wantRemoved int
+	}{
+		{"Equal", "a\nb\nc\n", "a\nb\nc\n", 0, 0},
+		{"Empty to text", "", "a\nb", 2, 0},
+		{"Text to empty", "a\nb", "", 0, 2},
+		{"Insert in middle", "a\nc", "a\nb\nc", 1, 0},
+		{"Replace line", "a\nb\nc", "a\nx\nc", 1, 1},
+		{"Classic", "a\nb\nc\na\nb\nb\na", "c\nb\na\nb\na\nc", 2, 3},
+		{"CRLF is normalized", "a\r\nb\r\n", "a\nb\n", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := Lines(tt.a, tt.b)
+
+			added, removed := Stats(lines)
+			if added != tt.wantAdded || removed != tt.wantRemoved {
+				t.Errorf("Stats() = +%d -%d, want +%d -%d", added, removed, tt.wantAdded, tt.wantRemoved)
+			}
+
+			oldText, newText := reconstruct(lines)
+			if strings.Join(oldText, "\n") != strings.Join(splitLines(tt.a), "\n") {
+				t.Errorf("Old text is not reconstructed: %q", oldText)
+			}
+
+			if strings.Join(newText, "\n") != strings.Join(splitLines(tt.b), "\n") {
+				t.Errorf("New text is not reconstructed: %q", newText)
+			}
+		})
+	}
+}
+
+func TestLines_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	alphabet := []string{"a", "b", "c", "d"}
+
+	randomText := func() []string {
+		text := make([]string, rnd.Intn(30))
+		for i := range text {
+			text[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return text
+	}
+
+	for range 200 {
+		a, b := randomText(), randomText()
+
+		oldText, newText := reconstruct(diffLines(a, b))
+		if strings.Join(oldText, "\n") != strings.Join(a, "\n") || strings.Join(newText, "\n") != strings.Join(b, "\n") {
+			t.Fatalf("Diff of %q and %q does not reconstruct inputs", a, b)
+		}
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := "line1\nline2\nline3\nline4\nline5\nline6\nline7\nline8\nline9\nline10\n"
+	b := "line1\nchanged\nline3\nline4\nline5\nline6\nline7\nline8\nline9\nline10\nline11\n"
+
+	hunks := Hunks(Lines(a, b), 1)
+	if len(hunks) != 2 {
+		t.Fatalf("Expected 2 hunks, got %d", len(hunks))
+	}
+
+	want := "--- v1\n+++ v2\n" +
+		"@@ -1,3 +1,3 @@\n line1\n-line2\n+changed\n line3\n" +
+		"@@ -10 +10,2 @@\n line10\n+line11\n"
+
+	if got := Unified("v1", "v2", hunks); got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := Hunks(Lines(a, a), DefaultContext); len(got) != 0 {
+		t.Errorf("Expected no hunks for equal texts, got %d", len(got))
+	}
+
+	// Близькі зміни об'єднуються в один hunk
+	if got := Hunks(Lines(a, b), DefaultContext*3); len(got) != 1 {
+		t.Errorf("Expected 1 merged hunk, got %d", len(got))
+	}
+}
+
+func TestHunk_EmptySide(t *testing.T) {
+	hunks := Hunks(Lines("", "a\nb\n"), DefaultContext)
+	if len(hunks) != 1 || hunks[0].Header() != "@@ -0,0 +1,2 @@" {
+		t.Errorf("Unexpected hunks for insertion into empty text: %+v", hunks)
+	}
+}
@@ -0,0 +1,124 @@
+// Package health перевіряє готовність застосунку приймати трафік. Залежності (база,
+// сховище, кеш) реєструються як перевірки, які виконуються паралельно з тайм-аутом.
+// Під час плавної зупинки готовність знімається заздалегідь, щоб балансувальник
+// встиг перестати надсилати нові запити.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusFailed   = "failed"
+	StatusDraining = "draining"
+)
+
+// CheckFunc перевіряє залежність; помилка означає, що вона недоступна
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult - результат однієї перевірки
+type CheckResult struct {
+	Status     string  `json:"status" example:"ok"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms" example:"1.25"`
+}
+
+// Report - результат перевірки готовності
+type Report struct {
+	Status string                 `json:"status" example:"ok"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Readiness - набір перевірок готовності
+type Readiness struct {
+	timeout  time.Duration
+	draining atomic.Bool
+
+	mu     sync.RWMutex
+	checks []namedCheck
+}
+
+// NewReadiness створює набір перевірок; кожна перевірка обмежена timeout
+func NewReadiness(timeout time.Duration) *Readiness {
+	return &Readiness{timeout: timeout}
+}
+
+// Register додає перевірку залежності name
+func (r *Readiness) Register(name string, check CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, namedCheck{name: name, check: check})
+}
+
+// Drain позначає застосунок неготовим до кінця роботи; викликається на початку зупинки
+func (r *Readiness) Drain() {
+	r.draining.Store(true)
+}
+
+// Check виконує всі перевірки паралельно. Застосунок готовий, якщо не зупиняється
+// і всі перевірки успішні.
+func (r *Readiness) Check(ctx context.Context) (Report, bool) {
+	if r.draining.Load() {
+		return Report{Status: StatusDraining}, false
+	}
+
+	r.mu.RLock()
+	checks := append([]namedCheck(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i] = r.run(ctx, c.check)
+		}()
+	}
+
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+
+		if results[i].Status != StatusOK {
+			report.Status = StatusFailed
+		}
+	}
+
+	return report, report.Status == StatusOK
+}
+
+func (r *Readiness) run(ctx context.Context, check CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+
+	result := CheckResult{
+		Status:     StatusOK,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+
+	return result
+}
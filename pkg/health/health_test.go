@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadiness_Check(t *testing.T) {
+	readiness := NewReadiness(50 * time.Millisecond)
+	readiness.Register("postgres", func(context.Context) error { return nil })
+
+	report, ready := readiness.Check(context.Background())
+	if !ready || report.Status != StatusOK || report.Checks["postgres"].Status != StatusOK {
+		t.Fatalf("Expected ready report, got %+v", report)
+	}
+
+	readiness.Register("storage", func(context.Context) error { return errors.New("bucket unavailable") })
+	readiness.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	report, ready = readiness.Check(context.Background())
+
+	if ready || report.Status != StatusFailed {
+		t.Fatalf("Expected failed report, got %+v", report)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected checks to be limited by the timeout, took %v", elapsed)
+	}
+
+	if report.Checks["postgres"].Status != StatusOK {
+		t.Errorf("Expected healthy check to stay ok, got %+v", report.Checks["postgres"])
+	}
+
+	if got := report.Checks["storage"]; got.Status != StatusFailed || got.Error != "bucket unavailable" {
+		t.Errorf("Unexpected storage check %+v", got)
+	}
+
+	if got := report.Checks["slow"]; got.Status != StatusFailed || got.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("Unexpected slow check %+v", got)
+	}
+}
+
+func TestReadiness_Drain(t *testing.T) {
+	called := false
+
+	readiness := NewReadiness(time.Second)
+	readiness.Register("postgres", func(context.Context) error {
+		called = true
+		return nil
+	})
+	readiness.Drain()
+
+	report, ready := readiness.Check(context.Background())
+	if ready || report.Status != StatusDraining {
+		t.Errorf("Expected draining report, got %+v", report)
+	}
+
+	if called {
+		t.Error("Expected checks to be skipped while draining")
+	}
+}
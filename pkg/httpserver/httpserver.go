@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -24,10 +25,13 @@ type Server struct {
 	readTimeout     time.Duration
 	writeTimeout    time.Duration
 	shutdownTimeout time.Duration
+	trustedProxies  []string
 }
 
 // NewServer -.
-func NewServer(opts ...Option) *Server {
+// Заголовкам X-Forwarded-For та X-Real-IP довіряється лише від TrustedProxies;
+// без них IP клієнта - це адреса з'єднання.
+func NewServer(opts ...Option) (*Server, error) {
 	s := &Server{
 		Engine:          nil,
 		notify:          make(chan error, 1),
@@ -45,6 +49,10 @@ func NewServer(opts ...Option) *Server {
 	engine := gin.New()
 	engine.Use(gin.Recovery())
 
+	if err := engine.SetTrustedProxies(s.trustedProxies); err != nil {
+		return nil, fmt.Errorf("httpserver - NewServer - SetTrustedProxies: %w", err)
+	}
+
 	s.Engine = engine
 	s.httpServer = &http.Server{
 		Addr:         s.address,
@@ -53,7 +61,7 @@ func NewServer(opts ...Option) *Server {
 		WriteTimeout: s.writeTimeout,
 	}
 
-	return s
+	return s, nil
 }
 
 func (s *Server) Start() {
@@ -0,0 +1,52 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewServer_TrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		proxies []string
+		want    string
+	}{
+		{"No trusted proxies", nil, "10.0.0.1"},
+		{"Trusted proxy", []string{"10.0.0.0/8"}, "203.0.113.7"},
+		{"Other proxy", []string{"192.168.0.1"}, "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewServer(TrustedProxies(tt.proxies))
+			if err != nil {
+				t.Fatalf("NewServer() error = %v", err)
+			}
+
+			s.Engine.GET("/", func(c *gin.Context) {
+				c.String(http.StatusOK, c.ClientIP())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			req.Header.Set("X-Real-IP", "203.0.113.7")
+
+			w := httptest.NewRecorder()
+			s.Engine.ServeHTTP(w, req)
+
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewServer(TrustedProxies([]string{"not-an-ip"})); err == nil {
+		t.Error("Expected error for invalid trusted proxy")
+	}
+}
@@ -29,6 +29,13 @@ func WriteTimeout(timeout time.Duration) Option {
 	}
 }
 
+// TrustedProxies - IP або CIDR проксі, яким дозволено передавати адресу клієнта в заголовках.
+func TrustedProxies(proxies []string) Option {
+	return func(s *Server) {
+		s.trustedProxies = proxies
+	}
+}
+
 // ShutdownTimeout - таймаут для завершення роботи сервера.
 func ShutdownTimeout(timeout time.Duration) Option {
 	return func(s *Server) {
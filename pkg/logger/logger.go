@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -15,8 +17,20 @@ type Interface interface {
 	Warn(message string, args ...interface{})
 	Error(message interface{}, args ...interface{})
 	Fatal(message interface{}, args ...interface{})
+	// With повертає логер, що додає поля fields до кожного запису
+	With(fields Fields) Interface
 }
 
+// Fields - структуровані поля запису журналу
+type Fields map[string]interface{}
+
+const (
+	// FormatJSON - по одному JSON об'єкту на запис; формат за замовчуванням
+	FormatJSON = "json"
+	// FormatConsole - кольоровий текст для локальної розробки
+	FormatConsole = "console"
+)
+
 // Logger - LoggerInterface.
 type Logger struct {
 	logger *zerolog.Logger
@@ -24,7 +38,7 @@ type Logger struct {
 
 var _ Interface = (*Logger)(nil)
 
-func New(level string) *Logger {
+func New(level string, opts ...Option) *Logger {
 	var l zerolog.Level
 
 	switch strings.ToLower(level) {
@@ -42,8 +56,18 @@ func New(level string) *Logger {
 
 	zerolog.SetGlobalLevel(l)
 
+	o := &options{output: os.Stdout, format: FormatJSON}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var output io.Writer = o.output
+	if o.format == FormatConsole {
+		output = zerolog.ConsoleWriter{Out: o.output}
+	}
+
 	skipFrameCount := 3
-	logger := zerolog.New(os.Stdout).
+	logger := zerolog.New(output).
 		With().
 		Timestamp().
 		CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + skipFrameCount).
@@ -55,46 +79,70 @@ func New(level string) *Logger {
 }
 
 func (l *Logger) Debug(message interface{}, args ...interface{}) {
-	l.msg("debug", message, args...)
+	l.msg(zerolog.DebugLevel, message, args...)
 }
 
 func (l *Logger) Info(message string, args ...interface{}) {
-	l.log(message, args...)
+	l.log(zerolog.InfoLevel, message, args...)
 }
 
 func (l *Logger) Warn(message string, args ...interface{}) {
-	l.log(message, args...)
+	l.log(zerolog.WarnLevel, message, args...)
 }
 
 func (l *Logger) Error(message interface{}, args ...interface{}) {
-	if l.logger.GetLevel() == zerolog.DebugLevel {
-		l.Debug(message, args...)
-	}
-
-	l.msg("error", message, args...)
+	l.msg(zerolog.ErrorLevel, message, args...)
 }
 
 func (l *Logger) Fatal(message interface{}, args ...interface{}) {
-	l.msg("fatal", message, args...)
+	// Fatal у zerolog сам завершує процес, тому запис робиться на рівні fatal через WithLevel
+	l.msg(zerolog.FatalLevel, message, args...)
 
 	os.Exit(1)
 }
 
-func (l *Logger) log(message string, args ...interface{}) {
+func (l *Logger) With(fields Fields) Interface {
+	logger := l.logger.With().Fields(map[string]interface{}(fields)).Logger()
+
+	return &Logger{
+		logger: &logger,
+	}
+}
+
+func (l *Logger) log(level zerolog.Level, message string, args ...interface{}) {
+	event := l.logger.WithLevel(level)
+
 	if len(args) == 0 {
-		l.logger.Info().Msg(message)
+		event.Msg(message)
 	} else {
-		l.logger.Info().Msgf(message, args...)
+		event.Msgf(message, args...)
 	}
 }
 
-func (l *Logger) msg(level string, message interface{}, args ...interface{}) {
+func (l *Logger) msg(level zerolog.Level, message interface{}, args ...interface{}) {
 	switch msg := message.(type) {
 	case error:
-		l.log(msg.Error(), args...)
+		l.log(level, msg.Error(), args...)
 	case string:
-		l.log(msg, args...)
+		l.log(level, msg, args...)
 	default:
-		l.log(fmt.Sprintf("%s message %v has unknown type %v", level, message, msg), args...)
+		l.log(level, fmt.Sprintf("%s message %v has unknown type %v", level, message, msg), args...)
 	}
 }
+
+type contextKey struct{}
+
+// NewContext повертає копію ctx з логером l; так логер запиту з його полями
+// (request_id, user_id) доходить до обробників
+func NewContext(ctx context.Context, l Interface) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext повертає логер з ctx або fallback, якщо його там немає
+func FromContext(ctx context.Context, fallback Interface) Interface {
+	if l, ok := ctx.Value(contextKey{}).(Interface); ok {
+		return l
+	}
+
+	return fallback
+}
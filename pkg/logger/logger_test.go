@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func decodeEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Log line is not JSON: %q", line)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestLogger_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	l := New("debug", Output(&buf))
+
+	l.Debug("debug %d", 1)
+	l.Info("info %d", 2)
+	l.Warn("warn %d", 3)
+	l.Error(errors.New("boom"))
+
+	entries := decodeEntries(t, &buf)
+	want := []struct{ level, message string }{
+		{"debug", "debug 1"},
+		{"info", "info 2"},
+		{"warn", "warn 3"},
+		{"error", "boom"},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("Got %d entries, want %d: %s", len(entries), len(want), buf.String())
+	}
+
+	for i, w := range want {
+		if entries[i]["level"] != w.level || entries[i]["message"] != w.message {
+			t.Errorf("Entry %d = %v, want level %q message %q", i, entries[i], w.level, w.message)
+		}
+	}
+}
+
+func TestLogger_WithAndContext(t *testing.T) {
+	var buf bytes.Buffer
+	base := New("debug", Output(&buf))
+
+	ctx := NewContext(context.Background(), base.With(Fields{"request_id": "req-1"}))
+	FromContext(ctx, base).With(Fields{"user_id": 7}).Info("scoped")
+	FromContext(context.Background(), base).Info("fallback")
+
+	entries := decodeEntries(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("Got %d entries, want 2: %s", len(entries), buf.String())
+	}
+
+	if entries[0]["request_id"] != "req-1" || entries[0]["user_id"] != float64(7) {
+		t.Errorf("Expected scoped fields, got %v", entries[0])
+	}
+
+	if _, ok := entries[1]["request_id"]; ok {
+		t.Errorf("Expected fallback logger without request fields, got %v", entries[1])
+	}
+}
@@ -0,0 +1,27 @@
+package logger
+
+import "io"
+
+type options struct {
+	output io.Writer
+	format string
+}
+
+// Option -.
+type Option func(*options)
+
+// Format - формат записів: FormatJSON або FormatConsole.
+func Format(format string) Option {
+	return func(o *options) {
+		if format != "" {
+			o.format = format
+		}
+	}
+}
+
+// Output - куди писати журнал; за замовчуванням stdout.
+func Output(w io.Writer) Option {
+	return func(o *options) {
+		o.output = w
+	}
+}
@@ -0,0 +1,63 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	_fileDirPerm  = 0o750
+	_fileFilePerm = 0o640
+)
+
+// File зберігає кожен лист файлом .eml у каталозі dir замість надсилання.
+// Файли відкриваються будь-яким поштовим клієнтом; транспорт для локальної розробки.
+type File struct {
+	dir  string
+	from string
+	now  func() time.Time
+}
+
+var _ Mailer = (*File)(nil)
+
+// NewFile створює каталог dir, якщо його ще немає
+func NewFile(dir, from string) (*File, error) {
+	if _, err := envelopeAddress(from); err != nil {
+		return nil, fmt.Errorf("mailer - NewFile - From: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, _fileDirPerm); err != nil {
+		return nil, fmt.Errorf("mailer - NewFile - os.MkdirAll: %w", err)
+	}
+
+	return &File{dir: dir, from: from, now: time.Now}, nil
+}
+
+func (f *File) Send(_ context.Context, msg Message) error {
+	now := f.now()
+
+	data, err := encode(f.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	to, err := envelopeAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	// Ім'я сортується за часом; наносекунди розрізняють листи однієї секунди
+	name := fmt.Sprintf("%s-%09d-%s.eml", now.UTC().Format("20060102T150405"), now.Nanosecond(),
+		strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(to))
+
+	err = os.WriteFile(filepath.Join(f.dir, name), data, _fileFilePerm)
+	if err != nil {
+		return fmt.Errorf("mailer - File - Send - os.WriteFile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"context"
+
+	"KnowledgeHub/pkg/logger"
+)
+
+// Log записує листи в журнал застосунку замість надсилання. Текст листа,
+// разом з посиланнями та токенами в ньому, потрапляє в журнал, тому транспорт
+// призначений лише для локальної розробки.
+type Log struct {
+	l logger.Interface
+}
+
+var _ Mailer = (*Log)(nil)
+
+func NewLog(l logger.Interface) *Log {
+	return &Log{l: l}
+}
+
+func (m *Log) Send(ctx context.Context, msg Message) error {
+	if _, err := envelopeAddress(msg.To); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx, m.l).With(logger.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+	}).Info("Email not sent (log transport):\n%s", msg.Text)
+
+	return nil
+}
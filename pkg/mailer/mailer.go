@@ -0,0 +1,153 @@
+// Package mailer надсилає листи через змінний транспорт: SMTP сервер, файли .eml
+// у каталозі або журнал застосунку (два останні - для локальної розробки).
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Message - лист одному отримувачу. Text обов'язковий, HTML - альтернативна версія
+// для поштових клієнтів, що її підтримують.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer - транспорт листів
+type Mailer interface {
+	// Send надсилає лист від імені адреси, заданої при створенні транспорту
+	Send(ctx context.Context, msg Message) error
+}
+
+// encode формує лист у форматі RFC 5322 з MIME частинами в quoted-printable
+func encode(from string, msg Message, now time.Time) ([]byte, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("mailer - invalid sender %q: %w", from, err)
+	}
+
+	recipient, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("mailer - invalid recipient %q: %w", msg.To, err)
+	}
+
+	messageID, err := newMessageID(sender.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", sender.String())
+	header.Set("To", recipient.String())
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header.Set("Date", now.Format(time.RFC1123Z))
+	header.Set("Message-ID", messageID)
+	header.Set("MIME-Version", "1.0")
+
+	if msg.HTML == "" {
+		header.Set("Content-Type", "text/plain; charset=utf-8")
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		writeHeader(&buf, header)
+
+		if err = writeQuotedPrintable(&buf, msg.Text); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header.Set("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	writeHeader(&buf, header)
+
+	// Клієнти показують останню частину, яку вміють відобразити
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("mailer - CreatePart: %w", err)
+		}
+
+		if err = writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = parts.Close(); err != nil {
+		return nil, fmt.Errorf("mailer - multipart.Close: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	for _, key := range []string{
+		"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding",
+	} {
+		if value := header.Get(key); value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+		}
+	}
+
+	buf.WriteString("\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+
+	// У текстовому режимі writer сам переводить рядки в CRLF
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("mailer - quotedprintable.Write: %w", err)
+	}
+
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("mailer - quotedprintable.Close: %w", err)
+	}
+
+	return nil
+}
+
+func newMessageID(sender string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("mailer - newMessageID: %w", err)
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndexByte(sender, '@'); at >= 0 {
+		domain = sender[at+1:]
+	}
+
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">", nil
+}
+
+// envelopeAddress повертає адресу без імені, як її передає SMTP конверт
+func envelopeAddress(address string) (string, error) {
+	addr, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("mailer - invalid address %q: %w", address, err)
+	}
+
+	return addr.Address, nil
+}
@@ -0,0 +1,233 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseMessage(t *testing.T, data []byte) (*mail.Message, map[string]string) {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error = %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("ParseMediaType() error = %v", err)
+	}
+
+	bodies := map[string]string{}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		body, _ := io.ReadAll(msg.Body)
+		bodies[mediaType] = string(body)
+
+		return msg, bodies
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("NextPart() error = %v", err)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(part) // quoted-printable декодується автоматично
+		bodies[partType] = string(body)
+	}
+
+	return msg, bodies
+}
+
+func TestEncode(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	data, err := encode("KnowledgeHub <no-reply@example.com>", Message{
+		To:      "john@example.com",
+		Subject: "Підтвердіть email",
+		Text:    "Hello,\nopen https://example.com/verify?token=" + strings.Repeat("a", 100),
+		HTML:    "<p>Hello</p>",
+	}, now)
+	if err != nil {
+		t.Fatalf("encode() error = %v", err)
+	}
+
+	msg, bodies := parseMessage(t, data)
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Підтвердіть email" {
+		t.Errorf("Subject = %q", subject)
+	}
+
+	if got := msg.Header.Get("To"); got != "<john@example.com>" {
+		t.Errorf("To = %q", got)
+	}
+
+	if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>") {
+		t.Errorf("Message-ID = %q, want sender domain", msg.Header.Get("Message-ID"))
+	}
+
+	// Довгий рядок переноситься quoted-printable і відновлюється при декодуванні
+	if !strings.Contains(bodies["text/plain"], strings.Repeat("a", 100)) {
+		t.Errorf("Text part = %q", bodies["text/plain"])
+	}
+
+	if bodies["text/html"] != "<p>Hello</p>" {
+		t.Errorf("HTML part = %q", bodies["text/html"])
+	}
+}
+
+func TestEncode_InvalidAddress(t *testing.T) {
+	_, err := encode("no-reply@example.com", Message{To: "john@example.com\r\nBcc: x@example.com"}, time.Now())
+	if err == nil {
+		t.Error("Expected error for recipient with header injection")
+	}
+}
+
+func TestFile_Send(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := NewFile(dir, "no-reply@example.com")
+	if err != nil {
+		t.Fatalf("NewFile() error = %v", err)
+	}
+
+	err = m.Send(context.Background(), Message{To: "John <john@example.com>", Subject: "Hi", Text: "Hello"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*john_at_example.com.eml"))
+	if len(files) != 1 {
+		t.Fatalf("Expected one .eml file, got %v", files)
+	}
+
+	data, _ := os.ReadFile(files[0])
+	if _, bodies := parseMessage(t, data); bodies["text/plain"] != "Hello" {
+		t.Errorf("Body = %q, want Hello", bodies["text/plain"])
+	}
+}
+
+// serveSMTP приймає одну SMTP сесію без TLS та автентифікації і повертає прийнятий лист
+func serveSMTP(t *testing.T) (int, <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = ln.Close() })
+
+	received := make(chan string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		var envelope, data strings.Builder
+
+		reply("220 localhost ESMTP")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(strings.TrimSpace(line))
+
+			switch {
+			case strings.HasPrefix(command, "EHLO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL"), strings.HasPrefix(command, "RCPT"):
+				envelope.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+
+				for {
+					line, err = r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+
+					data.WriteString(line)
+				}
+
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				received <- envelope.String() + "\n" + data.String()
+
+				return
+			default:
+				reply("502 Not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSMTP_Send(t *testing.T) {
+	port, received := serveSMTP(t)
+
+	m, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: port, From: "KnowledgeHub <no-reply@example.com>"})
+	if err != nil {
+		t.Fatalf("NewSMTP() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = m.Send(ctx, Message{To: "John <john@example.com>", Subject: "Hi", Text: "Hello"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case session := <-received:
+		for _, want := range []string{"MAIL FROM:<no-reply@example.com>", "RCPT TO:<john@example.com>", "Subject: Hi"} {
+			if !strings.Contains(session, want) {
+				t.Errorf("Expected SMTP session to contain %q, got:\n%s", want, session)
+			}
+		}
+	case <-ctx.Done():
+		t.Fatal("SMTP server did not receive the message")
+	}
+}
+
+func TestNewSMTP_Invalid(t *testing.T) {
+	if _, err := NewSMTP(SMTPConfig{Port: 587, From: "no-reply@example.com"}); err == nil {
+		t.Error("Expected error without host")
+	}
+
+	if _, err := NewSMTP(SMTPConfig{Host: "localhost", Port: 587, From: "not an address"}); err == nil {
+		t.Error("Expected error for invalid sender")
+	}
+}
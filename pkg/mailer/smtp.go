@@ -0,0 +1,146 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// _smtpTimeout обмежує всю SMTP сесію, якщо контекст не має власного дедлайну
+const _smtpTimeout = 30 * time.Second
+
+// SMTPConfig - параметри SMTP сервера
+type SMTPConfig struct {
+	Host string
+	// Port - 587 для STARTTLS або 465 для TLS з першого байта (ImplicitTLS)
+	Port int
+	// Username, Password - облікові дані PLAIN автентифікації; порожні - без автентифікації
+	Username string
+	Password string
+	// ImplicitTLS вмикає TLS одразу після з'єднання замість STARTTLS
+	ImplicitTLS bool
+	// From - адреса відправника, наприклад "KnowledgeHub <no-reply@example.com>"
+	From string
+}
+
+// SMTP надсилає листи через SMTP сервер. Якщо сервер підтримує STARTTLS, з'єднання
+// шифрується; net/smtp не передає пароль незашифрованим з'єднанням, крім localhost.
+type SMTP struct {
+	cfg  SMTPConfig
+	addr string
+	now  func() time.Time
+}
+
+var _ Mailer = (*SMTP)(nil)
+
+func NewSMTP(cfg SMTPConfig) (*SMTP, error) {
+	if cfg.Host == "" || cfg.Port == 0 {
+		return nil, errors.New("mailer - NewSMTP: host and port are required")
+	}
+
+	if _, err := envelopeAddress(cfg.From); err != nil {
+		return nil, fmt.Errorf("mailer - NewSMTP - From: %w", err)
+	}
+
+	return &SMTP{
+		cfg:  cfg,
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		now:  time.Now,
+	}, nil
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	data, err := encode(s.cfg.From, msg, s.now())
+	if err != nil {
+		return err
+	}
+
+	from, _ := envelopeAddress(s.cfg.From)
+
+	to, err := envelopeAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("mailer - SMTP - Send - dial: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(_smtpTimeout)
+	}
+
+	if err = conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("mailer - SMTP - Send - SetDeadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("mailer - SMTP - Send - NewClient: %w", err)
+	}
+	defer client.Close()
+
+	if err = s.deliver(client, from, to, data); err != nil {
+		return fmt.Errorf("mailer - SMTP - Send: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SMTP) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{}
+
+	if s.cfg.ImplicitTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}}
+		return tlsDialer.DialContext(ctx, "tcp", s.addr)
+	}
+
+	return dialer.DialContext(ctx, "tcp", s.addr)
+}
+
+func (s *SMTP) deliver(client *smtp.Client, from, to string, data []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok && !s.cfg.ImplicitTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("StartTLS: %w", err)
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("Auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("Mail: %w", err)
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("Rcpt: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("Data: %w", err)
+	}
+
+	if _, err = w.Write(data); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("Data - Write: %w", err)
+	}
+
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("Data - Close: %w", err)
+	}
+
+	return client.Quit()
+}
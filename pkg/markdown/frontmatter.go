@@ -0,0 +1,74 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrFrontMatter - front matter відкрито, але не закрито
+var ErrFrontMatter = errors.New("markdown: unterminated front matter")
+
+var _bom = []byte("\xef\xbb\xbf")
+
+// ParseFrontMatter розбирає YAML front matter на початку документа в out
+// і повертає текст після нього. Front matter обмежується рядками "---",
+// закриватися може також рядком "...". Документ без front matter повертається
+// без змін, а out не змінюється.
+func ParseFrontMatter(source []byte, out any) ([]byte, error) {
+	source = bytes.TrimPrefix(source, _bom)
+
+	first, header := splitLine(source)
+	if !isFence(first, "---") {
+		return source, nil
+	}
+
+	for rest := header; len(rest) > 0; {
+		line, next := splitLine(rest)
+		if isFence(line, "---") || isFence(line, "...") {
+			if err := yaml.Unmarshal(header[:len(header)-len(rest)], out); err != nil {
+				return nil, fmt.Errorf("markdown - ParseFrontMatter: %w", err)
+			}
+
+			return next, nil
+		}
+
+		rest = next
+	}
+
+	return nil, ErrFrontMatter
+}
+
+// WithFrontMatter повертає документ з YAML front matter, отриманим з meta, перед body.
+// Результат розбирається ParseFrontMatter назад у meta та body.
+func WithFrontMatter(meta any, body []byte) ([]byte, error) {
+	header, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("markdown - WithFrontMatter: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	buf.Grow(len(header) + len(body) + 8)
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n")
+	buf.Write(body)
+
+	return buf.Bytes(), nil
+}
+
+// splitLine повертає перший рядок без переводу рядка та решту тексту
+func splitLine(source []byte) (line, rest []byte) {
+	if i := bytes.IndexByte(source, '\n'); i >= 0 {
+		return source[:i], source[i+1:]
+	}
+
+	return source, nil
+}
+
+func isFence(line []byte, fence string) bool {
+	return string(bytes.TrimRight(line, " \t\r")) == fence
+}
@@ -0,0 +1,70 @@
+package markdown
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	type meta struct {
+		Title string   `yaml:"title"`
+		Tags  []string `yaml:"tags"`
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		wantMeta meta
+		wantBody string
+		wantErr  error
+	}{
+		{"With front matter", "---\ntitle: Deploy\ntags: [ops, k8s]\n---\n# Body\n", meta{"Deploy", []string{"ops", "k8s"}}, "# Body\n", nil},
+		{"Dots terminator and CRLF", "\xef\xbb\xbf---\r\ntitle: Deploy\r\n...\r\nBody", meta{Title: "Deploy"}, "Body", nil},
+		{"Empty front matter", "---\n---\nBody", meta{}, "Body", nil},
+		{"Without front matter", "# Title\n---\n", meta{}, "# Title\n---\n", nil},
+		{"Unterminated", "---\ntitle: Deploy\n", meta{}, "", ErrFrontMatter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got meta
+
+			body, err := ParseFrontMatter([]byte(tt.source), &got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseFrontMatter() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if string(body) != tt.wantBody || got.Title != tt.wantMeta.Title || len(got.Tags) != len(tt.wantMeta.Tags) {
+				t.Errorf("ParseFrontMatter() = %+v, %q; want %+v, %q", got, body, tt.wantMeta, tt.wantBody)
+			}
+		})
+	}
+
+	var got meta
+	if _, err := ParseFrontMatter([]byte("---\ntitle: [unclosed\n---\n"), &got); err == nil {
+		t.Error("Expected YAML error")
+	}
+}
+
+func TestWithFrontMatter(t *testing.T) {
+	type meta struct {
+		Title string   `yaml:"title"`
+		Tags  []string `yaml:"tags,omitempty"`
+	}
+
+	source, err := WithFrontMatter(meta{Title: "Deploy: part 1", Tags: []string{"ops"}}, []byte("---\n# Body\n"))
+	if err != nil {
+		t.Fatalf("WithFrontMatter() error = %v", err)
+	}
+
+	var got meta
+
+	body, err := ParseFrontMatter(source, &got)
+	if err != nil {
+		t.Fatalf("ParseFrontMatter() error = %v", err)
+	}
+
+	if got.Title != "Deploy: part 1" || len(got.Tags) != 1 || string(body) != "---\n# Body\n" {
+		t.Errorf("Round trip = %+v, %q from %q", got, body, source)
+	}
+}
@@ -0,0 +1,151 @@
+package markdown
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// _inlineLinkPattern - вбудоване посилання [text](dest "title") або зображення ![alt](dest).
+// Текст з вкладеними дужками не розпізнається, і таке посилання лишається без змін.
+var _inlineLinkPattern = regexp.MustCompile(
+	`(!?)\[([^\[\]\n]*)\]\([ \t]*(<[^<>\n]*>|[^\s()<>]+)(?:[ \t]+("[^"\n]*"|'[^'\n]*'))?[ \t]*\)`,
+)
+
+// Link - вбудоване посилання або зображення в markdown тексті
+type Link struct {
+	Image       bool
+	Text        string
+	Destination string
+	Title       string
+}
+
+// String повертає markdown розмітку посилання
+func (l Link) String() string {
+	var b strings.Builder
+
+	if l.Image {
+		b.WriteByte('!')
+	}
+
+	b.WriteString("[" + l.Text + "](")
+
+	if strings.ContainsAny(l.Destination, " ()<>") {
+		b.WriteString("<" + l.Destination + ">")
+	} else {
+		b.WriteString(l.Destination)
+	}
+
+	if l.Title != "" {
+		b.WriteString(` "` + strings.ReplaceAll(l.Title, `"`, `\"`) + `"`)
+	}
+
+	b.WriteByte(')')
+
+	return b.String()
+}
+
+// RewriteLinks замінює вбудовані посилання та зображення на результат rewrite;
+// якщо rewrite повертає ok == false, посилання лишається як є.
+// Посилання всередині коду та HTML блоків не змінюються.
+func RewriteLinks(source []byte, rewrite func(link Link) (replacement string, ok bool)) []byte {
+	code := codeRanges(source)
+	matches := _inlineLinkPattern.FindAllSubmatchIndex(source, -1)
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, m := range matches {
+		if inRanges(code, m[0]) {
+			continue
+		}
+
+		link := Link{
+			Image:       m[3] > m[2],
+			Text:        string(source[m[4]:m[5]]),
+			Destination: strings.TrimSuffix(strings.TrimPrefix(string(source[m[6]:m[7]]), "<"), ">"),
+		}
+
+		if m[8] >= 0 {
+			link.Title = string(source[m[8]+1 : m[9]-1])
+		}
+
+		replacement, ok := rewrite(link)
+		if !ok {
+			continue
+		}
+
+		b.Write(source[last:m[0]])
+		b.WriteString(replacement)
+
+		last = m[1]
+	}
+
+	if last == 0 {
+		return source
+	}
+
+	b.Write(source[last:])
+
+	return []byte(b.String())
+}
+
+// codeRanges повертає відсортовані ділянки [start, stop) з блоками коду,
+// вбудованим кодом та HTML блоками
+func codeRanges(source []byte) [][2]int {
+	doc := _parser.Parse(text.NewReader(source))
+	ranges := make([][2]int, 0)
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock:
+			if lines := n.Lines(); lines.Len() > 0 {
+				ranges = append(ranges, [2]int{lines.At(0).Start, lines.At(lines.Len() - 1).Stop})
+			}
+
+			return ast.WalkSkipChildren, nil
+		case *ast.CodeSpan:
+			start, stop := -1, -1
+
+			for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+				if t, ok := child.(*ast.Text); ok {
+					if start < 0 {
+						start = t.Segment.Start
+					}
+
+					stop = t.Segment.Stop
+				}
+			}
+
+			if start >= 0 {
+				ranges = append(ranges, [2]int{start, stop})
+			}
+
+			return ast.WalkSkipChildren, nil
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i][0] < ranges[j][0]
+	})
+
+	return ranges
+}
+
+func inRanges(ranges [][2]int, offset int) bool {
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i][1] > offset
+	})
+
+	return i < len(ranges) && ranges[i][0] <= offset
+}
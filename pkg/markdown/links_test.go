@@ -0,0 +1,42 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRewriteLinks(t *testing.T) {
+	source := "See [deploy](../ops/deploy.md#steps \"Deploy\") and ![diagram](<img/arch diagram.png>).\n\n" +
+		"Keep [site](https://example.com), `[code](x.md)` and [a [nested] link](y.md).\n\n" +
+		"```\n[block](z.md)\n```\n"
+
+	var seen []Link
+
+	got := string(RewriteLinks([]byte(source), func(link Link) (string, bool) {
+		seen = append(seen, link)
+
+		if strings.HasPrefix(link.Destination, "https:") {
+			return "", false
+		}
+
+		link.Destination = "/files/" + link.Destination
+
+		return link.String(), true
+	}))
+
+	want := "See [deploy](/files/../ops/deploy.md#steps \"Deploy\") and ![diagram](</files/img/arch diagram.png>).\n\n" +
+		"Keep [site](https://example.com), `[code](x.md)` and [a [nested] link](y.md).\n\n" +
+		"```\n[block](z.md)\n```\n"
+
+	if got != want {
+		t.Errorf("RewriteLinks() =\n%s\nwant\n%s", got, want)
+	}
+
+	if len(seen) != 3 || !seen[1].Image || seen[1].Text != "diagram" || seen[0].Title != "Deploy" {
+		t.Errorf("Unexpected links: %+v", seen)
+	}
+
+	if unchanged := RewriteLinks([]byte(source), func(Link) (string, bool) { return "", false }); string(unchanged) != source {
+		t.Errorf("Source changed without rewrites: %s", unchanged)
+	}
+}
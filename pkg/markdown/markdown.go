@@ -0,0 +1,187 @@
+// Package markdown перетворює markdown (CommonMark з розширеннями GFM) у HTML,
+// безпечний для вставки в сторінку, і будує зміст документа за заголовками.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// _defaultAnchor - якір заголовка, у тексті якого немає літер чи цифр
+const _defaultAnchor = "section"
+
+var (
+	_wikiLinkClassPattern = regexp.MustCompile(`^` + WikiLinkClass + `( ` + BrokenWikiLinkClass + `)?$`)
+	_anchorPattern        = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+	_codeLanguagePattern  = regexp.MustCompile(`^language-[\w+#.-]+$`)
+)
+
+// Heading - елемент змісту. Anchor - id заголовка в HTML, унікальний у межах документа.
+type Heading struct {
+	Level  int
+	Text   string
+	Anchor string
+}
+
+// Document - результат рендерингу
+type Document struct {
+	HTML     string
+	Headings []Heading
+}
+
+// Renderer перетворює markdown у HTML. Сирий HTML з тексту не виводиться,
+// а результат додатково проходить через allowlist bluemonday, тому скрипти,
+// обробники подій та javascript: посилання не потрапляють у вихід.
+// Безпечний для одночасного використання.
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+func New() *Renderer {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("id").Matching(_anchorPattern).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	policy.AllowAttrs("class").Matching(_codeLanguagePattern).OnElements("code")
+	policy.AllowAttrs("class").Matching(_wikiLinkClassPattern).OnElements("a", "span")
+	// Пункти списку завдань GFM
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return &Renderer{
+		md:     newMarkdown(),
+		policy: policy,
+	}
+}
+
+// _parser розбирає документи поза Renderer, наприклад для пошуку wiki-посилань
+var _parser = newMarkdown().Parser()
+
+func newMarkdown() goldmark.Markdown {
+	return goldmark.New(goldmark.WithExtensions(extension.GFM, wikiLinkExtension{}))
+}
+
+// Render повертає санітизований HTML і заголовки документа в порядку появи.
+// Адреси wiki-посилань визначає resolve; посилання без адреси виводяться як биті.
+func (r *Renderer) Render(source []byte, resolve LinkResolver) (*Document, error) {
+	doc := r.md.Parser().Parse(text.NewReader(source))
+	headings := assignAnchors(doc, source)
+	resolveWikiLinks(doc, resolve)
+
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, source, doc); err != nil {
+		return nil, fmt.Errorf("markdown - Render: %w", err)
+	}
+
+	return &Document{
+		HTML:     r.policy.SanitizeReader(&buf).String(),
+		Headings: headings,
+	}, nil
+}
+
+// assignAnchors задає кожному заголовку id і повертає зміст
+func assignAnchors(doc ast.Node, source []byte) []Heading {
+	headings := make([]Heading, 0)
+	used := make(map[string]bool)
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+
+		title := headingText(heading, source)
+		anchor := uniqueAnchor(Anchor(title), used)
+		heading.SetAttributeString("id", []byte(anchor))
+
+		headings = append(headings, Heading{Level: heading.Level, Text: title, Anchor: anchor})
+
+		return ast.WalkSkipChildren, nil
+	})
+
+	return headings
+}
+
+// headingText збирає видимий текст заголовка без розмітки
+func headingText(heading *ast.Heading, source []byte) string {
+	var b strings.Builder
+
+	_ = ast.Walk(heading, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.Text:
+			b.Write(n.Value(source))
+
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(n.Value)
+		case *ast.AutoLink:
+			b.Write(n.Label(source))
+		case *WikiLink:
+			b.WriteString(n.Label)
+		case *ast.RawHTML:
+			// Сирий HTML не виводиться, тому не входить і в текст заголовка
+			return ast.WalkSkipChildren, nil
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Anchor перетворює текст заголовка на якір: нижній регістр, літери та цифри
+// будь-якої мови, пробіли та дефіси стають одним '-', решта символів відкидається
+func Anchor(title string) string {
+	var b strings.Builder
+
+	pendingDash := false
+
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			if pendingDash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			pendingDash = false
+
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-':
+			pendingDash = true
+		}
+	}
+
+	if b.Len() == 0 {
+		return _defaultAnchor
+	}
+
+	return b.String()
+}
+
+// uniqueAnchor додає до повторного якоря суфікс -1, -2, ... як це робить GitHub
+func uniqueAnchor(anchor string, used map[string]bool) string {
+	candidate := anchor
+
+	for i := 1; used[candidate]; i++ {
+		candidate = anchor + "-" + strconv.Itoa(i)
+	}
+
+	used[candidate] = true
+
+	return candidate
+}
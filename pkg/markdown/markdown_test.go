@@ -0,0 +1,116 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender_Sanitizes(t *testing.T) {
+	renderer := New()
+
+	tests := []struct {
+		name   string
+		source string
+		banned []string
+	}{
+		{"Script tag", "<script>alert(1)</script>", []string{"<script", "alert(1)"}},
+		{"Inline event handler", `<img src=x onerror="alert(1)">`, []string{"onerror", "<img"}},
+		{"Javascript link", "[click](javascript:alert(1))", []string{"javascript:"}},
+		{"Javascript autolink", "<javascript:alert(1)>", []string{"href"}},
+		{"Data URI image", "![x](data:text/html;base64,PHNjcmlwdD4=)", []string{"data:text/html"}},
+		{"Iframe", `<iframe src="https://evil.example"></iframe>`, []string{"<iframe"}},
+		{"Style attribute", `<p style="position:fixed">x</p>`, []string{"style="}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := renderer.Render([]byte(tt.source), nil)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			for _, banned := range tt.banned {
+				if strings.Contains(doc.HTML, banned) {
+					t.Errorf("Render(%q) = %q, contains %q", tt.source, doc.HTML, banned)
+				}
+			}
+		})
+	}
+}
+
+func TestRender_Markdown(t *testing.T) {
+	source := "# Title\n\nSee [docs](https://example.com/docs) and **bold**.\n\n" +
+		"| a | b |\n|---|---|\n| 1 | 2 |\n\n- [x] done\n- [ ] todo\n\n~~old~~\n\n```go\nfmt.Println(\"<hi>\")\n```\n"
+
+	doc, err := New().Render([]byte(source), nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := []string{
+		`<h1 id="title">Title</h1>`,
+		`<a href="https://example.com/docs" rel="nofollow">docs</a>`,
+		"<strong>bold</strong>",
+		"<table>",
+		`<input checked="" disabled="" type="checkbox"`,
+		"<del>old</del>",
+		`<code class="language-go">fmt.Println(&#34;&lt;hi&gt;&#34;)`,
+	}
+
+	for _, fragment := range want {
+		if !strings.Contains(doc.HTML, fragment) {
+			t.Errorf("HTML does not contain %q:\n%s", fragment, doc.HTML)
+		}
+	}
+}
+
+func TestRender_Headings(t *testing.T) {
+	source := "# Огляд системи\n\n## Install `v2` *now*\n\n## Install v2 now\n\n### ???\n\nSetext\n------\n\n" +
+		"#### Install v2 now\n"
+
+	doc, err := New().Render([]byte(source), nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := []Heading{
+		{1, "Огляд системи", "огляд-системи"},
+		{2, "Install v2 now", "install-v2-now"},
+		{2, "Install v2 now", "install-v2-now-1"},
+		{3, "???", "section"},
+		{2, "Setext", "setext"},
+		{4, "Install v2 now", "install-v2-now-2"},
+	}
+
+	if len(doc.Headings) != len(want) {
+		t.Fatalf("Headings = %+v, want %+v", doc.Headings, want)
+	}
+
+	for i, heading := range want {
+		if doc.Headings[i] != heading {
+			t.Errorf("Headings[%d] = %+v, want %+v", i, doc.Headings[i], heading)
+		}
+
+		if !strings.Contains(doc.HTML, `id="`+heading.Anchor+`"`) {
+			t.Errorf("HTML has no anchor %q:\n%s", heading.Anchor, doc.HTML)
+		}
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Getting Started", "getting-started"},
+		{"  C++ & C# -- notes ", "c-c-notes"},
+		{"snake_case name", "snake_case-name"},
+		{"Крок 1: встановлення", "крок-1-встановлення"},
+		{"!!!", "section"},
+	}
+
+	for _, tt := range tests {
+		if got := Anchor(tt.in); got != tt.want {
+			t.Errorf("Anchor(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
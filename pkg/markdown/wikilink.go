@@ -0,0 +1,168 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+const (
+	// WikiLinkClass - клас посилання на іншу сторінку бази знань
+	WikiLinkClass = "wiki-link"
+	// BrokenWikiLinkClass додається до посилання, ціль якого не знайдено
+	BrokenWikiLinkClass = "wiki-link-broken"
+
+	// _maxWikiLinkLength обмежує довжину [[...]], щоб не шукати закриття до кінця рядка
+	_maxWikiLinkLength = 512
+)
+
+// LinkResolver повертає адресу сторінки для цілі wiki-посилання;
+// ok == false означає, що сторінки не існує
+type LinkResolver func(target string) (href string, ok bool)
+
+// KindWikiLink - тип вузла wiki-посилання в AST
+var KindWikiLink = ast.NewNodeKind("WikiLink")
+
+// WikiLink - посилання [[Target]] або [[Target|Label]] на іншу сторінку
+type WikiLink struct {
+	ast.BaseInline
+
+	Target string
+	Label  string
+	// Href - адреса цілі; порожня, якщо ціль не знайдено
+	Href string
+}
+
+func (n *WikiLink) Kind() ast.NodeKind {
+	return KindWikiLink
+}
+
+func (n *WikiLink) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Target": n.Target, "Label": n.Label, "Href": n.Href}, nil)
+}
+
+// WikiLinks повертає унікальні цілі wiki-посилань документа в порядку появи.
+// Посилання всередині коду не враховуються.
+func WikiLinks(source []byte) []string {
+	doc := _parser.Parse(text.NewReader(source))
+
+	targets := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, link := range collectWikiLinks(doc) {
+		if !seen[link.Target] {
+			seen[link.Target] = true
+			targets = append(targets, link.Target)
+		}
+	}
+
+	return targets
+}
+
+func collectWikiLinks(doc ast.Node) []*WikiLink {
+	links := make([]*WikiLink, 0)
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if link, ok := node.(*WikiLink); ok && entering {
+			links = append(links, link)
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return links
+}
+
+// resolveWikiLinks заповнює Href посилань через resolve; nil resolve лишає всі посилання битими
+func resolveWikiLinks(doc ast.Node, resolve LinkResolver) {
+	if resolve == nil {
+		return
+	}
+
+	for _, link := range collectWikiLinks(doc) {
+		if href, ok := resolve(link.Target); ok {
+			link.Href = href
+		}
+	}
+}
+
+// wikiLinkExtension додає до goldmark синтаксис [[Target|Label]]
+type wikiLinkExtension struct{}
+
+func (wikiLinkExtension) Extend(m goldmark.Markdown) {
+	// Раніше за стандартний парсер посилань (200), інакше [[ розбирається як звичайні дужки
+	m.Parser().AddOptions(parser.WithInlineParsers(util.Prioritized(wikiLinkParser{}, 199)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(wikiLinkRenderer{}, 199)))
+}
+
+type wikiLinkParser struct{}
+
+func (wikiLinkParser) Trigger() []byte {
+	return []byte{'['}
+}
+
+func (wikiLinkParser) Parse(_ ast.Node, block text.Reader, _ parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	if !bytes.HasPrefix(line, []byte("[[")) {
+		return nil
+	}
+
+	end := bytes.Index(line, []byte("]]"))
+	if end < 0 || end > _maxWikiLinkLength {
+		return nil
+	}
+
+	content := line[2:end]
+	if bytes.ContainsAny(content, "[]\n") {
+		return nil
+	}
+
+	target, label, hasLabel := strings.Cut(string(content), "|")
+	target = strings.Join(strings.Fields(target), " ")
+	label = strings.Join(strings.Fields(label), " ")
+
+	if target == "" {
+		return nil
+	}
+
+	if !hasLabel || label == "" {
+		label = target
+	}
+
+	block.Advance(end + 2)
+
+	return &WikiLink{Target: target, Label: label}
+}
+
+type wikiLinkRenderer struct{}
+
+func (wikiLinkRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindWikiLink, renderWikiLink)
+}
+
+func renderWikiLink(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	link := node.(*WikiLink) //nolint:errcheck // функція зареєстрована лише для KindWikiLink
+
+	label := util.EscapeHTML([]byte(link.Label))
+
+	if link.Href == "" {
+		_, _ = fmt.Fprintf(w, `<span class="%s %s">%s</span>`, WikiLinkClass, BrokenWikiLinkClass, label)
+		return ast.WalkSkipChildren, nil
+	}
+
+	href := util.EscapeHTML(util.URLEscape([]byte(link.Href), true))
+	_, _ = fmt.Fprintf(w, `<a href="%s" class="%s">%s</a>`, href, WikiLinkClass, label)
+
+	return ast.WalkSkipChildren, nil
+}
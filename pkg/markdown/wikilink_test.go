@@ -0,0 +1,63 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWikiLinks(t *testing.T) {
+	source := "See [[Getting Started]] and [[ Deploy  Guide | deploy ]].\n\n" +
+		"Again [[Getting Started|start]], not `[[Code Span]]` or [[]] or [[a [b] c]].\n\n" +
+		"```\n[[Code Block]]\n```\n\n[regular](https://example.com) [[Last]]"
+
+	want := []string{"Getting Started", "Deploy Guide", "Last"}
+	if got := WikiLinks([]byte(source)); !reflect.DeepEqual(got, want) {
+		t.Errorf("WikiLinks() = %q, want %q", got, want)
+	}
+}
+
+func TestRender_WikiLinks(t *testing.T) {
+	resolve := func(target string) (string, bool) {
+		if target == "Getting Started" {
+			return "/v1/articles/1", true
+		}
+
+		return "", false
+	}
+
+	source := "# See [[Getting Started|start]]\n\n[[Getting Started]], [[Missing <b>Page</b>]], " +
+		`[[Evil|<script>alert(1)</script>]]`
+
+	doc, err := New().Render([]byte(source), resolve)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := []string{
+		`<a href="/v1/articles/1" class="wiki-link" rel="nofollow">start</a>`,
+		`<a href="/v1/articles/1" class="wiki-link" rel="nofollow">Getting Started</a>`,
+		`<span class="wiki-link wiki-link-broken">Missing &lt;b&gt;Page&lt;/b&gt;</span>`,
+		`<span class="wiki-link wiki-link-broken">&lt;script&gt;alert(1)&lt;/script&gt;</span>`,
+	}
+
+	for _, fragment := range want {
+		if !strings.Contains(doc.HTML, fragment) {
+			t.Errorf("HTML does not contain %q:\n%s", fragment, doc.HTML)
+		}
+	}
+
+	if len(doc.Headings) != 1 || doc.Headings[0].Text != "See start" {
+		t.Errorf("Headings = %+v", doc.Headings)
+	}
+
+	// Без resolve всі посилання биті
+	doc, err = New().Render([]byte("[[Getting Started]]"), nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if strings.Contains(doc.HTML, "href") || !strings.Contains(doc.HTML, BrokenWikiLinkClass) {
+		t.Errorf("Unexpected HTML: %s", doc.HTML)
+	}
+}
@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// cursor - вміст токена: сортування, для якого його видано, і значення його полів
+type cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// Page обрізає вибірку з Limit+1 елементів до Limit і, якщо елементи лишились,
+// видає курсор наступної сторінки
+func (s *Spec[T]) Page(items []T, p Params) *Page[T] {
+	page := &Page[T]{Items: items}
+
+	if p.Limit == 0 || uint64(len(items)) <= p.Limit {
+		return page
+	}
+
+	page.Items = items[:p.Limit]
+	page.NextCursor = s.encodeCursor(s.sorts(p), page.Items[len(page.Items)-1])
+
+	return page
+}
+
+func (s *Spec[T]) encodeCursor(sorts []Sort, last T) string {
+	c := cursor{Sort: sortSignature(sorts), Values: make([]string, len(sorts))}
+
+	for i, order := range sorts {
+		field := s.fields[order.Field]
+		c.Values[i] = field.Type.format(field.Value(last))
+	}
+
+	// Структура з рядків серіалізується без помилок
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (s *Spec[T]) decodeCursor(token string, sorts []Sort) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	// Курсор іншого сортування вказував би на випадкове місце списку
+	if c.Sort != sortSignature(sorts) || len(c.Values) != len(sorts) {
+		return nil, ErrInvalidCursor
+	}
+
+	values := make([]any, len(sorts))
+
+	for i, order := range sorts {
+		if values[i], err = s.fields[order.Field].Type.parse(c.Values[i]); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return values, nil
+}
+
+func sortSignature(sorts []Sort) string {
+	terms := make([]string, len(sorts))
+
+	for i, order := range sorts {
+		terms[i] = order.Field
+		if order.Desc {
+			terms[i] = "-" + order.Field
+		}
+	}
+
+	return strings.Join(terms, ",")
+}
@@ -0,0 +1,127 @@
+package pagination
+
+import (
+	"cmp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Slice вибирає сторінку з елементів у пам'яті так само, як Apply вибирає її в SQL.
+// Призначена для тестових репозиторіїв; рядки порівнюються побайтово, без collation бази.
+func (s *Spec[T]) Slice(items []T, p Params) *Page[T] {
+	sorts := s.sorts(p)
+	selected := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if !s.matches(item, p.Filters) {
+			continue
+		}
+
+		if len(p.After) == len(sorts) && s.compareItem(item, sorts, p.After) <= 0 {
+			continue
+		}
+
+		selected = append(selected, item)
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return s.compareItem(selected[i], sorts, s.values(selected[j], sorts)) < 0
+	})
+
+	if p.Limit > 0 && uint64(len(selected)) > p.Limit+1 {
+		selected = selected[:p.Limit+1]
+	}
+
+	return s.Page(selected, p)
+}
+
+func (s *Spec[T]) matches(item T, filters []Filter) bool {
+	for _, filter := range filters {
+		value := normalize(s.fields[filter.Field].Value(item))
+
+		if !matchFilter(value, filter) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchFilter(value any, filter Filter) bool {
+	switch filter.Op {
+	case In:
+		for _, candidate := range filter.Values {
+			if compare(value, candidate) == 0 {
+				return true
+			}
+		}
+
+		return false
+	case Prefix:
+		str, _ := value.(string)
+		prefix, _ := filter.Values[0].(string)
+
+		return strings.HasPrefix(str, prefix)
+	}
+
+	c := compare(value, filter.Values[0])
+
+	switch filter.Op {
+	case Ne:
+		return c != 0
+	case Lt:
+		return c < 0
+	case Lte:
+		return c <= 0
+	case Gt:
+		return c > 0
+	case Gte:
+		return c >= 0
+	default:
+		return c == 0
+	}
+}
+
+// compareItem порівнює елемент зі значеннями полів сортування з урахуванням напрямку
+func (s *Spec[T]) compareItem(item T, sorts []Sort, values []any) int {
+	for i, order := range sorts {
+		c := compare(normalize(s.fields[order.Field].Value(item)), values[i])
+		if order.Desc {
+			c = -c
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func (s *Spec[T]) values(item T, sorts []Sort) []any {
+	values := make([]any, len(sorts))
+
+	for i, order := range sorts {
+		values[i] = normalize(s.fields[order.Field].Value(item))
+	}
+
+	return values
+}
+
+func compare(a, b any) int {
+	switch x := a.(type) {
+	case string:
+		return strings.Compare(x, b.(string))
+	case int64:
+		return cmp.Compare(x, b.(int64))
+	case uint64:
+		return cmp.Compare(x, b.(uint64))
+	case float64:
+		return cmp.Compare(x, b.(float64))
+	case time.Time:
+		return x.Compare(b.(time.Time))
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,242 @@
+// Package pagination описує списки з курсорною пагінацією: розбирає параметри
+// limit, cursor, sort та filter за білим списком полів, перетворює їх на умови
+// squirrel і будує сторінки з непрозорим курсором наступної сторінки.
+//
+// Курсор кодує значення полів сортування останнього елемента сторінки (keyset),
+// тому вставки та видалення між запитами не зсувають сторінки, як це робить offset.
+package pagination
+
+import (
+	"strings"
+
+	"KnowledgeHub/pkg/apperror"
+)
+
+const (
+	// DefaultLimit - розмір сторінки, якщо limit не задано
+	DefaultLimit uint64 = 20
+	// MaxLimit - найбільший розмір сторінки
+	MaxLimit uint64 = 100
+
+	_maxSortFields = 3
+	_maxFilters    = 10
+	_maxInValues   = 100
+)
+
+var (
+	// ErrInvalidQuery - параметри списку не відповідають специфікації
+	ErrInvalidQuery = apperror.Validation("invalid_list_query", "invalid list query")
+	// ErrInvalidCursor - курсор пошкоджено або видано для іншого сортування
+	ErrInvalidCursor = ErrInvalidQuery.Withf("invalid cursor")
+)
+
+// Query - параметри списку в тому вигляді, в якому вони приходять у запиті
+type Query struct {
+	// Limit - розмір сторінки; 0 - розмір за замовчуванням специфікації
+	Limit uint64 `form:"limit"`
+	// Cursor - next_cursor попередньої сторінки
+	Cursor string `form:"cursor"`
+	// Sort - поля через кому, "-" перед полем - за спаданням: "-updated_at,title"
+	Sort string `form:"sort"`
+	// Filter - вирази "поле:оператор:значення", наприклад "status:eq:published"
+	Filter []string `form:"filter"`
+}
+
+// Operator - оператор виразу фільтра
+type Operator string
+
+const (
+	Eq  Operator = "eq"
+	Ne  Operator = "ne"
+	Lt  Operator = "lt"
+	Lte Operator = "lte"
+	Gt  Operator = "gt"
+	Gte Operator = "gte"
+	// In - значення через кому
+	In Operator = "in"
+	// Prefix - рядок починається зі значення (з урахуванням регістру)
+	Prefix Operator = "prefix"
+)
+
+// Sort - поле сортування
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Filter - розібраний вираз фільтра; Values приведено до типу поля
+type Filter struct {
+	Field  string
+	Op     Operator
+	Values []any
+}
+
+// Params - перевірені параметри списку. Нульове значення - всі елементи
+// в порядку за замовчуванням, так вибирають списки внутрішні виклики.
+type Params struct {
+	// Limit - розмір сторінки; 0 - без обмеження
+	Limit   uint64
+	Sort    []Sort
+	Filters []Filter
+	// After - значення полів Sort останнього елемента попередньої сторінки
+	After []any
+}
+
+// Page - сторінка списку
+type Page[T any] struct {
+	Items []T
+	// NextCursor - курсор наступної сторінки; порожній на останній сторінці
+	NextCursor string
+}
+
+// Params перевіряє параметри запиту за специфікацією
+func (s *Spec[T]) Params(q Query) (Params, error) {
+	params := Params{Limit: q.Limit}
+
+	switch {
+	case params.Limit == 0:
+		params.Limit = s.defaultLimit
+	case params.Limit > s.maxLimit:
+		return Params{}, ErrInvalidQuery.Withf("limit must not exceed %d", s.maxLimit)
+	}
+
+	sorts, err := s.parseSort(q.Sort)
+	if err != nil {
+		return Params{}, err
+	}
+
+	params.Sort = sorts
+
+	if len(q.Filter) > _maxFilters {
+		return Params{}, ErrInvalidQuery.Withf("at most %d filters are allowed", _maxFilters)
+	}
+
+	for _, expr := range q.Filter {
+		filter, filterErr := s.parseFilter(expr)
+		if filterErr != nil {
+			return Params{}, filterErr
+		}
+
+		params.Filters = append(params.Filters, filter)
+	}
+
+	if q.Cursor != "" {
+		if params.After, err = s.decodeCursor(q.Cursor, sorts); err != nil {
+			return Params{}, err
+		}
+	}
+
+	return params, nil
+}
+
+// parseSort розбирає список полів і додає поля ключа, щоб порядок був однозначним
+func (s *Spec[T]) parseSort(value string) ([]Sort, error) {
+	if value == "" {
+		return s.defaultSort, nil
+	}
+
+	terms := strings.Split(value, ",")
+	if len(terms) > _maxSortFields {
+		return nil, ErrInvalidQuery.Withf("at most %d sort fields are allowed", _maxSortFields)
+	}
+
+	sorts := make([]Sort, 0, len(terms)+len(s.key))
+
+	for _, term := range terms {
+		order := Sort{Field: strings.TrimSpace(term)}
+
+		switch {
+		case strings.HasPrefix(order.Field, "-"):
+			order.Field, order.Desc = order.Field[1:], true
+		case strings.HasPrefix(order.Field, "+"):
+			order.Field = order.Field[1:]
+		}
+
+		field, ok := s.fields[order.Field]
+		if !ok || !field.Sort {
+			return nil, ErrInvalidQuery.Withf("cannot sort by %q", order.Field)
+		}
+
+		if containsSort(sorts, order.Field) {
+			return nil, ErrInvalidQuery.Withf("duplicate sort field %q", order.Field)
+		}
+
+		sorts = append(sorts, order)
+	}
+
+	return s.withKey(sorts), nil
+}
+
+// withKey доповнює сортування полями ключа в напрямку останнього поля
+func (s *Spec[T]) withKey(sorts []Sort) []Sort {
+	desc := len(sorts) > 0 && sorts[len(sorts)-1].Desc
+
+	for _, name := range s.key {
+		if !containsSort(sorts, name) {
+			sorts = append(sorts, Sort{Field: name, Desc: desc})
+		}
+	}
+
+	return sorts
+}
+
+func (s *Spec[T]) parseFilter(expr string) (Filter, error) {
+	parts := strings.SplitN(expr, ":", 3)
+	if len(parts) != 3 {
+		return Filter{}, ErrInvalidQuery.Withf("filter %q is not field:operator:value", expr)
+	}
+
+	field, ok := s.fields[parts[0]]
+	if !ok || !field.Filter {
+		return Filter{}, ErrInvalidQuery.Withf("cannot filter by %q", parts[0])
+	}
+
+	filter := Filter{Field: field.Name, Op: Operator(parts[1])}
+
+	raw := []string{parts[2]}
+
+	switch filter.Op {
+	case Eq, Ne, Lt, Lte, Gt, Gte:
+	case In:
+		raw = strings.Split(parts[2], ",")
+		if len(raw) > _maxInValues {
+			return Filter{}, ErrInvalidQuery.Withf("at most %d values are allowed in %q", _maxInValues, expr)
+		}
+	case Prefix:
+		if field.Type != String {
+			return Filter{}, ErrInvalidQuery.Withf("operator prefix is not supported for %q", field.Name)
+		}
+	default:
+		return Filter{}, ErrInvalidQuery.Withf("unknown operator %q", parts[1])
+	}
+
+	for _, value := range raw {
+		parsed, err := field.Type.parse(value)
+		if err != nil {
+			return Filter{}, ErrInvalidQuery.Withf("invalid value %q for %q", value, field.Name)
+		}
+
+		filter.Values = append(filter.Values, parsed)
+	}
+
+	return filter, nil
+}
+
+// sorts повертає сортування параметрів або сортування за замовчуванням
+func (s *Spec[T]) sorts(p Params) []Sort {
+	if len(p.Sort) == 0 {
+		return s.defaultSort
+	}
+
+	return p.Sort
+}
+
+func containsSort(sorts []Sort, name string) bool {
+	for _, order := range sorts {
+		if order.Field == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,219 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type item struct {
+	ID      uint
+	Title   string
+	Rank    int
+	Score   float32
+	Created time.Time
+}
+
+var _base = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testSpec() *Spec[*item] {
+	return NewSpec([]Field[*item]{
+		{Name: "id", Column: "id", Type: Uint, Sort: true, Filter: true, Value: func(i *item) any { return i.ID }},
+		{Name: "title", Column: "title", Type: String, Sort: true, Filter: true, Value: func(i *item) any { return i.Title }},
+		{Name: "rank", Column: "rank", Type: Int, Sort: true, Value: func(i *item) any { return i.Rank }},
+		{Name: "score", Column: "score", Type: Float, Sort: true, Value: func(i *item) any { return i.Score }},
+		{Name: "created_at", Column: "t.created_at", Type: Time, Sort: true, Filter: true,
+			Value: func(i *item) any { return i.Created }},
+	}, DefaultSort("-created_at"), Limits(2, 3))
+}
+
+// testItems - 7 елементів; у кожної пари сусідніх однаковий rank, щоб перевірити ключ,
+// а score - дробові значення, неточні у float32
+func testItems() []*item {
+	items := make([]*item, 0, 7)
+
+	for i := range 7 {
+		items = append(items, &item{
+			ID:      uint(i + 1),
+			Title:   fmt.Sprintf("item %c", 'g'-i),
+			Rank:    i / 2,
+			Score:   float32(i%3)/10 + 0.05,
+			Created: _base.Add(time.Duration(i) * time.Hour),
+		})
+	}
+
+	return items
+}
+
+func TestSpec_Params(t *testing.T) {
+	spec := testSpec()
+
+	tests := []struct {
+		name      string
+		query     Query
+		wantLimit uint64
+		wantSort  string
+		wantErr   bool
+	}{
+		{"Defaults", Query{}, 2, "-created_at,-id", false},
+		{"Max limit", Query{Limit: 3}, 3, "-created_at,-id", false},
+		{"Limit above max", Query{Limit: 4}, 0, "", true},
+		{"Key follows last direction", Query{Sort: "rank,-title"}, 2, "rank,-title,-id", false},
+		{"Explicit key", Query{Sort: "+id"}, 2, "id", false},
+		{"Unknown sort field", Query{Sort: "body"}, 0, "", true},
+		{"Duplicate sort field", Query{Sort: "rank,-rank"}, 0, "", true},
+		{"Too many sort fields", Query{Sort: "rank,title,created_at,id"}, 0, "", true},
+		{"Filter", Query{Filter: []string{"title:prefix:item", "id:in:1,2"}}, 2, "-created_at,-id", false},
+		{"Filter value with colons", Query{Filter: []string{"created_at:gte:2025-01-01T03:00:00Z"}}, 2, "-created_at,-id", false},
+		{"Filter by date", Query{Filter: []string{"created_at:lt:2025-01-02"}}, 2, "-created_at,-id", false},
+		{"Filter without operator", Query{Filter: []string{"title:item"}}, 0, "", true},
+		{"Unknown operator", Query{Filter: []string{"title:like:item"}}, 0, "", true},
+		{"Not filterable", Query{Filter: []string{"rank:eq:1"}}, 0, "", true},
+		{"Invalid value", Query{Filter: []string{"id:eq:abc"}}, 0, "", true},
+		{"Prefix of number", Query{Filter: []string{"id:prefix:1"}}, 0, "", true},
+		{"Malformed cursor", Query{Cursor: "!!!"}, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := spec.Params(tt.query)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidQuery) {
+					t.Fatalf("Params() error = %v, want ErrInvalidQuery", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Params() error = %v", err)
+			}
+
+			if params.Limit != tt.wantLimit {
+				t.Errorf("Limit = %d, want %d", params.Limit, tt.wantLimit)
+			}
+
+			if got := sortSignature(params.Sort); got != tt.wantSort {
+				t.Errorf("Sort = %q, want %q", got, tt.wantSort)
+			}
+		})
+	}
+}
+
+func TestSpec_Slice(t *testing.T) {
+	spec := testSpec()
+	items := testItems()
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []uint
+	}{
+		{"Default sort", Query{}, []uint{7, 6, 5, 4, 3, 2, 1}},
+		{"Ties are ordered by key", Query{Sort: "rank", Limit: 3}, []uint{1, 2, 3, 4, 5, 6, 7}},
+		{"Descending ties", Query{Sort: "-rank", Limit: 3}, []uint{7, 6, 5, 4, 3, 2, 1}},
+		{"String sort", Query{Sort: "title"}, []uint{7, 6, 5, 4, 3, 2, 1}},
+		{"Float sort", Query{Sort: "-score"}, []uint{6, 3, 5, 2, 7, 4, 1}},
+		{"Filter", Query{Filter: []string{"id:in:2,4,6", "created_at:gte:2025-01-01T02:00:00Z"}}, []uint{6, 4}},
+		{"Prefix", Query{Filter: []string{"title:prefix:item a"}}, []uint{7}},
+		{"Not equal", Query{Sort: "id", Filter: []string{"id:ne:1", "id:lte:3"}}, []uint{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []uint
+
+			query := tt.query
+
+			// Обхід усіх сторінок за курсорами
+			for range len(items) + 1 {
+				params, err := spec.Params(query)
+				if err != nil {
+					t.Fatalf("Params() error = %v", err)
+				}
+
+				page := spec.Slice(items, params)
+				if uint64(len(page.Items)) > params.Limit {
+					t.Fatalf("page has %d items, limit %d", len(page.Items), params.Limit)
+				}
+
+				for _, it := range page.Items {
+					got = append(got, it.ID)
+				}
+
+				if page.NextCursor == "" {
+					break
+				}
+
+				query.Cursor = page.NextCursor
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpec_CursorOfOtherSort(t *testing.T) {
+	spec := testSpec()
+
+	params, err := spec.Params(Query{Limit: 1})
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	page := spec.Slice(testItems(), params)
+	if page.NextCursor == "" {
+		t.Fatal("expected next cursor")
+	}
+
+	if _, err = spec.Params(Query{Cursor: page.NextCursor, Sort: "title"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Params() error = %v, want ErrInvalidCursor", err)
+	}
+
+	if _, err = spec.Params(Query{Cursor: page.NextCursor}); err != nil {
+		t.Errorf("Params() with the same sort error = %v", err)
+	}
+}
+
+func TestSpec_Apply(t *testing.T) {
+	spec := testSpec()
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select("id").From("items t")
+
+	params, err := spec.Params(Query{Sort: "rank", Filter: []string{"title:prefix:50%_off"}})
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	params.After = []any{int64(3), uint64(9)}
+
+	sql, args, err := spec.Apply(builder, params).ToSql()
+	if err != nil {
+		t.Fatalf("ToSql() error = %v", err)
+	}
+
+	wantSQL := "SELECT id FROM items t WHERE title LIKE $1 AND ((rank > $2) OR (rank = $3 AND id > $4)) " +
+		"ORDER BY rank ASC, id ASC LIMIT 3"
+	if sql != wantSQL {
+		t.Errorf("sql = %q\nwant  %q", sql, wantSQL)
+	}
+
+	wantArgs := []any{`50\%\_off%`, int64(3), int64(3), uint64(9)}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+
+	// Без параметрів - порядок за замовчуванням і без LIMIT
+	sql, _, err = spec.Apply(builder, Params{}).ToSql()
+	if err != nil {
+		t.Fatalf("ToSql() error = %v", err)
+	}
+
+	if want := "SELECT id FROM items t ORDER BY t.created_at DESC, id DESC"; sql != want {
+		t.Errorf("sql = %q, want %q", sql, want)
+	}
+}
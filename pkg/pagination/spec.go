@@ -0,0 +1,181 @@
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type - тип значень поля: визначає розбір значень фільтрів і курсора
+type Type int
+
+const (
+	String Type = iota + 1
+	Int
+	Uint
+	// Float - дробові значення, наприклад релевантність пошуку
+	Float
+	Time
+)
+
+// Field - поле списку, за яким дозволено сортувати та/або фільтрувати
+type Field[T any] struct {
+	// Name - назва поля в параметрах sort та filter
+	Name string
+	// Column - колонка або вираз SQL; задається лише специфікацією, не запитом
+	Column string
+	Type   Type
+	Sort   bool
+	Filter bool
+	// Value повертає значення поля елемента: string, int, int64, uint, uint64,
+	// float32, float64 або time.Time. Потрібне для курсора та вибірки в пам'яті.
+	Value func(T) any
+}
+
+// Spec - білий список полів списку та його порядок за замовчуванням
+type Spec[T any] struct {
+	fields       map[string]Field[T]
+	key          []string
+	defaultSort  []Sort
+	defaultLimit uint64
+	maxLimit     uint64
+}
+
+type options struct {
+	key          []string
+	defaultSort  string
+	defaultLimit uint64
+	maxLimit     uint64
+}
+
+// Option налаштовує Spec
+type Option func(*options)
+
+// Key задає поля, що разом однозначно визначають елемент (за замовчуванням "id").
+// Вони завжди додаються в кінець сортування, щоб курсор не пропускав елементи з однаковими значеннями.
+func Key(fields ...string) Option {
+	return func(o *options) {
+		o.key = fields
+	}
+}
+
+// DefaultSort задає сортування у форматі параметра sort, якщо його не передано
+func DefaultSort(sort string) Option {
+	return func(o *options) {
+		o.defaultSort = sort
+	}
+}
+
+// Limits задає розмір сторінки за замовчуванням і найбільший розмір
+func Limits(defaultLimit, maxLimit uint64) Option {
+	return func(o *options) {
+		o.defaultLimit = defaultLimit
+		o.maxLimit = maxLimit
+	}
+}
+
+// NewSpec створює специфікацію списку. Специфікації оголошуються в коді,
+// тому помилка в них (невідоме поле ключа чи сортування) - паніка, як у regexp.MustCompile.
+func NewSpec[T any](fields []Field[T], opts ...Option) *Spec[T] {
+	o := options{
+		key:          []string{"id"},
+		defaultLimit: DefaultLimit,
+		maxLimit:     MaxLimit,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &Spec[T]{
+		fields:       make(map[string]Field[T], len(fields)),
+		key:          o.key,
+		defaultLimit: o.defaultLimit,
+		maxLimit:     o.maxLimit,
+	}
+
+	for _, field := range fields {
+		s.fields[field.Name] = field
+	}
+
+	for _, name := range s.key {
+		if _, ok := s.fields[name]; !ok {
+			panic(fmt.Sprintf("pagination: key field %q is not declared", name))
+		}
+	}
+
+	sorts := make([]Sort, 0, len(s.key))
+
+	for _, term := range strings.Split(o.defaultSort, ",") {
+		if term == "" {
+			continue
+		}
+
+		order := Sort{Field: strings.TrimPrefix(term, "-"), Desc: strings.HasPrefix(term, "-")}
+		if _, ok := s.fields[order.Field]; !ok {
+			panic(fmt.Sprintf("pagination: default sort field %q is not declared", order.Field))
+		}
+
+		sorts = append(sorts, order)
+	}
+
+	s.defaultSort = s.withKey(sorts)
+
+	return s
+}
+
+func (t Type) parse(value string) (any, error) {
+	switch t {
+	case String:
+		return value, nil
+	case Int:
+		return strconv.ParseInt(value, 10, 64)
+	case Uint:
+		return strconv.ParseUint(value, 10, 64)
+	case Float:
+		return strconv.ParseFloat(value, 64)
+	case Time:
+		if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return parsed, nil
+		}
+
+		return time.Parse(time.DateOnly, value)
+	default:
+		return nil, fmt.Errorf("unknown type %d", t)
+	}
+}
+
+func (t Type) format(value any) string {
+	switch v := normalize(value).(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		// Найкоротший запис, що розбирається в те саме значення
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// normalize приводить значення Field.Value до типів, які повертає parse
+func normalize(value any) any {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case float32:
+		return float64(v)
+	default:
+		return v
+	}
+}
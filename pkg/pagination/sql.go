@@ -0,0 +1,98 @@
+package pagination
+
+import (
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Apply додає до запиту фільтри, умову курсора, сортування та LIMIT Limit+1:
+// зайвий рядок показує, що наступна сторінка існує (див. Page)
+func (s *Spec[T]) Apply(query squirrel.SelectBuilder, p Params) squirrel.SelectBuilder {
+	for _, filter := range p.Filters {
+		query = query.Where(s.condition(filter))
+	}
+
+	sorts := s.sorts(p)
+
+	if len(p.After) == len(sorts) {
+		query = query.Where(s.after(sorts, p.After))
+	}
+
+	query = s.Order(query, p)
+
+	if p.Limit > 0 {
+		query = query.Limit(p.Limit + 1)
+	}
+
+	return query
+}
+
+// Order додає лише сортування сторінки. Потрібне зовнішньому запиту над вкладеною
+// вибіркою Apply: порядок рядків підзапиту не зберігається без власного ORDER BY.
+func (s *Spec[T]) Order(query squirrel.SelectBuilder, p Params) squirrel.SelectBuilder {
+	for _, order := range s.sorts(p) {
+		direction := " ASC"
+		if order.Desc {
+			direction = " DESC"
+		}
+
+		query = query.OrderBy(s.fields[order.Field].Column + direction)
+	}
+
+	return query
+}
+
+func (s *Spec[T]) condition(filter Filter) squirrel.Sqlizer {
+	column := s.fields[filter.Field].Column
+	value := filter.Values[0]
+
+	switch filter.Op {
+	case Ne:
+		return squirrel.NotEq{column: value}
+	case Lt:
+		return squirrel.Lt{column: value}
+	case Lte:
+		return squirrel.LtOrEq{column: value}
+	case Gt:
+		return squirrel.Gt{column: value}
+	case Gte:
+		return squirrel.GtOrEq{column: value}
+	case In:
+		return squirrel.Eq{column: filter.Values}
+	case Prefix:
+		return squirrel.Like{column: escapeLike(value.(string)) + "%"}
+	default:
+		return squirrel.Eq{column: value}
+	}
+}
+
+// after - умова "елемент іде після курсора" для сортування з кількох полів:
+// (a > x) OR (a = x AND b > y) OR ... з оберненим порівнянням для полів за спаданням
+func (s *Spec[T]) after(sorts []Sort, values []any) squirrel.Or {
+	or := make(squirrel.Or, 0, len(sorts))
+
+	for i, order := range sorts {
+		and := make(squirrel.And, 0, i+1)
+
+		for j := range i {
+			and = append(and, squirrel.Eq{s.fields[sorts[j].Field].Column: values[j]})
+		}
+
+		column := s.fields[order.Field].Column
+		if order.Desc {
+			and = append(and, squirrel.Lt{column: values[i]})
+		} else {
+			and = append(and, squirrel.Gt{column: values[i]})
+		}
+
+		or = append(or, and)
+	}
+
+	return or
+}
+
+// escapeLike екранує символи шаблону LIKE; у PostgreSQL екрануючий символ за замовчуванням - "\"
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// _migrationLockID - ключ advisory lock, щоб кілька інстансів не накочували міграції одночасно.
+const _migrationLockID = 7_241_100_173
+
+const _createMigrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+// Migrate застосовує SQL-міграції з fsys, які ще не були застосовані.
+// Кожен файл *.sql виконується в окремій транзакції разом із записом у schema_migrations.
+func (p *Postgres) Migrate(ctx context.Context, fsys fs.FS) error {
+	files, err := migrationFiles(fsys)
+	if err != nil {
+		return fmt.Errorf("postgres - Migrate - migrationFiles: %w", err)
+	}
+
+	conn, err := p.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres - Migrate - Pool.Acquire: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1)", _migrationLockID); err != nil {
+		return fmt.Errorf("postgres - Migrate - pg_advisory_lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", _migrationLockID) //nolint:errcheck // lock is released with session anyway
+
+	if _, err = conn.Exec(ctx, _createMigrationsTable); err != nil {
+		return fmt.Errorf("postgres - Migrate - create schema_migrations: %w", err)
+	}
+
+	for _, name := range files {
+		version := strings.TrimSuffix(name, path.Ext(name))
+
+		var applied bool
+		err = conn.QueryRow(ctx,
+			"SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version,
+		).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("postgres - Migrate - check %s: %w", version, err)
+		}
+
+		if applied {
+			continue
+		}
+
+		var script []byte
+
+		script, err = fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("postgres - Migrate - read %s: %w", name, err)
+		}
+
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, txErr := tx.Exec(ctx, string(script)); txErr != nil {
+				return txErr
+			}
+
+			_, txErr := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version)
+
+			return txErr
+		})
+		if err != nil {
+			return fmt.Errorf("postgres - Migrate - apply %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+func migrationFiles(fsys fs.FS) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		files = append(files, entry.Name())
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no migration files found")
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := &queryTracer{tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")}
+
+	tests := []struct {
+		name       string
+		sql        string
+		err        error
+		wantName   string
+		wantFailed bool
+	}{
+		{"select", "  select id from articles where id = $1", nil, "postgres SELECT", false},
+		{"no rows is not a failure", "SELECT 1", pgx.ErrNoRows, "postgres SELECT", false},
+		{"failed insert", "INSERT INTO tags (name) VALUES ($1)", errors.New("duplicate key"), "postgres INSERT", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: tt.sql})
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1"), Err: tt.err})
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+
+			if span.Name() != tt.wantName {
+				t.Errorf("Span name = %q, want %q", span.Name(), tt.wantName)
+			}
+
+			if failed := span.Status().Code == codes.Error; failed != tt.wantFailed {
+				t.Errorf("Span failed = %v, want %v", failed, tt.wantFailed)
+			}
+
+			for _, attr := range span.Attributes() {
+				if attr.Key == "db.query.text" && attr.Value.AsString() != tt.sql {
+					t.Errorf("db.query.text = %q, want %q", attr.Value.AsString(), tt.sql)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package ratelimit обмежує частоту запитів алгоритмом token bucket: ключ має відро
+// на Burst токенів, яке поповнюється зі швидкістю Rate, і кожен запит забирає токен.
+// Стан відер зберігає Store: Memory - в пам'яті процесу; для кількох екземплярів
+// застосунку підключається спільне сховище з тим самим інтерфейсом.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit - параметри відра
+type Limit struct {
+	// Rate - токенів на секунду
+	Rate float64
+	// Burst - місткість відра: скільки запитів можна зробити поспіль
+	Burst int
+}
+
+// PerMinute - ліміт у n запитів на хвилину з місткістю burst
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Result - рішення щодо запиту
+type Result struct {
+	Allowed bool
+	Limit   int
+	// Remaining - токенів лишилось після запиту
+	Remaining int
+	// Reset - через скільки відро знову стане повним
+	Reset time.Duration
+	// RetryAfter - через скільки з'явиться токен; 0, якщо запит дозволено
+	RetryAfter time.Duration
+}
+
+// Store - сховище стану відер
+type Store interface {
+	// Take забирає токен з відра key
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+const _sweepEvery = 1024
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// refill - за скільки порожнє відро наповнюється
+	refill time.Duration
+}
+
+// Memory - Store у пам'яті процесу. Повні відра періодично видаляються,
+// тому пам'ять залежить лише від кількості активних ключів.
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	takes   int
+
+	now func() time.Time
+}
+
+var _ Store = (*Memory)(nil)
+
+// NewMemory створює сховище в пам'яті
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	burst := float64(limit.Burst)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, updated: now}
+		m.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+	b.refill = secondsToDuration(burst / limit.Rate)
+
+	result := Result{Limit: limit.Burst}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = secondsToDuration((burst - b.tokens) / limit.Rate)
+
+	m.takes++
+	if m.takes%_sweepEvery == 0 {
+		m.sweep(now)
+	}
+
+	return result, nil
+}
+
+// sweep видаляє відра, що встигли наповнитись: їх стан не відрізняється від нового
+func (m *Memory) sweep(now time.Time) {
+	for key, b := range m.buckets {
+		if now.Sub(b.updated) >= b.refill {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
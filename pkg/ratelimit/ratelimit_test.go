@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemory_Take(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := NewMemory()
+	store.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	limit := PerMinute(6, 3) // токен кожні 10 секунд
+
+	for i := range 3 {
+		result, err := store.Take(ctx, "ip:1", limit)
+		if err != nil || !result.Allowed {
+			t.Fatalf("Take %d: expected allowed, got %+v, %v", i, result, err)
+		}
+
+		if result.Remaining != 2-i {
+			t.Errorf("Take %d: remaining = %d, want %d", i, result.Remaining, 2-i)
+		}
+	}
+
+	result, _ := store.Take(ctx, "ip:1", limit)
+	if result.Allowed || result.RetryAfter != 10*time.Second || result.Reset != 30*time.Second {
+		t.Errorf("Expected rejection with 10s retry and 30s reset, got %+v", result)
+	}
+
+	// Інші ключі мають власні відра
+	if result, _ = store.Take(ctx, "ip:2", limit); !result.Allowed {
+		t.Errorf("Expected separate bucket for another key, got %+v", result)
+	}
+
+	now = now.Add(10 * time.Second)
+
+	if result, _ = store.Take(ctx, "ip:1", limit); !result.Allowed || result.Remaining != 0 {
+		t.Errorf("Expected one refilled token, got %+v", result)
+	}
+}
+
+func TestMemory_Sweep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := NewMemory()
+	store.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	_, _ = store.Take(ctx, "idle", PerMinute(60, 1))
+
+	now = now.Add(time.Minute)
+	for range _sweepEvery {
+		_, _ = store.Take(ctx, "active", PerMinute(60, 1))
+	}
+
+	if _, ok := store.buckets["idle"]; ok {
+		t.Error("Expected refilled bucket to be swept")
+	}
+
+	if _, ok := store.buckets["active"]; !ok {
+		t.Error("Expected active bucket to be kept")
+	}
+}
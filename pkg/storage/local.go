@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	_localDirPerm  = 0o750
+	_localFilePerm = 0o640
+)
+
+// Local зберігає об'єкти файлами в каталозі root; ключ стає відносним шляхом файлу.
+// Запис іде в тимчасовий файл поруч і завершується перейменуванням, тому читачі
+// ніколи не бачать частково записаний об'єкт.
+type Local struct {
+	root string
+}
+
+// NewLocal створює каталог root, якщо його ще немає
+func NewLocal(root string) (*Local, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("storage - NewLocal - filepath.Abs: %w", err)
+	}
+
+	if err = os.MkdirAll(abs, _localDirPerm); err != nil {
+		return nil, fmt.Errorf("storage - NewLocal - os.MkdirAll: %w", err)
+	}
+
+	return &Local{root: abs}, nil
+}
+
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, _ string) error {
+	name, err := l.path(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(name)
+	if err = os.MkdirAll(dir, _localDirPerm); err != nil {
+		return fmt.Errorf("storage - Local - Put - os.MkdirAll: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("storage - Local - Put - os.CreateTemp: %w", err)
+	}
+
+	// Після успішного перейменування Remove нічого не знаходить
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	err = writeFile(tmp, r, size)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("storage - Local - Put - write: %w", err)
+	}
+
+	if err = os.Chmod(tmp.Name(), _localFilePerm); err != nil {
+		return fmt.Errorf("storage - Local - Put - os.Chmod: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("storage - Local - Put - os.Rename: %w", err)
+	}
+
+	return nil
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := l.path(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("storage - Local - Get - os.Open: %w", err)
+	}
+
+	return file, nil
+}
+
+func (l *Local) Exists(ctx context.Context, key string) (bool, error) {
+	name, err := l.path(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("storage - Local - Exists - os.Stat: %w", err)
+	}
+
+	return info.Mode().IsRegular(), nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	name, err := l.path(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("storage - Local - Delete - os.Remove: %w", err)
+	}
+
+	return nil
+}
+
+func (l *Local) path(ctx context.Context, key string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+// writeFile копіює рівно size байтів і скидає їх на диск
+func writeFile(file *os.File, r io.Reader, size int64) error {
+	written, err := io.Copy(file, io.LimitReader(r, size+1))
+	if err != nil {
+		return err
+	}
+
+	if written != size {
+		return ErrSize
+	}
+
+	return file.Sync()
+}
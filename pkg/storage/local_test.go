@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "blobs")
+
+	store, err := NewLocal(root)
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	testBlobStore(t, store)
+
+	// Невдалі записи не лишають тимчасових файлів
+	err = filepath.WalkDir(root, func(path string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if !entry.IsDir() && filepath.Base(path) != "empty" {
+			t.Errorf("unexpected file left in store: %s", path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir: %v", err)
+	}
+}
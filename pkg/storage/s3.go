@@ -0,0 +1,416 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	_s3Service       = "s3"
+	_s3Algorithm     = "AWS4-HMAC-SHA256"
+	_s3TimeFormat    = "20060102T150405Z"
+	_unsignedPayload = "UNSIGNED-PAYLOAD"
+	// _emptyPayloadHash - SHA-256 порожнього тіла запиту
+	_emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// _maxErrorBody обмежує читання XML помилки від сховища
+	_maxErrorBody = 64 << 10
+)
+
+// S3Config - параметри S3-сумісного сховища (AWS S3, MinIO, Ceph RGW тощо)
+type S3Config struct {
+	// Endpoint - базова адреса, наприклад https://s3.eu-central-1.amazonaws.com або http://minio:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle адресує об'єкти як endpoint/bucket/key замість bucket.endpoint/key; потрібен для MinIO
+	PathStyle bool
+}
+
+// S3 зберігає об'єкти в бакеті S3-сумісного сховища через REST API з підписом AWS Signature V4
+type S3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+type S3Option func(*S3)
+
+// HTTPClient задає HTTP клієнт для запитів до сховища
+func HTTPClient(client *http.Client) S3Option {
+	return func(s *S3) {
+		s.client = client
+	}
+}
+
+func NewS3(cfg S3Config, opts ...S3Option) (*S3, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("storage - NewS3 - url.Parse: %w", err)
+	}
+
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, fmt.Errorf("storage - NewS3: endpoint must be an absolute http(s) URL, got %q", cfg.Endpoint)
+	}
+
+	if cfg.Bucket == "" || cfg.Region == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("storage - NewS3: bucket, region and credentials are required")
+	}
+
+	s := &S3{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   http.DefaultClient,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		return ErrSize
+	}
+
+	body := &sizedReader{r: r, remaining: size}
+
+	// Тіло не хешується наперед: підпис покриває заголовки, а цілісність перевіряє Content-Length
+	req, err := s.newRequest(ctx, http.MethodPut, key, body, _unsignedPayload)
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+
+	// Порожнє тіло з ненульовим Body транспорт надіслав би без Content-Length
+	if size == 0 {
+		if err = body.checkEnd(); err != nil {
+			return err
+		}
+
+		req.Body = http.NoBody
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		if errors.Is(body.err, ErrSize) {
+			return ErrSize
+		}
+
+		return fmt.Errorf("storage - S3 - Put: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("storage - S3 - Put: %w", responseError(resp))
+	}
+
+	return nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil, _emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage - S3 - Get: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("storage - S3 - Get: %w", responseError(resp))
+	}
+}
+
+func (s *S3) Exists(ctx context.Context, key string) (bool, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil, _emptyPayloadHash)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return false, fmt.Errorf("storage - S3 - Exists: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("storage - S3 - Exists: %w", responseError(resp))
+	}
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil, _emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("storage - S3 - Delete: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// S3 відповідає 204 і для відсутнього об'єкта, деякі сумісні сховища - 404
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK &&
+		resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("storage - S3 - Delete: %w", responseError(resp))
+	}
+
+	return nil
+}
+
+func (s *S3) newRequest(
+	ctx context.Context,
+	method, key string,
+	body io.Reader,
+	payloadHash string,
+) (*http.Request, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	u := *s.endpoint
+	objectPath := u.Path + "/" + key
+
+	if s.cfg.PathStyle {
+		objectPath = u.Path + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+	}
+
+	u.Path = objectPath
+	u.RawPath = s3Escape(objectPath, false)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("storage - S3 - http.NewRequest: %w", err)
+	}
+
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	return req, nil
+}
+
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	signV4(req, s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, s.now())
+
+	return s.client.Do(req)
+}
+
+// sizedReader віддає рівно remaining байтів і повертає ErrSize, якщо джерело коротше або довше
+type sizedReader struct {
+	r         io.Reader
+	remaining int64
+	err       error
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	if s.remaining == 0 {
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > s.remaining {
+		p = p[:s.remaining]
+	}
+
+	n, err := s.r.Read(p)
+	s.remaining -= int64(n)
+
+	switch {
+	case s.remaining == 0:
+		// Транспорт не читає далі Content-Length, тому зайві байти перевіряються до того,
+		// як останній фрагмент буде надіслано - інакше сховище отримало б обрізаний об'єкт
+		if s.err = s.checkEnd(); s.err != nil {
+			return 0, s.err
+		}
+
+		return n, nil
+	case errors.Is(err, io.EOF):
+		s.err = ErrSize
+		return n, s.err
+	default:
+		return n, err
+	}
+}
+
+// checkEnd повертає ErrSize, якщо джерело містить більше байтів, ніж очікувалось
+func (s *sizedReader) checkEnd() error {
+	var extra [1]byte
+	if n, _ := io.ReadFull(s.r, extra[:]); n > 0 {
+		return ErrSize
+	}
+
+	return nil
+}
+
+// S3Error - помилка, яку повернуло сховище у XML тілі відповіді
+type S3Error struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *S3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("s3 status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("s3 status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func responseError(resp *http.Response) error {
+	s3Err := &S3Error{StatusCode: resp.StatusCode}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, _maxErrorBody))
+	if err == nil && len(body) > 0 {
+		// Тіло без XML (наприклад, у відповіді на HEAD) лишає лише статус
+		_ = xml.Unmarshal(body, s3Err)
+	}
+
+	return s3Err
+}
+
+// signV4 підписує запит за AWS Signature Version 4, додаючи X-Amz-Date та Authorization.
+// Підписуються host і всі заголовки запиту; X-Amz-Content-Sha256 має бути встановлений заздалегідь.
+func signV4(req *http.Request, accessKey, secretKey, region string, now time.Time) {
+	amzDate := now.UTC().Format(_s3TimeFormat)
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		if strings.EqualFold(name, "Authorization") {
+			continue
+		}
+
+		trimmed := make([]string, 0, len(values))
+		for _, value := range values {
+			trimmed = append(trimmed, strings.Join(strings.Fields(value), " "))
+		}
+
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + _s3Service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := _s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, _s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		_s3Algorithm, accessKey, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(name, true)+"="+s3Escape(value, true))
+		}
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape кодує рядок за правилами SigV4: незмінними лишаються лише A-Z, a-z, 0-9, "-._~"
+// і, якщо encodeSlash = false, "/"
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+
+	for i := range len(s) {
+		c := s[i]
+
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,52 @@
+// Package storage зберігає двійкові об'єкти за ключем у змінному бекенді:
+// локальній файловій системі або S3-сумісному сховищі.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+// _maxKeyLength - обмеження S3 на довжину ключа в байтах
+const _maxKeyLength = 1024
+
+var (
+	ErrNotFound   = errors.New("storage: object not found")
+	ErrInvalidKey = errors.New("storage: invalid object key")
+	ErrSize       = errors.New("storage: object size mismatch")
+)
+
+// BlobStore - сховище об'єктів. Ключ - відносний шлях зі слешами без "." та ".." сегментів,
+// наприклад "sha256/ab/abcdef...".
+type BlobStore interface {
+	// Put зберігає рівно size байтів з r під ключем key, замінюючи наявний об'єкт.
+	// Якщо r містить іншу кількість байтів, повертає ErrSize і нічого не зберігає.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get відкриває об'єкт для читання; повертає ErrNotFound, якщо його немає.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete видаляє об'єкт; відсутність об'єкта не є помилкою.
+	Delete(ctx context.Context, key string) error
+}
+
+// ValidateKey перевіряє, що ключ не виходить за межі сховища
+func ValidateKey(key string) error {
+	if key == "" || len(key) > _maxKeyLength || strings.HasPrefix(key, "/") || strings.ContainsAny(key, "\\\x00") {
+		return ErrInvalidKey
+	}
+
+	if path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// testBlobStore перевіряє поведінку, спільну для всіх реалізацій BlobStore
+func testBlobStore(t *testing.T, store BlobStore) {
+	t.Helper()
+
+	ctx := context.Background()
+	key := "sha256/ab/abcdef"
+
+	exists, err := store.Exists(ctx, key)
+	if err != nil || exists {
+		t.Fatalf("Exists before Put = %v, %v; want false, nil", exists, err)
+	}
+
+	if _, err = store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get before Put error = %v, want ErrNotFound", err)
+	}
+
+	if err = store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if got := readObject(t, store, key); got != "hello" {
+		t.Errorf("Get = %q, want %q", got, "hello")
+	}
+
+	if err = store.Put(ctx, key, strings.NewReader("replaced"), 8, "text/plain"); err != nil {
+		t.Fatalf("Put replace: %v", err)
+	}
+
+	if got := readObject(t, store, key); got != "replaced" {
+		t.Errorf("Get after replace = %q, want %q", got, "replaced")
+	}
+
+	if err = store.Put(ctx, "short", strings.NewReader("abc"), 5, ""); !errors.Is(err, ErrSize) {
+		t.Errorf("Put short body error = %v, want ErrSize", err)
+	}
+
+	if err = store.Put(ctx, "long", strings.NewReader("abcdef"), 5, ""); !errors.Is(err, ErrSize) {
+		t.Errorf("Put long body error = %v, want ErrSize", err)
+	}
+
+	for _, bad := range []string{"short", "long"} {
+		if exists, err = store.Exists(ctx, bad); err != nil || exists {
+			t.Errorf("Exists(%q) after failed Put = %v, %v; want false, nil", bad, exists, err)
+		}
+	}
+
+	if err = store.Put(ctx, "empty", strings.NewReader(""), 0, ""); err != nil {
+		t.Fatalf("Put empty: %v", err)
+	}
+
+	if got := readObject(t, store, "empty"); got != "" {
+		t.Errorf("Get empty = %q", got)
+	}
+
+	if err = store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if exists, err = store.Exists(ctx, key); err != nil || exists {
+		t.Errorf("Exists after Delete = %v, %v; want false, nil", exists, err)
+	}
+
+	if err = store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete missing object: %v", err)
+	}
+
+	for _, bad := range []string{"", "/abs", "../escape", "a/../../b", "a//b", "a/./b", `a\b`} {
+		if err = store.Put(ctx, bad, strings.NewReader("x"), 1, ""); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidKey", bad, err)
+		}
+	}
+}
+
+func readObject(t *testing.T, store BlobStore, key string) string {
+	t.Helper()
+
+	reader, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%q): %v", key, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll(%q): %v", key, err)
+	}
+
+	return string(data)
+}
+
+func TestValidateKey(t *testing.T) {
+	valid := []string{"a", "sha256/ab/abcdef", "dir/file.pdf", "with space/ünicode"}
+	for _, key := range valid {
+		if err := ValidateKey(key); err != nil {
+			t.Errorf("ValidateKey(%q) = %v, want nil", key, err)
+		}
+	}
+
+	invalid := []string{"", "/a", "a/", "..", "../a", "a/..", "a//b", "./a", "a\x00b", strings.Repeat("k", 1025)}
+	for _, key := range invalid {
+		if err := ValidateKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("ValidateKey(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package tracing налаштовує OpenTelemetry: глобальний TracerProvider з експортером
+// спанів та пропагатор W3C Trace Context, що зв'язує наші спани з викликачем через
+// заголовок traceparent. Без експортера спани не записуються, але контекст трасування
+// все одно передається далі.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExporterNone - спани не експортуються
+	ExporterNone = "none"
+	// ExporterStdout - спани друкуються в stdout; для локальної розробки
+	ExporterStdout = "stdout"
+	// ExporterOTLP - спани надсилаються колектору OTLP/HTTP; адресу та заголовки
+	// задають стандартні змінні OTEL_EXPORTER_OTLP_*
+	ExporterOTLP = "otlp"
+
+	_instrumentationName = "KnowledgeHub"
+)
+
+// Config - параметри трасування
+type Config struct {
+	Exporter       string
+	ServiceName    string
+	ServiceVersion string
+	// SampleRatio - частка кореневих трас, що записуються; рішення викликача в traceparent шанується
+	SampleRatio float64
+}
+
+// Setup встановлює глобальні пропагатор і TracerProvider. Повернена функція
+// відправляє залишок спанів і має викликатися при зупинці застосунку.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, err := newExporter(ctx, cfg.Exporter)
+	if err != nil {
+		return nil, fmt.Errorf("tracing - Setup - newExporter: %w", err)
+	}
+
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("tracing - Setup - resource.Merge: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		return otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", name)
+	}
+}
+
+// Tracer повертає трасувальник застосунку. Він звертається до глобального
+// TracerProvider при кожному спані, тому його можна отримати ще до Setup.
+func Tracer() trace.Tracer {
+	return otel.Tracer(_instrumentationName)
+}
+
+// Start починає внутрішній спан name, дочірній до спану з ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// ID - атрибут спану з ідентифікатором сутності
+func ID(key string, id uint) attribute.KeyValue {
+	return attribute.Int64(key, int64(id)) //nolint:gosec // skip integer overflow conversion uint -> int64
+}